@@ -11,12 +11,16 @@ import (
 	"compress/gzip"
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
+	"time"
+	"unicode/utf8"
 
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
 	"github.com/vmihailenco/msgpack"
 )
 
@@ -41,6 +45,17 @@ type (
 		file io.ReadCloser
 		size int64
 	}
+	// indexed (see cluster/lom_arch.go)
+	cslSection struct {
+		io.SectionReader
+	}
+	// archived file to add (APPEND) - see rewriteArch
+	archAdd struct {
+		mtime time.Time
+		r     io.Reader
+		name  string
+		size  int64
+	}
 
 	detect struct {
 		mime   string // '.' + IANA mime
@@ -68,6 +83,8 @@ func (csf *cslFile) Read(b []byte) (int, error) { return csf.file.Read(b) }
 func (csf *cslFile) Size() int64                { return csf.size }
 func (csf *cslFile) Close() error               { return csf.file.Close() }
 
+func (*cslSection) Close() error { return nil }
+
 func notFoundInArch(filename, archname string) error {
 	return cmn.NewErrNotFound("file %q in archive %q", filename, archname)
 }
@@ -76,16 +93,26 @@ func notFoundInArch(filename, archname string) error {
 // GET OBJECT: archive //
 /////////////////////////
 
-func (goi *getObjInfo) freadArch(file *os.File, mime string) (cos.ReadCloseSizer, error) {
-	archname := filepath.Join(goi.lom.Bck().Name, goi.lom.ObjName)
-	filename := goi.archive.filename
+func (goi *getObjInfo) freadArch(file cos.LomReader, mime string) (cos.ReadCloseSizer, error) {
+	return freadArch(goi.lom, file, goi.archive.filename, mime)
+}
+
+func (goi *getObjInfo) mime(file cos.LomReader) (string, error) {
+	return archMime(goi.t.smm, goi.lom, file, goi.archive.mime)
+}
+
+func freadArch(lom *cluster.LOM, file cos.LomReader, filename, mime string) (cos.ReadCloseSizer, error) {
+	archname := filepath.Join(lom.Bck().Name, lom.ObjName)
 	switch mime {
 	case cos.ExtTar:
+		if !lom.IsEncrypted() {
+			return freadTarIdx(lom, file, filename, archname)
+		}
 		return freadTar(file, filename, archname)
 	case cos.ExtTarTgz, cos.ExtTgz:
 		return freadTgz(file, filename, archname)
 	case cos.ExtZip:
-		return freadZip(file, filename, archname, goi.lom.SizeBytes())
+		return freadZip(file, filename, archname, lom.SizeBytes())
 	case cos.ExtMsgpack:
 		return freadMsgpack(file, filename, archname)
 	default:
@@ -94,14 +121,14 @@ func (goi *getObjInfo) freadArch(file *os.File, mime string) (cos.ReadCloseSizer
 	}
 }
 
-func (goi *getObjInfo) mime(file *os.File) (m string, err error) {
+func archMime(smm *memsys.MMSA, lom *cluster.LOM, file cos.LomReader, userMime string) (m string, err error) {
 	// either ok or non-empty user-defined mime type (that must work)
-	if m, err = cos.Mime(goi.archive.mime, goi.lom.ObjName); err == nil || goi.archive.mime != "" {
+	if m, err = cos.Mime(userMime, lom.ObjName); err == nil || userMime != "" {
 		return
 	}
 	// otherwise, by magic
 	var (
-		buf, slab = goi.t.smm.AllocSize(sizeDetectMime)
+		buf, slab = smm.AllocSize(sizeDetectMime)
 		n         int
 	)
 	n, err = file.Read(buf)
@@ -113,7 +140,7 @@ func (goi *getObjInfo) mime(file *os.File) (m string, err error) {
 	}
 	if m == "" {
 		if err == nil {
-			err = cos.NewUnknownMimeError(goi.lom.ObjName)
+			err = cos.NewUnknownMimeError(lom.ObjName)
 		} else {
 			err = cos.NewUnknownMimeError(err.Error())
 		}
@@ -143,6 +170,58 @@ func freadTar(reader io.Reader, filename, archname string) (*cslLimited, error)
 	}
 }
 
+// seek directly to the archived file using the object's index (and build the latter if need be)
+func freadTarIdx(lom *cluster.LOM, file cos.LomReader, filename, archname string) (cos.ReadCloseSizer, error) {
+	idx := lom.ArchIndex()
+	if idx == nil {
+		var err error
+		if idx, err = lom.BuildArchIndex(file); err != nil {
+			glog.Errorf("%s: failed to index archive: %v", lom, err)
+		}
+		if _, errS := file.Seek(0, io.SeekStart); errS != nil {
+			return nil, errS
+		}
+		if idx == nil {
+			return freadTar(file, filename, archname)
+		}
+	}
+	e, ok := idx.Lookup(filename)
+	if !ok {
+		return nil, notFoundInArch(filename, archname)
+	}
+	if !archEntryEq(file, e) {
+		// (unlikely) object's content does not match the index; remove the latter and scan
+		glog.Errorf("%s: archive index is stale (%q)", lom, filename)
+		lom.DelArchIndex()
+		return freadTar(file, filename, archname)
+	}
+	return &cslSection{SectionReader: *io.NewSectionReader(file, e.Offset, e.Size)}, nil
+}
+
+// double-check the index entry against the TAR header that immediately precedes
+// the archived content (NOTE: long and non-ASCII names are stored elsewhere -
+// see PAX and GNU formats - and are therefore not compared)
+func archEntryEq(file io.ReaderAt, e *cluster.ArchEntry) bool {
+	const nameSize = 100 // ustar
+	if e.Offset < cos.TarBlockSize {
+		return false
+	}
+	tr := tar.NewReader(io.NewSectionReader(file, e.Offset-cos.TarBlockSize, cos.TarBlockSize))
+	hdr, err := tr.Next()
+	if err != nil || hdr.Size != e.Size {
+		return false
+	}
+	if len(e.Name) > nameSize {
+		return true
+	}
+	for i := 0; i < len(e.Name); i++ {
+		if e.Name[i] >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return hdr.Name == e.Name
+}
+
 func freadTgz(reader io.Reader, filename, archname string) (csc *cslClose, err error) {
 	var (
 		gzr *gzip.Reader
@@ -208,6 +287,120 @@ found:
 	return
 }
 
+////////////////////////////////////////
+// APPEND (to), DELETE (from) archive //
+////////////////////////////////////////
+
+// rewriteArch copies archived content from `src` to `w` in the same format, skipping all
+// occurrences of `filename` and, if `add` is non-nil, appending the latter at the end;
+// returns true if `filename` was found (and skipped)
+func rewriteArch(src cos.ReadReaderAt, w io.Writer, size int64, mime, filename string, add *archAdd,
+	buf []byte) (found bool, err error) {
+	switch mime {
+	case cos.ExtTar:
+		return rewriteTar(src, w, filename, add, buf)
+	case cos.ExtTgz, cos.ExtTarTgz:
+		var gzr *gzip.Reader
+		if gzr, err = gzip.NewReader(src); err != nil {
+			return
+		}
+		gzw := gzip.NewWriter(w)
+		found, err = rewriteTar(gzr, gzw, filename, add, buf)
+		if err == nil {
+			err = gzw.Close()
+		}
+		cos.Close(gzr)
+		return
+	case cos.ExtZip:
+		return rewriteZip(src, w, size, filename, add, buf)
+	default:
+		debug.Assert(false, mime)
+		return false, cos.NewUnknownMimeError(mime)
+	}
+}
+
+func rewriteTar(r io.Reader, w io.Writer, filename string, add *archAdd, buf []byte) (found bool, err error) {
+	var (
+		hdr *tar.Header
+		tr  = tar.NewReader(r)
+		tw  = tar.NewWriter(w)
+	)
+	for {
+		if hdr, err = tr.Next(); err != nil {
+			if err != io.EOF {
+				return
+			}
+			break
+		}
+		if hdr.Name != "" && archNamesEq(hdr.Name, filename) {
+			found = true
+			continue
+		}
+		if err = tw.WriteHeader(hdr); err != nil {
+			return
+		}
+		if _, err = io.CopyBuffer(tw, tr, buf); err != nil {
+			return
+		}
+	}
+	if add != nil {
+		hdr = &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     add.name,
+			Size:     add.size,
+			ModTime:  add.mtime,
+			Mode:     int64(cos.PermRWR), // default value '0' - no access at all
+		}
+		if err = tw.WriteHeader(hdr); err != nil {
+			return
+		}
+		if _, err = io.CopyBuffer(tw, add.r, buf); err != nil {
+			return
+		}
+	}
+	err = tw.Close() // (fails if fewer than add.size bytes were written)
+	return
+}
+
+func rewriteZip(r io.ReaderAt, w io.Writer, size int64, filename string, add *archAdd,
+	buf []byte) (found bool, err error) {
+	var (
+		zr *zip.Reader
+		zw = zip.NewWriter(w)
+	)
+	if zr, err = zip.NewReader(r, size); err != nil {
+		return
+	}
+	for _, f := range zr.File {
+		if f.Name != "" && archNamesEq(f.Name, filename) {
+			found = true
+			continue
+		}
+		if err = zw.Copy(f); err != nil { // (no decompression)
+			return
+		}
+	}
+	if add != nil {
+		var (
+			fw io.Writer
+			n  int64
+			fh = &zip.FileHeader{Name: add.name, Method: zip.Deflate, Modified: add.mtime}
+		)
+		fh.SetMode(cos.PermRWR)
+		if fw, err = zw.CreateHeader(fh); err != nil {
+			return
+		}
+		if n, err = io.CopyBuffer(fw, add.r, buf); err != nil {
+			return
+		}
+		if n != add.size {
+			return found, fmt.Errorf("%q: size mismatch (%d vs %d)", add.name, n, add.size)
+		}
+	}
+	err = zw.Close()
+	return
+}
+
 // NOTE: in re `--absolute-names` (simplified)
 func archNamesEq(n1, n2 string) bool {
 	if n1[0] == filepath.Separator {
@@ -0,0 +1,141 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+var archFiles = []string{"a.txt", "labels/b.cls", "c.jpg"}
+
+func newTestArch(t *testing.T, mime string) []byte {
+	var (
+		buf bytes.Buffer
+		w   io.Writer = &buf
+		gzw *gzip.Writer
+	)
+	if mime == cos.ExtZip {
+		zw := zip.NewWriter(&buf)
+		for _, name := range archFiles {
+			fw, err := zw.Create(name)
+			tassert.CheckFatal(t, err)
+			_, err = fw.Write([]byte("content of " + name))
+			tassert.CheckFatal(t, err)
+		}
+		tassert.CheckFatal(t, zw.Close())
+		return buf.Bytes()
+	}
+	if mime == cos.ExtTgz {
+		gzw = gzip.NewWriter(&buf)
+		w = gzw
+	}
+	tw := tar.NewWriter(w)
+	for _, name := range archFiles {
+		content := "content of " + name
+		hdr := &tar.Header{Typeflag: tar.TypeReg, Name: name, Size: int64(len(content)), Mode: int64(cos.PermRWR)}
+		tassert.CheckFatal(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(content))
+		tassert.CheckFatal(t, err)
+	}
+	tassert.CheckFatal(t, tw.Close())
+	if gzw != nil {
+		tassert.CheckFatal(t, gzw.Close())
+	}
+	return buf.Bytes()
+}
+
+// returns archived files (in the archive order) and their respective contents
+func readTestArch(t *testing.T, b []byte, mime string) (names []string, contents map[string]string) {
+	contents = make(map[string]string, 4)
+	if mime == cos.ExtZip {
+		zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		tassert.CheckFatal(t, err)
+		for _, f := range zr.File {
+			fr, err := f.Open()
+			tassert.CheckFatal(t, err)
+			content, err := io.ReadAll(fr)
+			tassert.CheckFatal(t, err)
+			fr.Close()
+			names = append(names, f.Name)
+			contents[f.Name] = string(content)
+		}
+		return
+	}
+	var r io.Reader = bytes.NewReader(b)
+	if mime == cos.ExtTgz {
+		gzr, err := gzip.NewReader(r)
+		tassert.CheckFatal(t, err)
+		r = gzr
+	}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return
+		}
+		tassert.CheckFatal(t, err)
+		content, err := io.ReadAll(tr)
+		tassert.CheckFatal(t, err)
+		names = append(names, hdr.Name)
+		contents[hdr.Name] = string(content)
+	}
+}
+
+func TestRewriteArch(t *testing.T) {
+	buf := make([]byte, 32*cos.KiB)
+	for _, mime := range []string{cos.ExtTar, cos.ExtTgz, cos.ExtZip} {
+		t.Run(mime, func(t *testing.T) {
+			var (
+				arch = newTestArch(t, mime)
+				out  bytes.Buffer
+			)
+			// delete
+			found, err := rewriteArch(bytes.NewReader(arch), &out, int64(len(arch)), mime, "labels/b.cls", nil, buf)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, found, "expected to find archived file")
+			names, _ := readTestArch(t, out.Bytes(), mime)
+			tassert.Errorf(t, strings.Join(names, ",") == "a.txt,c.jpg", "unexpected content after delete: %v", names)
+
+			// delete non-existing
+			out.Reset()
+			found, err = rewriteArch(bytes.NewReader(arch), &out, int64(len(arch)), mime, "nonexisting", nil, buf)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, !found, "not expecting to find archived file")
+
+			// append (and replace)
+			for _, name := range []string{"d.txt", "/labels/b.cls"} {
+				out.Reset()
+				content := "new content of " + name
+				add := &archAdd{r: strings.NewReader(content), name: name, size: int64(len(content)), mtime: time.Now()}
+				_, err = rewriteArch(bytes.NewReader(arch), &out, int64(len(arch)), mime, name, add, buf)
+				tassert.CheckFatal(t, err)
+				names, contents := readTestArch(t, out.Bytes(), mime)
+				tassert.Errorf(t, names[len(names)-1] == name, "expected %q at the end, got %v", name, names)
+				tassert.Errorf(t, contents[name] == content, "%q: wrong content %q", name, contents[name])
+				expected := len(archFiles) + 1
+				if name != "d.txt" {
+					expected = len(archFiles)
+				}
+				tassert.Errorf(t, len(names) == expected, "expected %d files, got %v", expected, names)
+			}
+
+			// append: size mismatch
+			out.Reset()
+			add := &archAdd{r: strings.NewReader("short"), name: "e.txt", size: 100, mtime: time.Now()}
+			_, err = rewriteArch(bytes.NewReader(arch), &out, int64(len(arch)), mime, "e.txt", add, buf)
+			tassert.Errorf(t, err != nil, "expected size mismatch error")
+		})
+	}
+}
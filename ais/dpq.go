@@ -28,6 +28,8 @@ type dpq struct {
 	fltPresence         string // QparamFltPresence
 	dontAddRemote       string // QparamDontAddRemote
 	etlName             string // QparamETLName
+	etlArgs             string // QparamETLArgs
+	objVer              string // QparamObjVersion (s3: versionId)
 }
 
 var (
@@ -108,6 +110,12 @@ func (dpq *dpq) fromRawQ(rawQuery string) (err error) {
 			dpq.dontAddRemote = value
 		case apc.QparamETLName:
 			dpq.etlName = value
+		case apc.QparamETLArgs:
+			if dpq.etlArgs, err = url.QueryUnescape(value); err != nil {
+				return
+			}
+		case apc.QparamObjVersion, s3.QparamVersionID:
+			dpq.objVer = value
 
 		case s3.QparamMptUploadID, s3.QparamMptUploads, s3.QparamMptPartNo:
 			// TODO: ignore for now
@@ -25,6 +25,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/ext/dload"
 	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/xact/xreg"
@@ -421,6 +422,7 @@ type (
 	cresEM struct{} // -> etl.CPUMemUsed
 	cresIC struct{} // -> icBundle
 	cresBM struct{} // -> bucketMD
+	cresDH struct{} // -> []dload.Job (history of a scheduled download)
 
 	cresLso   struct{} // -> cmn.LsoResult
 	cresBsumm struct{} // -> cmn.AllBsummResults
@@ -438,6 +440,7 @@ var (
 	_ cresv = cresIC{}
 	_ cresv = cresBM{}
 	_ cresv = cresBsumm{}
+	_ cresv = cresDH{}
 )
 
 func (res *callResult) read(body io.Reader)  { res.bytes, res.err = io.ReadAll(body) }
@@ -484,6 +487,9 @@ func (c cresBM) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jr
 func (cresBsumm) newV() any                              { return &cmn.AllBsummResults{} }
 func (c cresBsumm) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
+func (cresDH) newV() any                              { return &[]dload.Job{} }
+func (c cresDH) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
+
 ////////////////
 // glogWriter //
 ////////////////
@@ -16,6 +16,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -74,6 +75,7 @@ type (
 		metasyncer *metasyncer
 		ic         ic
 		qm         lsobjMem
+		dlsched    dlsched
 		rproxy     reverseProxy
 		notifs     notifs
 		reg        struct {
@@ -186,6 +188,7 @@ func (p *proxy) Run() error {
 	p.notifs.init(p)
 	p.ic.init(p)
 	p.qm.init()
+	p.dlsched.init(p)
 
 	//
 	// REST API: register proxy handlers and start listening
@@ -199,6 +202,7 @@ func (p *proxy) Run() error {
 		{r: apc.Download, h: p.downloadHandler, net: accessNetPublic},
 		{r: apc.ETL, h: p.etlHandler, net: accessNetPublic},
 		{r: apc.Sort, h: p.dsortHandler, net: accessNetPublic},
+		{r: apc.Batch, h: p.batchHandler, net: accessNetPublic},
 
 		{r: apc.IC, h: p.ic.handler, net: accessNetIntraControl},
 		{r: apc.Daemon, h: p.daemonHandler, net: accessNetPublicControl},
@@ -727,10 +731,19 @@ func (p *proxy) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		bckArgs.perms = apc.AceObjDELETE
 		bckArgs.createAIS = false
 	}
+	if r.URL.Query().Get(apc.QparamArchpath) != "" {
+		bckArgs.perms = apc.AcePUT // deleting archived file modifies the archive
+	}
 	bck, objName, err := p._parseReqTry(w, r, bckArgs)
 	if err != nil {
 		return
 	}
+	if cos.IsParseBool(r.URL.Query().Get(apc.QparamBypassGovernance)) {
+		// (see cmn.ObjLockGovernance)
+		if err := p.checkAccess(w, r, bck, apc.AceAdmin); err != nil {
+			return
+		}
+	}
 	smap := p.owner.smap.get()
 	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
@@ -1426,6 +1439,36 @@ func (p *proxy) listObjects(w http.ResponseWriter, r *http.Request, bck *cluster
 	if bck.IsHTTP() || lsmsg.IsFlagSet(apc.LsArchDir) {
 		lsmsg.SetFlag(apc.LsObjCached)
 	}
+	// tags and custom metadata are only available for in-cluster objects;
+	// list-objects cache is keyed by prefix and, therefore, cannot be used
+	if lsmsg.CustomFilter != "" {
+		if _, err := cmn.ParseCustomFilter(lsmsg.CustomFilter); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		lsmsg.SetFlag(apc.LsObjCached)
+		lsmsg.ClearFlag(apc.UseListObjsCache)
+	}
+	// archived files are selected by the targets (see LsArchDir above)
+	if lsmsg.ArchRegex != "" {
+		if !lsmsg.IsFlagSet(apc.LsArchDir) {
+			p.writeErrf(w, r, "%s: archive regex %q requires listing archived content", bck, lsmsg.ArchRegex)
+			return
+		}
+		if _, err := regexp.Compile(lsmsg.ArchRegex); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		lsmsg.ClearFlag(apc.UseListObjsCache)
+	}
+	// non-current versions are only retained in ais buckets (see cmn.VersionConf.Retain)
+	if lsmsg.IsFlagSet(apc.LsVersions) {
+		if !bck.IsAIS() {
+			p.writeErrf(w, r, "%s: listing object versions is only supported for ais buckets", bck)
+			return
+		}
+		lsmsg.ClearFlag(apc.UseListObjsCache)
+	}
 
 	var (
 		err                        error
@@ -1547,7 +1590,7 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request) {
 	}
 	apireq := apiReqAlloc(1, apc.URLPathObjects.L, false /*dpq*/)
 	defer apiReqFree(apireq)
-	if msg.Action == apc.ActRenameObject {
+	if msg.Action == apc.ActRenameObject || msg.Action == apc.ActCompose {
 		apireq.after = 2
 	}
 	if err := p.parseReq(w, r, apireq); err != nil {
@@ -1575,6 +1618,12 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request) {
 		}
 		p.objMv(w, r, bck, apireq.items[1], msg)
 		return
+	case apc.ActCompose:
+		if err := p.checkAccess(w, r, bck, apc.AcePUT); err != nil {
+			return
+		}
+		p.compose(w, r, bck, apireq.items[1], msg)
+		return
 	case apc.ActPromote:
 		if err := p.checkAccess(w, r, bck, apc.AcePromote); err != nil {
 			return
@@ -1821,6 +1870,12 @@ func (p *proxy) httpobjpatch(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if cos.IsParseBool(r.URL.Query().Get(apc.QparamBypassGovernance)) {
+		// (see cmn.ObjLockGovernance)
+		if err := p.checkAccess(w, r, bck, apc.AceAdmin); err != nil {
+			return
+		}
+	}
 	smap := p.owner.smap.get()
 	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
@@ -2262,6 +2317,70 @@ func (p *proxy) objMv(w http.ResponseWriter, r *http.Request, bck *cluster.Bck,
 	p.statsT.Inc(stats.RenameCount)
 }
 
+// compose: validate, authorize sources, and redirect to the destination's owner
+// (that will then pull the sources from their respective owners - see ais/tgtcompose.go)
+func (p *proxy) compose(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string, msg *apc.ActMsg) {
+	started := time.Now()
+	cmsg := &cmn.ComposeMsg{}
+	if err := cos.MorphMarshal(msg.Value, cmsg); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	var (
+		query = r.URL.Query()
+		perms = apc.AceGET
+		done  = make(cos.StrSet, 2)
+	)
+	if cmsg.DeleteSrc {
+		perms |= apc.AceObjDELETE
+	}
+	for i := range cmsg.Sources {
+		src := &cmsg.Sources[i]
+		if src.Bck.IsEmpty() {
+			src.Bck = *bck.Bucket() // (same as destination)
+		}
+	}
+	if err := cmsg.Validate(); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	for i := range cmsg.Sources {
+		bckSrc := cluster.CloneBck(&cmsg.Sources[i].Bck)
+		uname := bckSrc.MakeUname("")
+		if done.Contains(uname) {
+			continue
+		}
+		bckArgs := allocInitBckArgs()
+		{
+			bckArgs.p = p
+			bckArgs.w = w
+			bckArgs.r = r
+			bckArgs.bck = bckSrc
+			bckArgs.query = query
+			bckArgs.perms = perms
+			bckArgs.createAIS = false
+		}
+		_, err := bckArgs.initAndTry()
+		freeInitBckArgs(bckArgs)
+		if err != nil {
+			return
+		}
+		done.Add(uname)
+	}
+
+	smap := p.owner.smap.get()
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%q %s(%d sources) => %s", msg.Action, bck.Cname(objName), len(cmsg.Sources), si)
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraControl)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect) // NOTE: preserving the body
+}
+
 func (p *proxy) listrange(method, bucket string, msg *apc.ActMsg, query url.Values) (xid string, err error) {
 	var (
 		smap   = p.owner.smap.get()
@@ -2873,7 +2992,11 @@ func (p *proxy) dsortHandler(w http.ResponseWriter, r *http.Request) {
 
 	switch r.Method {
 	case http.MethodPost:
-		p.proxyStartSortHandler(w, r)
+		if len(apiItems) == 1 && apiItems[0] == apc.Resume {
+			dsort.ProxyResumeSortHandler(w, r)
+		} else {
+			p.proxyStartSortHandler(w, r)
+		}
 	case http.MethodGet:
 		dsort.ProxyGetHandler(w, r)
 	case http.MethodDelete:
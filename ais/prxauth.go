@@ -5,12 +5,15 @@
 package ais
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/authn"
 	"github.com/NVIDIA/aistore/cluster"
@@ -18,6 +21,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/sigv4"
 	"github.com/NVIDIA/aistore/memsys"
 )
 
@@ -216,6 +220,9 @@ func (p *proxy) validateToken(hdr http.Header) (*tok.Token, error) {
 		glog.Errorf("invalid token: %v", err)
 		return nil, err
 	}
+	if tk.S3 {
+		return nil, tok.ErrS3Token
+	}
 	return tk, nil
 }
 
@@ -239,7 +246,7 @@ func (p *proxy) checkAccess(w http.ResponseWriter, r *http.Request, bck *cluster
 func aceErrToCode(err error) (status int) {
 	switch err {
 	case nil:
-	case tok.ErrNoToken, tok.ErrInvalidToken:
+	case tok.ErrNoToken, tok.ErrInvalidToken, tok.ErrS3Token:
 		status = http.StatusUnauthorized
 	default:
 		status = http.StatusForbidden
@@ -293,3 +300,127 @@ func (p *proxy) access(hdr http.Header, bck *cluster.Bck, ace apc.AccessAttrs) e
 	}
 	return bck.Allow(ace)
 }
+
+//
+// S3 API: SigV4 authentication
+//
+
+// When AuthN is on, S3 requests must be either signed (SigV4) with S3 credentials
+// issued by AuthN (see authn.GetS3Creds), or carry a regular bearer token.
+// Signed requests include the session token (X-Amz-Security-Token) that, in turn,
+// determines the secret access key (see tok.S3Secret) and the user's permissions.
+// NOTE: must be called prior to modifying the request's URL (see cos.ReparseQuery)
+func (p *proxy) s3Authenticate(r *http.Request) (*tok.Token, error) {
+	cfg := cmn.GCO.Get()
+	if !cfg.Auth.Enabled {
+		return nil, nil
+	}
+	a, err := sigv4.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		tk, err := p.validateToken(r.Header)
+		if err != nil {
+			return nil, sigv4.NewErr(sigv4.ErrCodeAccessDenied, "%v", err)
+		}
+		return tk, nil
+	}
+	if a.SessionToken == "" {
+		return nil, sigv4.NewErr(sigv4.ErrCodeInvalidToken, "missing session token (%s)", sigv4.QparamSecurityToken)
+	}
+	tk, err := p.authn.validateToken(a.SessionToken)
+	if err != nil {
+		code := sigv4.ErrCodeInvalidToken
+		if errors.Is(err, tok.ErrTokenExpired) {
+			code = sigv4.ErrCodeExpiredToken
+		}
+		return nil, sigv4.NewErr(code, "%v", err)
+	}
+	if !tk.S3 || tk.UserID != a.AccessKeyID {
+		return nil, sigv4.NewErr(sigv4.ErrCodeInvalidToken, "session token does not match access key %q", a.AccessKeyID)
+	}
+	if err := a.Verify(r, tok.S3Secret(a.SessionToken, cfg.Auth.Secret), time.Now()); err != nil {
+		return nil, err
+	}
+	return tk, nil
+}
+
+// Check user's permissions to execute a given S3 request (in addition to
+// bucket's own access attributes that S3 handlers check on their own)
+func (p *proxy) s3Authorize(tk *tok.Token, r *http.Request, items []string) error {
+	var (
+		ace apc.AccessAttrs
+		q   = r.URL.Query()
+		uid = p.owner.smap.get().UUID
+	)
+	switch {
+	case len(items) == 0:
+		return tk.CheckPermissions(uid, nil, apc.AceListBuckets)
+	case len(items) == 1:
+		switch r.Method {
+		case http.MethodHead:
+			ace = apc.AceBckHEAD
+		case http.MethodGet:
+			ace = apc.AceObjLIST
+		case http.MethodPut:
+			if !q.Has(s3.QparamVersioning) && !q.Has(s3.QparamLifecycle) && !q.Has(s3.QparamObjLock) &&
+				!q.Has(s3.QparamNotification) {
+				return tk.CheckPermissions(uid, nil, apc.AceCreateBucket)
+			}
+			ace = apc.AcePATCH
+		case http.MethodPost:
+			ace = apc.AceObjDELETE // multi-object delete
+		case http.MethodDelete:
+			if q.Has(s3.QparamLifecycle) {
+				ace = apc.AcePATCH
+				break
+			}
+			if !q.Has(s3.QparamMultiDelete) {
+				return tk.CheckPermissions(uid, nil, apc.AceDestroyBucket)
+			}
+			ace = apc.AceObjDELETE
+		}
+	case q.Has(s3.QparamTagging) || q.Has(s3.QparamRetention) || q.Has(s3.QparamLegalHold):
+		// object tags and lock are metadata: read with HEAD, modify with PUT permissions
+		ace = apc.AcePUT
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			ace = apc.AceObjHEAD
+		}
+	default:
+		switch r.Method {
+		case http.MethodHead:
+			ace = apc.AceObjHEAD
+		case http.MethodGet:
+			ace = apc.AceGET
+		case http.MethodPut, http.MethodPost: // including multipart upload
+			ace = apc.AcePUT
+			if src := r.Header.Get(cos.S3HdrObjSrc); src != "" {
+				bckName, _, _ := strings.Cut(strings.Trim(src, "/"), "/")
+				if err := p.s3CheckBck(tk, uid, bckName, apc.AceGET); err != nil {
+					return err
+				}
+			}
+		case http.MethodDelete:
+			ace = apc.AceObjDELETE
+		}
+	}
+	if ace == 0 {
+		return nil // (unsupported method, handled elsewhere)
+	}
+	// bypassing governance retention (see cmn.ObjLockGovernance) requires admin
+	if len(items) > 1 && s3.BypassGovernance(r.Header) {
+		if err := tk.CheckPermissions(uid, nil, apc.AceAdmin); err != nil {
+			return err
+		}
+	}
+	return p.s3CheckBck(tk, uid, items[0], ace)
+}
+
+func (p *proxy) s3CheckBck(tk *tok.Token, uid, bckName string, ace apc.AccessAttrs) error {
+	bck, err, _ := cluster.InitByNameOnly(bckName, p.owner.bmd)
+	if err != nil {
+		return nil // (the handler will respond with NoSuchBucket)
+	}
+	return tk.CheckPermissions(uid, bck.Bucket(), ace)
+}
@@ -0,0 +1,91 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// GET /v1/batch
+// multi-object GET (see cmn.GetBatchMsg): validate, authorize, and redirect to
+// a designated target that will assemble and stream back the resulting archive
+func (p *proxy) batchHandler(w http.ResponseWriter, r *http.Request) {
+	if !p.ClusterStartedWithRetry() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		cmn.WriteErr405(w, r, http.MethodGet)
+		return
+	}
+	if _, err := p.apiItems(w, r, 0, false, apc.URLPathBatch.L); err != nil {
+		return
+	}
+	msg := &cmn.GetBatchMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	if err := msg.Validate(); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+
+	// init (and, if need be, add to BMD) all buckets; check permissions
+	var (
+		query = r.URL.Query()
+		done  = make(cos.StrSet, 2)
+	)
+	for i := range msg.Entries {
+		bck := cluster.CloneBck(&msg.Entries[i].Bck)
+		uname := bck.MakeUname("")
+		if done.Contains(uname) {
+			continue
+		}
+		bckArgs := allocInitBckArgs()
+		{
+			bckArgs.p = p
+			bckArgs.w = w
+			bckArgs.r = r
+			bckArgs.bck = bck
+			bckArgs.query = query
+			bckArgs.perms = apc.AceGET
+			bckArgs.createAIS = false
+		}
+		_, err := bckArgs.initAndTry()
+		freeInitBckArgs(bckArgs)
+		if err != nil {
+			return
+		}
+		done.Add(uname)
+	}
+
+	// redirect
+	var (
+		uuid = cos.GenUUID()
+		smap = p.owner.smap.get()
+	)
+	si, err := cluster.HrwTarget(uuid, &smap.Smap)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s %s(%d entries) => %s", r.Method, apc.ActGetBatch, len(msg.Entries), si)
+	}
+	query.Set(apc.QparamUUID, uuid)
+	r.URL.RawQuery = query.Encode()
+	redirectURL := p.redirectURL(r, si, time.Now() /*started*/, cmn.NetIntraData)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect) // NOTE: preserving the body
+
+	p.statsT.Inc(stats.GetCount)
+}
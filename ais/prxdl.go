@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -27,6 +28,10 @@ func (p *proxy) downloadHandler(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusServiceUnavailable)
 		return
 	}
+	if strings.HasPrefix(r.URL.Path, apc.URLPathDownloadSched.S) {
+		p.httpdlsched(w, r)
+		return
+	}
 	switch r.Method {
 	case http.MethodGet, http.MethodDelete:
 		p.httpdladm(w, r)
@@ -83,8 +88,6 @@ func (p *proxy) httpdlpost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jobID := dload.PrefixJobID + cos.GenUUID() // prefix to visually differentiate vs. xaction IDs
-
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		p.writeErrStatusf(w, r, http.StatusInternalServerError, "Error starting download: %v", err)
@@ -94,30 +97,39 @@ func (p *proxy) httpdlpost(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
+	jobID, errCode, err := p.dlnew(&dlb, &dlBase, body)
+	if err != nil {
+		p.writeErrStatusf(w, r, errCode, "Error starting download: %v", err)
+		return
+	}
+
+	w.Header().Set(cos.HdrContentType, cos.ContentJSON)
+	b := cos.MustMarshal(dload.DlPostResp{ID: jobID})
+	w.Write(b)
+}
 
+// start new download job (is called by the handler above and by scheduled downloads - see prxdlsched.go)
+func (p *proxy) dlnew(dlb *dload.Body, dlBase *dload.Base, body []byte) (jobID string, errCode int, err error) {
 	var progressInterval = dload.DownloadProgressInterval
 	if dlBase.ProgressInterval != "" {
-		ival, err := time.ParseDuration(dlBase.ProgressInterval)
-		if err != nil {
-			p.writeErrf(w, r, "%s: invalid progress interval %q: %v", p, dlBase.ProgressInterval, err)
-			return
+		ival, errV := time.ParseDuration(dlBase.ProgressInterval)
+		if errV != nil {
+			err = fmt.Errorf("%s: invalid progress interval %q: %v", p, dlBase.ProgressInterval, errV)
+			return "", http.StatusBadRequest, err
 		}
 		progressInterval = ival
 	}
 
+	jobID = dload.PrefixJobID + cos.GenUUID() // prefix to visually differentiate vs. xaction IDs
 	xid := cos.GenUUID()
-	if errCode, err := p.dlstart(r, xid, jobID, body); err != nil {
-		p.writeErrStatusf(w, r, errCode, "Error starting download: %v", err)
-		return
+	if errCode, err = p.dlstart(xid, jobID, body); err != nil {
+		return "", errCode, err
 	}
 	smap := p.owner.smap.get()
 	nl := dload.NewDownloadNL(jobID, string(dlb.Type), &smap.Smap, progressInterval)
 	nl.SetOwner(equalIC)
 	p.ic.registerEqual(regIC{nl: nl, smap: smap})
-
-	w.Header().Set(cos.HdrContentType, cos.ContentJSON)
-	b := cos.MustMarshal(dload.DlPostResp{ID: jobID})
-	w.Write(b)
+	return jobID, http.StatusOK, nil
 }
 
 func (p *proxy) dladm(method, path string, msg *dload.AdminBody) ([]byte, int, error) {
@@ -244,12 +256,12 @@ func (p *proxy) dlstatus(nl nl.Listener) ([]byte, int, error) {
 	return respJSON, http.StatusOK, nil
 }
 
-func (p *proxy) dlstart(r *http.Request, xid, jobID string, body []byte) (errCode int, err error) {
+func (p *proxy) dlstart(xid, jobID string, body []byte) (errCode int, err error) {
 	query := make(url.Values, 2)
 	query.Set(apc.QparamUUID, xid)
 	query.Set(apc.QparamJobID, jobID)
 	args := allocBcArgs()
-	args.req = cmn.HreqArgs{Method: http.MethodPost, Path: r.URL.Path, Body: body, Query: query}
+	args.req = cmn.HreqArgs{Method: http.MethodPost, Path: apc.URLPathDownload.S, Body: body, Query: query}
 	config := cmn.GCO.Get()
 	args.timeout = config.Timeout.MaxHostBusy.D()
 	results := p.bcastGroup(args)
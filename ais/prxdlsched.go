@@ -0,0 +1,364 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dload"
+	"github.com/NVIDIA/aistore/hk"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Scheduled (recurring) downloads are stored in the destination buckets' props
+// (see cmn.DownloadConf) and, therefore, survive restarts and primary failover.
+// The primary evaluates the schedules and starts each run as a regular download
+// job (see dload.PrefixScheduleID, and ext/dload/schedule.go).
+// NOTE: only the primary keeps the (in-memory) next-run times. A new primary
+// recomputes them: intervals are counted from the schedule creation, and cron
+// schedules resume with their next matching time.
+
+const dlschedIval = 10 * time.Second
+
+type dlsched struct {
+	p    *proxy
+	next map[string]time.Time // schedule ID => next run
+	jobs map[string]string    // schedule ID => the most recently started job
+	mu   sync.Mutex
+}
+
+func (s *dlsched) init(p *proxy) {
+	s.p = p
+	s.next = make(map[string]time.Time, 4)
+	s.jobs = make(map[string]string, 4)
+	hk.Reg("download-schedule"+hk.NameSuffix, s.housekeep, dlschedIval)
+}
+
+func (s *dlsched) housekeep() time.Duration {
+	p := s.p
+	if !p.ClusterStarted() || !p.owner.smap.get().isPrimary(p.si) {
+		s.mu.Lock()
+		if len(s.next) > 0 {
+			s.next = make(map[string]time.Time, 4)
+		}
+		s.mu.Unlock()
+		return dlschedIval
+	}
+	for _, due := range s.due(time.Now()) {
+		go s.run(due.sched, due.bck)
+	}
+	return dlschedIval
+}
+
+type dlschedDue struct {
+	sched *cmn.DlSchedule
+	bck   *cluster.Bck
+}
+
+func (s *dlsched) due(now time.Time) (due []dlschedDue) {
+	var (
+		bmd  = s.p.owner.bmd.get()
+		seen = make(cos.StrSet, len(s.next))
+	)
+	s.mu.Lock()
+	bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+		schedules := bck.Props.Download.Schedules
+		for i := range schedules {
+			sched := &schedules[i]
+			if sched.Paused {
+				continue
+			}
+			seen.Set(sched.ID)
+			next, ok := s.next[sched.ID]
+			if !ok {
+				s.next[sched.ID] = sched.Next(now)
+				continue
+			}
+			if next.IsZero() || now.Before(next) {
+				continue
+			}
+			s.next[sched.ID] = sched.Next(now)
+			due = append(due, dlschedDue{sched: sched, bck: bck})
+		}
+		return false
+	})
+	for id := range s.next {
+		if !seen.Contains(id) { // removed or paused
+			delete(s.next, id)
+		}
+	}
+	for id := range s.jobs {
+		if _, ok := s.next[id]; !ok {
+			delete(s.jobs, id)
+		}
+	}
+	s.mu.Unlock()
+	return
+}
+
+func (s *dlsched) run(sched *cmn.DlSchedule, bck *cluster.Bck) {
+	p := s.p
+	s.mu.Lock()
+	prev := s.jobs[sched.ID]
+	s.mu.Unlock()
+	if prev != "" {
+		if nl := p.notifs.entry(prev); nl != nil && !nl.Finished() {
+			glog.Warningf("%s: skipping scheduled download %s (bucket %s) - previous run %s is still running",
+				p, sched, bck, prev)
+			return
+		}
+	}
+	dlb := dload.Body{}
+	if err := jsoniter.Unmarshal(sched.Request, &dlb); err != nil {
+		glog.Errorf("%s: scheduled download %s (bucket %s): %v", p, sched, bck, err)
+		return
+	}
+	if err := dlb.SetSchedule(sched.ID); err != nil {
+		glog.Errorf("%s: scheduled download %s (bucket %s): %v", p, sched, bck, err)
+		return
+	}
+	dlBase := dload.Base{}
+	if err := jsoniter.Unmarshal(dlb.RawMessage, &dlBase); err != nil {
+		glog.Errorf("%s: scheduled download %s (bucket %s): %v", p, sched, bck, err)
+		return
+	}
+	jobID, _, err := p.dlnew(&dlb, &dlBase, dlb.RawMessage)
+	if err != nil {
+		glog.Errorf("%s: failed to start scheduled download %s (bucket %s): %v", p, sched, bck, err)
+		return
+	}
+	s.mu.Lock()
+	s.jobs[sched.ID] = jobID
+	s.mu.Unlock()
+	glog.Infof("%s: started scheduled download %s (bucket %s): job %s", p, sched, bck, jobID)
+}
+
+func (s *dlsched) info(sched *cmn.DlSchedule, bck *cluster.Bck) *dload.ScheduleInfo {
+	info := dload.NewScheduleInfo(sched, bck.Bucket())
+	s.mu.Lock()
+	info.NextRun, info.LastJobID = s.next[sched.ID], s.jobs[sched.ID]
+	s.mu.Unlock()
+	if info.NextRun.IsZero() && !sched.Paused {
+		info.NextRun = sched.Next(time.Now())
+	}
+	return info
+}
+
+///////////
+// proxy //
+///////////
+
+// GET    /v1/download/schedule[/<id>]
+// POST   /v1/download/schedule
+// PUT    /v1/download/schedule/<id>/{pause, resume}
+// DELETE /v1/download/schedule/<id>
+func (p *proxy) httpdlsched(w http.ResponseWriter, r *http.Request) {
+	items, err := p.apiItems(w, r, 0, true, apc.URLPathDownloadSched.L)
+	if err != nil {
+		return
+	}
+	if p.forwardCP(w, r, nil, "download schedule") {
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && len(items) == 0:
+		p.listDlScheds(w, r)
+	case r.Method == http.MethodGet && len(items) == 1:
+		p.getDlSched(w, r, items[0])
+	case r.Method == http.MethodPost && len(items) == 0:
+		p.newDlSched(w, r)
+	case r.Method == http.MethodPut && len(items) == 2:
+		if items[1] != apc.Pause && items[1] != apc.Resume {
+			p.writeErrAct(w, r, items[1])
+			return
+		}
+		p.pauseDlSched(w, r, items[0], items[1] == apc.Pause)
+	case r.Method == http.MethodDelete && len(items) == 1:
+		p.rmDlSched(w, r, items[0])
+	case r.Method != http.MethodGet && r.Method != http.MethodPost && r.Method != http.MethodPut &&
+		r.Method != http.MethodDelete:
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPost, http.MethodPut)
+	default:
+		p.writeErrURL(w, r)
+	}
+}
+
+func (p *proxy) listDlScheds(w http.ResponseWriter, r *http.Request) {
+	infos := make(dload.ScheduleInfos, 0, 4)
+	p.owner.bmd.get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		schedules := bck.Props.Download.Schedules
+		for i := range schedules {
+			infos = append(infos, p.dlsched.info(&schedules[i], bck))
+		}
+		return false
+	})
+	sort.Sort(infos)
+	p.writeJSON(w, r, infos, "list-download-schedules")
+}
+
+// including the history of runs (as recorded by the targets)
+func (p *proxy) getDlSched(w http.ResponseWriter, r *http.Request, id string) {
+	sched, bck, err := p.findDlSched(id)
+	if err != nil {
+		p.writeErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	info := p.dlsched.info(sched, bck)
+
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodGet, Path: apc.URLPathDownloadSched.Join(id)}
+	args.timeout = cmn.Timeout.MaxKeepalive()
+	args.cresv = cresDH{}
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	histories := make([][]dload.Job, 0, len(results))
+	for _, res := range results {
+		if res.err != nil {
+			p.writeErr(w, r, res.toErr())
+			freeBcastRes(results)
+			return
+		}
+		histories = append(histories, *res.v.(*[]dload.Job))
+	}
+	freeBcastRes(results)
+	info.Runs = dload.MergeRuns(histories...)
+	p.writeJSON(w, r, info, "get-download-schedule")
+}
+
+func (p *proxy) newDlSched(w http.ResponseWriter, r *http.Request) {
+	sb := &dload.ScheduleBody{}
+	if err := cmn.ReadJSON(w, r, sb); err != nil {
+		return
+	}
+	if err := sb.Validate(); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if _, _, ok := p.validateStartDownload(w, r, sb.Req.RawMessage); !ok {
+		return
+	}
+	sched := sb.ToSchedule()
+	if _, _, err := p.findDlSched(sched.ID); err == nil {
+		p.writeErrf(w, r, "download schedule %q already exists", sched.ID)
+		return
+	}
+	dlBase := dload.Base{}
+	if err := jsoniter.Unmarshal(sb.Req.RawMessage, &dlBase); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	bck := cluster.CloneBck(&dlBase.Bck)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	schedules := make([]cmn.DlSchedule, 0, len(bck.Props.Download.Schedules)+1)
+	schedules = append(schedules, bck.Props.Download.Schedules...)
+	schedules = append(schedules, *sched)
+	if err := p.setDlScheds(bck, schedules); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	glog.Infof("%s: new download schedule %s (bucket %s)", p, sched, bck)
+	w.Header().Set(cos.HdrContentType, cos.ContentJSON)
+	w.Write(cos.MustMarshal(dload.DlPostResp{ID: sched.ID}))
+}
+
+func (p *proxy) pauseDlSched(w http.ResponseWriter, r *http.Request, id string, pause bool) {
+	sched, bck, err := p.findDlSched(id)
+	if err != nil {
+		p.writeErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	if err := p.checkAccess(w, r, bck, apc.AccessRW); err != nil {
+		return
+	}
+	if sched.Paused == pause {
+		return
+	}
+	schedules := make([]cmn.DlSchedule, len(bck.Props.Download.Schedules))
+	copy(schedules, bck.Props.Download.Schedules)
+	for i := range schedules {
+		if schedules[i].ID == id {
+			schedules[i].Paused = pause
+		}
+	}
+	if err := p.setDlScheds(bck, schedules); err != nil {
+		p.writeErr(w, r, err)
+	}
+}
+
+func (p *proxy) rmDlSched(w http.ResponseWriter, r *http.Request, id string) {
+	_, bck, err := p.findDlSched(id)
+	if err != nil {
+		p.writeErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	if err := p.checkAccess(w, r, bck, apc.AccessRW); err != nil {
+		return
+	}
+	schedules := make([]cmn.DlSchedule, 0, len(bck.Props.Download.Schedules))
+	for _, sched := range bck.Props.Download.Schedules {
+		if sched.ID != id {
+			schedules = append(schedules, sched)
+		}
+	}
+	if err := p.setDlScheds(bck, schedules); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	// cleanup run history (best effort)
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodDelete, Path: apc.URLPathDownloadSched.Join(id)}
+	args.timeout = cmn.Timeout.MaxKeepalive()
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			glog.Errorf("%s: failed to remove download schedule %q history: %v", p, id, res.toErr())
+		}
+	}
+	freeBcastRes(results)
+}
+
+func (p *proxy) findDlSched(id string) (sched *cmn.DlSchedule, bck *cluster.Bck, err error) {
+	p.owner.bmd.get().Range(nil, nil, func(b *cluster.Bck) bool {
+		if sched = b.Props.Download.Find(id); sched != nil {
+			bck = b
+			return true
+		}
+		return false
+	})
+	if sched == nil {
+		err = cmn.NewErrNotFound("%s: download schedule %q", p.si, id)
+	}
+	return
+}
+
+func (p *proxy) setDlScheds(bck *cluster.Bck, schedules []cmn.DlSchedule) error {
+	var (
+		msg           = &apc.ActMsg{Action: apc.ActSetBprops}
+		propsToUpdate = cmn.BucketPropsToUpdate{
+			Download: &cmn.DownloadConfToUpdate{Schedules: &schedules},
+		}
+	)
+	nprops, err := p.makeNewBckProps(bck, &propsToUpdate)
+	if err != nil {
+		return err
+	}
+	if _, err := p.setBucketProps(msg, bck, nprops); err != nil {
+		return fmt.Errorf("%s: failed to update %s download schedules: %v", p, bck, err)
+	}
+	return nil
+}
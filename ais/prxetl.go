@@ -5,6 +5,7 @@
 package ais
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -115,6 +116,20 @@ func (p *proxy) handleETLPut(w http.ResponseWriter, r *http.Request) {
 		p.writeErrf(w, r, "%s: etl[%s] already exists", p, initMsg.Name())
 		return
 	}
+	// pipeline stages must exist and cannot be pipelines themselves
+	if msg, ok := initMsg.(*etl.PipelineMsg); ok {
+		for _, stage := range msg.Stages {
+			stageMsg := etlMD.get(stage.Name)
+			if stageMsg == nil {
+				p.writeErr(w, r, cmn.NewErrNotFound("%s: %s stage etl[%s]", p, msg, stage.Name))
+				return
+			}
+			if stageMsg.Type() == etl.Pipeline {
+				p.writeErrf(w, r, "%s: %s stage etl[%s] is a pipeline (nesting is not supported)", p, msg, stage.Name)
+				return
+			}
+		}
+	}
 
 	// add to cluster MD and start running
 	if err := p.startETL(w, initMsg, true /*add to etlMD*/); err != nil {
@@ -181,6 +196,19 @@ func (p *proxy) handleETLDelete(w http.ResponseWriter, r *http.Request) {
 
 func (p *proxy) _deleteETLPre(ctx *etlMDModifier, clone *etlMD) (err error) {
 	debug.AssertNoErr(k8s.ValidateEtlName(ctx.etlName))
+	// cannot delete ETL that is a stage of an existing pipeline
+	for _, msg := range clone.ETLs {
+		pipeline, ok := msg.(*etl.PipelineMsg)
+		if !ok {
+			continue
+		}
+		for _, stage := range pipeline.Stages {
+			if stage.Name == ctx.etlName {
+				return fmt.Errorf("%s: cannot delete etl[%s] - used by %s (delete the pipeline first)",
+					p, ctx.etlName, pipeline)
+			}
+		}
+	}
 	if exists := clone.del(ctx.etlName); !exists {
 		err = cmn.NewErrNotFound("%s: etl[%s]", p, ctx.etlName)
 	}
@@ -19,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/sigv4"
 	jsoniter "github.com/json-iterator/go"
 )
 
@@ -33,12 +34,28 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 		glog.Infof("S3Request: %s - %s", r.Method, r.URL)
 	}
 
+	tk, err := p.s3Authenticate(r)
+	if err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+
 	// TODO: Fix the hack, https://github.com/tensorflow/tensorflow/issues/41798
 	cos.ReparseQuery(r)
 	apiItems, err := p.apiItems(w, r, 0, true, apc.URLPathS3.L)
 	if err != nil {
 		return
 	}
+	if tk != nil {
+		if err := p.s3Authorize(tk, r, apiItems); err != nil {
+			s3.WriteErr(w, r, err, http.StatusForbidden)
+			return
+		}
+	}
+	// payload integrity: request bodies handled by the proxy itself (objects - see t.s3Handler)
+	if r.Method == http.MethodPut || r.Method == http.MethodPost {
+		r.Body = sigv4.NewPayloadReader(r.Body, r.Header.Get(cos.S3HdrContentSHA256))
+	}
 
 	switch r.Method {
 	case http.MethodHead:
@@ -64,8 +81,22 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 			_, policy    = q[s3.QparamPolicy]
 			_, cors      = q[s3.QparamCORS]
 			_, acl       = q[s3.QparamACL]
+			_, tagging   = q[s3.QparamTagging]
 		)
-		if lifecycle || policy || cors || acl {
+		if lifecycle && len(apiItems) == 1 {
+			p.getBckLifecycleS3(w, r, apiItems[0])
+			return
+		}
+		if q.Has(s3.QparamObjLock) && len(apiItems) == 1 {
+			p.getBckObjLockS3(w, r, apiItems[0])
+			return
+		}
+		if q.Has(s3.QparamNotification) && len(apiItems) == 1 {
+			p.getBckNotifS3(w, r, apiItems[0])
+			return
+		}
+		// (bucket tagging is not supported - object tagging is)
+		if lifecycle || policy || cors || acl || (tagging && len(apiItems) == 1) {
 			p.unsupported(w, r, apiItems[0])
 			return
 		}
@@ -76,6 +107,10 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.getBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if q.Has(s3.QparamVersions) {
+				p.listObjectVersionsS3(w, r, apiItems[0])
+				return
+			}
 			// only bucket name - list objects in the bucket
 			p.listObjectsS3(w, r, apiItems[0])
 			return
@@ -89,6 +124,22 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 		}
 		if len(apiItems) == 1 {
 			q := r.URL.Query()
+			if q.Has(s3.QparamTagging) {
+				p.unsupported(w, r, apiItems[0])
+				return
+			}
+			if q.Has(s3.QparamLifecycle) {
+				p.putBckLifecycleS3(w, r, apiItems[0])
+				return
+			}
+			if q.Has(s3.QparamObjLock) {
+				p.putBckObjLockS3(w, r, apiItems[0])
+				return
+			}
+			if q.Has(s3.QparamNotification) {
+				p.putBckNotifS3(w, r, apiItems[0])
+				return
+			}
 			_, versioning := q[s3.QparamVersioning]
 			if versioning {
 				p.putBckVersioningS3(w, r, apiItems[0])
@@ -120,6 +171,14 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 		}
 		if len(apiItems) == 1 {
 			q := r.URL.Query()
+			if q.Has(s3.QparamTagging) {
+				p.unsupported(w, r, apiItems[0])
+				return
+			}
+			if q.Has(s3.QparamLifecycle) {
+				p.delBckLifecycleS3(w, r, apiItems[0])
+				return
+			}
 			_, multiple := q[s3.QparamMultiDelete]
 			if multiple {
 				p.delMultipleObjs(w, r, apiItems[0])
@@ -164,6 +223,10 @@ func (p *proxy) putBckS3(w http.ResponseWriter, r *http.Request, bucket string)
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if cos.IsParseBool(r.Header.Get(cos.S3HdrBckObjLock)) {
+		bck.Props = defaultBckProps(bckPropsArgs{bck: bck})
+		bck.Props.ObjLock.Enabled = true
+	}
 	if err := p.createBucket(&msg, bck, nil); err != nil {
 		s3.WriteErr(w, r, err, crerrStatus(err))
 	}
@@ -345,6 +408,35 @@ func (p *proxy) listObjectsS3(w http.ResponseWriter, r *http.Request, bucket str
 	sgl.Free()
 }
 
+// GET /s3/<bucket-name>?versions (ais buckets that retain versions - see cmn.VersionConf.Retain)
+func (p *proxy) listObjectVersionsS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	if !bck.IsAIS() {
+		s3.WriteErr(w, r, cmn.NewErrUnsupp("list object versions in", bck.Cname("")), 0)
+		return
+	}
+	lsmsg := &apc.LsoMsg{UUID: cos.GenUUID(), TimeFormat: cos.ISO8601}
+	lsmsg.AddProps(apc.GetPropsSize, apc.GetPropsChecksum, apc.GetPropsAtime, apc.GetPropsVersion)
+	s3.FillMsgFromS3VersionsQuery(r.URL.Query(), lsmsg)
+
+	lst, err := p.lsObjsA(bck, lsmsg)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	resp := s3.NewListVersionsResult(bucket, lsmsg)
+	resp.FillFromLso(lst, lsmsg)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
 // PUT /s3/<bucket-name>/<object-name>
 func (p *proxy) putObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	if r.Header.Get(cos.S3HdrObjSrc) == "" {
@@ -389,7 +481,17 @@ func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string
 		return
 	}
 	objName := strings.Trim(parts[1], "/")
-	si, err = cluster.HrwTarget(bckSrc.MakeUname(objName), &smap.Smap)
+	uname := bckSrc.MakeUname(objName)
+	if q := r.URL.Query(); q.Has(s3.QparamMptPartNo) && q.Has(s3.QparamMptUploadID) {
+		// upload part copy: redirect to the owner of the destination (and its multipart upload)
+		// that'll pull the source from its respective owner
+		if len(items) < 2 {
+			s3.WriteErr(w, r, errS3Obj, 0)
+			return
+		}
+		uname = bckDst.MakeUname(s3.ObjName(items))
+	}
+	si, err = cluster.HrwTarget(uname, &smap.Smap)
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
@@ -562,8 +664,12 @@ func (p *proxy) delObjS3(w http.ResponseWriter, r *http.Request, items []string)
 	var (
 		si   *cluster.Snode
 		smap = p.owner.smap.get()
+		ace  = apc.AceObjDELETE
 	)
-	if err = bck.Allow(apc.AceObjDELETE); err != nil {
+	if r.URL.Query().Has(s3.QparamTagging) {
+		ace = apc.AcePUT // removing object tags
+	}
+	if err = bck.Allow(ace); err != nil {
 		s3.WriteErr(w, r, err, http.StatusForbidden)
 		return
 	}
@@ -600,7 +706,7 @@ func (p *proxy) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 	sgl.Free()
 }
 
-// GET /s3/<bucket-name>?lifecycle|cors|policy|acl
+// GET /s3/<bucket-name>?cors|policy|acl
 func (p *proxy) unsupported(w http.ResponseWriter, r *http.Request, bucket string) {
 	if _, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd); err != nil {
 		s3.WriteErr(w, r, err, errCode)
@@ -640,3 +746,178 @@ func (p *proxy) putBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 		s3.WriteErr(w, r, err, 0)
 	}
 }
+
+// GET /s3/<bucket-name>?lifecycle
+func (p *proxy) getBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	if len(bck.Props.Lifecycle.Rules) == 0 {
+		s3.WriteErr(w, r, s3.ErrNoLifecycle, http.StatusNotFound)
+		return
+	}
+	resp := s3.NewLifecycleConfiguration(&bck.Props.Lifecycle)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// GET /s3/<bucket-name>?object-lock
+func (p *proxy) getBckObjLockS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	if !bck.Props.ObjLock.Enabled {
+		s3.WriteErr(w, r, s3.ErrNoObjLockConf, http.StatusNotFound)
+		return
+	}
+	resp := s3.NewObjLockConfiguration(&bck.Props.ObjLock)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?object-lock
+// (enables object lock, if need be, and sets or removes default retention)
+func (p *proxy) putBckObjLockS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	lconf := &s3.ObjLockConfiguration{}
+	if err := xml.NewDecoder(r.Body).Decode(lconf); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	conf, err := lconf.ToConf()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	propsToUpdate := cmn.BucketPropsToUpdate{
+		ObjLock: &cmn.ObjLockConfToUpdate{Enabled: &conf.Enabled, Mode: &conf.Mode, Days: &conf.Days},
+	}
+	// make and validate new props
+	nprops, err := p.makeNewBckProps(bck, &propsToUpdate)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if _, err := p.setBucketProps(msg, bck, nprops); err != nil {
+		s3.WriteErr(w, r, err, 0)
+	}
+}
+
+// GET /s3/<bucket-name>?notification
+// (empty configuration when none)
+func (p *proxy) getBckNotifS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	resp := s3.NewNotifConfiguration(&bck.Props.Notif)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?notification
+// (replaces the bucket's notification targets in their entirety)
+func (p *proxy) putBckNotifS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	nconf := &s3.NotifConfiguration{}
+	if err := xml.NewDecoder(r.Body).Decode(nconf); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	conf, err := nconf.ToConf()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	propsToUpdate := cmn.BucketPropsToUpdate{
+		Notif: &cmn.NotifConfToUpdate{Targets: &conf.Targets},
+	}
+	// make and validate new props
+	nprops, err := p.makeNewBckProps(bck, &propsToUpdate)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if _, err := p.setBucketProps(msg, bck, nprops); err != nil {
+		s3.WriteErr(w, r, err, 0)
+	}
+}
+
+// PUT /s3/<bucket-name>?lifecycle
+// (replaces the bucket's lifecycle rules in their entirety)
+func (p *proxy) putBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	lcy := &s3.LifecycleConfiguration{}
+	if err := xml.NewDecoder(r.Body).Decode(lcy); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	conf, err := lcy.ToConf()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	p.setBckLifecycleS3(w, r, bucket, conf.Rules)
+}
+
+// DELETE /s3/<bucket-name>?lifecycle
+func (p *proxy) delBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	if p.setBckLifecycleS3(w, r, bucket, []cmn.LifecycleRule{}) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (p *proxy) setBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string, rules []cmn.LifecycleRule) bool {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return false
+	}
+	bck, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return false
+	}
+	propsToUpdate := cmn.BucketPropsToUpdate{
+		Lifecycle: &cmn.LifecycleConfToUpdate{Rules: &rules},
+	}
+	// make and validate new props
+	nprops, err := p.makeNewBckProps(bck, &propsToUpdate)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return false
+	}
+	if _, err := p.setBucketProps(msg, bck, nprops); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return false
+	}
+	return true
+}
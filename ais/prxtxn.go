@@ -364,6 +364,7 @@ func (p *proxy) setBucketProps(msg *apc.ActMsg, bck *cluster.Bck, nprops *cmn.Bu
 			bargs.hdr = remoteBckProps
 		}
 		nprops = defaultBckProps(bargs)
+		nprops.ObjLock = bprops.ObjLock // (cannot be disabled - see makeNewBckProps)
 	default:
 		return "", fmt.Errorf(fmtErrInvaldAction, msg.Action, []string{apc.ActSetBprops, apc.ActResetBprops})
 	}
@@ -1132,6 +1133,10 @@ func (p *proxy) makeNewBckProps(bck *cluster.Bck, propsToUpdate *cmn.BucketProps
 		nprops.Versioning.Enabled = false
 		// TODO: Check if the `RefDirectory` does not overlap with other buckets.
 	}
+	if bprops.ObjLock.Enabled && !nprops.ObjLock.Enabled {
+		err = fmt.Errorf("%s: once enabled, object lock cannot be disabled (bucket %s)", p.si, bck)
+		return
+	}
 	if bprops.EC.Enabled && nprops.EC.Enabled {
 		sameSlices := bprops.EC.DataSlices == nprops.EC.DataSlices && bprops.EC.ParitySlices == nprops.EC.ParitySlices
 		sameLimit := bprops.EC.ObjSizeLimit == nprops.EC.ObjSizeLimit
@@ -8,9 +8,11 @@ const (
 	// AWS URL params
 	QparamVersioning        = "versioning"
 	QparamLifecycle         = "lifecycle"
+	QparamNotification      = "notification"
 	QparamCORS              = "cors"
 	QparamPolicy            = "policy"
 	QparamACL               = "acl"
+	QparamTagging           = "tagging"
 	QparamMultiDelete       = "delete"
 	QparamMaxKeys           = "max-keys"
 	QparamPrefix            = "prefix"
@@ -18,6 +20,17 @@ const (
 	QparamStartAfter        = "start-after"
 	QparamDelimiter         = "delimiter"
 
+	// object lock
+	QparamObjLock   = "object-lock"
+	QparamRetention = "retention"
+	QparamLegalHold = "legal-hold"
+
+	// versions
+	QparamVersions        = "versions"
+	QparamVersionID       = "versionId"
+	QparamKeyMarker       = "key-marker"
+	QparamVersionIDMarker = "version-id-marker"
+
 	// multipart
 	QparamMptUploads        = "uploads"
 	QparamMptUploadID       = "uploadId"
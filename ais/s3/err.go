@@ -6,11 +6,13 @@ package s3
 
 import (
 	"encoding/xml"
+	"errors"
 	"net/http"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/sigv4"
 	"github.com/NVIDIA/aistore/memsys"
 )
 
@@ -33,7 +35,11 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, errCode int) {
 		in        *cmn.ErrHTTP
 		ok        bool
 		allocated bool
+		errSig    *sigv4.Error
 	)
+	if errors.As(err, &errSig) && errSig.Code == sigv4.ErrCodeSHA256Mismatch {
+		errCode = http.StatusBadRequest // (payload, rather than server, error)
+	}
 	if in, ok = err.(*cmn.ErrHTTP); !ok {
 		in = cmn.InitErrHTTP(r, err, errCode)
 		allocated = true
@@ -44,6 +50,18 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, errCode int) {
 		out.Code = "BucketAlreadyExists"
 	case cmn.IsErrBckNotFound(err):
 		out.Code = "NoSuchBucket"
+	case cmn.IsErrQuotaExceeded(err):
+		out.Code = "QuotaExceeded"
+	case cmn.IsErrInvalidTag(err):
+		out.Code = "InvalidTag"
+	case err == ErrNoLifecycle:
+		out.Code = "NoSuchLifecycleConfiguration"
+	case cmn.IsErrObjLocked(err):
+		out.Code = "AccessDenied"
+	case err == ErrNoObjLockConf:
+		out.Code = "ObjectLockConfigurationNotFoundError"
+	case errors.As(err, &errSig):
+		out.Code = errSig.Code
 	default:
 		out.Code = in.TypeCode
 	}
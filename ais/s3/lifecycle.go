@@ -0,0 +1,182 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Bucket lifecycle configuration: S3 XML <=> cmn.LifecycleConf
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLifecycleConfiguration.html
+//
+// Limitations:
+// - expiration by date (as opposed to number of days) is not supported;
+// - "Transition" maps to eviction (`evict_days`) and is only valid for buckets
+//   with remote backends - the storage class is ignored.
+
+const (
+	lcyEnabled  = "Enabled"
+	lcyDisabled = "Disabled"
+)
+
+var ErrNoLifecycle = errors.New("the lifecycle configuration does not exist")
+
+type (
+	LifecycleConfiguration struct {
+		XMLName xml.Name        `xml:"LifecycleConfiguration"`
+		Rules   []LifecycleRule `xml:"Rule"`
+	}
+	LifecycleRule struct {
+		ID                             string                `xml:"ID,omitempty"`
+		Filter                         *LifecycleFilter      `xml:"Filter,omitempty"`
+		Prefix                         *string               `xml:"Prefix,omitempty"` // legacy (superseded by Filter)
+		Status                         string                `xml:"Status"`
+		Expiration                     *LifecycleExpiration  `xml:"Expiration,omitempty"`
+		Transition                     *LifecycleExpiration  `xml:"Transition,omitempty"`
+		NoncurrentVersionExpiration    *LifecycleNoncurrent  `xml:"NoncurrentVersionExpiration,omitempty"`
+		AbortIncompleteMultipartUpload *LifecycleAbortUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
+	}
+	LifecycleFilter struct {
+		Prefix string        `xml:"Prefix,omitempty"`
+		Tag    *Tag          `xml:"Tag,omitempty"`
+		And    *LifecycleAnd `xml:"And,omitempty"`
+	}
+	LifecycleAnd struct {
+		Prefix string `xml:"Prefix,omitempty"`
+		Tags   []Tag  `xml:"Tag"`
+	}
+	// (used for both expiration and transition)
+	LifecycleExpiration struct {
+		Days         int    `xml:"Days,omitempty"`
+		Date         string `xml:"Date,omitempty"`
+		StorageClass string `xml:"StorageClass,omitempty"` // (transition only; ignored)
+	}
+	LifecycleNoncurrent struct {
+		NoncurrentDays int `xml:"NoncurrentDays"`
+	}
+	LifecycleAbortUpload struct {
+		DaysAfterInitiation int `xml:"DaysAfterInitiation"`
+	}
+)
+
+func NewLifecycleConfiguration(conf *cmn.LifecycleConf) *LifecycleConfiguration {
+	r := &LifecycleConfiguration{Rules: make([]LifecycleRule, 0, len(conf.Rules))}
+	for i := range conf.Rules {
+		var (
+			in  = &conf.Rules[i]
+			out = LifecycleRule{ID: in.ID, Status: lcyEnabled, Filter: newLcyFilter(in)}
+		)
+		if in.Disabled {
+			out.Status = lcyDisabled
+		}
+		if in.ExpirationDays > 0 {
+			out.Expiration = &LifecycleExpiration{Days: in.ExpirationDays}
+		}
+		if in.EvictDays > 0 {
+			out.Transition = &LifecycleExpiration{Days: in.EvictDays}
+		}
+		if in.NoncurrentDays > 0 {
+			out.NoncurrentVersionExpiration = &LifecycleNoncurrent{NoncurrentDays: in.NoncurrentDays}
+		}
+		if in.AbortMptDays > 0 {
+			out.AbortIncompleteMultipartUpload = &LifecycleAbortUpload{DaysAfterInitiation: in.AbortMptDays}
+		}
+		r.Rules = append(r.Rules, out)
+	}
+	return r
+}
+
+func newLcyFilter(rule *cmn.LifecycleRule) *LifecycleFilter {
+	f := &LifecycleFilter{}
+	switch {
+	case len(rule.Tags) == 0:
+		f.Prefix = rule.Prefix
+	case len(rule.Tags) == 1 && rule.Prefix == "":
+		for k, v := range rule.Tags {
+			f.Tag = &Tag{Key: k, Value: v}
+		}
+	default:
+		f.And = &LifecycleAnd{Prefix: rule.Prefix, Tags: NewTagging(rule.Tags).TagSet}
+	}
+	return f
+}
+
+func (r *LifecycleConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// convert to bucket props (the caller then validates the result as part of bucket props)
+func (r *LifecycleConfiguration) ToConf() (*cmn.LifecycleConf, error) {
+	conf := &cmn.LifecycleConf{Rules: make([]cmn.LifecycleRule, 0, len(r.Rules))}
+	for i := range r.Rules {
+		rule, err := r.Rules[i].toRule(i)
+		if err != nil {
+			return nil, err
+		}
+		conf.Rules = append(conf.Rules, rule)
+	}
+	return conf, nil
+}
+
+func (in *LifecycleRule) toRule(idx int) (out cmn.LifecycleRule, err error) {
+	out.ID = in.ID
+	if out.ID == "" {
+		out.ID = fmt.Sprintf("rule-%d", idx+1)
+	}
+	switch in.Status {
+	case lcyEnabled:
+	case lcyDisabled:
+		out.Disabled = true
+	default:
+		return out, fmt.Errorf("lifecycle rule %q: invalid status %q", out.ID, in.Status)
+	}
+	if in.Prefix != nil {
+		out.Prefix = *in.Prefix
+	}
+	if f := in.Filter; f != nil {
+		if in.Prefix != nil {
+			return out, fmt.Errorf("lifecycle rule %q: prefix and filter are mutually exclusive", out.ID)
+		}
+		switch {
+		case f.And != nil:
+			out.Prefix = f.And.Prefix
+			if out.Tags, err = (&Tagging{TagSet: f.And.Tags}).ToKVs(); err != nil {
+				return
+			}
+		case f.Tag != nil:
+			out.Tags = cos.StrKVs{f.Tag.Key: f.Tag.Value}
+		default:
+			out.Prefix = f.Prefix
+		}
+	}
+	if e := in.Expiration; e != nil {
+		if e.Date != "" {
+			return out, fmt.Errorf("lifecycle rule %q: expiration date is not supported (use days)", out.ID)
+		}
+		out.ExpirationDays = e.Days
+	}
+	if t := in.Transition; t != nil {
+		if t.Date != "" {
+			return out, fmt.Errorf("lifecycle rule %q: transition date is not supported (use days)", out.ID)
+		}
+		out.EvictDays = t.Days
+	}
+	if nve := in.NoncurrentVersionExpiration; nve != nil {
+		out.NoncurrentDays = nve.NoncurrentDays
+	}
+	if a := in.AbortIncompleteMultipartUpload; a != nil {
+		out.AbortMptDays = a.DaysAfterInitiation
+	}
+	return
+}
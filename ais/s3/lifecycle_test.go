@@ -0,0 +1,56 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestLifecycleXML(t *testing.T) {
+	body := `<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">` +
+		`<Rule><ID>tmp</ID><Filter><Prefix>tmp/</Prefix></Filter><Status>Enabled</Status>` +
+		`<Expiration><Days>7</Days></Expiration>` +
+		`<AbortIncompleteMultipartUpload><DaysAfterInitiation>2</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule>` +
+		`<Rule><Filter><And><Prefix>data/</Prefix><Tag><Key>split</Key><Value>val</Value></Tag>` +
+		`<Tag><Key>owner</Key><Value>bob</Value></Tag></And></Filter><Status>Disabled</Status>` +
+		`<Transition><Days>30</Days><StorageClass>GLACIER</StorageClass></Transition></Rule>` +
+		`<Rule><ID>legacy</ID><Prefix>old/</Prefix><Status>Enabled</Status>` +
+		`<NoncurrentVersionExpiration><NoncurrentDays>3</NoncurrentDays></NoncurrentVersionExpiration></Rule>` +
+		`</LifecycleConfiguration>`
+	lcy := &LifecycleConfiguration{}
+	tassert.CheckFatal(t, xml.Unmarshal([]byte(body), lcy))
+	conf, err := lcy.ToConf()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(conf.Rules) == 3, "expected 3 rules, got %d", len(conf.Rules))
+
+	r0, r1, r2 := conf.Rules[0], conf.Rules[1], conf.Rules[2]
+	tassert.Errorf(t, r0.ID == "tmp" && r0.Prefix == "tmp/" && r0.ExpirationDays == 7 && r0.AbortMptDays == 2,
+		"unexpected %+v", r0)
+	tassert.Errorf(t, r1.ID == "rule-2" && r1.Disabled && r1.Prefix == "data/" && len(r1.Tags) == 2 && r1.EvictDays == 30,
+		"unexpected %+v", r1)
+	tassert.Errorf(t, r2.Prefix == "old/" && r2.NoncurrentDays == 3, "unexpected %+v", r2)
+
+	// round trip
+	b, err := xml.Marshal(NewLifecycleConfiguration(conf))
+	tassert.CheckFatal(t, err)
+	out := &LifecycleConfiguration{}
+	tassert.CheckFatal(t, xml.Unmarshal(b, out))
+	conf2, err := out.ToConf()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(conf2.Rules) == 3 && conf2.Rules[1].Tags["owner"] == "bob" && conf2.Rules[2].Prefix == "old/",
+		"unexpected %+v", conf2.Rules)
+
+	// not supported
+	lcy.Rules[0].Expiration.Date = "2023-01-01T00:00:00Z"
+	_, err = lcy.ToConf()
+	tassert.Errorf(t, err != nil, "expected error (expiration date)")
+	lcy.Rules[0].Expiration.Date = ""
+	lcy.Rules[0].Status = "enabled"
+	_, err = lcy.ToConf()
+	tassert.Errorf(t, err != nil, "expected error (invalid status)")
+}
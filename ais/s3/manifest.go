@@ -0,0 +1,144 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Active multipart uploads are persisted on the mountpath of the (future) object:
+// - manifest: <upload-id>.mft.<obj-name>
+// - parts:    <upload-id>.<part-number>.<obj-name>
+// both of the `MptType` content type that neither rebalance nor space cleanup touch.
+// Stale uploads are aborted via `AbortStale` (housekeeping).
+
+const MptType = "mp"
+
+const mftSuffix = "mft"
+
+type (
+	MptContentResolver struct{}
+
+	// persistent state of an active upload
+	mptManifest struct {
+		ID      string     `json:"upload_id"`
+		Bck     cmn.Bck    `json:"bck"`
+		ObjName string     `json:"obj_name"`
+		Parts   []*MptPart `json:"parts"`
+		Ctime   int64      `json:"ctime"`
+		Mtime   int64      `json:"mtime"`
+	}
+)
+
+// interface guard
+var _ fs.ContentResolver = (*MptContentResolver)(nil)
+
+func (*MptContentResolver) PermToMove() bool    { return false }
+func (*MptContentResolver) PermToEvict() bool   { return false }
+func (*MptContentResolver) PermToProcess() bool { return false }
+
+func (*MptContentResolver) GenUniqueFQN(base, prefix string) string {
+	dir, fname := filepath.Split(base)
+	return filepath.Join(dir, prefix+"."+fname)
+}
+
+func (*MptContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	// <upload-id>.<mft | part-number>.<obj-name>
+	parts := strings.SplitN(base, ".", 3)
+	if len(parts) != 3 {
+		return "", false, false
+	}
+	return parts[2], false, true
+}
+
+func manifestFQN(id string, lom *cluster.LOM) string {
+	return fs.CSM.Gen(lom, MptType, id+"."+mftSuffix)
+}
+
+// FQN of the file to store the given part of the upload
+func PartFQN(id string, lom *cluster.LOM, partNum int64) string {
+	return fs.CSM.Gen(lom, MptType, id+"."+strconv.FormatInt(partNum, 10))
+}
+
+func (mpt *mpt) persist(id string) error {
+	mft := &mptManifest{
+		ID:      id,
+		Bck:     mpt.bck,
+		ObjName: mpt.objName,
+		Parts:   mpt.parts,
+		Ctime:   mpt.ctime.UnixNano(),
+		Mtime:   mpt.mtime.UnixNano(),
+	}
+	if err := cos.CreateDir(filepath.Dir(mpt.fqn)); err != nil {
+		return err
+	}
+	return jsp.Save(mpt.fqn, mft, jsp.CksumSign(0), nil)
+}
+
+func loadManifest(fqn string) (*mpt, error) {
+	mft := &mptManifest{}
+	if _, err := jsp.Load(fqn, mft, jsp.CksumSign(0)); err != nil {
+		return nil, err
+	}
+	return &mpt{
+		bck:     mft.Bck,
+		objName: mft.ObjName,
+		parts:   mft.Parts,
+		ctime:   time.Unix(0, mft.Ctime),
+		mtime:   time.Unix(0, mft.Mtime),
+		fqn:     fqn,
+	}, nil
+}
+
+// (under lock) load all persisted uploads of a given bucket - once
+func loadBck(bck *cluster.Bck) {
+	uname := bck.MakeUname("")
+	if loaded.Contains(uname) {
+		return
+	}
+	loaded.Add(uname)
+
+	avail := fs.GetAvail()
+	for _, mi := range avail {
+		opts := &fs.WalkOpts{Mi: mi, Bck: *bck.Bucket(), CTs: []string{MptType}}
+		opts.Callback = func(fqn string, de fs.DirEntry) error {
+			if de.IsDir() {
+				return nil
+			}
+			id, rest, ok := strings.Cut(filepath.Base(fqn), ".")
+			if !ok || !strings.HasPrefix(rest, mftSuffix+".") {
+				return nil // part file
+			}
+			if _, ok := ups[id]; ok {
+				return nil
+			}
+			mpt, err := loadManifest(fqn)
+			if err != nil {
+				glog.Errorf("%s: failed to load upload %q manifest: %v", bck, id, err)
+				return nil
+			}
+			if !mpt.bck.Equal(bck.Bucket()) || filepath.Base(fqn) != id+"."+mftSuffix+"."+filepath.Base(mpt.objName) {
+				return nil // e.g., leftover jsp temp file
+			}
+			ups[id] = mpt
+			return nil
+		}
+		if err := fs.Walk(opts); err != nil && !errors.Is(err, os.ErrNotExist) {
+			glog.Errorf("%s: failed to load multipart uploads from %s: %v", bck, mi, err)
+		}
+	}
+}
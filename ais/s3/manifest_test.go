@@ -0,0 +1,121 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func tmptInit(t *testing.T, objName string) (*cluster.Bck, *cluster.LOM) {
+	bck := cluster.NewBck("mpt-bck", apc.AIS, cmn.NsGlobal, &cmn.BucketProps{})
+	fs.TestNew(nil)
+	fs.TestDisableValidation()
+	_, err := fs.Add(t.TempDir(), "daeID")
+	tassert.CheckFatal(t, err)
+	_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
+	mock.NewTarget(mock.NewBaseBownerMock(bck))
+	Init()
+
+	lom := &cluster.LOM{ObjName: objName}
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+	return bck, lom
+}
+
+func tmptPart(t *testing.T, id string, lom *cluster.LOM, num int64, data string) *MptPart {
+	partFQN := PartFQN(id, lom, num)
+	tassert.CheckFatal(t, cos.CreateDir(filepath.Dir(partFQN)))
+	tassert.CheckFatal(t, os.WriteFile(partFQN, []byte(data), cos.PermRWR))
+	return &MptPart{MD5: "md5", FQN: partFQN, Size: int64(len(data)), Num: num}
+}
+
+func TestMptPersistence(t *testing.T) {
+	const (
+		uploadID = "mpt-upload-1"
+		objName  = "dir/obj.bin"
+	)
+	bck, lom := tmptInit(t, objName)
+	tassert.CheckFatal(t, InitUpload(uploadID, lom))
+
+	partFQN := tmptPart(t, uploadID, lom, 1, "part-1").FQN
+	tassert.CheckFatal(t, AddPart(uploadID, lom, &MptPart{MD5: "md5", FQN: partFQN, Size: 6, Num: 1}))
+
+	// simulate restart
+	ups, loaded = make(uploads), make(cos.StrSet)
+
+	res := ListUploads(bck, "", 0)
+	tassert.Fatalf(t, len(res.Uploads) == 1, "expected 1 upload, got %d", len(res.Uploads))
+	tassert.Errorf(t, res.Uploads[0].UploadID == uploadID && res.Uploads[0].Key == objName,
+		"unexpected upload %+v", res.Uploads[0])
+
+	size, err := ObjSize(uploadID, lom)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, size == 6, "expected size 6, got %d", size)
+
+	// not stale yet
+	n := AbortStale(bck, "", time.Hour)
+	tassert.Errorf(t, n == 0, "expected no stale uploads, got %d", n)
+
+	n = AbortStale(bck, "", 0)
+	tassert.Errorf(t, n == 1, "expected 1 stale upload, got %d", n)
+	for _, fqn := range []string{partFQN, manifestFQN(uploadID, lom)} {
+		_, err := os.Stat(fqn)
+		tassert.Errorf(t, os.IsNotExist(err), "expected %q to be removed (err: %v)", fqn, err)
+	}
+}
+
+func TestMptConcurrentAddPart(t *testing.T) {
+	const (
+		uploadID = "mpt-upload-2"
+		numParts = 50
+	)
+	_, lom := tmptInit(t, "obj.bin")
+	tassert.CheckFatal(t, InitUpload(uploadID, lom))
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, 2*numParts)
+	)
+	for i := 1; i <= numParts; i++ {
+		part := tmptPart(t, uploadID, lom, int64(i), "part")
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			errs <- AddPart(uploadID, lom, part)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := ObjSize(uploadID, lom)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		tassert.CheckFatal(t, err)
+	}
+
+	// simulate restart: the manifest must have all the parts
+	ups, loaded = make(uploads), make(cos.StrSet)
+	size, err := ObjSize(uploadID, lom)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, size == numParts*int64(len("part")), "expected size %d, got %d", numParts*len("part"), size)
+
+	// no parts can be added once finished
+	tassert.Fatalf(t, FinishUpload(uploadID, lom, true /*aborted*/), "expected upload %q to exist", uploadID)
+	err = AddPart(uploadID, lom, tmptPart(t, uploadID, lom, 1, "part"))
+	tassert.Errorf(t, err != nil, "expected error adding part to a finished upload")
+}
@@ -1,6 +1,6 @@
 // Package s3 provides Amazon S3 compatibility layer
 /*
- * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
  */
 package s3
 
@@ -10,73 +10,111 @@ import (
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
 )
 
 // NOTE: xattr stores only the (*) marked attributes
 type (
 	MptPart struct {
-		MD5  string // MD5 of the part (*)
-		FQN  string // FQN of the corresponding workfile
-		Size int64  // part size in bytes (*)
-		Num  int64  // part number (*)
+		MD5  string `json:"md5"`  // MD5 of the part (*)
+		FQN  string `json:"fqn"`  // FQN of the corresponding part file
+		Size int64  `json:"size"` // part size in bytes (*)
+		Num  int64  `json:"num"`  // part number (*)
 	}
 	mpt struct {
-		bckName string
+		bck     cmn.Bck
 		objName string
 		parts   []*MptPart // by part number
 		ctime   time.Time  // InitUpload time
+		mtime   time.Time  // last activity (init or add-part)
+		fqn     string     // manifest (see below)
+		mu      sync.Mutex // protects the upload's parts, mtime, and manifest
+		done    bool       // completed or aborted
 	}
 	uploads map[string]*mpt // by upload ID
 )
 
+// NOTE: global `mu` protects `ups` and `loaded`, and is never held while acquiring
+// (or holding) a given upload's lock; the latter serializes part updates and persists
+// the manifest - see AddPart
 var (
-	ups uploads
-	mu  sync.RWMutex
+	ups    uploads
+	loaded cos.StrSet // buckets that have their persisted uploads loaded
+	mu     sync.Mutex
 )
 
-func Init() { ups = make(uploads) }
+func Init() {
+	ups = make(uploads)
+	loaded = make(cos.StrSet)
+	if err := fs.CSM.Reg(MptType, &MptContentResolver{}); err != nil {
+		debug.AssertNoErr(err)
+		glog.Error(err)
+	}
+}
 
 // Start miltipart upload
-func InitUpload(id, bckName, objName string) {
-	mu.Lock()
-	ups[id] = &mpt{
-		bckName: bckName,
-		objName: objName,
+func InitUpload(id string, lom *cluster.LOM) error {
+	now := time.Now()
+	mpt := &mpt{
+		bck:     *lom.Bucket(),
+		objName: lom.ObjName,
 		parts:   make([]*MptPart, 0, iniCapParts),
-		ctime:   time.Now(),
+		ctime:   now,
+		mtime:   now,
+		fqn:     manifestFQN(id, lom),
+	}
+	if err := mpt.persist(id); err != nil {
+		return err
 	}
+	mu.Lock()
+	ups[id] = mpt
 	mu.Unlock()
+	return nil
 }
 
 // Add part to an active upload.
 // Some clients may omit size and md5. Only partNum is must-have.
-// md5 and fqn is filled by a target after successful saving the data to a workfile.
-func AddPart(id string, npart *MptPart) (err error) {
-	mu.Lock()
-	mpt, ok := ups[id]
-	if !ok {
-		err = fmt.Errorf("upload %q not found (%s, %d)", id, npart.FQN, npart.Num)
+// md5 and fqn is filled by a target after successful saving the data to a part file.
+// Uploading the same part number more than once replaces the previous part.
+func AddPart(id string, lom *cluster.LOM, npart *MptPart) (err error) {
+	mpt, err := lookup(id, lom)
+	if err != nil {
+		return fmt.Errorf("%v (%s, %d)", err, npart.FQN, npart.Num)
+	}
+	mpt.mu.Lock()
+	defer mpt.mu.Unlock()
+	if mpt.done {
+		return fmt.Errorf("upload %q not found (%s, %d)", id, npart.FQN, npart.Num)
+	}
+	if i := mpt.partIdx(npart.Num); i >= 0 {
+		if prev := mpt.parts[i]; prev.FQN != npart.FQN {
+			_ = os.Remove(prev.FQN)
+		}
+		mpt.parts[i] = npart
 	} else {
 		mpt.parts = append(mpt.parts, npart)
 	}
-	mu.Unlock()
-	return
+	mpt.mtime = time.Now()
+	return mpt.persist(id)
 }
 
 // TODO: compare non-zero sizes (note: s3cmd sends 0) and part.ETag as well, if specified
-func CheckParts(id string, parts []*PartInfo) ([]*MptPart, error) {
-	mu.RLock()
-	defer mu.RUnlock()
-	mpt, ok := ups[id]
-	if !ok {
-		return nil, fmt.Errorf("upload %q not found", id)
+func CheckParts(id string, lom *cluster.LOM, parts []*PartInfo) ([]*MptPart, error) {
+	mpt, err := lookup(id, lom)
+	if err != nil {
+		return nil, err
 	}
+	mpt.mu.Lock()
+	defer mpt.mu.Unlock()
 	// first, check that all parts are present
 	var prev = int64(-1)
 	for _, part := range parts {
@@ -102,53 +140,76 @@ func ParsePartNum(s string) (partNum int64, err error) {
 	return
 }
 
+// Parse `x-amz-copy-source-range` (UploadPartCopy): "bytes=first-last", both inclusive
+// and required (unlike the general-purpose HTTP Range) - returns offset and length.
+func ParseCopySrcRange(s string) (offset, length int64, err error) {
+	err = fmt.Errorf("invalid %s %q (expecting \"%sfirst-last\")", cos.S3HdrObjSrcRange, s, cos.HdrRangeValPrefix)
+	if !strings.HasPrefix(s, cos.HdrRangeValPrefix) {
+		return
+	}
+	i := strings.IndexByte(s, '-')
+	if i < 0 {
+		return
+	}
+	first, errF := strconv.ParseInt(s[len(cos.HdrRangeValPrefix):i], 10, 64)
+	last, errL := strconv.ParseInt(s[i+1:], 10, 64)
+	if errF != nil || errL != nil || first < 0 || first > last {
+		return
+	}
+	return first, last - first + 1, nil
+}
+
 // Return a sum of upload part sizes.
 // Used on upload completion to calculate the final size of the object.
-func ObjSize(id string) (size int64, err error) {
-	mu.RLock()
-	mpt, ok := ups[id]
-	if !ok {
-		err = fmt.Errorf("upload %q not found", id)
-	} else {
-		for _, part := range mpt.parts {
-			size += part.Size
-		}
+func ObjSize(id string, lom *cluster.LOM) (size int64, err error) {
+	mpt, err := lookup(id, lom)
+	if err != nil {
+		return
+	}
+	mpt.mu.Lock()
+	for _, part := range mpt.parts {
+		size += part.Size
 	}
-	mu.RUnlock()
+	mpt.mu.Unlock()
 	return
 }
 
-// remove all temp files and delete from the map
+// remove all part files and the manifest, and delete from the map
 // if completed (i.e., not aborted): store xattr
-func FinishUpload(id, fqn string, aborted bool) (exists bool) {
+func FinishUpload(id string, lom *cluster.LOM, aborted bool) (exists bool) {
 	mu.Lock()
-	mpt, ok := ups[id]
-	if !ok {
+	mpt, err := get(id, lom)
+	if err != nil {
 		mu.Unlock()
-		glog.Warningf("fqn %s, id %s", fqn, id)
+		glog.Warningf("%s, id %s: %v", lom, id, err)
 		return false
 	}
 	delete(ups, id)
 	mu.Unlock()
 
+	mpt.mu.Lock()
+	mpt.done = true
 	if !aborted {
-		if err := storeMptXattr(fqn, mpt); err != nil {
-			glog.Warningf("fqn %s, id %s: %v", fqn, id, err)
+		if err := storeMptXattr(lom.FQN, mpt); err != nil {
+			glog.Warningf("%s, id %s: %v", lom, id, err)
 		}
 	}
-	for _, part := range mpt.parts {
-		_ = os.RemoveAll(part.FQN)
-	}
+	mpt.cleanup()
+	mpt.mu.Unlock()
 	return true
 }
 
-func ListUploads(bckName, idMarker string, maxUploads int) (result *ListMptUploadsResult) {
-	mu.RLock()
+func ListUploads(bck *cluster.Bck, idMarker string, maxUploads int) (result *ListMptUploadsResult) {
+	mu.Lock()
+	loadBck(bck)
 	results := make([]UploadInfoResult, 0, len(ups))
 	for id, mpt := range ups {
+		if !mpt.bck.Equal(bck.Bucket()) {
+			continue
+		}
 		results = append(results, UploadInfoResult{Key: mpt.objName, UploadID: id, Initiated: mpt.ctime})
 	}
-	mu.RUnlock()
+	mu.Unlock()
 
 	sort.Slice(results, func(i int, j int) bool {
 		return results[i].Initiated.Before(results[j].Initiated)
@@ -169,27 +230,99 @@ func ListUploads(bckName, idMarker string, maxUploads int) (result *ListMptUploa
 	if maxUploads > 0 && len(results) > maxUploads {
 		results = results[:maxUploads]
 	}
-	result = &ListMptUploadsResult{Bucket: bckName, Uploads: results, IsTruncated: from > 0}
+	result = &ListMptUploadsResult{Bucket: bck.Name, Uploads: results, IsTruncated: from > 0}
 	return
 }
 
 func ListParts(id string, lom *cluster.LOM) (parts []*PartInfo, err error, errCode int) {
-	mu.RLock()
-	mpt, ok := ups[id]
-	if !ok {
+	mpt, errV := lookup(id, lom)
+	if errV != nil {
 		errCode = http.StatusNotFound
 		mpt, err = loadMptXattr(lom.FQN)
 		if err != nil || mpt == nil {
-			mu.RUnlock()
 			return
 		}
-		mpt.bckName, mpt.objName = lom.Bck().Name, lom.ObjName
+		mpt.bck, mpt.objName = *lom.Bucket(), lom.ObjName
 		mpt.ctime = lom.Atime()
 	}
+	mpt.mu.Lock()
 	parts = make([]*PartInfo, 0, len(mpt.parts))
 	for _, part := range mpt.parts {
 		parts = append(parts, &PartInfo{ETag: part.MD5, PartNumber: part.Num, Size: part.Size})
 	}
-	mu.RUnlock()
+	mpt.mu.Unlock()
 	return
 }
+
+// Abort the bucket's uploads (optionally, only those with object names starting with `prefix`)
+// that have had no activity for longer than `maxAge`. Returns the number of aborted uploads.
+func AbortStale(bck *cluster.Bck, prefix string, maxAge time.Duration) (n int) {
+	var (
+		ids  []string
+		mpts []*mpt
+		now  = time.Now()
+	)
+	mu.Lock()
+	loadBck(bck)
+	for id, mpt := range ups {
+		if mpt.bck.Equal(bck.Bucket()) && cmn.ObjHasPrefix(mpt.objName, prefix) {
+			ids = append(ids, id)
+			mpts = append(mpts, mpt)
+		}
+	}
+	mu.Unlock()
+
+	for i, mpt := range mpts {
+		mpt.mu.Lock()
+		if !mpt.done && now.Sub(mpt.mtime) > maxAge {
+			mpt.done = true
+			mu.Lock()
+			delete(ups, ids[i])
+			mu.Unlock()
+			mpt.cleanup()
+			n++
+		}
+		mpt.mu.Unlock()
+	}
+	return
+}
+
+func lookup(id string, lom *cluster.LOM) (mpt *mpt, err error) {
+	mu.Lock()
+	mpt, err = get(id, lom)
+	mu.Unlock()
+	return
+}
+
+// (under lock) lookup in memory or, failing that, load persisted manifest
+func get(id string, lom *cluster.LOM) (*mpt, error) {
+	if mpt, ok := ups[id]; ok {
+		return mpt, nil
+	}
+	if !cos.IsAlphaNice(id) {
+		return nil, fmt.Errorf("invalid upload ID %q", id)
+	}
+	fqn := manifestFQN(id, lom)
+	mpt, err := loadManifest(fqn)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			glog.Errorf("upload %q: failed to load %s: %v", id, fqn, err)
+		}
+		return nil, fmt.Errorf("upload %q not found", id)
+	}
+	ups[id] = mpt
+	return mpt, nil
+}
+
+/////////
+// mpt //
+/////////
+
+func (mpt *mpt) cleanup() {
+	for _, part := range mpt.parts {
+		_ = os.RemoveAll(part.FQN)
+	}
+	if err := cos.RemoveFile(mpt.fqn); err != nil {
+		glog.Errorf("failed to remove %s: %v", mpt.fqn, err)
+	}
+}
@@ -0,0 +1,39 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestParseCopySrcRange(t *testing.T) {
+	tests := []struct {
+		s              string
+		offset, length int64
+		valid          bool
+	}{
+		{"bytes=0-0", 0, 1, true},
+		{"bytes=0-9", 0, 10, true},
+		{"bytes=100-5242979", 100, 5242880, true},
+		{"bytes=10-9", 0, 0, false},
+		{"bytes=-9", 0, 0, false},
+		{"bytes=10-", 0, 0, false},
+		{"bytes=0-9,20-29", 0, 0, false},
+		{"0-9", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, test := range tests {
+		offset, length, err := ParseCopySrcRange(test.s)
+		if !test.valid {
+			tassert.Errorf(t, err != nil, "%q: expected error", test.s)
+			continue
+		}
+		tassert.CheckError(t, err)
+		tassert.Errorf(t, offset == test.offset && length == test.length,
+			"%q: expected (%d, %d), got (%d, %d)", test.s, test.offset, test.length, offset, length)
+	}
+}
@@ -0,0 +1,184 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Bucket notification configuration: S3 XML <=> cmn.NotifConf
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketNotificationConfiguration.html
+//
+// Limitations:
+// - instead of SNS/SQS/Lambda ARNs, Topic, Queue, and CloudFunction specify the destination URL
+//   (webhook or local sink - see cmn.NotifTarget);
+// - S3 event types map onto (coarser-grained) AIS events, e.g. both "s3:ObjectCreated:Put"
+//   and "s3:ObjectCreated:Post" map to cmn.EventObjCreated; GET returns "s3:ObjectCreated:Put";
+// - filter rules: at most one "prefix" and one "suffix".
+
+const (
+	s3EventCreated     = "s3:ObjectCreated:"
+	s3EventRemoved     = "s3:ObjectRemoved:"
+	s3EventExpiration  = "s3:LifecycleExpiration:"
+	s3EventCreatedPut  = "s3:ObjectCreated:Put"
+	s3EventCreatedCopy = "s3:ObjectCreated:Copy"
+	s3EventRemovedAll  = "s3:ObjectRemoved:*"
+	aisEventEvicted    = "ais:ObjectEvicted" // (no S3 equivalent)
+
+	filterPrefix = "prefix"
+	filterSuffix = "suffix"
+)
+
+type (
+	NotifConfiguration struct {
+		XMLName xml.Name      `xml:"NotificationConfiguration"`
+		Ns      string        `xml:"xmlns,attr,omitempty"`
+		Topics  []NotifConfig `xml:"TopicConfiguration"`
+		Queues  []NotifConfig `xml:"QueueConfiguration"`
+		Lambdas []NotifConfig `xml:"CloudFunctionConfiguration"`
+	}
+	NotifConfig struct {
+		ID            string       `xml:"Id,omitempty"`
+		Topic         string       `xml:"Topic,omitempty"`
+		Queue         string       `xml:"Queue,omitempty"`
+		CloudFunction string       `xml:"CloudFunction,omitempty"`
+		Events        []string     `xml:"Event"`
+		Filter        *NotifFilter `xml:"Filter,omitempty"`
+	}
+	NotifFilter struct {
+		Key NotifKeyFilter `xml:"S3Key"`
+	}
+	NotifKeyFilter struct {
+		Rules []NotifFilterRule `xml:"FilterRule"`
+	}
+	NotifFilterRule struct {
+		Name  string `xml:"Name"`
+		Value string `xml:"Value"`
+	}
+)
+
+// (all targets are reported as queues)
+func NewNotifConfiguration(conf *cmn.NotifConf) *NotifConfiguration {
+	c := &NotifConfiguration{Ns: s3Namespace, Queues: make([]NotifConfig, 0, len(conf.Targets))}
+	for i := range conf.Targets {
+		nt := &conf.Targets[i]
+		if nt.Disabled {
+			continue
+		}
+		out := NotifConfig{ID: nt.ID, Queue: nt.URL, Events: make([]string, 0, len(nt.Events))}
+		for _, kind := range nt.Events {
+			out.Events = append(out.Events, toS3Event(kind))
+		}
+		var rules []NotifFilterRule
+		if nt.Prefix != "" {
+			rules = append(rules, NotifFilterRule{Name: filterPrefix, Value: nt.Prefix})
+		}
+		if nt.Suffix != "" {
+			rules = append(rules, NotifFilterRule{Name: filterSuffix, Value: nt.Suffix})
+		}
+		if len(rules) > 0 {
+			out.Filter = &NotifFilter{Key: NotifKeyFilter{Rules: rules}}
+		}
+		c.Queues = append(c.Queues, out)
+	}
+	return c
+}
+
+// (empty configuration removes all notification targets)
+func (c *NotifConfiguration) ToConf() (*cmn.NotifConf, error) {
+	all := make([]NotifConfig, 0, len(c.Topics)+len(c.Queues)+len(c.Lambdas))
+	all = append(all, c.Topics...)
+	all = append(all, c.Queues...)
+	all = append(all, c.Lambdas...)
+	conf := &cmn.NotifConf{Targets: make([]cmn.NotifTarget, 0, len(all))}
+	for i := range all {
+		in := &all[i]
+		nt := cmn.NotifTarget{ID: in.ID}
+		if nt.ID == "" {
+			nt.ID = "notif-" + strconv.Itoa(i+1)
+		}
+		switch {
+		case in.Topic != "":
+			nt.URL = in.Topic
+		case in.Queue != "":
+			nt.URL = in.Queue
+		default:
+			nt.URL = in.CloudFunction
+		}
+		for _, ev := range in.Events {
+			kinds, err := fromS3Event(ev)
+			if err != nil {
+				return nil, err
+			}
+			for _, kind := range kinds {
+				if !cos.StringInSlice(kind, nt.Events) {
+					nt.Events = append(nt.Events, kind)
+				}
+			}
+		}
+		if in.Filter != nil {
+			for _, rule := range in.Filter.Key.Rules {
+				switch strings.ToLower(rule.Name) {
+				case filterPrefix:
+					nt.Prefix = rule.Value
+				case filterSuffix:
+					nt.Suffix = rule.Value
+				default:
+					return nil, fmt.Errorf("invalid filter rule name %q (expecting %q or %q)",
+						rule.Name, filterPrefix, filterSuffix)
+				}
+			}
+		}
+		conf.Targets = append(conf.Targets, nt)
+	}
+	return conf, conf.ValidateAsProps()
+}
+
+func (c *NotifConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(c)
+	debug.AssertNoErr(err)
+}
+
+func toS3Event(kind string) string {
+	switch kind {
+	case cmn.EventObjCreated:
+		return s3EventCreatedPut
+	case cmn.EventObjCopied:
+		return s3EventCreatedCopy
+	case cmn.EventObjRemoved:
+		return s3EventRemovedAll
+	default:
+		debug.Assert(kind == cmn.EventObjEvicted, kind)
+		return aisEventEvicted
+	}
+}
+
+func fromS3Event(ev string) ([]string, error) {
+	switch {
+	case ev == s3EventCreated+"*":
+		return []string{cmn.EventObjCreated, cmn.EventObjCopied}, nil
+	case ev == s3EventCreatedCopy:
+		return []string{cmn.EventObjCopied}, nil
+	case strings.HasPrefix(ev, s3EventCreated):
+		return []string{cmn.EventObjCreated}, nil // Put, Post, CompleteMultipartUpload
+	case strings.HasPrefix(ev, s3EventRemoved), strings.HasPrefix(ev, s3EventExpiration):
+		return []string{cmn.EventObjRemoved}, nil
+	case ev == aisEventEvicted:
+		return []string{cmn.EventObjEvicted}, nil
+	case cos.StringInSlice(ev, cmn.EventKinds):
+		return []string{ev}, nil // (native)
+	default:
+		return nil, fmt.Errorf("unsupported event type %q", ev)
+	}
+}
@@ -0,0 +1,44 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestNotifConfiguration(t *testing.T) {
+	const in = `<NotificationConfiguration>` +
+		`<QueueConfiguration><Id>q</Id><Queue>http://host:8000/hook</Queue>` +
+		`<Event>s3:ObjectCreated:*</Event><Event>s3:ObjectRemoved:Delete</Event>` +
+		`<Filter><S3Key><FilterRule><Name>prefix</Name><Value>img/</Value></FilterRule>` +
+		`<FilterRule><Name>Suffix</Name><Value>.jpg</Value></FilterRule></S3Key></Filter>` +
+		`</QueueConfiguration>` +
+		`<TopicConfiguration><Topic>file:///tmp/events.jsonl</Topic><Event>ais:ObjectEvicted</Event></TopicConfiguration>` +
+		`</NotificationConfiguration>`
+	nconf := &NotifConfiguration{}
+	tassert.CheckFatal(t, xml.Unmarshal([]byte(in), nconf))
+	conf, err := nconf.ToConf()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(conf.Targets) == 2, "expected 2 targets, got %d", len(conf.Targets))
+
+	// (topics first)
+	nt := &conf.Targets[0]
+	tassert.Errorf(t, nt.ID == "notif-1" && nt.URL == "file:///tmp/events.jsonl" &&
+		len(nt.Events) == 1 && nt.Events[0] == cmn.EventObjEvicted, "unexpected %+v", nt)
+	nt = &conf.Targets[1]
+	tassert.Errorf(t, nt.ID == "q" && nt.Prefix == "img/" && nt.Suffix == ".jpg" && len(nt.Events) == 3,
+		"unexpected %+v", nt)
+
+	out := NewNotifConfiguration(conf)
+	tassert.Errorf(t, len(out.Queues) == 2 && out.Queues[1].Filter != nil, "unexpected %+v", out)
+
+	nconf.Queues[0].Events = []string{"s3:ObjectRestore:Post"}
+	_, err = nconf.ToConf()
+	tassert.Errorf(t, err != nil, "expected unsupported event type")
+}
@@ -0,0 +1,229 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Object lock: S3 XML and headers <=> cmn.ObjLockConf, cmn.ObjRetention, and legal hold
+// See:
+// - https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectLockConfiguration.html
+// - https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectRetention.html
+// - https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectLegalHold.html
+//
+// Limitations:
+// - default retention in years is converted to days (365 days a year);
+// - unlike S3, object lock does not require versioning - see ais/tgtlock.go.
+
+const (
+	lockEnabled  = "Enabled"
+	legalHoldOn  = "ON"
+	legalHoldOff = "OFF"
+	daysPerYear  = 365
+	lockDateFmt  = time.RFC3339
+)
+
+var ErrNoObjLockConf = errors.New("object lock configuration does not exist for this bucket")
+
+type (
+	ObjLockConfiguration struct {
+		XMLName           xml.Name     `xml:"ObjectLockConfiguration"`
+		Ns                string       `xml:"xmlns,attr,omitempty"`
+		ObjectLockEnabled string       `xml:"ObjectLockEnabled,omitempty"`
+		Rule              *ObjLockRule `xml:"Rule,omitempty"`
+	}
+	ObjLockRule struct {
+		DefaultRetention ObjLockDefault `xml:"DefaultRetention"`
+	}
+	ObjLockDefault struct {
+		Mode  string `xml:"Mode"`
+		Days  int    `xml:"Days,omitempty"`
+		Years int    `xml:"Years,omitempty"`
+	}
+	Retention struct {
+		XMLName         xml.Name `xml:"Retention"`
+		Ns              string   `xml:"xmlns,attr,omitempty"`
+		Mode            string   `xml:"Mode,omitempty"`
+		RetainUntilDate string   `xml:"RetainUntilDate,omitempty"`
+	}
+	LegalHold struct {
+		XMLName xml.Name `xml:"LegalHold"`
+		Ns      string   `xml:"xmlns,attr,omitempty"`
+		Status  string   `xml:"Status"`
+	}
+)
+
+//
+// bucket
+//
+
+func NewObjLockConfiguration(conf *cmn.ObjLockConf) *ObjLockConfiguration {
+	c := &ObjLockConfiguration{Ns: s3Namespace, ObjectLockEnabled: lockEnabled}
+	if conf.Mode != "" {
+		c.Rule = &ObjLockRule{DefaultRetention: ObjLockDefault{Mode: strings.ToUpper(conf.Mode), Days: conf.Days}}
+	}
+	return c
+}
+
+func (c *ObjLockConfiguration) ToConf() (*cmn.ObjLockConf, error) {
+	if c.ObjectLockEnabled != lockEnabled {
+		return nil, fmt.Errorf("invalid ObjectLockEnabled %q (expecting %q)", c.ObjectLockEnabled, lockEnabled)
+	}
+	conf := &cmn.ObjLockConf{Enabled: true}
+	if c.Rule == nil {
+		return conf, nil
+	}
+	dr := &c.Rule.DefaultRetention
+	if (dr.Days == 0) == (dr.Years == 0) {
+		return nil, errors.New("default retention requires either Days or Years (but not both)")
+	}
+	mode, err := lockMode(dr.Mode)
+	if err != nil {
+		return nil, err
+	}
+	conf.Mode, conf.Days = mode, dr.Days+dr.Years*daysPerYear
+	return conf, conf.ValidateAsProps()
+}
+
+func (c *ObjLockConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(c)
+	debug.AssertNoErr(err)
+}
+
+//
+// object
+//
+
+func NewRetention(r *cmn.ObjRetention) *Retention {
+	return &Retention{
+		Ns:              s3Namespace,
+		Mode:            strings.ToUpper(r.Mode),
+		RetainUntilDate: r.RetainUntil.UTC().Format(lockDateFmt),
+	}
+}
+
+// empty retention (no mode and no date) means "remove"
+func (r *Retention) ToRetention() (*cmn.ObjRetention, error) {
+	if r.Mode == "" && r.RetainUntilDate == "" {
+		return nil, nil
+	}
+	return parseRetention(r.Mode, r.RetainUntilDate)
+}
+
+func (r *Retention) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+func NewLegalHold(on bool) *LegalHold {
+	lh := &LegalHold{Ns: s3Namespace, Status: legalHoldOff}
+	if on {
+		lh.Status = legalHoldOn
+	}
+	return lh
+}
+
+func (lh *LegalHold) IsOn() (bool, error) { return parseLegalHold(lh.Status) }
+
+func (lh *LegalHold) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(lh)
+	debug.AssertNoErr(err)
+}
+
+//
+// headers
+//
+
+// PUT object: `x-amz-object-lock-*` request headers => object's retention and legal hold
+func ObjLockFromHeader(header http.Header, oa *cmn.ObjAttrs) (set bool, err error) {
+	mode, until, hold := header.Get(cos.S3HdrObjLockMode), header.Get(cos.S3HdrObjLockUntil),
+		header.Get(cos.S3HdrObjLegalHold)
+	if mode != "" || until != "" {
+		r, err := parseRetention(mode, until)
+		if err != nil {
+			return false, err
+		}
+		if err := r.Validate(time.Now()); err != nil {
+			return false, err
+		}
+		oa.SetRetention(r)
+		set = true
+	}
+	if hold != "" {
+		on, err := parseLegalHold(hold)
+		if err != nil {
+			return false, err
+		}
+		oa.SetLegalHold(on)
+		set = set || on
+	}
+	return set, nil
+}
+
+// HEAD object
+func SetObjLock(header http.Header, lom *cluster.LOM) {
+	if !lom.Bprops().ObjLock.Enabled {
+		return
+	}
+	oa := lom.ObjAttrs()
+	if r := oa.Retention(); r != nil {
+		header.Set(cos.S3HdrObjLockMode, strings.ToUpper(r.Mode))
+		header.Set(cos.S3HdrObjLockUntil, r.RetainUntil.UTC().Format(lockDateFmt))
+	}
+	if oa.LegalHold() {
+		header.Set(cos.S3HdrObjLegalHold, legalHoldOn)
+	}
+}
+
+func BypassGovernance(header http.Header) bool {
+	return cos.IsParseBool(header.Get(cos.S3HdrBypassGovernance))
+}
+
+func parseRetention(mode, until string) (*cmn.ObjRetention, error) {
+	m, err := lockMode(mode)
+	if err != nil {
+		return nil, err
+	}
+	t, err := time.Parse(lockDateFmt, until)
+	if err != nil {
+		return nil, fmt.Errorf("invalid retain-until date %q: %v", until, err)
+	}
+	return &cmn.ObjRetention{Mode: m, RetainUntil: t}, nil
+}
+
+func lockMode(mode string) (string, error) {
+	switch m := strings.ToLower(mode); m {
+	case cmn.ObjLockGovernance, cmn.ObjLockCompliance:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid object lock mode %q (expecting GOVERNANCE or COMPLIANCE)", mode)
+	}
+}
+
+func parseLegalHold(status string) (bool, error) {
+	switch status {
+	case legalHoldOn:
+		return true, nil
+	case legalHoldOff:
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid legal hold status %q (expecting %s or %s)", status, legalHoldOn, legalHoldOff)
+	}
+}
@@ -0,0 +1,58 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestObjLockConfiguration(t *testing.T) {
+	const in = `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled>` +
+		`<Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Years>1</Years></DefaultRetention></Rule></ObjectLockConfiguration>`
+	lconf := &ObjLockConfiguration{}
+	tassert.CheckFatal(t, xml.Unmarshal([]byte(in), lconf))
+	conf, err := lconf.ToConf()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, conf.Enabled && conf.Mode == cmn.ObjLockCompliance && conf.Days == 365, "unexpected %+v", conf)
+
+	out := NewObjLockConfiguration(conf)
+	tassert.Errorf(t, out.Rule != nil && out.Rule.DefaultRetention.Mode == "COMPLIANCE", "unexpected %+v", out)
+
+	lconf.Rule.DefaultRetention.Days = 1 // both days and years
+	_, err = lconf.ToConf()
+	tassert.Errorf(t, err != nil, "expected error")
+}
+
+func TestObjLockHeaders(t *testing.T) {
+	var (
+		until = time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		hdr   = http.Header{}
+		oa    = &cmn.ObjAttrs{}
+	)
+	hdr.Set(cos.S3HdrObjLockMode, "GOVERNANCE")
+	hdr.Set(cos.S3HdrObjLockUntil, until.Format(time.RFC3339))
+	hdr.Set(cos.S3HdrObjLegalHold, "ON")
+	set, err := ObjLockFromHeader(hdr, oa)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, set, "expected object lock")
+	r := oa.Retention()
+	tassert.Errorf(t, r != nil && r.Mode == cmn.ObjLockGovernance && r.RetainUntil.Equal(until), "unexpected %+v", r)
+	tassert.Errorf(t, oa.LegalHold(), "expected legal hold")
+
+	hdr.Set(cos.S3HdrObjLockMode, "strict")
+	_, err = ObjLockFromHeader(hdr, &cmn.ObjAttrs{})
+	tassert.Errorf(t, err != nil, "expected invalid mode")
+
+	lh := &LegalHold{Status: "maybe"}
+	_, err = lh.IsOn()
+	tassert.Errorf(t, err != nil, "expected invalid legal hold status")
+}
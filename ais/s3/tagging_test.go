@@ -0,0 +1,51 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"net/http"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestTaggingXML(t *testing.T) {
+	body := `<Tagging xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><TagSet>` +
+		`<Tag><Key>split</Key><Value>train</Value></Tag><Tag><Key>owner</Key><Value>a b</Value></Tag>` +
+		`</TagSet></Tagging>`
+	tagging := &Tagging{}
+	tassert.CheckFatal(t, xml.Unmarshal([]byte(body), tagging))
+	tags, err := tagging.ToKVs()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(tags) == 2 && tags["split"] == "train" && tags["owner"] == "a b", "unexpected %v", tags)
+
+	b, err := xml.Marshal(NewTagging(tags))
+	tassert.CheckFatal(t, err)
+	out := &Tagging{}
+	tassert.CheckFatal(t, xml.Unmarshal(b, out))
+	tassert.Fatalf(t, len(out.TagSet) == 2 && out.TagSet[0].Key == "owner", "unexpected %+v", out.TagSet)
+
+	tagging.TagSet = append(tagging.TagSet, Tag{Key: "split", Value: "val"})
+	_, err = tagging.ToKVs()
+	tassert.Errorf(t, cmn.IsErrInvalidTag(err), "expected duplicate key error, got %v", err)
+}
+
+func TestTagsFromHeader(t *testing.T) {
+	hdr := http.Header{}
+	tags, err := TagsFromHeader(hdr)
+	tassert.Errorf(t, tags == nil && err == nil, "expected no tags")
+
+	hdr.Set(cos.S3HdrTagging, "split=train&owner=a%20b")
+	tags, err = TagsFromHeader(hdr)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(tags) == 2 && tags["owner"] == "a b", "unexpected %v", tags)
+
+	hdr.Set(cos.S3HdrTagging, "k=1&k=2")
+	_, err = TagsFromHeader(hdr)
+	tassert.Errorf(t, cmn.IsErrInvalidTag(err), "expected duplicate key error, got %v", err)
+}
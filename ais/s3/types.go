@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"sort"
 	"strconv"
 	"time"
 
@@ -18,10 +19,17 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/sse"
 )
 
 const defaultLastModified = 0 // When an object was not accessed yet
 
+// x-amz-server-side-encryption values
+const (
+	SSEAlgoAES = "AES256"
+	SSEAlgoKMS = "aws:kms"
+)
+
 // NOTE: do not rename structs that have `xml` tags. The names of those structs
 // become a top level tag of resulting XML, and those tags S3-compatible
 // clients require.
@@ -56,6 +64,12 @@ type (
 		ETag         string `xml:"ETag"`
 	}
 
+	// Response for upload part copy request
+	CopyPartResult struct {
+		LastModified string `xml:"LastModified"`
+		ETag         string `xml:"ETag"`
+	}
+
 	// Multipart upload start response
 	InitiateMptUploadResult struct {
 		Bucket   string `xml:"Bucket"`
@@ -113,6 +127,17 @@ type (
 	DeleteResult struct {
 		Objs []DeletedObjInfo `xml:"Deleted"`
 	}
+
+	// Object tagging: GET(?tagging) response and PUT(?tagging) request
+	// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectTagging.html
+	Tagging struct {
+		Ns     string `xml:"xmlns,attr"`
+		TagSet []Tag  `xml:"TagSet>Tag"`
+	}
+	Tag struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	}
 )
 
 func ObjName(items []string) string { return path.Join(items[1:]...) }
@@ -205,12 +230,106 @@ func SetETag(header http.Header, lom *cluster.LOM) {
 	}
 }
 
+// server-side encryption: request headers => key ID ("" when not requested)
+// See: https://docs.aws.amazon.com/AmazonS3/latest/userguide/specifying-s3-encryption.html
+func SSEKeyID(header http.Header) (string, error) {
+	switch v := header.Get(cos.S3HdrSSE); v {
+	case "":
+		return "", nil
+	case SSEAlgoAES:
+		return sse.DfltKeyID, nil
+	case SSEAlgoKMS:
+		if kid := header.Get(cos.S3HdrSSEKeyID); kid != "" {
+			return kid, nil
+		}
+		return sse.DfltKeyID, nil
+	default:
+		return "", cmn.NewErrUnsupp("encrypt with", "'"+v+"'")
+	}
+}
+
+func SetSSE(header http.Header, lom *cluster.LOM) {
+	kid, ok := lom.GetCustomKey(cmn.SSEKeyIDObjMD)
+	if !ok {
+		return
+	}
+	if kid == sse.DfltKeyID {
+		header.Set(cos.S3HdrSSE, SSEAlgoAES)
+	} else {
+		header.Set(cos.S3HdrSSE, SSEAlgoKMS)
+		header.Set(cos.S3HdrSSEKeyID, kid)
+	}
+}
+
+//
+// tagging
+//
+
+func NewTagging(tags cos.StrKVs) *Tagging {
+	keys := tags.Keys()
+	sort.Strings(keys)
+	t := &Tagging{Ns: s3Namespace, TagSet: make([]Tag, 0, len(keys))}
+	for _, k := range keys {
+		t.TagSet = append(t.TagSet, Tag{Key: k, Value: tags[k]})
+	}
+	return t
+}
+
+func (t *Tagging) ToKVs() (cos.StrKVs, error) {
+	tags := make(cos.StrKVs, len(t.TagSet))
+	for _, tag := range t.TagSet {
+		if _, ok := tags[tag.Key]; ok {
+			return nil, cmn.NewErrInvalidTag("duplicate key %q", tag.Key)
+		}
+		tags[tag.Key] = tag.Value
+	}
+	return tags, cmn.ValidateTags(tags)
+}
+
+func (t *Tagging) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(t)
+	debug.AssertNoErr(err)
+}
+
+// `x-amz-tagging` header (PUT object): URL-encoded query, e.g. "k1=v1&k2=v2"
+func TagsFromHeader(header http.Header) (cos.StrKVs, error) {
+	v := header.Get(cos.S3HdrTagging)
+	if v == "" {
+		return nil, nil
+	}
+	q, err := url.ParseQuery(v)
+	if err != nil {
+		return nil, cmn.NewErrInvalidTag("%s: %v", cos.S3HdrTagging, err)
+	}
+	tags := make(cos.StrKVs, len(q))
+	for k, vals := range q {
+		if len(vals) > 1 {
+			return nil, cmn.NewErrInvalidTag("duplicate key %q", k)
+		}
+		tags[k] = vals[0]
+	}
+	return tags, cmn.ValidateTags(tags)
+}
+
+func SetTaggingCount(header http.Header, lom *cluster.LOM) {
+	if n := len(lom.ObjAttrs().Tags()); n > 0 {
+		header.Set(cos.S3HdrTaggingCount, strconv.Itoa(n))
+	}
+}
+
 func (r *CopyObjectResult) MustMarshal(sgl *memsys.SGL) {
 	sgl.Write([]byte(xml.Header))
 	err := xml.NewEncoder(sgl).Encode(r)
 	debug.AssertNoErr(err)
 }
 
+func (r *CopyPartResult) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
 func (r *InitiateMptUploadResult) MustMarshal(sgl *memsys.SGL) {
 	sgl.Write([]byte(xml.Header))
 	err := xml.NewEncoder(sgl).Encode(r)
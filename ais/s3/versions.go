@@ -0,0 +1,136 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// List object versions: S3 XML <= apc.LsVersions listing
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectVersions.html
+//
+// Limitations:
+// - pages end at object (key) boundaries: `max-keys` limits the number of objects,
+//   with all versions of each object included;
+// - `version-id-marker` is ignored (see above).
+
+type (
+	ListVersionsResult struct {
+		XMLName       xml.Name      `xml:"ListVersionsResult"`
+		Ns            string        `xml:"xmlns,attr"`
+		Name          string        `xml:"Name"`
+		Prefix        string        `xml:"Prefix"`
+		KeyMarker     string        `xml:"KeyMarker"`
+		NextKeyMarker string        `xml:"NextKeyMarker,omitempty"`
+		MaxKeys       int           `xml:"MaxKeys"`
+		IsTruncated   bool          `xml:"IsTruncated"`
+		Versions      []*ObjVersion `xml:"Version"`
+		DeleteMarkers []*DelMarker  `xml:"DeleteMarker"`
+	}
+	ObjVersion struct {
+		Key          string `xml:"Key"`
+		VersionID    string `xml:"VersionId"`
+		IsLatest     bool   `xml:"IsLatest"`
+		LastModified string `xml:"LastModified"`
+		ETag         string `xml:"ETag"`
+		Size         int64  `xml:"Size"`
+		Class        string `xml:"StorageClass"`
+	}
+	DelMarker struct {
+		Key          string `xml:"Key"`
+		VersionID    string `xml:"VersionId"`
+		IsLatest     bool   `xml:"IsLatest"`
+		LastModified string `xml:"LastModified"`
+	}
+)
+
+func FillMsgFromS3VersionsQuery(query url.Values, msg *apc.LsoMsg) {
+	FillMsgFromS3Query(query, msg)
+	if marker := query.Get(QparamKeyMarker); marker != "" {
+		msg.ContinuationToken = marker
+	}
+	msg.SetFlag(apc.LsVersions)
+}
+
+func NewListVersionsResult(bucket string, lsmsg *apc.LsoMsg) *ListVersionsResult {
+	return &ListVersionsResult{
+		Name:          bucket,
+		Ns:            s3Namespace,
+		Prefix:        lsmsg.Prefix,
+		KeyMarker:     lsmsg.ContinuationToken,
+		MaxKeys:       1000,
+		Versions:      make([]*ObjVersion, 0),
+		DeleteMarkers: make([]*DelMarker, 0),
+	}
+}
+
+// the listing has non-current versions ordered from oldest to newest and followed
+// by the current version; S3 wants them the other way around
+func (r *ListVersionsResult) FillFromLso(lst *cmn.LsoResult, lsmsg *apc.LsoMsg) {
+	r.IsTruncated = lst.ContinuationToken != ""
+	r.NextKeyMarker = lst.ContinuationToken
+	entries := lst.Entries
+	for i := 0; i < len(entries); {
+		j := i + 1
+		for j < len(entries) && entries[j].Name == entries[i].Name {
+			j++
+		}
+		for k := j - 1; k >= i; k-- {
+			r.add(entries[k], lsmsg)
+		}
+		i = j
+	}
+}
+
+func (r *ListVersionsResult) add(e *cmn.LsoEntry, lsmsg *apc.LsoMsg) {
+	if e.Flags&apc.EntryIsDir != 0 {
+		return
+	}
+	lastModified := e.Atime
+	if lastModified == "" {
+		lastModified = cos.FormatNanoTime(defaultLastModified, lsmsg.TimeFormat)
+	}
+	if e.IsDelMarker() {
+		r.DeleteMarkers = append(r.DeleteMarkers, &DelMarker{
+			Key:          e.Name,
+			VersionID:    e.Version,
+			IsLatest:     !e.IsNoncurrent(),
+			LastModified: lastModified,
+		})
+		return
+	}
+	r.Versions = append(r.Versions, &ObjVersion{
+		Key:          e.Name,
+		VersionID:    e.Version,
+		IsLatest:     !e.IsNoncurrent(),
+		LastModified: lastModified,
+		ETag:         e.Checksum,
+		Size:         e.Size,
+	})
+}
+
+func (r *ListVersionsResult) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// `x-amz-version-id` (ais buckets with versioning enabled)
+func SetVersionID(header http.Header, lom *cluster.LOM) {
+	if lom.Bck().IsAIS() && lom.VersionConf().Enabled {
+		if v := lom.Version(); v != "" {
+			header.Set(cos.S3VersionHeader, v)
+		}
+	}
+}
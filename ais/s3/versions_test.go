@@ -0,0 +1,47 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestListVersionsResult(t *testing.T) {
+	var (
+		lsmsg = &apc.LsoMsg{}
+		query = url.Values{QparamKeyMarker: []string{"a"}, "prefix": []string{"d/"}}
+	)
+	FillMsgFromS3VersionsQuery(query, lsmsg)
+	tassert.Fatalf(t, lsmsg.IsFlagSet(apc.LsVersions), "expected versions flag")
+	tassert.Errorf(t, lsmsg.ContinuationToken == "a", "expected continuation token %q, got %q", "a", lsmsg.ContinuationToken)
+
+	lst := &cmn.LsoResult{
+		Entries: cmn.LsoEntries{
+			{Name: "d/a", Version: "1", Flags: apc.EntryIsNoncurrent, Size: 1},
+			{Name: "d/a", Version: "2", Flags: apc.EntryIsNoncurrent | apc.EntryIsDelMarker},
+			{Name: "d/a", Version: "3", Size: 3},
+			{Name: "d/b", Version: "1", Flags: apc.EntryIsDelMarker},
+		},
+		ContinuationToken: "d/b",
+	}
+	res := NewListVersionsResult("bck", lsmsg)
+	res.FillFromLso(lst, lsmsg)
+	tassert.Errorf(t, res.IsTruncated && res.NextKeyMarker == "d/b", "expected truncated result, next %q", res.NextKeyMarker)
+	tassert.Fatalf(t, len(res.Versions) == 2 && len(res.DeleteMarkers) == 2,
+		"expected 2 versions and 2 delete markers, got %d and %d", len(res.Versions), len(res.DeleteMarkers))
+
+	// newest first
+	v0, v1 := res.Versions[0], res.Versions[1]
+	tassert.Errorf(t, v0.VersionID == "3" && v0.IsLatest, "unexpected %+v", v0)
+	tassert.Errorf(t, v1.VersionID == "1" && !v1.IsLatest, "unexpected %+v", v1)
+	m0, m1 := res.DeleteMarkers[0], res.DeleteMarkers[1]
+	tassert.Errorf(t, m0.Key == "d/a" && !m0.IsLatest, "unexpected %+v", m0)
+	tassert.Errorf(t, m1.Key == "d/b" && m1.IsLatest, "unexpected %+v", m1)
+}
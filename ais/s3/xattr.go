@@ -111,10 +111,17 @@ func (mpt *mpt) unpack(b []byte) (err error) {
 }
 
 func (mpt *mpt) getPart(num int64) *MptPart {
-	for _, part := range mpt.parts {
+	if i := mpt.partIdx(num); i >= 0 {
+		return mpt.parts[i]
+	}
+	return nil
+}
+
+func (mpt *mpt) partIdx(num int64) int {
+	for i, part := range mpt.parts {
 		if part.Num == num {
-			return part
+			return i
 		}
 	}
-	return nil
+	return -1
 }
@@ -33,15 +33,18 @@ import (
 	"github.com/NVIDIA/aistore/cmn/kvdb"
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/event"
 	"github.com/NVIDIA/aistore/ext/dload"
 	"github.com/NVIDIA/aistore/ext/dsort"
 	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/fs/health"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/reb"
 	"github.com/NVIDIA/aistore/res"
+	"github.com/NVIDIA/aistore/sse"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/volume"
@@ -67,6 +70,7 @@ type (
 		reb          *reb.Reb
 		res          *res.Res
 		transactions transactions
+		quotas       quotas   // per-bucket usage (see tgtquota.go)
 		regstate     regstate // the state of being registered with the primary, can be (en/dis)abled via API
 	}
 )
@@ -299,6 +303,15 @@ func (t *target) Run() error {
 	if err := fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}); err != nil {
 		cos.ExitLogf("%v", err)
 	}
+	if err := fs.CSM.Reg(fs.VersionType, &fs.VersionContentResolver{}); err != nil {
+		cos.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.Reg(fs.ETLCacheType, &fs.ETLCacheContentResolver{}); err != nil {
+		cos.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.Reg(fs.ArchIndexType, &fs.ArchIndexContentResolver{}); err != nil {
+		cos.ExitLogf("%v", err)
+	}
 
 	// Init meta-owners and load local instances
 	t.owner.bmd.init()
@@ -375,6 +388,13 @@ func (t *target) Run() error {
 
 	ec.Init(t)
 	mirror.Init()
+	sse.Init(config.ConfigDir)
+	event.Init(t.si.ID(), config.ConfigDir, t.statsT)
+
+	hk.Reg("s3-mpt"+hk.NameSuffix, t.abortStaleMpt, mptAbortIval)
+	t.quotas.load()
+	hk.Reg("bck-quota"+hk.NameSuffix, t.persistQuotas, quotaPersistIval)
+	hk.Reg("lifecycle"+hk.NameSuffix, t.lifecycleHK, lcyIval)
 
 	xreg.RegWithHK()
 
@@ -453,6 +473,8 @@ func (t *target) initRecvHandlers() {
 		{r: apc.Download, h: t.downloadHandler, net: accessNetIntraControl},
 		{r: apc.Sort, h: dsort.TargetHandler, net: accessControlData},
 		{r: apc.ETL, h: t.etlHandler, net: accessNetAll},
+		{r: apc.Batch, h: t.batchHandler, net: accessNetAll},
+		{r: apc.Compose, h: t.composeHandler, net: accessNetIntraControl},
 
 		{r: "/" + apc.S3, h: t.s3Handler, net: accessNetPublicData},
 		{r: "/", h: t.errURL, net: accessNetAll},
@@ -474,6 +496,8 @@ func (t *target) Stop(err error) {
 	}
 	f("Stopping %s, err: %v", t.si, err)
 	xreg.AbortAll(err)
+	event.Stop()
+	t.persistQuotas()
 	t.htrun.stop(t.netServ.pub.s != nil && !isErrNoUnregister(err) /*rm from Smap*/)
 }
 
@@ -611,10 +635,23 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 
 	debug.Assert(dpq.uuid == "", dpq.uuid)
 	if dpq.etlName != "" {
-		t.doETL(w, r, dpq.etlName, bck, lom.ObjName)
+		t.doETL(w, r, dpq.etlName, dpq.etlArgs, bck, lom.ObjName)
 		return lom
 	}
 
+	if dpq.objVer != "" { // apc.QparamObjVersion
+		done, errCode, err := t.getObjVersion(w, lom, dpq.objVer, r.Header.Get(cos.HdrRange))
+		if err != nil {
+			t.statsT.IncErr(stats.GetCount)
+			if err != errSendingResp {
+				t.writeErr(w, r, err, errCode)
+			}
+		}
+		if done {
+			return lom
+		}
+	}
+
 	filename := dpq.archpath // apc.QparamArchpath
 	if strings.HasPrefix(filename, lom.ObjName) {
 		if rel, err := filepath.Rel(lom.ObjName, filename); err == nil {
@@ -780,7 +817,26 @@ func (t *target) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	errCode, err := t.DeleteObject(lom, evict)
+	var (
+		errCode int
+		err     error
+	)
+	// delete archived file
+	if archpath := apireq.query.Get(apc.QparamArchpath); archpath != "" && !evict {
+		if errCode, err := t.delFromArch(lom, archpath, apireq.query.Get(apc.QparamArchmime)); err != nil {
+			t.writeErr(w, r, err, errCode)
+		}
+		return
+	}
+
+	bypass := cos.IsParseBool(apireq.query.Get(apc.QparamBypassGovernance))
+	ver := apireq.query.Get(apc.QparamObjVersion)
+	if ver != "" && !evict {
+		// (EC-wise, handled by delObjVersion - the current version may get restored)
+		errCode, err = t.delObjVersion(lom, ver, bypass)
+	} else {
+		errCode, err = t.delObject(lom, evict, bypass)
+	}
 	if err != nil {
 		if errCode == http.StatusNotFound {
 			t.writeErrSilentf(w, r, http.StatusNotFound, "%s doesn't exist", lom.Cname())
@@ -789,8 +845,10 @@ func (t *target) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	// EC cleanup if EC is enabled
-	ec.ECM.CleanupObject(lom)
+	if ver == "" || evict {
+		// EC cleanup if EC is enabled
+		ec.ECM.CleanupObject(lom)
+	}
 }
 
 // POST /v1/objects/bucket-name/object-name
@@ -799,7 +857,7 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if msg.Action != apc.ActRenameObject {
+	if msg.Action != apc.ActRenameObject && msg.Action != apc.ActCompose {
 		t.writeErrAct(w, r, msg.Action)
 		return
 	}
@@ -814,7 +872,23 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	lom := cluster.AllocLOM(apireq.items[1])
+	defer cluster.FreeLOM(lom)
 	err = lom.InitBck(apireq.bck.Bucket())
+	if msg.Action == apc.ActCompose {
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		cmsg := &cmn.ComposeMsg{}
+		if err := cos.MorphMarshal(msg.Value, cmsg); err != nil {
+			t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
+			return
+		}
+		if errCode, err := t.compose(lom, cmsg); err != nil {
+			t.writeErr(w, r, err, errCode)
+		}
+		return
+	}
 	if err == nil {
 		err = t.objMv(lom, msg)
 	}
@@ -824,7 +898,6 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request) {
 		t.statsT.IncErr(stats.RenameCount)
 		t.writeErr(w, r, err)
 	}
-	cluster.FreeLOM(lom)
 }
 
 // HEAD /v1/objects/<bucket-name>/<object-name>
@@ -874,8 +947,17 @@ func (t *target) objhead(hdr http.Header, query url.Values, bck *cluster.Bck, lo
 		}
 		return
 	}
+	var vattrs *cmn.ObjAttrs // (non-current version)
 	err = lom.Load(true /*cache it*/, false /*locked*/)
 	if err == nil {
+		if ver := query.Get(apc.QparamObjVersion); ver != "" && ver != lom.Version() {
+			if vattrs, errCode, err = t.headObjVersion(lom, ver); err != nil {
+				return
+			}
+		} else if lom.IsDelMarker() {
+			hdr.Set(cos.S3HdrDeleteMarker, "true")
+			return http.StatusNotFound, cmn.NewErrNotFound("%s: object %s", t, lom.Cname())
+		}
 		if apc.IsFltNoProps(fltPresence) {
 			return
 		}
@@ -905,6 +987,9 @@ func (t *target) objhead(hdr http.Header, query url.Values, bck *cluster.Bck, lo
 	op := cmn.ObjectProps{Name: lom.ObjName, Bck: *lom.Bucket(), Present: exists}
 	if exists {
 		op.ObjAttrs = *lom.ObjAttrs()
+		if vattrs != nil {
+			op.ObjAttrs = *vattrs
+		}
 		op.Location = lom.Location()
 		op.Mirror.Copies = lom.NumCopies()
 		if lom.HasCopies() {
@@ -999,17 +1084,68 @@ func (t *target) httpobjpatch(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	custom := cos.StrKVs{}
-	if err := cos.MorphMarshal(msg.Value, &custom); err != nil {
-		t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, "set-custom", msg.Value, err)
-		return
-	}
 	lom := cluster.AllocLOM(apireq.items[1] /*objName*/)
 	defer cluster.FreeLOM(lom)
 	if err := lom.InitBck(apireq.bck.Bucket()); err != nil {
 		t.writeErr(w, r, err)
 		return
 	}
+	delOldSetNew := cos.IsParseBool(apireq.query.Get(apc.QparamNewCustom))
+	switch msg.Action {
+	case "":
+	case apc.ActSetObjTags:
+		tags := cos.StrKVs{}
+		if err := cos.MorphMarshal(msg.Value, &tags); err != nil {
+			t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
+			return
+		}
+		if errCode, err := t.setObjTags(lom, tags, delOldSetNew); err != nil {
+			t.writeErr(w, r, err, errCode)
+		}
+		return
+	case apc.ActObjRetention:
+		var retention *cmn.ObjRetention
+		if msg.Value != nil {
+			retention = &cmn.ObjRetention{}
+			if err := cos.MorphMarshal(msg.Value, retention); err != nil {
+				t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
+				return
+			}
+		}
+		bypass := cos.IsParseBool(apireq.query.Get(apc.QparamBypassGovernance))
+		if errCode, err := t.setObjRetention(lom, retention, bypass); err != nil {
+			t.writeErr(w, r, err, errCode)
+		}
+		return
+	case apc.ActObjLegalHold:
+		var on bool
+		if err := cos.MorphMarshal(msg.Value, &on); err != nil {
+			t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
+			return
+		}
+		if errCode, err := t.setObjLegalHold(lom, on); err != nil {
+			t.writeErr(w, r, err, errCode)
+		}
+		return
+	case apc.ActDelObjTags:
+		var keys []string
+		if err := cos.MorphMarshal(msg.Value, &keys); err != nil {
+			t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
+			return
+		}
+		if errCode, err := t.delObjTags(lom, keys); err != nil {
+			t.writeErr(w, r, err, errCode)
+		}
+		return
+	default:
+		t.writeErrAct(w, r, msg.Action)
+		return
+	}
+	custom := cos.StrKVs{}
+	if err := cos.MorphMarshal(msg.Value, &custom); err != nil {
+		t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, "set-custom", msg.Value, err)
+		return
+	}
 	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
 		if cmn.IsObjNotExist(err) {
 			t.writeErr(w, r, err, http.StatusNotFound)
@@ -1018,8 +1154,34 @@ func (t *target) httpobjpatch(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	delOldSetNew := cos.IsParseBool(apireq.query.Get(apc.QparamNewCustom))
+	// user-defined tags can be set as custom keys as well - enforce the same limits
+	if tags := (&cmn.ObjAttrs{CustomMD: custom}).Tags(); tags != nil {
+		if !delOldSetNew {
+			for k, v := range lom.ObjAttrs().Tags() {
+				if _, ok := tags[k]; !ok {
+					tags[k] = v
+				}
+			}
+		}
+		if err := cmn.ValidateTags(tags); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+	}
+	// encryption keys, object lock (modified only via apc.ActObjRetention and
+	// apc.ActObjLegalHold), and delete marker are system-maintained
+	for key := range custom {
+		if cmn.IsReservedObjMD(key) {
+			t.writeErrf(w, r, "%s: custom key %q is reserved", lom, key)
+			return
+		}
+	}
 	if delOldSetNew {
+		for key, val := range lom.GetCustomMD() {
+			if cmn.IsReservedObjMD(key) {
+				custom[key] = val
+			}
+		}
 		lom.SetCustomMD(custom)
 	} else {
 		for key, val := range custom {
@@ -1161,34 +1323,16 @@ func (t *target) doAppend(r *http.Request, lom *cluster.LOM, started time.Time,
 	return aoi.appendObject()
 }
 
-func (t *target) doAppendArch(r *http.Request, lom *cluster.LOM, started time.Time, dpq *dpq) (errCode int, err error) {
-	var (
-		sizeStr  = r.Header.Get(cos.HdrContentLength)
-		mime     = dpq.archmime // apc.QparamArchmime
-		filename = dpq.archpath // apc.QparamArchpath
-	)
-	if strings.HasPrefix(filename, lom.ObjName) {
-		if rel, err := filepath.Rel(lom.ObjName, filename); err == nil {
-			filename = rel
-		}
-	}
-	lom.Lock(true)
-	defer lom.Unlock(true)
-	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
-		if os.IsNotExist(err) {
-			return http.StatusNotFound, err
-		}
-		return http.StatusInternalServerError, err
-	}
+func (t *target) doAppendArch(r *http.Request, lom *cluster.LOM, started time.Time, dpq *dpq) (int, error) {
 	aaoi := &appendArchObjInfo{
 		started:  started,
 		t:        t,
 		lom:      lom,
 		r:        r.Body,
-		filename: filename,
-		mime:     mime,
+		filename: dpq.archpath, // apc.QparamArchpath
+		mime:     dpq.archmime, // apc.QparamArchmime
 	}
-	if sizeStr != "" {
+	if sizeStr := r.Header.Get(cos.HdrContentLength); sizeStr != "" {
 		if size, ers := strconv.ParseInt(sizeStr, 10, 64); ers == nil {
 			aaoi.size = size
 		}
@@ -1196,7 +1340,37 @@ func (t *target) doAppendArch(r *http.Request, lom *cluster.LOM, started time.Ti
 	if aaoi.size == 0 {
 		return http.StatusBadRequest, errors.New("size is not defined")
 	}
-	return aaoi.appendObject()
+	lom.Lock(true)
+	errCode, err := aaoi.init()
+	if err == nil {
+		errCode, err = aaoi.appendObject()
+	}
+	lom.Unlock(true)
+	if err == nil {
+		event.Emit(cmn.EventObjCreated, lom) // (new version)
+	}
+	return errCode, err
+}
+
+// DELETE /v1/objects/bucket-name/object-name?archpath=...
+func (t *target) delFromArch(lom *cluster.LOM, filename, mime string) (int, error) {
+	aaoi := &appendArchObjInfo{
+		started:  time.Now(),
+		t:        t,
+		lom:      lom,
+		filename: filename,
+		mime:     mime,
+	}
+	lom.Lock(true)
+	errCode, err := aaoi.init()
+	if err == nil {
+		errCode, err = aaoi.deleteObject()
+	}
+	lom.Unlock(true)
+	if err == nil {
+		event.Emit(cmn.EventObjCreated, lom) // (new version)
+	}
+	return errCode, err
 }
 
 func (t *target) putMirror(lom *cluster.LOM) {
@@ -1228,9 +1402,13 @@ func (t *target) putMirror(lom *cluster.LOM) {
 }
 
 func (t *target) DeleteObject(lom *cluster.LOM, evict bool) (code int, err error) {
+	return t.delObject(lom, evict, false /*bypass governance*/)
+}
+
+func (t *target) delObject(lom *cluster.LOM, evict, bypass bool) (code int, err error) {
 	var isback bool
 	lom.Lock(true)
-	code, err, isback = t.delobj(lom, evict)
+	code, err, isback = t.delobj(lom, evict, bypass)
 	lom.Unlock(true)
 
 	// special corner-case retry (quote):
@@ -1245,13 +1423,19 @@ func (t *target) DeleteObject(lom *cluster.LOM, evict bool) (code int, err error
 	}
 	if err == nil {
 		t.statsT.Inc(stats.DeleteCount)
+		etl.DropCached(lom)
+		if evict {
+			event.Emit(cmn.EventObjEvicted, lom)
+		} else {
+			event.Emit(cmn.EventObjRemoved, lom)
+		}
 	} else {
 		t.statsT.IncErr(stats.DeleteCount) // TODO: count GET/PUT/DELETE remote errors separately..
 	}
 	return
 }
 
-func (t *target) delobj(lom *cluster.LOM, evict bool) (int, error, bool) {
+func (t *target) delobj(lom *cluster.LOM, evict, bypass bool) (int, error, bool) {
 	var (
 		aisErr, backendErr         error
 		aisErrCode, backendErrCode int
@@ -1259,6 +1443,13 @@ func (t *target) delobj(lom *cluster.LOM, evict bool) (int, error, bool) {
 	)
 	delFromBackend = lom.Bck().IsRemote() && !evict
 	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+		if lom.Bprops().Versioning.Retains() {
+			code, err := t.delobjRetain(lom)
+			return code, err, false
+		}
+		if err := checkObjLock(lom, bypass); err != nil {
+			return http.StatusForbidden, err, false
+		}
 		delFromAIS = true
 	} else if !cmn.IsObjNotExist(err) {
 		return 0, err, false
@@ -1284,12 +1475,15 @@ func (t *target) delobj(lom *cluster.LOM, evict bool) (int, error, bool) {
 				}
 				return 0, aisErr, false
 			}
-		} else if evict {
-			debug.Assert(lom.Bck().IsRemote())
-			t.statsT.AddMany(
-				cos.NamedVal64{Name: stats.LruEvictCount, Value: 1},
-				cos.NamedVal64{Name: stats.LruEvictSize, Value: size},
-			)
+		} else {
+			t.quotas.sub(lom.Bck(), size)
+			if evict {
+				debug.Assert(lom.Bck().IsRemote())
+				t.statsT.AddMany(
+					cos.NamedVal64{Name: stats.LruEvictCount, Value: 1},
+					cos.NamedVal64{Name: stats.LruEvictSize, Value: size},
+				)
+			}
 		}
 	}
 	if backendErr != nil {
@@ -1309,6 +1503,11 @@ func (t *target) objMv(lom *cluster.LOM, msg *apc.ActMsg) error {
 	if msg.Name == lom.ObjName {
 		return fmt.Errorf("%s: cannot rename/move object %s onto itself", t.si, lom)
 	}
+	if lom.Bprops().ObjLock.Enabled {
+		if err := t.checkMvLock(lom, msg.Name); err != nil {
+			return err
+		}
+	}
 
 	buf, slab := t.gmm.Alloc()
 	coi := allocCopyObjInfo()
@@ -1327,13 +1526,32 @@ func (t *target) objMv(lom *cluster.LOM, msg *apc.ActMsg) error {
 
 	// TODO: combine copy+delete under a single write lock
 	lom.Lock(true)
+	size := lom.SizeBytes()
 	if err := lom.Remove(); err != nil {
 		glog.Warningf("%s: failed to delete renamed object %s (new name %s): %v", t, lom, msg.Name, err)
+	} else {
+		t.quotas.sub(lom.Bck(), size)
 	}
 	lom.Unlock(true)
 	return nil
 }
 
+// object lock: neither the source nor the (existing) destination can be locked
+func (*target) checkMvLock(lom *cluster.LOM, newName string) error {
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		return err
+	}
+	if err := checkObjLock(lom, false /*bypass*/); err != nil {
+		return err
+	}
+	dst := cluster.AllocLOM(newName)
+	defer cluster.FreeLOM(dst)
+	if err := dst.InitBck(lom.Bucket()); err != nil {
+		return err
+	}
+	return checkOverwrite(dst, false /*locked*/)
+}
+
 func (t *target) fsErr(err error, filepath string) {
 	if !cmn.GCO.Get().FSHC.Enabled || !cos.IsIOError(err) {
 		return
@@ -0,0 +1,238 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xact/xs"
+)
+
+// multi-object GET (see also: xact/xs/getbatch.go)
+
+// designated target => peer: entries to read and send back
+type batchPeerMsg struct {
+	UUID    string              `json:"uuid"`
+	DT      string              `json:"dt"`  // designated target ID
+	Idx     []int               `json:"idx"` // entry indices in the original request
+	Entries []cmn.GetBatchEntry `json:"entries"`
+}
+
+// [METHOD] /v1/batch
+func (t *target) batchHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		t.getBatch(w, r)
+	case http.MethodPost:
+		t.sendBatch(w, r)
+	default:
+		cmn.WriteErr405(w, r, http.MethodGet, http.MethodPost)
+	}
+}
+
+// GET /v1/batch (redirected by proxy)
+// designated target: assemble the archive and stream it back
+func (t *target) getBatch(w http.ResponseWriter, r *http.Request) {
+	if _, err := t.apiItems(w, r, 0, false, apc.URLPathBatch.L); err != nil {
+		return
+	}
+	msg := &cmn.GetBatchMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	if err := msg.Validate(); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	uuid := r.URL.Query().Get(apc.QparamUUID)
+	if uuid == "" {
+		uuid = cos.GenUUID()
+	}
+	xctn, err := t.renewGetBatch()
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+
+	// partition entries by their respective owners
+	var (
+		smap  = t.owner.smap.get()
+		local = make([]bool, len(msg.Entries))
+		peers = make(map[string]*batchPeerMsg, smap.CountActiveTs())
+	)
+	for i := range msg.Entries {
+		e := &msg.Entries[i]
+		tsi, err := cluster.HrwTarget(e.Bck.MakeUname(e.ObjName), &smap.Smap)
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		if tsi.ID() == t.SID() {
+			local[i] = true
+			continue
+		}
+		pmsg, ok := peers[tsi.ID()]
+		if !ok {
+			pmsg = &batchPeerMsg{UUID: uuid, DT: t.SID()}
+			peers[tsi.ID()] = pmsg
+		}
+		pmsg.Idx = append(pmsg.Idx, i)
+		pmsg.Entries = append(pmsg.Entries, *e)
+	}
+
+	wi, err := xctn.Begin(uuid, msg, local)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	for tid, pmsg := range peers {
+		go func(tsi *cluster.Snode, pmsg *batchPeerMsg) {
+			if err := t.activateBatch(tsi, pmsg); err != nil {
+				for _, idx := range pmsg.Idx {
+					wi.Fail(idx, err)
+				}
+			}
+		}(smap.GetTarget(tid), pmsg)
+	}
+
+	if msg.Mime == cos.ExtMsgpack {
+		w.Header().Set(cos.HdrContentType, cos.ContentMsgPack)
+	} else {
+		w.Header().Set(cos.HdrContentType, cos.ContentBinary)
+	}
+	err = wi.Write(w, t.batchOpen)
+	xctn.End(wi)
+	if err != nil {
+		// (the response, most likely, has already been partially written)
+		glog.Errorf("%s: %s %q failed: %v", t, apc.ActGetBatch, uuid, err)
+	}
+}
+
+// POST /v1/batch (intra-cluster)
+// peer target: read the specified entries and send them to the designated target
+func (t *target) sendBatch(w http.ResponseWriter, r *http.Request) {
+	if _, err := t.apiItems(w, r, 0, false, apc.URLPathBatch.L); err != nil {
+		return
+	}
+	if !t.ensureIntraControl(w, r, false /* from primary */) {
+		return
+	}
+	pmsg := &batchPeerMsg{}
+	if err := cmn.ReadJSON(w, r, pmsg); err != nil {
+		return
+	}
+	if len(pmsg.Idx) != len(pmsg.Entries) {
+		t.writeErrf(w, r, "%s: invalid %s request %q (%d vs %d)", t, apc.ActGetBatch, pmsg.UUID, len(pmsg.Idx), len(pmsg.Entries))
+		return
+	}
+	smap := t.owner.smap.get()
+	tsi := smap.GetTarget(pmsg.DT)
+	if tsi == nil {
+		t.writeErr(w, r, &errNodeNotFound{apc.ActGetBatch + " failure", pmsg.DT, t.si, smap})
+		return
+	}
+	xctn, err := t.renewGetBatch()
+	if err == nil {
+		err = xctn.Send(pmsg.UUID, tsi, pmsg.Idx, pmsg.Entries, t.batchOpen)
+	}
+	if err != nil {
+		t.writeErr(w, r, err)
+	}
+}
+
+func (t *target) renewGetBatch() (*xs.XactGetBatch, error) {
+	rns := xreg.RenewGetBatch(t, cos.GenUUID())
+	if rns.Err != nil {
+		return nil, rns.Err
+	}
+	return rns.Entry.Get().(*xs.XactGetBatch), nil
+}
+
+func (t *target) activateBatch(tsi *cluster.Snode, pmsg *batchPeerMsg) error {
+	cargs := allocCargs()
+	{
+		cargs.si = tsi
+		cargs.req = cmn.HreqArgs{
+			Method: http.MethodPost,
+			Base:   tsi.URL(cmn.NetIntraControl),
+			Path:   apc.URLPathBatch.S,
+			Body:   cos.MustMarshal(pmsg),
+		}
+		cargs.timeout = cmn.Timeout.CplaneOperation()
+	}
+	res := t.call(cargs)
+	err := res.toErr()
+	freeCargs(cargs)
+	freeCR(res)
+	return err
+}
+
+// open object or archived file for reading (under rlock until closed)
+func (t *target) batchOpen(e *cmn.GetBatchEntry) (cos.ReadCloseSizer, error) {
+	lom := cluster.AllocLOM(e.ObjName)
+	if err := lom.InitBck(&e.Bck); err != nil {
+		cluster.FreeLOM(lom)
+		return nil, err
+	}
+	lom.Lock(false)
+	err := lom.Load(true /*cache it*/, true /*locked*/)
+	if err != nil && cmn.IsObjNotExist(err) && lom.Bck().IsRemote() {
+		lom.Unlock(false)
+		if _, err = t.GetCold(context.Background(), lom, cmn.OwtGetLock); err != nil {
+			cluster.FreeLOM(lom)
+			return nil, err
+		}
+		lom.Lock(false)
+		err = lom.Load(true /*cache it*/, true /*locked*/)
+	}
+	if err == nil && lom.IsDelMarker() {
+		err = cmn.NewErrNotFound("%s: object %s", t, lom.Cname())
+	}
+	var fh cos.LomReader
+	if err == nil {
+		fh, err = lom.Open(lom.FQN)
+	}
+	if err != nil {
+		lom.Unlock(false)
+		cluster.FreeLOM(lom)
+		return nil, err
+	}
+	unlock := func() {
+		lom.Unlock(false)
+		cluster.FreeLOM(lom)
+	}
+
+	// object
+	if e.ArchPath == "" {
+		return cos.NewReaderWithArgs(cos.ReaderArgs{R: fh, Size: lom.SizeBytes(), DeferCb: unlock}), nil
+	}
+
+	// archived file
+	var csl cos.ReadCloseSizer
+	mime, err := archMime(t.smm, lom, fh, "" /*by magic*/)
+	if err == nil {
+		csl, err = freadArch(lom, fh, e.ArchPath, mime)
+	}
+	if err != nil {
+		cos.Close(fh)
+		unlock()
+		return nil, err
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:    csl,
+		Size: csl.Size(),
+		DeferCb: func() {
+			cos.Close(fh)
+			unlock()
+		},
+	}), nil
+}
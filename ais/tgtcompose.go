@@ -0,0 +1,306 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xact/xs"
+)
+
+// compose objects (see also: xact/xs/compose.go)
+
+// designated target => peer:
+// - send the (single) source #Idx, or
+// - delete all the sources upon successful compose
+type composePeerMsg struct {
+	UUID    string           `json:"uuid"`
+	DT      string           `json:"dt"` // designated target ID
+	Sources []cmn.ComposeSrc `json:"sources"`
+	Idx     int              `json:"idx"` // source index in the original request
+	Delete  bool             `json:"delete,omitempty"`
+}
+
+// POST /v1/compose (intra-cluster)
+// peer target: read the specified source and send it to the designated target
+func (t *target) composeHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		cmn.WriteErr405(w, r, http.MethodPost)
+		return
+	}
+	if _, err := t.apiItems(w, r, 0, false, apc.URLPathCompose.L); err != nil {
+		return
+	}
+	if !t.ensureIntraControl(w, r, false /* from primary */) {
+		return
+	}
+	pmsg := &composePeerMsg{}
+	if err := cmn.ReadJSON(w, r, pmsg); err != nil {
+		return
+	}
+	if pmsg.Delete {
+		t.composeDelete(pmsg.Sources)
+		return
+	}
+	if len(pmsg.Sources) != 1 {
+		t.writeErrf(w, r, "%s: invalid %s request %q (num sources %d)", t, apc.ActCompose, pmsg.UUID, len(pmsg.Sources))
+		return
+	}
+	smap := t.owner.smap.get()
+	tsi := smap.GetTarget(pmsg.DT)
+	if tsi == nil {
+		t.writeErr(w, r, &errNodeNotFound{apc.ActCompose + " failure", pmsg.DT, t.si, smap})
+		return
+	}
+	xctn, err := t.renewCompose()
+	if err == nil {
+		err = xctn.Send(pmsg.UUID, tsi, pmsg.Idx, &pmsg.Sources[0], t.composeOpen)
+	}
+	if err != nil {
+		t.writeErr(w, r, err)
+	}
+}
+
+// designated target: compose the destination object `lom`
+func (t *target) compose(lom *cluster.LOM, msg *cmn.ComposeMsg) (errCode int, err error) {
+	if err = t.composeInit(lom.Bucket(), msg); err != nil {
+		return http.StatusBadRequest, err
+	}
+	var (
+		cksum   cos.CksumHashSize
+		fh      io.WriteCloser
+		started = time.Now()
+		workFQN = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileCompose)
+	)
+	if fh, err = lom.CreateFile(workFQN); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	cksum.Init(lom.CksumType())
+	_, err = t.composeTo(cos.NewWriterMulti(fh, &cksum), msg)
+	cos.Close(fh)
+	if err != nil {
+		if errRm := cos.RemoveFile(workFQN); errRm != nil {
+			glog.Errorf(fmtNested, t, err, "remove", workFQN, errRm)
+		}
+		return http.StatusInternalServerError, err
+	}
+	cksum.Finalize()
+	lom.SetSize(cksum.Size)
+	lom.SetCksum(cksum.Clone())
+	lom.SetAtimeUnix(started.UnixNano())
+	if errCode, err = t.FinalizeObj(lom, workFQN, nil); err != nil { // locks inside
+		return
+	}
+	if msg.DeleteSrc {
+		t.composeDelSrc(lom, msg)
+	}
+	return
+}
+
+// source buckets: default to destination, initialize, validate
+func (t *target) composeInit(dst *cmn.Bck, msg *cmn.ComposeMsg) error {
+	for i := range msg.Sources {
+		src := &msg.Sources[i]
+		if src.Bck.IsEmpty() {
+			src.Bck = *dst
+			continue
+		}
+		bck := cluster.CloneBck(&src.Bck)
+		if err := bck.Init(t.owner.bmd); err != nil {
+			return err
+		}
+		src.Bck = *bck.Bucket()
+	}
+	return msg.Validate()
+}
+
+// designated target: write all sources into `w` in the specified order,
+// pulling remote ones from their respective owners
+func (t *target) composeTo(w io.Writer, msg *cmn.ComposeMsg) (size int64, err error) {
+	var (
+		smap   = t.owner.smap.get()
+		owners = make([]*cluster.Snode, len(msg.Sources))
+		remote = make([]bool, len(msg.Sources))
+		uuid   = cos.GenUUID()
+	)
+	for i := range msg.Sources {
+		src := &msg.Sources[i]
+		tsi, err := cluster.HrwTarget(src.Bck.MakeUname(src.ObjName), &smap.Smap)
+		if err != nil {
+			return 0, err
+		}
+		if tsi.ID() != t.SID() {
+			owners[i], remote[i] = tsi, true
+		}
+	}
+	xctn, err := t.renewCompose()
+	if err != nil {
+		return 0, err
+	}
+	wi, err := xctn.Begin(uuid, w)
+	if err != nil {
+		return 0, err
+	}
+	pull := func(idx int, src *cmn.ComposeSrc) error {
+		pmsg := &composePeerMsg{UUID: uuid, DT: t.SID(), Sources: []cmn.ComposeSrc{*src}, Idx: idx}
+		return t.callCompose(owners[idx], pmsg)
+	}
+	size, err = wi.Write(msg, remote, t.composeOpen, pull)
+	xctn.End(wi)
+	return
+}
+
+// (best effort) delete the sources upon successful compose, excluding the destination itself
+func (t *target) composeDelSrc(dst *cluster.LOM, msg *cmn.ComposeMsg) {
+	var (
+		local []cmn.ComposeSrc
+		smap  = t.owner.smap.get()
+		peers = make(map[string][]cmn.ComposeSrc, 2)
+		done  = make(cos.StrSet, len(msg.Sources))
+	)
+	done.Add(dst.Uname())
+	for i := range msg.Sources {
+		src := &msg.Sources[i]
+		uname := src.Bck.MakeUname(src.ObjName)
+		if done.Contains(uname) {
+			continue
+		}
+		done.Add(uname)
+		tsi, err := cluster.HrwTarget(uname, &smap.Smap)
+		if err != nil {
+			glog.Errorf("%s: %s: failed to delete source %s: %v", t, apc.ActCompose, src.Cname(), err)
+			continue
+		}
+		if tsi.ID() == t.SID() {
+			local = append(local, *src)
+		} else {
+			peers[tsi.ID()] = append(peers[tsi.ID()], *src)
+		}
+	}
+	t.composeDelete(local)
+	for tid, srcs := range peers {
+		pmsg := &composePeerMsg{DT: t.SID(), Sources: srcs, Delete: true}
+		if err := t.callCompose(smap.GetTarget(tid), pmsg); err != nil {
+			glog.Errorf("%s: %s: failed to delete %d source%s at %s: %v", t, apc.ActCompose, len(srcs), cos.Plural(len(srcs)), tid, err)
+		}
+	}
+}
+
+func (t *target) composeDelete(srcs []cmn.ComposeSrc) {
+	for i := range srcs {
+		lom := cluster.AllocLOM(srcs[i].ObjName)
+		err := lom.InitBck(&srcs[i].Bck)
+		if err == nil {
+			_, err = t.DeleteObject(lom, false /*evict*/)
+		}
+		if err != nil && !cmn.IsObjNotExist(err) {
+			glog.Warningf("%s: %s: failed to delete source %s: %v", t, apc.ActCompose, srcs[i].Cname(), err)
+		}
+		cluster.FreeLOM(lom)
+	}
+}
+
+func (t *target) renewCompose() (*xs.XactCompose, error) {
+	rns := xreg.RenewCompose(t, cos.GenUUID())
+	if rns.Err != nil {
+		return nil, rns.Err
+	}
+	return rns.Entry.Get().(*xs.XactCompose), nil
+}
+
+func (t *target) callCompose(tsi *cluster.Snode, pmsg *composePeerMsg) error {
+	cargs := allocCargs()
+	{
+		cargs.si = tsi
+		cargs.req = cmn.HreqArgs{
+			Method: http.MethodPost,
+			Base:   tsi.URL(cmn.NetIntraControl),
+			Path:   apc.URLPathCompose.S,
+			Body:   cos.MustMarshal(pmsg),
+		}
+		cargs.timeout = cmn.Timeout.CplaneOperation()
+	}
+	res := t.call(cargs)
+	err := res.toErr()
+	freeCargs(cargs)
+	freeCR(res)
+	return err
+}
+
+// open source object's range for reading (under rlock until closed)
+func (t *target) composeOpen(src *cmn.ComposeSrc) (cos.ReadCloseSizer, error) {
+	lom, fh, err := t.openRlocked(&src.Bck, src.ObjName)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		size   = lom.SizeBytes()
+		length = src.Length
+	)
+	if length == 0 {
+		length = size - src.Offset
+	}
+	if src.Offset > size || src.Offset+length > size {
+		err = fmt.Errorf("%s: range [%d, %d) is out of bounds (object size %d)", lom.Cname(), src.Offset, src.Offset+length, size)
+		cos.Close(fh)
+		lom.Unlock(false)
+		cluster.FreeLOM(lom)
+		return nil, err
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:    io.NewSectionReader(fh, src.Offset, length),
+		Size: length,
+		DeferCb: func() {
+			cos.Close(fh)
+			lom.Unlock(false)
+			cluster.FreeLOM(lom)
+		},
+	}), nil
+}
+
+// rlock, load (cold-GET from remote backend if need be), and open the object for reading;
+// upon success, the caller must close the returned reader, unlock the object, and free the LOM
+func (t *target) openRlocked(bck *cmn.Bck, objName string) (*cluster.LOM, cos.LomReader, error) {
+	lom := cluster.AllocLOM(objName)
+	if err := lom.InitBck(bck); err != nil {
+		cluster.FreeLOM(lom)
+		return nil, nil, err
+	}
+	lom.Lock(false)
+	err := lom.Load(true /*cache it*/, true /*locked*/)
+	if err != nil && cmn.IsObjNotExist(err) && lom.Bck().IsRemote() {
+		lom.Unlock(false)
+		if _, err = t.GetCold(context.Background(), lom, cmn.OwtGetLock); err != nil {
+			cluster.FreeLOM(lom)
+			return nil, nil, err
+		}
+		lom.Lock(false)
+		err = lom.Load(true /*cache it*/, true /*locked*/)
+	}
+	if err == nil && lom.IsDelMarker() {
+		err = cmn.NewErrNotFound("%s: object %s", t, lom.Cname())
+	}
+	var fh cos.LomReader
+	if err == nil {
+		fh, err = lom.Open(lom.FQN)
+	}
+	if err != nil {
+		lom.Unlock(false)
+		cluster.FreeLOM(lom)
+		return nil, nil, err
+	}
+	return lom, fh, nil
+}
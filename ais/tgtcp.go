@@ -23,6 +23,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/event"
 	"github.com/NVIDIA/aistore/ext/dsort"
 	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/fs"
@@ -687,6 +688,8 @@ func (t *target) _postBMD(tag string, rmbcks []*cluster.Bck) {
 			glog.Errorf("Failed to initialize EC manager: %v", err)
 		}
 	}
+	// remove event sinks that are no longer configured
+	event.BucketsMDChanged(&t.owner.bmd.get().BMD)
 	// since some buckets may have been destroyed
 	if cs := fs.Cap(); cs.Err != nil {
 		_ = t.OOS(nil)
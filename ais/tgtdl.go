@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -32,6 +33,10 @@ func (t *target) downloadHandler(w http.ResponseWriter, r *http.Request) {
 	if !t.ensureIntraControl(w, r, false /* from primary */) {
 		return
 	}
+	if strings.HasPrefix(r.URL.Path, apc.URLPathDownloadSched.S) {
+		t.dlschedHistory(w, r)
+		return
+	}
 
 	switch r.Method {
 	case http.MethodPost:
@@ -188,3 +193,25 @@ func (t *target) renewdl(xid string) (*dload.Xact, error) {
 	xctn := rns.Entry.Get()
 	return xctn.(*dload.Xact), nil
 }
+
+// GET    /v1/download/schedule/<id> - history of the scheduled runs
+// DELETE /v1/download/schedule/<id> - (the schedule is being removed)
+func (t *target) dlschedHistory(w http.ResponseWriter, r *http.Request) {
+	items, err := t.apiItems(w, r, 1, false, apc.URLPathDownloadSched.L)
+	if err != nil {
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		history, err := dload.ScheduleHistory(items[0])
+		if err != nil {
+			t.writeErr(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		t.writeJSON(w, r, history, "download-schedule-history")
+	case http.MethodDelete:
+		dload.RemoveScheduleHistory(items[0])
+	default:
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet)
+	}
+}
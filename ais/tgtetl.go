@@ -9,18 +9,23 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 
+	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/ext/etl"
+	"github.com/NVIDIA/aistore/memsys"
 )
 
 // [METHOD] /v1/etl
 func (t *target) etlHandler(w http.ResponseWriter, r *http.Request) {
-	if err := k8s.Detect(); err != nil {
+	if err := k8s.Detect(); err != nil && !cmn.Features.IsSet(feat.LocalETL) {
 		t.writeErr(w, r, err, 0, Silent)
 		return
 	}
@@ -64,9 +69,14 @@ func (t *target) handleETLPut(w http.ResponseWriter, r *http.Request) {
 		err = etl.InitSpec(t, msg, xid, etl.StartOpts{})
 	case *etl.InitCodeMsg:
 		err = etl.InitCode(t, msg, xid)
+	case *etl.PipelineMsg:
+		err = etl.InitPipeline(t, msg, xid)
 	default:
 		debug.Assert(false, initMsg.String())
 	}
+	if err == nil {
+		err = etl.InitCache(t, initMsg)
+	}
 	if err != nil {
 		t.writeErr(w, r, err)
 		return
@@ -137,11 +147,15 @@ func (t *target) stopETL(w http.ResponseWriter, r *http.Request, etlName string)
 	}
 }
 
-func (t *target) doETL(w http.ResponseWriter, r *http.Request, etlName string, bck *cluster.Bck, objName string) {
+func (t *target) doETL(w http.ResponseWriter, r *http.Request, etlName, etlArgs string, bck *cluster.Bck, objName string) {
 	var (
 		comm etl.Communicator
 		err  error
 	)
+	if err = etl.ValidateArgs(etlArgs); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
 	comm, err = etl.GetCommunicator(etlName, t.si)
 	if err != nil {
 		if cmn.IsErrNotFound(err) {
@@ -155,7 +169,7 @@ func (t *target) doETL(w http.ResponseWriter, r *http.Request, etlName string, b
 		t.writeErr(w, r, err)
 		return
 	}
-	if err := comm.OnlineTransform(w, r, bck, objName); err != nil {
+	if err := etl.OnlineTransform(comm, w, r, bck, objName, etlArgs); err != nil {
 		t.writeErr(w, r, cmn.NewErrETL(&cmn.ETLErrCtx{
 			ETLName: etlName,
 			PodName: comm.PodName(),
@@ -199,13 +213,18 @@ func (t *target) metricsETL(w http.ResponseWriter, r *http.Request, etlName stri
 	t.writeJSON(w, r, metricMsg, "metrics-etl")
 }
 
-func etlParseObjectReq(_ http.ResponseWriter, r *http.Request) (secret string, bck *cluster.Bck, objName string, err error) {
+// returns either (bucket, object) or pipeline stage token (see etl.IsStagePath)
+func etlParseObjectReq(_ http.ResponseWriter, r *http.Request) (secret string, bck *cluster.Bck, objName, stage string, err error) {
 	var items []string
 	items, err = cmn.MatchItems(r.URL.EscapedPath(), 2, false, apc.URLPathETLObject.L)
 	if err != nil {
 		return
 	}
 	secret = items[0]
+	if etl.IsStagePath(items[1]) {
+		stage = items[1]
+		return
+	}
 	// Encoding is done in `transformerPath`.
 	var uname string
 	uname, err = url.PathUnescape(items[1])
@@ -232,7 +251,7 @@ func etlParseObjectReq(_ http.ResponseWriter, r *http.Request) (secret string, b
 // NOTE: this is an internal URL with `_objects` in its path intended to avoid
 // conflicts with ETL name in `/v1/elts/<etl-name>/...`
 func (t *target) getObjectETL(w http.ResponseWriter, r *http.Request) {
-	secret, bck, objName, err := etlParseObjectReq(w, r)
+	secret, bck, objName, stage, err := etlParseObjectReq(w, r)
 	if err != nil {
 		t.writeErr(w, r, err)
 		return
@@ -241,6 +260,10 @@ func (t *target) getObjectETL(w http.ResponseWriter, r *http.Request) {
 		t.writeErr(w, r, err)
 		return
 	}
+	if stage != "" {
+		t.getStageETL(w, r, stage)
+		return
+	}
 	dpq := dpqAlloc()
 	if err := dpq.fromRawQ(r.URL.RawQuery); err != nil {
 		dpqFree(dpq)
@@ -253,12 +276,40 @@ func (t *target) getObjectETL(w http.ResponseWriter, r *http.Request) {
 	dpqFree(dpq)
 }
 
+// GET /v1/etl/_objects/<secret>/<stage-token>
+// Serves intermediate (in-memory) result of the previous pipeline stage - one time only.
+func (t *target) getStageETL(w http.ResponseWriter, r *http.Request, stage string) {
+	reader, err := etl.TakeStage(stage)
+	if err != nil {
+		if cmn.IsErrNotFound(err) {
+			t.writeErr(w, r, err, http.StatusNotFound)
+		} else {
+			t.writeErr(w, r, err, http.StatusConflict)
+		}
+		return
+	}
+	size := reader.Size()
+	if size >= 0 {
+		w.Header().Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+	} else {
+		size = memsys.DefaultBufSize
+	}
+	w.Header().Set(cos.HdrContentType, cos.ContentBinary)
+	buf, slab := t.gmm.AllocSize(size)
+	_, err = io.CopyBuffer(w, reader, buf)
+	slab.Free(buf)
+	cos.Close(reader)
+	if err != nil {
+		glog.Errorf("%s: failed to send etl stage %q: %v", t, stage, err)
+	}
+}
+
 // HEAD /v1/etl/objects/<secret>/<uname>
 //
 // Handles HEAD requests from ETL containers (K8s Pods).
 // Validates the secret that was injected into a Pod during its initialization.
 func (t *target) headObjectETL(w http.ResponseWriter, r *http.Request) {
-	secret, bck, objName, err := etlParseObjectReq(w, r)
+	secret, bck, objName, stage, err := etlParseObjectReq(w, r)
 	if err != nil {
 		t.writeErr(w, r, err)
 		return
@@ -267,6 +318,15 @@ func (t *target) headObjectETL(w http.ResponseWriter, r *http.Request) {
 		t.writeErr(w, r, err)
 		return
 	}
+	if stage != "" {
+		size, err := etl.StageSize(stage)
+		if err != nil {
+			t.writeErr(w, r, err, http.StatusNotFound, Silent)
+		} else if size >= 0 {
+			w.Header().Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+		}
+		return
+	}
 
 	lom := cluster.AllocLOM(objName)
 	errCode, err := t.objhead(w.Header(), r.URL.Query(), bck, lom)
@@ -18,6 +18,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/event"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/stats"
@@ -72,6 +73,7 @@ func (t *target) PutObject(lom *cluster.LOM, params *cluster.PutObjectParams) er
 		poi.xctn = params.Xact
 		poi.owt = params.OWT
 		poi.skipEC = params.SkipEncode
+		poi.sealed = params.Sealed
 	}
 	if poi.owt != cmn.OwtPut {
 		poi.cksumToUse = params.Cksum
@@ -117,7 +119,10 @@ func (t *target) EvictObject(lom *cluster.LOM) (errCode int, err error) {
 //   - if the dst is cloud, we perform a regular PUT logic thus also making sure that the new
 //     replica gets created in the cloud bucket of _this_ AIS cluster.
 func (t *target) CopyObject(lom *cluster.LOM, params *cluster.CopyObjectParams, dryRun bool) (size int64, err error) {
-	objNameTo := lom.ObjName
+	var (
+		objNameTo   = lom.ObjName
+		transformed = params.DP != nil
+	)
 	coi := allocCopyObjInfo()
 	{
 		coi.CopyObjectParams = *params
@@ -136,6 +141,9 @@ func (t *target) CopyObject(lom *cluster.LOM, params *cluster.CopyObjectParams,
 	}
 	coi.objsAdd(size, err)
 	freeCopyObjInfo(coi)
+	if err == nil && size > 0 && !dryRun {
+		event.EmitCopy(lom, params.BckTo, objNameTo, size, transformed)
+	}
 	return
 }
 
@@ -249,6 +257,7 @@ func (t *target) _promLocal(params *cluster.PromoteParams, lom *cluster.LOM) (fi
 	if err = lom.Load(true /*cache it*/, false /*locked*/); err == nil && !params.OverwriteDst {
 		return
 	}
+	lom.SetDEK(nil) // plaintext source (encryption, if configured, is done upon finalization)
 	if params.DeleteSrc {
 		// To use `params.SrcFQN` as `workFQN`, make sure both are
 		// located on the same filesystem. About "filesystem sharing" see also:
@@ -0,0 +1,156 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Object lock (WORM) - see cmn.ObjLockConf:
+// - retention and legal hold are stored in the object's metadata (xattr) as custom keys
+//   with `cmn.LockObjMDPrefix` and can only be set in buckets with object lock enabled;
+// - a locked object cannot be deleted, overwritten, renamed, or evicted; neither
+//   can its bucket be destroyed (or evicted);
+// - in buckets that retain non-current versions (cmn.VersionConf.Retain), overwrite and
+//   delete keep the locked version as non-current - the version itself cannot be deleted;
+// - governance retention can be bypassed by users with admin permission (checked by proxy).
+
+var errNoObjLock = errors.New("object lock is not enabled for the bucket")
+
+// (caller holds the lock and has loaded the lom)
+func checkObjLock(lom *cluster.LOM, bypass bool) error {
+	if !lom.Bprops().ObjLock.Enabled {
+		return nil
+	}
+	return lom.ObjAttrs().CheckLocked(lom.Cname(), time.Now(), bypass)
+}
+
+// overwrite (PUT) protection: `lom` carries the new object's metadata and so
+// the current one is loaded separately
+func checkOverwrite(lom *cluster.LOM, locked bool) error {
+	if !lom.Bprops().ObjLock.Enabled || lom.Bprops().Versioning.Retains() {
+		return nil
+	}
+	cur := cluster.AllocLOM(lom.ObjName)
+	defer cluster.FreeLOM(cur)
+	if err := cur.InitBck(lom.Bucket()); err != nil {
+		return err
+	}
+	if err := cur.Load(false /*cache it*/, locked); err != nil {
+		if cmn.IsObjNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return checkObjLock(cur, false /*bypass*/)
+}
+
+// new objects inherit the bucket's default retention unless specified explicitly
+func setDefaultRetention(lom *cluster.LOM, now time.Time) {
+	if lom.ObjAttrs().Retention() != nil {
+		return
+	}
+	if r := lom.Bprops().ObjLock.DefaultRetention(now); r != nil {
+		lom.ObjAttrs().SetRetention(r)
+	}
+}
+
+// set, extend, or remove (nil `r`) object retention
+func (*target) setObjRetention(lom *cluster.LOM, r *cmn.ObjRetention, bypass bool) (int, error) {
+	if !lom.Bprops().ObjLock.Enabled {
+		return http.StatusBadRequest, errNoObjLock
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			return http.StatusNotFound, err
+		}
+		return 0, err
+	}
+	if err := lom.ObjAttrs().CheckRetention(lom.Cname(), r, time.Now(), bypass); err != nil {
+		if cmn.IsErrObjLocked(err) {
+			return http.StatusForbidden, err
+		}
+		return http.StatusBadRequest, err
+	}
+	lom.ObjAttrs().SetRetention(r)
+	return 0, lom.Persist()
+}
+
+func (*target) setObjLegalHold(lom *cluster.LOM, on bool) (int, error) {
+	if !lom.Bprops().ObjLock.Enabled {
+		return http.StatusBadRequest, errNoObjLock
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			return http.StatusNotFound, err
+		}
+		return 0, err
+	}
+	lom.ObjAttrs().SetLegalHold(on)
+	return 0, lom.Persist()
+}
+
+// destroy (evict) bucket: fail if any object or non-current version is locked
+func (*target) findLockedObj(bck *cluster.Bck) error {
+	if !bck.Props.ObjLock.Enabled {
+		return nil
+	}
+	var (
+		now    = time.Now()
+		locked error
+	)
+	for _, mi := range fs.GetAvail() {
+		opts := &fs.WalkOpts{Mi: mi, Bck: bck.Clone(), CTs: []string{fs.ObjectType}}
+		opts.Callback = func(fqn string, de fs.DirEntry) error {
+			if de.IsDir() {
+				return nil
+			}
+			locked = _lockedFQN(fqn, bck, now)
+			return locked // (non-nil stops the walk)
+		}
+		err := fs.Walk(opts)
+		if locked != nil {
+			return locked
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _lockedFQN(fqn string, bck *cluster.Bck, now time.Time) error {
+	lom := cluster.AllocLOM("")
+	defer cluster.FreeLOM(lom)
+	if lom.InitFQN(fqn, bck.Bucket()) != nil || lom.Load(false /*cache it*/, false /*locked*/) != nil {
+		return nil
+	}
+	if err := lom.ObjAttrs().CheckLocked(lom.Cname(), now, false); err != nil {
+		return err
+	}
+	if !lom.Bprops().Versioning.Retains() {
+		return nil
+	}
+	vers, err := lom.Versions()
+	if err != nil {
+		return err
+	}
+	for _, v := range vers {
+		if err := v.CheckLocked(lom.Cname()+" version "+v.Ver, now, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -5,7 +5,6 @@
 package ais
 
 import (
-	"archive/tar"
 	"context"
 	"encoding"
 	"encoding/base64"
@@ -28,9 +27,13 @@ import (
 	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/event"
+	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/reb"
+	"github.com/NVIDIA/aistore/sse"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/xact/xreg"
@@ -55,12 +58,17 @@ type (
 
 		workFQN string // temp fqn to be renamed
 
+		dek    *sse.DEK // data key when encrypting (see sse package)
+		sseKID string   // per-request encryption (e.g., S3 "x-amz-server-side-encryption")
+
 		size    int64   // Content-Length
 		owt     cmn.OWT // object write transaction enum { OwtPut, ..., OwtGet* }
 		restful bool    // being invoked via RESTful API
 		t2t     bool    // by another target
 		skipEC  bool    // do not erasure-encode when finalizing
+		sealed  bool    // content is encrypted with the LOM's data key (e.g., EC replica) - store as is
 		skipVC  bool    // skip loading existing Version and skip comparing Checksums (skip VC)
+		locked  bool    // caller holds exclusive lock (e.g., when modifying archived content)
 	}
 
 	getObjInfo struct {
@@ -138,6 +146,9 @@ func (poi *putObjInfo) do(resphdr http.Header, r *http.Request, dpq *dpq) (int,
 		poi.r = r.Body
 		poi.resphdr = resphdr
 		poi.workFQN = fs.CSM.Gen(poi.lom, fs.WorkfileType, fs.WorkfilePut)
+		if !poi.t2t {
+			delReservedMD(r.Header)
+		}
 		poi.cksumToUse = poi.lom.ObjAttrs().FromHeader(r.Header)
 		poi.owt = cmn.OwtPut // default
 	}
@@ -160,6 +171,13 @@ func (poi *putObjInfo) do(resphdr http.Header, r *http.Request, dpq *dpq) (int,
 			poi.size = size
 		}
 	}
+	// fail early (quota is checked and reserved upon finalization - see poi.fini)
+	if poi.size > 0 {
+		if err := poi.t.quotaCheck(poi.lom, poi.size); err != nil {
+			cos.DrainReader(poi.r)
+			return http.StatusInsufficientStorage, err
+		}
+	}
 	return poi.putObject()
 }
 
@@ -250,6 +268,16 @@ func (poi *putObjInfo) finalize() (errCode int, err error) {
 		}
 	}
 	poi.t.putMirror(poi.lom)
+	etl.DropCached(poi.lom) // (overwrite)
+
+	// bucket event notifications: user PUT, APPEND, promote, and finalize
+	// (copies and transformations - see t.CopyObject; modified archives - see t.doAppendArch)
+	if poi.locked {
+		return
+	}
+	if poi.owt == cmn.OwtPromote || poi.owt == cmn.OwtFinalize || (poi.owt == cmn.OwtPut && poi.restful && !poi.t2t) {
+		event.Emit(cmn.EventObjCreated, poi.lom)
+	}
 	return
 }
 
@@ -259,8 +287,21 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 		lom = poi.lom
 		bck = lom.Bck()
 	)
+	// bucket quota: fail early (prior to locking and, in particular, writing remote)
+	if poi.newContent() {
+		if err = poi.t.quotaCheck(lom, lom.SizeBytes()); err != nil {
+			return http.StatusInsufficientStorage, err
+		}
+	}
+	if poi.dek == nil && !poi.sealed {
+		lom.SetDEK(nil) // plaintext workfile (to be encrypted, if need be, below)
+	}
 	// put remote
 	if bck.IsRemote() && (poi.owt == cmn.OwtPut || poi.owt == cmn.OwtFinalize || poi.owt == cmn.OwtPromote) {
+		// object lock: fail prior to overwriting remote (and see below)
+		if err = checkOverwrite(lom, false /*locked*/); err != nil {
+			return http.StatusForbidden, err
+		}
 		errCode, err = poi.putRemote()
 		if err != nil {
 			loghdr := poi.loghdr()
@@ -292,15 +333,52 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 		}
 		defer lom.Unlock(true)
 	default:
-		lom.Lock(true)
-		defer lom.Unlock(true)
+		if poi.locked {
+			debug.AssertFunc(func() bool { _, exclusive := lom.IsLocked(); return exclusive })
+		} else {
+			lom.Lock(true)
+			defer lom.Unlock(true)
+		}
 		lom.SetAtimeUnix(poi.atime.UnixNano())
 	}
 
+	// object lock (see tgtlock.go)
+	if poi.newContent() && lom.Bprops().ObjLock.Enabled {
+		if err = checkOverwrite(lom, true /*locked*/); err != nil {
+			return http.StatusForbidden, err
+		}
+		setDefaultRetention(lom, poi.atime)
+	}
+
+	// bucket quota: check and reserve (to release unless the new content makes it)
+	qd, err := poi.t.quotaReserve(lom, lom.SizeBytes(), curSize(lom), poi.newContent())
+	if err != nil {
+		return http.StatusInsufficientStorage, err
+	}
+	defer func() {
+		if err != nil {
+			poi.t.quotas.release(bck, qd)
+		}
+	}()
+
+	// last-modified (ais buckets; remote objects get it from the backend); carried
+	// along when migrating and used by lifecycle expiration (see space/lifecycle.go)
+	if bck.IsAIS() && poi.newContent() && !poi.t2t {
+		lom.SetCustomKey(cmn.LastModified, poi.atime.UTC().Format(time.RFC3339))
+	}
+
 	// ais versioning
+	var archived string
 	if bck.IsAIS() && lom.VersionConf().Enabled {
 		if poi.owt == cmn.OwtPut || poi.owt == cmn.OwtFinalize || poi.owt == cmn.OwtPromote {
-			if poi.skipVC {
+			if lom.Bprops().Versioning.Retains() {
+				// keep the current version (or delete marker) as non-current
+				if archived, err = poi.t.archiveCurrent(lom); err != nil {
+					return
+				}
+				err = lom.IncVersion()
+				debug.Assert(err == nil)
+			} else if poi.skipVC {
 				err = lom.IncVersion()
 				debug.Assert(err == nil)
 			} else if remSrc, ok := lom.GetCustomKey(cmn.SourceObjMD); !ok || remSrc == "" {
@@ -311,8 +389,20 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 		}
 	}
 
+	// content that did not go through poi.write (promoted, finalized, etc.)
+	if poi.dek == nil && !poi.sealed {
+		if err = poi.encrypt(); err != nil {
+			return
+		}
+	}
+
 	// done
 	if err = lom.RenameFile(poi.workFQN); err != nil {
+		if archived != "" {
+			if errV := lom.RestoreVersion(archived); errV != nil {
+				glog.Errorf("PUT (%s): failed to restore version %q: %v", poi.loghdr(), archived, errV)
+			}
+		}
 		return
 	}
 	if lom.HasCopies() {
@@ -320,20 +410,44 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 			glog.Errorf("PUT (%s): failed to delete old copies [%v], proceeding to PUT anyway...", poi.loghdr(), errdc)
 		}
 	}
+	lom.InvalidateArchIndex()
 	if lom.AtimeUnix() == 0 { // (is set when migrating within cluster; prefetch special case)
 		lom.SetAtimeUnix(poi.atime.UnixNano())
 	}
-	err = lom.PersistMain()
+	if err = lom.PersistMain(); err == nil {
+		if archived != "" {
+			poi.t.pruneVersions(lom, archived)
+		}
+		if poi.newContent() && cmn.Features.IsSet(feat.IndexArchOnPUT) {
+			poi.indexArch()
+		}
+	}
 	return
 }
 
+// (best-effort) index new TAR content right away - see cluster/lom_arch.go
+func (poi *putObjInfo) indexArch() {
+	lom := poi.lom
+	if mime, err := cos.Mime("", lom.ObjName); err != nil || mime != cos.ExtTar || lom.IsEncrypted() {
+		return
+	}
+	if _, err := lom.BuildArchIndex(nil); err != nil {
+		glog.Errorf("PUT (%s): failed to index archive: %v", poi.loghdr(), err)
+	}
+}
+
+// new content (as opposed to migration, cold GET, etc.) is subject to bucket quota
+func (poi *putObjInfo) newContent() bool {
+	return poi.owt == cmn.OwtPut || poi.owt == cmn.OwtPromote || poi.owt == cmn.OwtFinalize
+}
+
 // via backend.PutObj()
 func (poi *putObjInfo) putRemote() (errCode int, err error) {
 	var (
 		lom     = poi.lom
 		backend = poi.t.Backend(lom.Bck())
 	)
-	lmfh, err := cos.NewFileHandle(poi.workFQN)
+	lmfh, err := lom.Open(poi.workFQN)
 	if err != nil {
 		err = cmn.NewErrFailedTo(poi.t, "open", poi.workFQN, err)
 		return
@@ -357,6 +471,7 @@ func (poi *putObjInfo) write() (err error) {
 		buf     []byte
 		slab    *memsys.Slab
 		lmfh    *os.File
+		encw    *sse.Writer
 		writer  io.Writer
 		writers = make([]io.Writer, 0, 4)
 		cksums  = struct {
@@ -379,6 +494,23 @@ func (poi *putObjInfo) write() (err error) {
 	defer func() {
 		poi._cleanup(buf, slab, lmfh, err)
 	}()
+	// encryption at rest
+	if poi.sealed {
+		// already encrypted - no plaintext checksum to compute (use the provided one, if any)
+		if !poi.cksumToUse.IsEmpty() {
+			poi.lom.SetCksum(poi.cksumToUse)
+		}
+		goto write
+	}
+	if poi.dek, err = poi.newDEK(); err != nil {
+		return
+	}
+	if poi.dek != nil {
+		if encw, err = sse.NewWriter(lmfh, poi.dek.Key); err != nil {
+			return
+		}
+		writer = cos.WriterOnly{Writer: encw}
+	}
 	// checksums
 	if ckconf.Type == cos.ChecksumNone {
 		poi.lom.SetCksum(cos.NoneCksum)
@@ -433,13 +565,25 @@ write:
 	}
 
 	// ok
+	if encw != nil {
+		if err = encw.Close(); err != nil {
+			return
+		}
+	}
 	if cmn.Features.IsSet(feat.FsyncPUT) {
 		err = lmfh.Sync() // compare w/ cos.FlushClose
 		debug.AssertNoErr(err)
 	}
 	cos.Close(lmfh)
 	lmfh = nil
+	if poi.sealed {
+		if stored := poi.lom.StoredSize(); written != stored {
+			err = fmt.Errorf("%s: invalid size of the encrypted content (%d != %d)", poi.lom, written, stored)
+		}
+		return
+	}
 	poi.lom.SetSize(written) // TODO: compare with non-zero lom.SizeBytes() that may have been set via oa.FromHeader()
+	poi.lom.SetDEK(poi.dek)
 	if cksums.store != nil {
 		if !cksums.finalized {
 			cksums.store.Finalize()
@@ -449,6 +593,76 @@ write:
 	return
 }
 
+// user PUT cannot set system-maintained custom keys (see cmn.IsReservedObjMD)
+func delReservedMD(hdr http.Header) {
+	var (
+		key    = http.CanonicalHeaderKey(apc.HdrObjCustomMD)
+		custom = hdr[key]
+		kept   = custom[:0]
+	)
+	for _, kv := range custom {
+		if k, _, _ := strings.Cut(kv, "="); !cmn.IsReservedObjMD(k) {
+			kept = append(kept, kv)
+		}
+	}
+	if len(custom) > 0 {
+		hdr[key] = kept
+	}
+}
+
+// data key to encrypt the object with (nil if not encrypting)
+func (poi *putObjInfo) newDEK() (*sse.DEK, error) {
+	if poi.sseKID != "" {
+		return sse.NewDEK(poi.sseKID)
+	}
+	return poi.lom.NewDEK()
+}
+
+// encrypt plaintext workfile (compare with poi.write)
+func (poi *putObjInfo) encrypt() error {
+	dek, err := poi.newDEK()
+	if err != nil || dek == nil {
+		return err
+	}
+	var (
+		lom    = poi.lom
+		encFQN = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileEncrypt)
+	)
+	src, err := os.Open(poi.workFQN)
+	if err != nil {
+		return err
+	}
+	dst, err := lom.CreateFile(encFQN)
+	if err != nil {
+		cos.Close(src)
+		return err
+	}
+	buf, slab := poi.t.gmm.AllocSize(lom.SizeBytes())
+	encw, err := sse.NewWriter(dst, dek.Key)
+	if err == nil {
+		if _, err = io.CopyBuffer(cos.WriterOnly{Writer: encw}, src, buf); err == nil {
+			err = encw.Close()
+		}
+	}
+	slab.Free(buf)
+	cos.Close(src)
+	if erc := dst.Close(); err == nil {
+		err = erc
+	}
+	if err == nil {
+		err = cos.Rename(encFQN, poi.workFQN)
+	}
+	if err != nil {
+		if errRm := cos.RemoveFile(encFQN); errRm != nil {
+			glog.Errorf(fmtNested, poi.t, err, "remove", encFQN, errRm)
+		}
+		return err
+	}
+	poi.dek = dek
+	lom.SetDEK(dek)
+	return nil
+}
+
 // post-write close & cleanup
 func (poi *putObjInfo) _cleanup(buf []byte, slab *memsys.Slab, lmfh *os.File, err error) {
 	if buf != nil {
@@ -505,6 +719,10 @@ func (goi *getObjInfo) get() (errCode int, err error) {
 	)
 do:
 	err = goi.lom.Load(true /*cache it*/, true /*locked*/)
+	if err == nil && goi.lom.IsDelMarker() {
+		goi.w.Header().Set(cos.S3HdrDeleteMarker, "true")
+		return http.StatusNotFound, cmn.NewErrNotFound("%s: object %s", goi.t, goi.lom.Cname())
+	}
 	if err != nil {
 		cold = cmn.IsObjNotExist(err)
 		if !cold {
@@ -828,14 +1046,14 @@ func (goi *getObjInfo) getFromNeighbor(lom *cluster.LOM, tsi *cluster.Snode) boo
 
 func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err error) {
 	var (
-		lmfh *os.File
+		lmfh cos.LomReader
 		hrng *htrange
 		fqn  = goi.lom.FQN
 	)
 	if !coldGet && !goi.isGFN {
 		fqn = goi.lom.LBGet() // best-effort GET load balancing (see also mirror.findLeastUtilized())
 	}
-	lmfh, err = os.Open(fqn)
+	lmfh, err = goi.lom.Open(fqn)
 	if err != nil {
 		if os.IsNotExist(err) {
 			errCode = http.StatusNotFound
@@ -853,7 +1071,8 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 	}()
 
 	hdr := goi.w.Header()
-	if goi.ranges.Range != "" {
+	// (range-reading archived file - see fini below)
+	if goi.ranges.Range != "" && goi.archive.filename == "" {
 		rsize := goi.lom.SizeBytes()
 		if goi.ranges.Size > 0 {
 			rsize = goi.ranges.Size
@@ -861,18 +1080,13 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 		if hrng, errCode, err = goi.parseRange(hdr, rsize); err != nil {
 			return
 		}
-		if goi.archive.filename != "" {
-			err = cmn.NewErrUnsupp("range-read archived file", goi.archive.filename)
-			errCode = http.StatusRequestedRangeNotSatisfiable
-			return
-		}
 	}
 	errCode, err = goi.fini(fqn, lmfh, hdr, hrng, coldGet)
 	return
 }
 
 // in particular, setup reader and writer and set headers
-func (goi *getObjInfo) fini(fqn string, lmfh *os.File, hdr http.Header, hrng *htrange, coldGet bool) (errCode int, err error) {
+func (goi *getObjInfo) fini(fqn string, lmfh cos.LomReader, hdr http.Header, hrng *htrange, coldGet bool) (errCode int, err error) {
 	var (
 		slab   *memsys.Slab
 		buf    []byte
@@ -903,6 +1117,21 @@ func (goi *getObjInfo) fini(fqn string, lmfh *os.File, hdr http.Header, hrng *ht
 			csl.Close()
 		}()
 		reader, size = csl, csl.Size()
+		if goi.ranges.Range != "" {
+			// only indexed archives (see cluster/lom_arch.go) can be range-read
+			sect, ok := csl.(*cslSection)
+			if !ok {
+				err = cmn.NewErrUnsupp("range-read archived file", goi.archive.filename+" (archive not indexed)")
+				errCode = http.StatusRequestedRangeNotSatisfiable
+				return
+			}
+			if hrng, errCode, err = goi.parseRange(hdr, size); err != nil {
+				return
+			}
+			if hrng != nil {
+				reader, size = io.NewSectionReader(sect, hrng.Start, hrng.Length), hrng.Length
+			}
+		}
 		hdr.Del(apc.HdrObjCksumVal)
 		hdr.Del(apc.HdrObjCksumType)
 		hdr.Set(apc.HdrArchmime, mime)
@@ -986,9 +1215,14 @@ func (goi *getObjInfo) transmit(r io.Reader, buf []byte, fqn string, coldGet boo
 }
 
 // parse & validate user-spec-ed goi.ranges, and set response header
-func (goi *getObjInfo) parseRange(resphdr http.Header, size int64) (hrng *htrange, errCode int, err error) {
+func (goi *getObjInfo) parseRange(resphdr http.Header, size int64) (*htrange, int, error) {
+	return parseRangeHdr(resphdr, goi.ranges.Range, size, goi.lom.Cname())
+}
+
+// (used by both current and non-current versions - see tgtver.go)
+func parseRangeHdr(resphdr http.Header, rangeHdr string, size int64, cname string) (hrng *htrange, errCode int, err error) {
 	var ranges []htrange
-	ranges, err = parseMultiRange(goi.ranges.Range, size)
+	ranges, err = parseMultiRange(rangeHdr, size)
 	if err != nil {
 		if _, ok := err.(*errRangeNoOverlap); ok {
 			// https://datatracker.ietf.org/doc/html/rfc7233#section-4.2
@@ -1001,16 +1235,10 @@ func (goi *getObjInfo) parseRange(resphdr http.Header, size int64) (hrng *htrang
 		return
 	}
 	if len(ranges) > 1 {
-		err = cmn.NewErrUnsupp("multi-range read", goi.lom.Cname())
+		err = cmn.NewErrUnsupp("multi-range read", cname)
 		errCode = http.StatusRequestedRangeNotSatisfiable
 		return
 	}
-	if goi.archive.filename != "" {
-		err = cmn.NewErrUnsupp("range-read archived file", goi.archive.filename)
-		errCode = http.StatusRequestedRangeNotSatisfiable
-		return
-	}
-
 	// set response header
 	hrng = &ranges[0]
 	resphdr.Set(cos.HdrAcceptRanges, "bytes")
@@ -1155,6 +1383,11 @@ func (coi *copyObjInfo) copyObject(lom *cluster.LOM, objNameTo string) (size int
 		coi.DP = &cluster.LDP{}
 		return coi.copyReader(lom, objNameTo)
 	}
+	if lom.Bprops().SSE.Enabled || coi.BckTo.Props.SSE.Enabled {
+		// decrypt and/or encrypt (with a new data key) - see sse package
+		coi.DP = &cluster.LDP{}
+		return coi.copyReader(lom, objNameTo)
+	}
 
 	smap := coi.t.owner.smap.Get()
 	tsi, err := cluster.HrwTarget(coi.BckTo.MakeUname(objNameTo), smap)
@@ -1194,23 +1427,32 @@ func (coi *copyObjInfo) copyObject(lom *cluster.LOM, objNameTo string) (size int
 		return
 	}
 	// w-lock the destination unless overwriting the source
+	prev := lom.SizeBytes()
 	if lom.Uname() != dst.Uname() {
 		dst.Lock(true)
 		defer dst.Unlock(true)
+		prev = -1
 		if err = dst.Load(false /*cache it*/, true /*locked*/); err == nil {
 			if lom.EqCksum(dst.Checksum()) {
 				return
 			}
+			prev = dst.SizeBytes()
 		} else if cmn.IsErrBucketNought(err) {
 			return
 		}
 	}
+	qd, err := coi.t.quotaReserve(dst, lom.SizeBytes(), prev, true /*enforce*/)
+	if err != nil {
+		return
+	}
 	dst2, err2 := lom.Copy2FQN(dst.FQN, coi.Buf)
 	if err2 == nil {
 		size = lom.SizeBytes()
 		if coi.finalize {
 			coi.t.putMirror(dst2)
 		}
+	} else {
+		coi.t.quotas.release(dst.Bck(), qd)
 	}
 	err = err2
 	if dst2 != nil {
@@ -1408,12 +1650,28 @@ func (coi *copyObjInfo) dm(lom *cluster.LOM, sargs *sendArgs) error {
 		hdr.ObjName = sargs.objNameTo
 		hdr.ObjAttrs.CopyFrom(oa)
 	}
+	// copying bucket (as is): archive index, if any, goes first (and see mirror/tcb.go)
+	if coi.DP == nil && sargs.owt != cmn.OwtPromote && coi.Xact != nil && coi.Xact.Kind() == apc.ActCopyBck {
+		coi.sendArchIndex(lom, sargs)
+	}
 	o.Callback = func(_ transport.ObjHdr, _ io.ReadCloser, _ any, _ error) {
 		cluster.FreeLOM(lom)
 	}
 	return sargs.dm.Send(o, sargs.reader, sargs.tsi)
 }
 
+// (best-effort)
+func (coi *copyObjInfo) sendArchIndex(lom *cluster.LOM, sargs *sendArgs) {
+	o, fh, err := lom.OpenArchIndex(sargs.bckTo.Bucket(), sargs.objNameTo)
+	if err == nil && o != nil {
+		o.Hdr.Opcode = mirror.OpcArchIndex
+		err = sargs.dm.Send(o, fh, sargs.tsi)
+	}
+	if err != nil {
+		glog.Errorf("%s: failed to send %s archive index: %v", coi.t, lom, err)
+	}
+}
+
 // PUT(lom) => destination target (compare with coi.dm())
 // always closes params.Reader, either explicitly or via Do()
 func (coi *copyObjInfo) put(sargs *sendArgs) error {
@@ -1452,97 +1710,98 @@ func (coi *copyObjInfo) put(sargs *sendArgs) error {
 }
 
 //
-// APPEND (to archive)
+// APPEND (to) and DELETE (from) archive
 //
 
-func (aaoi *appendArchObjInfo) appendToArch(fqn string) error {
-	fh, err := cos.OpenTarForAppend(aaoi.lom.String(), fqn)
-	if err != nil {
-		return err
-	}
-	buf, slab := aaoi.t.gmm.AllocSize(aaoi.size)
-	tw := tar.NewWriter(fh)
-	hdr := tar.Header{
-		Typeflag: tar.TypeReg,
-		Name:     aaoi.filename,
-		Size:     aaoi.size,
-		ModTime:  aaoi.started,
-		Mode:     int64(cos.PermRWR), // default value '0' - no access at all
-	}
-	tw.WriteHeader(&hdr)
-	_, err = io.CopyBuffer(tw, aaoi.r, buf)
-	tw.Close()
-	cos.Close(fh)
-	slab.Free(buf)
-	if err != nil {
-		return err
-	}
+// The archive gets rewritten into a workfile, in the same format and without the archived
+// file in question (if exists) - the latter is then appended at the end (APPEND) or not (DELETE).
+// The result is finalized as a new version of the object - see poi.fini() in re versioning,
+// existing copies, archive index, encryption, and remote backend - followed by EC and mirroring
+// (poi.finalize). Everything's done under the object's exclusive lock.
 
-	st, err := os.Stat(fqn)
-	if err != nil {
-		return err
+func (aaoi *appendArchObjInfo) init() (int, error) {
+	lom := aaoi.lom
+	aaoi.filename = strings.TrimPrefix(aaoi.filename, lom.ObjName+"/")
+	if aaoi.filename == "" {
+		return http.StatusBadRequest, errors.New("archive path is not defined")
 	}
-	aaoi.lom.SetSize(st.Size())
-	aaoi.lom.SetCksum(cos.NewCksum(cos.ChecksumNone, ""))
-	aaoi.lom.SetAtimeUnix(aaoi.started.UnixNano())
-	return nil
-}
-
-func (aaoi *appendArchObjInfo) finalize(fqn string) error {
-	if err := os.Rename(fqn, aaoi.lom.FQN); err != nil {
-		return err
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			return http.StatusNotFound, err
+		}
+		return http.StatusInternalServerError, err
 	}
-	aaoi.lom.SetAtimeUnix(aaoi.started.UnixNano())
-	if err := aaoi.lom.Persist(); err != nil {
-		return err
+	mime, err := cos.Mime(aaoi.mime, lom.ObjName)
+	if err != nil {
+		return http.StatusBadRequest, err
 	}
-	if aaoi.lom.Bprops().EC.Enabled {
-		if err := ec.ECM.EncodeObject(aaoi.lom); err != nil && err != ec.ErrorECDisabled {
-			return err
-		}
+	switch mime {
+	case cos.ExtTar, cos.ExtTgz, cos.ExtTarTgz, cos.ExtZip:
+		aaoi.mime = mime
+		return 0, nil
+	default:
+		return http.StatusBadRequest, fmt.Errorf("%s: modifying %s archives is not supported", lom, mime)
 	}
-	aaoi.t.putMirror(aaoi.lom)
-	return nil
 }
 
-func (aaoi *appendArchObjInfo) begin() (string, error) {
-	workFQN := fs.CSM.Gen(aaoi.lom, fs.WorkfileType, fs.WorkfileAppendToArch)
-	if err := os.Rename(aaoi.lom.FQN, workFQN); err != nil {
-		return "", err
-	}
-	return workFQN, nil
+func (aaoi *appendArchObjInfo) appendObject() (int, error) {
+	add := &archAdd{r: aaoi.r, name: aaoi.filename, size: aaoi.size, mtime: aaoi.started}
+	return aaoi.rewrite(add)
 }
 
-func (aaoi *appendArchObjInfo) abort(fqn string) {
-	aaoi.lom.Uncache(true)
-	if err := os.Rename(aaoi.lom.FQN, fqn); err != nil {
-		glog.Errorf("nested error while rolling back %s: %v", aaoi.lom.ObjName, err)
-	}
-}
+func (aaoi *appendArchObjInfo) deleteObject() (int, error) { return aaoi.rewrite(nil) }
 
-func (aaoi *appendArchObjInfo) appendObject() (errCode int, err error) {
-	if aaoi.filename == "" {
-		return http.StatusBadRequest, errors.New("archive path is not defined")
-	}
-	// Standard library does not support appending to archive of any type.
-	// For TAR there is a working workaround, so allow appending to TAR only.
-	if aaoi.mime != cos.ExtTar {
-		return http.StatusBadRequest, fmt.Errorf("append is supported only for %s archives", cos.ExtTar)
+func (aaoi *appendArchObjInfo) rewrite(add *archAdd) (errCode int, err error) {
+	var (
+		lom     = aaoi.lom
+		workFQN = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileAppendToArch)
+		cksum   cos.CksumHashSize
+		src     cos.LomReader
+		fh      *os.File
+		found   bool
+	)
+	if src, err = lom.Open(lom.FQN); err != nil {
+		return http.StatusInternalServerError, err
 	}
-	workFQN, err := aaoi.begin()
-	if err != nil {
+	if fh, err = lom.CreateFile(workFQN); err != nil {
+		cos.Close(src)
 		return http.StatusInternalServerError, err
 	}
-	if err = aaoi.appendToArch(workFQN); err == nil {
-		if err = aaoi.finalize(workFQN); err == nil {
-			return 0, nil
+	cksum.Init(lom.CksumType())
+	buf, slab := aaoi.t.gmm.Alloc()
+	w := cos.NewWriterMulti(fh, &cksum)
+	found, err = rewriteArch(src, w, lom.SizeBytes(), aaoi.mime, aaoi.filename, add, buf)
+	slab.Free(buf)
+	cos.Close(src)
+	cos.Close(fh)
+	if err == nil && add == nil && !found {
+		err = notFoundInArch(aaoi.filename, lom.Cname())
+	}
+	if err != nil {
+		if errRm := cos.RemoveFile(workFQN); errRm != nil {
+			glog.Errorf(fmtNested, aaoi.t, err, "remove", workFQN, errRm)
 		}
+		errCode = http.StatusInternalServerError
+		if cmn.IsErrNotFound(err) {
+			errCode = http.StatusNotFound
+		}
+		return
 	}
-	aaoi.abort(workFQN)
-	errCode = http.StatusInternalServerError
-	if cmn.IsErrCapacityExceeded(err) {
-		errCode = http.StatusInsufficientStorage
+	cksum.Finalize()
+	lom.SetSize(cksum.Size)
+	lom.SetCksum(cksum.Clone())
+
+	poi := allocPutObjInfo()
+	{
+		poi.atime = aaoi.started
+		poi.t = aaoi.t
+		poi.lom = lom
+		poi.workFQN = workFQN
+		poi.owt = cmn.OwtFinalize
+		poi.locked = true
 	}
+	errCode, err = poi.finalize()
+	freePutObjInfo(poi)
 	return
 }
 
@@ -0,0 +1,253 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Per-bucket quotas (see cmn.QuotaConf) are enforced by each target on its own:
+// a target admits new content as long as its local usage stays within 1/N-th
+// of the bucket's quota, where N is the number of active targets (objects are
+// HRW-distributed, and so the shares are expected to fill up evenly).
+//
+// Local usage is tracked incrementally for all buckets (so that a quota can be
+// set at any time) and never requires walking the bucket:
+// * new content checks and reserves its delta (compare-and-swap) under the object's
+//   write lock, and releases the reservation if it fails to materialize;
+// * deletions, evictions, and restored versions apply their respective deltas;
+// * usage is persisted on local mountpaths (fname.QuotaUsage) - periodically, if
+//   changed, and upon shutdown - and gets loaded upon restart.
+
+const (
+	quotaPersistIval = time.Minute
+	quotaCopies      = 2 // (compare with bmdCopies)
+)
+
+type (
+	bckUsage struct {
+		size atomic.Int64
+		cnt  atomic.Int64
+		bid  uint64
+	}
+	quotas struct {
+		m     map[string]*bckUsage // by bucket uname
+		mu    sync.Mutex
+		dirty atomic.Bool
+	}
+	// reserved or applied change in usage
+	usageDelta struct {
+		size int64
+		cnt  int64
+	}
+
+	// persistent usage (all buckets)
+	usageMD struct {
+		Buckets map[string]usageEntry `json:"buckets"`
+	}
+	usageEntry struct {
+		Size int64  `json:"size,string"`
+		Cnt  int64  `json:"cnt,string"`
+		BID  uint64 `json:"bid,string"`
+	}
+)
+
+var usageJspOpts = jsp.CCSign(cmn.MetaverQuotaUsage)
+
+// Fail early: new content that cannot possibly fit (compare with quotaReserve below).
+func (t *target) quotaCheck(lom *cluster.LOM, size int64) error {
+	quota := &lom.Bprops().Quota
+	if quota.MaxSize == 0 {
+		return nil
+	}
+	if limit := t.quotaShare(int64(quota.MaxSize)); size > limit {
+		return cmn.NewErrQuotaExceeded(lom.Bucket(), cmn.QuotaSize, limit, 0)
+	}
+	return nil
+}
+
+// Check whether writing `size` bytes into `lom` (currently of size `prev`, -1 if
+// the object does not exist) would exceed its bucket's quota and, if it wouldn't,
+// reserve the corresponding delta - to release should the write fail.
+// Non-enforced deltas (e.g., cold GET) get applied unconditionally.
+// The caller must hold the object's write lock.
+func (t *target) quotaReserve(lom *cluster.LOM, size, prev int64, enforce bool) (d usageDelta, err error) {
+	var (
+		bck   = lom.Bck()
+		quota = &bck.Props.Quota
+		u     = t.quotas.usage(bck)
+	)
+	d.size = size
+	if prev >= 0 {
+		d.size -= prev
+	} else {
+		d.cnt = 1
+	}
+	if !enforce || !quota.IsSet() {
+		u.add(d)
+	} else {
+		err = u.reserve(d, t.quotaShare(int64(quota.MaxSize)), t.quotaShare(quota.MaxObjects), lom.Bucket())
+	}
+	if err == nil {
+		t.quotas.dirty.Store(true)
+	}
+	return
+}
+
+// size of the object that is about to be overwritten, or -1 if none (caller holds wlock)
+func curSize(lom *cluster.LOM) int64 {
+	cur := cluster.AllocLOM(lom.ObjName)
+	defer cluster.FreeLOM(cur)
+	if cur.InitBck(lom.Bucket()) != nil || cur.Load(false /*cache it*/, true /*locked*/) != nil {
+		return -1
+	}
+	return cur.SizeBytes()
+}
+
+// this target's share of a given (cluster-wide) limit, or 0 (no limit)
+func (t *target) quotaShare(limit int64) int64 {
+	n := int64(t.owner.smap.get().CountActiveTs())
+	if n == 0 {
+		n = 1
+	}
+	return (limit + n - 1) / n
+}
+
+func (t *target) persistQuotas() time.Duration {
+	t.quotas.persist(t.owner.bmd.get())
+	return quotaPersistIval
+}
+
+//////////////
+// bckUsage //
+//////////////
+
+// compare-and-swap: concurrent PUTs cannot jointly exceed the limits (0 - no limit)
+func (u *bckUsage) reserve(d usageDelta, maxSize, maxCnt int64, bck *cmn.Bck) error {
+	if d.cnt > 0 && maxCnt > 0 {
+		for {
+			cnt := u.cnt.Load()
+			if cnt+d.cnt > maxCnt {
+				return cmn.NewErrQuotaExceeded(bck, cmn.QuotaObjects, maxCnt, cnt)
+			}
+			if u.cnt.CAS(cnt, cnt+d.cnt) {
+				break
+			}
+		}
+	} else {
+		u.cnt.Add(d.cnt)
+	}
+	if d.size > 0 && maxSize > 0 {
+		for {
+			size := u.size.Load()
+			if size+d.size > maxSize {
+				u.cnt.Sub(d.cnt)
+				return cmn.NewErrQuotaExceeded(bck, cmn.QuotaSize, maxSize, size)
+			}
+			if u.size.CAS(size, size+d.size) {
+				break
+			}
+		}
+	} else {
+		u.size.Add(d.size)
+	}
+	return nil
+}
+
+func (u *bckUsage) add(d usageDelta) {
+	u.size.Add(d.size)
+	u.cnt.Add(d.cnt)
+}
+
+////////////
+// quotas //
+////////////
+
+// get or create bucket's usage
+func (qs *quotas) usage(bck *cluster.Bck) (u *bckUsage) {
+	uname := bck.MakeUname("")
+	qs.mu.Lock()
+	if qs.m == nil {
+		qs.m = make(map[string]*bckUsage, 4)
+	}
+	u, ok := qs.m[uname]
+	if !ok || u.bid != bck.Props.BID {
+		u = &bckUsage{bid: bck.Props.BID} // (new or re-created bucket)
+		qs.m[uname] = u
+	}
+	qs.mu.Unlock()
+	return
+}
+
+// undo reservation (see quotaReserve)
+func (qs *quotas) release(bck *cluster.Bck, d usageDelta) {
+	qs.add(bck, usageDelta{size: -d.size, cnt: -d.cnt})
+}
+
+// object of a given size removed
+func (qs *quotas) sub(bck *cluster.Bck, size int64) {
+	qs.add(bck, usageDelta{size: -size, cnt: -1})
+}
+
+func (qs *quotas) add(bck *cluster.Bck, d usageDelta) {
+	qs.usage(bck).add(d)
+	qs.dirty.Store(true)
+}
+
+func (qs *quotas) load() {
+	md := &usageMD{}
+	for _, mi := range fs.GetAvail() {
+		fpath := filepath.Join(mi.Path, fname.QuotaUsage)
+		if _, err := jsp.LoadMeta(fpath, md); err != nil {
+			if !cmn.IsNotExist(err) {
+				glog.Errorf("failed to load %s: %v", fpath, err)
+			}
+			continue
+		}
+		break
+	}
+	qs.mu.Lock()
+	qs.m = make(map[string]*bckUsage, len(md.Buckets))
+	for uname, e := range md.Buckets {
+		u := &bckUsage{bid: e.BID}
+		u.size.Store(e.Size)
+		u.cnt.Store(e.Cnt)
+		qs.m[uname] = u
+	}
+	qs.mu.Unlock()
+}
+
+// persist if changed, and drop destroyed buckets
+func (qs *quotas) persist(bmd *bucketMD) {
+	if !qs.dirty.CAS(true, false) {
+		return
+	}
+	md := &usageMD{Buckets: make(map[string]usageEntry, 4)}
+	qs.mu.Lock()
+	for uname, u := range qs.m {
+		bck, _ := cmn.ParseUname(uname)
+		if props, ok := bmd.Get(cluster.CloneBck(&bck)); !ok || props.BID != u.bid {
+			delete(qs.m, uname)
+			continue
+		}
+		md.Buckets[uname] = usageEntry{Size: u.size.Load(), Cnt: u.cnt.Load(), BID: u.bid}
+	}
+	qs.mu.Unlock()
+	if cnt, _ := fs.PersistOnMpaths(fname.QuotaUsage, "", md, quotaCopies, nil, nil); cnt == 0 {
+		qs.dirty.Store(true) // retry next time
+	}
+}
+
+func (*usageMD) JspOpts() jsp.Options { return usageJspOpts }
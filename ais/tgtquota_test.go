@@ -0,0 +1,113 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+const (
+	tquotaSize = 100
+	tquotaCnt  = 3
+)
+
+func tquotaReserve(u *bckUsage, size, prev int64) error {
+	d := usageDelta{size: size, cnt: 1}
+	if prev >= 0 {
+		d = usageDelta{size: size - prev}
+	}
+	return u.reserve(d, tquotaSize, tquotaCnt, &cmn.Bck{Name: "quota", Provider: apc.AIS})
+}
+
+func tquotaCheck(t *testing.T, u *bckUsage, size, cnt int64) {
+	t.Helper()
+	tassert.Errorf(t, u.size.Load() == size && u.cnt.Load() == cnt, "expected usage (%d, %d), got (%d, %d)",
+		size, cnt, u.size.Load(), u.cnt.Load())
+}
+
+func TestQuotaOverwrite(t *testing.T) {
+	u := &bckUsage{}
+	tassert.CheckFatal(t, tquotaReserve(u, 60, -1))
+	tquotaCheck(t, u, 60, 1)
+
+	// new object that does not fit
+	err := tquotaReserve(u, 50, -1)
+	tassert.Fatalf(t, cmn.IsErrQuotaExceeded(err), "expected quota exceeded, got %v", err)
+	tquotaCheck(t, u, 60, 1)
+
+	// overwrite: only the delta counts
+	tassert.CheckFatal(t, tquotaReserve(u, 100, 60))
+	tquotaCheck(t, u, 100, 1)
+	err = tquotaReserve(u, 101, 100)
+	tassert.Fatalf(t, cmn.IsErrQuotaExceeded(err), "expected quota exceeded, got %v", err)
+	tassert.CheckFatal(t, tquotaReserve(u, 10, 100))
+	tquotaCheck(t, u, 10, 1)
+
+	// number of objects
+	tassert.CheckFatal(t, tquotaReserve(u, 10, -1))
+	tassert.CheckFatal(t, tquotaReserve(u, 10, -1))
+	err = tquotaReserve(u, 0, -1)
+	tassert.Fatalf(t, cmn.IsErrQuotaExceeded(err), "expected quota exceeded, got %v", err)
+	tassert.CheckFatal(t, tquotaReserve(u, 0, 10))
+	tquotaCheck(t, u, 20, 3)
+}
+
+func TestQuotaDelete(t *testing.T) {
+	var (
+		qs  = &quotas{}
+		bck = cluster.NewBck("quota", apc.AIS, cmn.NsGlobal, &cmn.BucketProps{BID: 1})
+		u   = qs.usage(bck)
+	)
+	tassert.CheckFatal(t, tquotaReserve(u, 50, -1))
+	tassert.CheckFatal(t, tquotaReserve(u, 50, -1))
+	err := tquotaReserve(u, 1, -1)
+	tassert.Fatalf(t, cmn.IsErrQuotaExceeded(err), "expected quota exceeded, got %v", err)
+
+	qs.sub(bck, 50)
+	tquotaCheck(t, u, 50, 1)
+	tassert.CheckFatal(t, tquotaReserve(u, 50, -1))
+
+	// failed PUT releases its reservation
+	qs.release(bck, usageDelta{size: 50, cnt: 1})
+	tquotaCheck(t, u, 50, 1)
+
+	// re-created bucket starts from scratch
+	bck.Props = &cmn.BucketProps{BID: 2}
+	tquotaCheck(t, qs.usage(bck), 0, 0)
+}
+
+func TestQuotaConcurrentPUT(t *testing.T) {
+	const (
+		numPuts = 1000
+		size    = 1
+	)
+	var (
+		u     = &bckUsage{}
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		admit int64
+	)
+	for i := 0; i < numPuts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := u.reserve(usageDelta{size: size, cnt: 1}, tquotaSize, 0 /*no limit*/, &cmn.Bck{Name: "quota"})
+			if err == nil {
+				mu.Lock()
+				admit++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	tassert.Errorf(t, admit == tquotaSize/size, "expected %d admitted, got %d", tquotaSize/size, admit)
+	tquotaCheck(t, u, tquotaSize, admit)
+}
@@ -6,6 +6,7 @@ package ais
 
 import (
 	"context"
+	"encoding/xml"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -18,8 +19,10 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/sigv4"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
 )
 
 // [METHOD] /s3
@@ -28,6 +31,10 @@ func (t *target) s3Handler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	// payload integrity: PUT (object, part) fails unless its content matches x-amz-content-sha256
+	if r.Method == http.MethodPut || r.Method == http.MethodPost {
+		r.Body = sigv4.NewPayloadReader(r.Body, r.Header.Get(cos.S3HdrContentSHA256))
+	}
 	switch r.Method {
 	case http.MethodHead:
 		t.headObjS3(w, r, apiItems)
@@ -37,9 +44,12 @@ func (t *target) s3Handler(w http.ResponseWriter, r *http.Request) {
 		t.putCopyMpt(w, r, apiItems)
 	case http.MethodDelete:
 		q := r.URL.Query()
-		if q.Has(s3.QparamMptUploadID) {
+		switch {
+		case q.Has(s3.QparamMptUploadID):
 			t.abortMptUpload(w, r, apiItems, q)
-		} else {
+		case q.Has(s3.QparamTagging):
+			t.delObjTaggingS3(w, r, apiItems)
+		default:
 			t.delObjS3(w, r, apiItems)
 		}
 	case http.MethodPost:
@@ -63,9 +73,15 @@ func (t *target) putCopyMpt(w http.ResponseWriter, r *http.Request, items []stri
 	}
 	q := r.URL.Query()
 	switch {
+	case q.Has(s3.QparamTagging):
+		t.putObjTaggingS3(w, r, items, bck)
+	case q.Has(s3.QparamRetention):
+		t.putObjRetentionS3(w, r, items, bck)
+	case q.Has(s3.QparamLegalHold):
+		t.putObjLegalHoldS3(w, r, items, bck)
 	case q.Has(s3.QparamMptPartNo) && q.Has(s3.QparamMptUploadID):
 		if r.Header.Get(cos.S3HdrObjSrc) != "" {
-			t.putMptCopy(w, r, items)
+			t.putMptCopy(w, r, items, q, bck)
 		} else {
 			t.putMptPart(w, r, items, q, bck)
 		}
@@ -181,6 +197,28 @@ func (t *target) putObjS3(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	sseKID, err := s3.SSEKeyID(r.Header)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	tags, err := s3.TagsFromHeader(r.Header)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if tags != nil {
+		lom.ObjAttrs().SetTags(tags)
+	}
+	locked, err := s3.ObjLockFromHeader(r.Header, lom.ObjAttrs())
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if locked && !bck.Props.ObjLock.Enabled {
+		s3.WriteErr(w, r, errNoObjLock, 0)
+		return
+	}
 	poi := allocPutObjInfo()
 	{
 		poi.atime = started
@@ -188,6 +226,7 @@ func (t *target) putObjS3(w http.ResponseWriter, r *http.Request, items []string
 		poi.lom = lom
 		poi.skipVC = cmn.Features.IsSet(feat.SkipVC) || cos.IsParseBool(dpq.skipVC) // apc.QparamSkipVC
 		poi.restful = true
+		poi.sseKID = sseKID
 	}
 	errCode, err := poi.do(nil /*response hdr*/, r, dpq)
 	freePutObjInfo(poi)
@@ -197,6 +236,8 @@ func (t *target) putObjS3(w http.ResponseWriter, r *http.Request, items []string
 		return
 	}
 	s3.SetETag(w.Header(), lom)
+	s3.SetSSE(w.Header(), lom)
+	s3.SetVersionID(w.Header(), lom)
 }
 
 // GET s3/<bucket-name[/<object-name>]
@@ -217,6 +258,14 @@ func (t *target) getObjS3(w http.ResponseWriter, r *http.Request, items []string
 		return
 	}
 	objName := s3.ObjName(items)
+	if q.Has(s3.QparamTagging) {
+		t.getObjTaggingS3(w, r, bck, objName)
+		return
+	}
+	if q.Has(s3.QparamRetention) || q.Has(s3.QparamLegalHold) {
+		t.getObjLockS3(w, r, bck, objName, q.Has(s3.QparamRetention))
+		return
+	}
 	if q.Has(s3.QparamMptPartNo) {
 		t.getMptPart(w, r, bck, objName, q)
 		return
@@ -261,6 +310,11 @@ func (t *target) headObjS3(w http.ResponseWriter, r *http.Request, items []strin
 	}
 	exists := true
 	err = lom.Load(true /*cache it*/, false /*locked*/)
+	if err == nil && lom.IsDelMarker() {
+		w.Header().Set(cos.S3HdrDeleteMarker, "true")
+		s3.WriteErr(w, r, cmn.NewErrNotFound("%s: object %s", t.si, lom.Cname()), http.StatusNotFound)
+		return
+	}
 	if err != nil {
 		exists = false
 		if !cmn.IsObjNotExist(err) {
@@ -277,7 +331,14 @@ func (t *target) headObjS3(w http.ResponseWriter, r *http.Request, items []strin
 		hdr = w.Header()
 		op  cmn.ObjectProps
 	)
-	if exists {
+	if ver := r.URL.Query().Get(s3.QparamVersionID); exists && ver != "" && ver != lom.Version() {
+		vattrs, errCode, err := t.headObjVersion(lom, ver)
+		if err != nil {
+			s3.WriteErr(w, r, err, errCode)
+			return
+		}
+		op.ObjAttrs = *vattrs
+	} else if exists {
 		op.ObjAttrs = *lom.ObjAttrs()
 	} else {
 		// cold HEAD
@@ -295,6 +356,12 @@ func (t *target) headObjS3(w http.ResponseWriter, r *http.Request, items []strin
 		hdr.Set(cos.HdrETag, v)
 	}
 	s3.SetETag(hdr, lom)
+	s3.SetSSE(hdr, lom)
+	s3.SetTaggingCount(hdr, lom)
+	s3.SetObjLock(hdr, lom)
+	if exists {
+		s3.SetVersionID(hdr, lom)
+	}
 	hdr.Set(cos.HdrContentLength, strconv.FormatInt(op.Size, 10))
 	if v, ok := custom[cos.HdrContentType]; ok {
 		hdr.Set(cos.HdrContentType, v)
@@ -326,12 +393,26 @@ func (t *target) delObjS3(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	errCode, err = t.DeleteObject(lom, false)
+	if ver := r.URL.Query().Get(s3.QparamVersionID); ver != "" {
+		errCode, err = t.delObjVersion(lom, ver, s3.BypassGovernance(r.Header))
+		if err == nil {
+			w.Header().Set(cos.S3VersionHeader, ver)
+		}
+	} else {
+		errCode, err = t.delObject(lom, false /*evict*/, s3.BypassGovernance(r.Header))
+		if err == nil && lom.IsDelMarker() {
+			w.Header().Set(cos.S3HdrDeleteMarker, "true")
+			s3.SetVersionID(w.Header(), lom)
+		}
+	}
 	if err != nil {
 		name := lom.Cname()
-		if errCode == http.StatusNotFound {
+		switch {
+		case errCode == http.StatusNotFound:
 			s3.WriteErr(w, r, cmn.NewErrNotFound("%s: %s", t.si, name), http.StatusNotFound)
-		} else {
+		case cmn.IsErrObjLocked(err):
+			s3.WriteErr(w, r, err, errCode)
+		default:
 			s3.WriteErr(w, r, fmt.Errorf("error deleting %s: %v", name, err), errCode)
 		}
 		return
@@ -340,6 +421,183 @@ func (t *target) delObjS3(w http.ResponseWriter, r *http.Request, items []string
 	ec.ECM.CleanupObject(lom)
 }
 
+// GET /s3/<bucket-name>/<object-name>?tagging
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectTagging.html
+func (t *target) getObjTaggingS3(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string) {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			s3.WriteErr(w, r, cmn.NewErrNotFound("%s: object %s", t.si, lom.Cname()), 0)
+		} else {
+			s3.WriteErr(w, r, err, 0)
+		}
+		return
+	}
+	tagging := s3.NewTagging(lom.ObjAttrs().Tags())
+	sgl := t.gmm.NewSGL(0)
+	tagging.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>/<object-name>?tagging (replaces all existing tags)
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectTagging.html
+func (t *target) putObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string, bck *cluster.Bck) {
+	if len(items) < 2 {
+		s3.WriteErr(w, r, errS3Obj, 0)
+		return
+	}
+	tagging := &s3.Tagging{}
+	if err := xml.NewDecoder(r.Body).Decode(tagging); err != nil {
+		s3.WriteErr(w, r, fmt.Errorf("failed to decode %s request: %v", s3.QparamTagging, err), 0)
+		return
+	}
+	tags, err := tagging.ToKVs()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	lom := cluster.AllocLOM(s3.ObjName(items))
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if errCode, err := t.setObjTags(lom, tags, true /*replace*/); err != nil {
+		s3.WriteErr(w, r, err, errCode)
+	}
+}
+
+// DELETE /s3/<bucket-name>/<object-name>?tagging
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteObjectTagging.html
+func (t *target) delObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	bck, err, errCode := cluster.InitByNameOnly(items[0], t.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	if len(items) < 2 {
+		s3.WriteErr(w, r, errS3Obj, 0)
+		return
+	}
+	lom := cluster.AllocLOM(s3.ObjName(items))
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if errCode, err := t.delObjTags(lom, nil); err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /s3/<bucket-name>/<object-name>?retention|legal-hold
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectRetention.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectLegalHold.html
+func (t *target) getObjLockS3(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string, retention bool) {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if !lom.Bprops().ObjLock.Enabled {
+		s3.WriteErr(w, r, s3.ErrNoObjLockConf, http.StatusBadRequest)
+		return
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			s3.WriteErr(w, r, cmn.NewErrNotFound("%s: object %s", t.si, lom.Cname()), 0)
+		} else {
+			s3.WriteErr(w, r, err, 0)
+		}
+		return
+	}
+	var (
+		resp interface{ MustMarshal(*memsys.SGL) }
+		oa   = lom.ObjAttrs()
+	)
+	if retention {
+		rt := oa.Retention()
+		if rt == nil {
+			s3.WriteErr(w, r, cmn.NewErrNotFound("%s: object %s retention", t.si, lom.Cname()), 0)
+			return
+		}
+		resp = s3.NewRetention(rt)
+	} else {
+		resp = s3.NewLegalHold(oa.LegalHold())
+	}
+	sgl := t.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>/<object-name>?retention
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectRetention.html
+func (t *target) putObjRetentionS3(w http.ResponseWriter, r *http.Request, items []string, bck *cluster.Bck) {
+	if len(items) < 2 {
+		s3.WriteErr(w, r, errS3Obj, 0)
+		return
+	}
+	in := &s3.Retention{}
+	if err := xml.NewDecoder(r.Body).Decode(in); err != nil {
+		s3.WriteErr(w, r, fmt.Errorf("failed to decode %s request: %v", s3.QparamRetention, err), 0)
+		return
+	}
+	retention, err := in.ToRetention()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	lom := cluster.AllocLOM(s3.ObjName(items))
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if errCode, err := t.setObjRetention(lom, retention, s3.BypassGovernance(r.Header)); err != nil {
+		s3.WriteErr(w, r, err, errCode)
+	}
+}
+
+// PUT /s3/<bucket-name>/<object-name>?legal-hold
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectLegalHold.html
+func (t *target) putObjLegalHoldS3(w http.ResponseWriter, r *http.Request, items []string, bck *cluster.Bck) {
+	if len(items) < 2 {
+		s3.WriteErr(w, r, errS3Obj, 0)
+		return
+	}
+	in := &s3.LegalHold{}
+	if err := xml.NewDecoder(r.Body).Decode(in); err != nil {
+		s3.WriteErr(w, r, fmt.Errorf("failed to decode %s request: %v", s3.QparamLegalHold, err), 0)
+		return
+	}
+	on, err := in.IsOn()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	lom := cluster.AllocLOM(s3.ObjName(items))
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if errCode, err := t.setObjLegalHold(lom, on); err != nil {
+		s3.WriteErr(w, r, err, errCode)
+	}
+}
+
 // POST /s3/<bucket-name>/<object-name>
 func (t *target) postObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	bck, err, errCode := cluster.InitByNameOnly(items[0], t.owner.bmd)
@@ -12,8 +12,10 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -27,55 +29,136 @@ import (
 
 const fmtErrBO = "bucket and object names are required to complete multipart upload (have %v)"
 
+const (
+	mptAbortDflt = 7 * 24 * time.Hour // see config.Timeout.MptAbort
+	mptAbortIval = time.Hour
+)
+
 // Copy another object or its range as a part of the multipart upload.
 // Body is empty, everything in the query params and the header.
+// The source (range) is pulled from its owner - see t.composeTo.
 // https://docs.aws.amazon.com/AmazonS3/latest/API/API_UploadPartCopy.html
-// TODO: not implemented yet
-func (*target) putMptCopy(w http.ResponseWriter, r *http.Request, items []string) {
+func (t *target) putMptCopy(w http.ResponseWriter, r *http.Request, items []string, q url.Values, bck *cluster.Bck) {
 	if len(items) < 2 {
 		err := fmt.Errorf(fmtErrBO, items)
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	s3.WriteErr(w, r, errors.New("not implemented yet"), http.StatusNotImplemented)
-}
+	uploadID, partNum, err := mptPartArgs(q)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
 
-// PUT a part of the multipart upload.
-// Body is empty, everything in the query params and the header.
-//
-// "Content-MD5" in the part headers seems be to be deprecated:
-// either not present (s3cmd) or cannot be trusted (aws s3api).
-//
-// https://docs.aws.amazon.com/AmazonS3/latest/API/API_UploadPart.html
-func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []string, q url.Values, bck *cluster.Bck) {
-	if len(items) < 2 {
-		err := fmt.Errorf(fmtErrBO, items)
+	// source (range)
+	src := strings.Trim(r.Header.Get(cos.S3HdrObjSrc), "/") // in AWS examples the path starts with "/"
+	parts := strings.SplitN(src, "/", 2)
+	if len(parts) < 2 {
+		s3.WriteErr(w, r, errS3Obj, 0)
+		return
+	}
+	bckSrc, err, errCode := cluster.InitByNameOnly(parts[0], t.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	cmsg := &cmn.ComposeMsg{Sources: []cmn.ComposeSrc{{Bck: *bckSrc.Bucket(), ObjName: strings.Trim(parts[1], "/")}}}
+	if rng := r.Header.Get(cos.S3HdrObjSrcRange); rng != "" {
+		if cmsg.Sources[0].Offset, cmsg.Sources[0].Length, err = s3.ParseCopySrcRange(rng); err != nil {
+			s3.WriteErr(w, r, err, http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+	}
+	if err := cmsg.Validate(); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	uploadID := q.Get(s3.QparamMptUploadID)
-	if uploadID == "" {
-		s3.WriteErr(w, r, errors.New("empty uploadId"), 0)
+
+	// destination part
+	lom := &cluster.LOM{ObjName: s3.ObjName(items)}
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	part := q.Get(s3.QparamMptPartNo)
-	if part == "" {
-		s3.WriteErr(w, r, fmt.Errorf("upload %q: missing part number", uploadID), 0)
+	wfqn := s3.PartFQN(uploadID, lom, partNum) // <upload-id>.<part-number>.<obj-name>
+	if err := cos.CreateDir(filepath.Dir(wfqn)); err != nil {
+		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	partNum, err := s3.ParsePartNum(part)
+	fh, err := os.Create(wfqn)
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	cksumMD5 := cos.NewCksumHash(cos.ChecksumMD5)
+	size, err := t.composeTo(io.MultiWriter(cksumMD5.H, fh), cmsg)
+	cos.Close(fh)
+	if err != nil {
+		if nerr := cos.RemoveFile(wfqn); nerr != nil {
+			glog.Errorf(fmtNested, t, err, "remove", wfqn, nerr)
+		}
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	cksumMD5.Finalize()
+
+	npart := &s3.MptPart{
+		MD5:  cksumMD5.Value(),
+		FQN:  wfqn,
+		Size: size,
+		Num:  partNum,
+	}
+	if err := s3.AddPart(uploadID, lom, npart); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	result := s3.CopyPartResult{
+		LastModified: cos.FormatNanoTime(time.Now().UnixNano(), cos.ISO8601),
+		ETag:         cksumMD5.Value(),
+	}
+	sgl := t.gmm.NewSGL(0)
+	result.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// upload ID and part number (UploadPart, UploadPartCopy)
+func mptPartArgs(q url.Values) (uploadID string, partNum int64, err error) {
+	uploadID = q.Get(s3.QparamMptUploadID)
+	if uploadID == "" {
+		return "", 0, errors.New("empty uploadId")
+	}
+	part := q.Get(s3.QparamMptPartNo)
+	if part == "" {
+		return "", 0, fmt.Errorf("upload %q: missing part number", uploadID)
+	}
+	if partNum, err = s3.ParsePartNum(part); err != nil {
+		return "", 0, err
+	}
 	if partNum < 1 || partNum > s3.MaxPartsPerUpload {
-		err := fmt.Errorf("upload %q: invalid part number %d, must be between 1 and %d",
+		err = fmt.Errorf("upload %q: invalid part number %d, must be between 1 and %d",
 			uploadID, partNum, s3.MaxPartsPerUpload)
+	}
+	return
+}
+
+// PUT a part of the multipart upload.
+// Body is empty, everything in the query params and the header.
+//
+// "Content-MD5" in the part headers seems be to be deprecated:
+// either not present (s3cmd) or cannot be trusted (aws s3api).
+//
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_UploadPart.html
+func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []string, q url.Values, bck *cluster.Bck) {
+	if len(items) < 2 {
+		err := fmt.Errorf(fmtErrBO, items)
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	if r.Header.Get(cos.S3HdrObjSrc) != "" {
-		s3.WriteErr(w, r, errors.New("uploading a copy is not supported yet"), http.StatusNotImplemented)
+	uploadID, partNum, err := mptPartArgs(q)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
 		return
 	}
 
@@ -87,8 +170,11 @@ func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []stri
 		return
 	}
 
-	prefix := fmt.Sprintf("%s.%d", uploadID, partNum) // workfile name format: <upload-id>.<part-number>.<obj-name>
-	wfqn := fs.CSM.Gen(lom, fs.WorkfileType, prefix)
+	wfqn := s3.PartFQN(uploadID, lom, partNum) // <upload-id>.<part-number>.<obj-name>
+	if err := cos.CreateDir(filepath.Dir(wfqn)); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
 	fh, err := os.Create(wfqn)
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
@@ -136,7 +222,7 @@ func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []stri
 		Size: size,
 		Num:  partNum,
 	}
-	if err := s3.AddPart(uploadID, npart); err != nil {
+	if err := s3.AddPart(uploadID, lom, npart); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
@@ -156,7 +242,10 @@ func (t *target) startMpt(w http.ResponseWriter, r *http.Request, items []string
 	}
 
 	uploadID := cos.GenUUID()
-	s3.InitUpload(uploadID, bck.Name, objName)
+	if err := s3.InitUpload(uploadID, &lom); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
 	result := &s3.InitiateMptUploadResult{Bucket: bck.Name, Key: objName, UploadID: uploadID}
 
 	sgl := t.gmm.NewSGL(0)
@@ -208,11 +297,20 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 		return partList.Parts[i].PartNumber < partList.Parts[j].PartNumber
 	})
 	// 2. check
-	nparts, err := s3.CheckParts(uploadID, partList.Parts)
+	nparts, err := s3.CheckParts(uploadID, lom, partList.Parts)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	size, err := s3.ObjSize(uploadID, lom)
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if err := t.quotaCheck(lom, size); err != nil { // (fail early - see poi.fini)
+		s3.WriteErr(w, r, err, http.StatusInsufficientStorage)
+		return
+	}
 	// 3. append all parts and, separately, their respective MD5s
 	buf, slab := t.gmm.Alloc()
 	defer slab.Free(buf)
@@ -255,19 +353,17 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 	objETag := fmt.Sprintf("%s%s%d", resMD5.Value(), cmn.AwsMultipartDelim, len(partList.Parts))
 
 	// 5. finalize
-	size, err := s3.ObjSize(uploadID)
-	if err != nil {
-		s3.WriteErr(w, r, err, 0)
-		return
-	}
 	lom.SetSize(size)
 	lom.SetAtimeUnix(time.Now().UnixNano())
 	lom.SetCustomKey(cmn.ETag, objETag)
 	lom.SetCksum(actualMD5.Cksum.Clone())
-	t.FinalizeObj(lom, objWorkfile, nil) // locks inside
+	if errCode, err := t.FinalizeObj(lom, objWorkfile, nil); err != nil { // locks inside
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
 
 	// 6. mpt state => xattr
-	exists := s3.FinishUpload(uploadID, lom.FQN, false /*aborted*/)
+	exists := s3.FinishUpload(uploadID, lom, false /*aborted*/)
 	debug.Assert(exists)
 
 	// 7. respond
@@ -322,7 +418,7 @@ func (t *target) listMptUploads(w http.ResponseWriter, bck *cluster.Bck, q url.V
 		}
 	}
 	idMarker = q.Get(s3.QparamMptUploadIDMarker)
-	result := s3.ListUploads(bck.Name, idMarker, maxUploads)
+	result := s3.ListUploads(bck, idMarker, maxUploads)
 	sgl := t.gmm.NewSGL(0)
 	result.MustMarshal(sgl)
 	w.Header().Set(cos.HdrContentType, cos.ContentXML)
@@ -334,16 +430,26 @@ func (t *target) listMptUploads(w http.ResponseWriter, bck *cluster.Bck, q url.V
 // Body is empty, only URL query contains uploadID
 // 1. uploadID must exists
 // 2. Remove all temporary files
-// 3. Remove all info from in-memory structs
+// 3. Remove all info from in-memory structs and the persisted manifest
 // https://docs.aws.amazon.com/AmazonS3/latest/API/API_AbortMultipartUpload.html
-func (*target) abortMptUpload(w http.ResponseWriter, r *http.Request, items []string, q url.Values) {
+func (t *target) abortMptUpload(w http.ResponseWriter, r *http.Request, items []string, q url.Values) {
 	if len(items) < 2 {
 		err := fmt.Errorf(fmtErrBO, items)
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	bck, err, errCode := cluster.InitByNameOnly(items[0], t.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	lom := &cluster.LOM{ObjName: s3.ObjName(items)}
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
 	uploadID := q.Get(s3.QparamMptUploadID)
-	exists := s3.FinishUpload(uploadID, "", true /*aborted*/)
+	exists := s3.FinishUpload(uploadID, lom, true /*aborted*/)
 	if !exists {
 		err := fmt.Errorf("upload %q does not exist", uploadID)
 		s3.WriteErr(w, r, err, http.StatusNotFound)
@@ -376,7 +482,7 @@ func (t *target) getMptPart(w http.ResponseWriter, r *http.Request, bck *cluster
 	if err != nil {
 		s3.WriteErr(w, r, err, status)
 	}
-	fh, err := os.Open(lom.FQN)
+	fh, err := lom.Open(lom.FQN)
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
@@ -389,3 +495,19 @@ func (t *target) getMptPart(w http.ResponseWriter, r *http.Request, bck *cluster
 	cos.Close(fh)
 	slab.Free(buf)
 }
+
+// housekeeping: abort stale (no activity for longer than config.Timeout.MptAbort) multipart uploads
+func (t *target) abortStaleMpt() time.Duration {
+	maxAge := cmn.GCO.Get().Timeout.MptAbort.D()
+	if maxAge == 0 {
+		maxAge = mptAbortDflt
+	}
+	bmd := t.owner.bmd.get()
+	bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+		if n := s3.AbortStale(bck, "" /*prefix*/, maxAge); n > 0 {
+			glog.Infof("%s: %s: aborted %d stale multipart upload%s", t, bck, n, cos.Plural(n))
+		}
+		return false
+	})
+	return mptAbortIval
+}
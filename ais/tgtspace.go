@@ -6,8 +6,10 @@ package ais
 
 import (
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -21,6 +23,9 @@ import (
 	"github.com/NVIDIA/aistore/xact/xreg"
 )
 
+// how often to check (and execute) bucket lifecycle rules
+const lcyIval = time.Hour
+
 // triggers by an out-of-space condition or a suspicion of thereof
 func (t *target) OOS(csRefreshed *fs.CapStatus) (cs fs.CapStatus) {
 	var err error
@@ -73,6 +78,7 @@ func (t *target) runLRU(id string, wg *sync.WaitGroup, force bool, bcks ...cmn.B
 		Buckets:             bcks,
 		GetFSUsedPercentage: ios.GetFSUsedPercentage,
 		GetFSStats:          ios.GetFSStats,
+		Evicted:             func(lom *cluster.LOM, size int64) { t.quotas.sub(lom.Bck(), size) },
 		WG:                  wg,
 		Force:               force,
 	}
@@ -116,3 +122,47 @@ func (t *target) runStoreCleanup(id string, wg *sync.WaitGroup, bcks ...cmn.Bck)
 	})
 	return space.RunCleanup(&ini)
 }
+
+// housekeeping: execute lifecycle rules iff there's at least one bucket that has them
+func (t *target) lifecycleHK() time.Duration {
+	if space.HasLifecycle(t.owner.bmd) {
+		go t.runLifecycle("" /*uuid*/, nil /*wg*/)
+	}
+	return lcyIval
+}
+
+func (t *target) runLifecycle(id string, wg *sync.WaitGroup, bcks ...cmn.Bck) {
+	regToIC := id == ""
+	if regToIC {
+		id = cos.GenUUID()
+	}
+	rns := xreg.RenewLifecycle(id)
+	if rns.Err != nil || rns.IsRunning() {
+		debug.Assert(rns.Err == nil || cmn.IsErrUsePrevXaction(rns.Err))
+		if wg != nil {
+			wg.Done()
+		}
+		return
+	}
+	xlcy := rns.Entry.Get()
+	if regToIC && xlcy.ID() == id {
+		// pre-existing UUID: notify IC members
+		regMsg := xactRegMsg{UUID: id, Kind: apc.ActLifecycle, Srcs: []string{t.si.ID()}}
+		msg := t.newAmsgActVal(apc.ActRegGlobalXaction, regMsg)
+		t.bcastAsyncIC(msg)
+	}
+	ini := space.IniLcy{
+		T:        t,
+		Xaction:  xlcy.(*space.XactLcy),
+		StatsT:   t.statsT,
+		Buckets:  bcks,
+		AbortMpt: s3.AbortStale,
+		Removed:  func(lom *cluster.LOM, size int64) { t.quotas.sub(lom.Bck(), size) },
+		WG:       wg,
+	}
+	xlcy.AddNotif(&xact.NotifXact{
+		Base: nl.Base{When: cluster.UponTerm, Dsts: []string{equalIC}, F: t.callerNotifyFin},
+		Xact: xlcy,
+	})
+	space.RunLifecycle(&ini)
+}
@@ -0,0 +1,59 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// object tags are stored in the object's metadata (xattr) as custom keys
+// with `cmn.TagObjMDPrefix` - see cmn/objmeta.go for the limits
+
+// set (merge with existing or replace all) object tags
+func (t *target) setObjTags(lom *cluster.LOM, tags cos.StrKVs, replace bool) (int, error) {
+	return t.updObjTags(lom, func(cur cos.StrKVs) cos.StrKVs {
+		if replace || cur == nil {
+			return tags
+		}
+		for k, v := range tags {
+			cur[k] = v
+		}
+		return cur
+	})
+}
+
+// delete selected or all (when `keys` is empty) object tags
+func (t *target) delObjTags(lom *cluster.LOM, keys []string) (int, error) {
+	return t.updObjTags(lom, func(cur cos.StrKVs) cos.StrKVs {
+		if len(keys) == 0 {
+			return nil
+		}
+		for _, k := range keys {
+			delete(cur, k)
+		}
+		return cur
+	})
+}
+
+func (*target) updObjTags(lom *cluster.LOM, upd func(cur cos.StrKVs) cos.StrKVs) (int, error) {
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			return http.StatusNotFound, err
+		}
+		return 0, err
+	}
+	tags := upd(lom.ObjAttrs().Tags())
+	if err := cmn.ValidateTags(tags); err != nil {
+		return http.StatusBadRequest, err
+	}
+	lom.ObjAttrs().SetTags(tags)
+	return 0, lom.Persist()
+}
@@ -484,7 +484,7 @@ func (t *target) validateBckRenTxn(bckFrom, bckTo *cluster.Bck, msg *aisMsg) err
 }
 
 func (t *target) etlDP(msg *apc.TCBMsg) (cluster.DP, error) {
-	if err := k8s.Detect(); err != nil {
+	if err := k8s.Detect(); err != nil && !cmn.Features.IsSet(feat.LocalETL) {
 		return nil, err
 	}
 	if err := msg.Validate(true); err != nil {
@@ -871,6 +871,12 @@ func (t *target) startMaintenance(c *txnServerCtx) error {
 func (t *target) destroyBucket(c *txnServerCtx) error {
 	switch c.phase {
 	case apc.ActBegin:
+		// object lock (see tgtlock.go)
+		if c.bck.Init(t.owner.bmd) == nil {
+			if err := t.findLockedObj(c.bck); err != nil {
+				return err
+			}
+		}
 		nlp := c.bck.GetNameLockPair()
 		if !nlp.TryLock(c.timeout.netw / 2) {
 			return cmn.NewErrBckIsBusy(c.bck.Bucket())
@@ -0,0 +1,257 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/event"
+	"github.com/NVIDIA/aistore/ext/etl"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// ais buckets that retain non-current versions (cmn.VersionConf.Retain):
+// - PUT moves the current version (object or delete marker) to non-current;
+// - DELETE without version does the same and puts a delete marker in place;
+// - GET, HEAD, and DELETE with apc.QparamObjVersion address a specific version;
+// - retention limits are enforced upon PUT and DELETE;
+// - lifecycle rules with `noncurrent_days` are executed by space/lifecycle.go.
+// See also: cluster/lom_ver.go
+
+// archive the current version that is about to be replaced and set the new version's
+// base (caller holds wlock)
+func (*target) archiveCurrent(lom *cluster.LOM) (archived string, err error) {
+	if archived, err = lom.ArchiveCurrent(); err != nil {
+		return
+	}
+	lom.ObjAttrs().DelCustomKeys(cmn.DelMarkerObjMD)
+	latest, err := lom.LatestVersion()
+	if err != nil {
+		return
+	}
+	lom.SetVersion(latest)
+	return
+}
+
+// enforce retention limits and, in erasure coded buckets, sync the versions replicated
+// to other targets (see ec/versions.go); `archived` is the version that has just
+// become non-current, if any
+func (t *target) pruneVersions(lom *cluster.LOM, archived string) {
+	n, err := lom.PruneVersions()
+	if err != nil {
+		glog.Errorf("%s: failed to prune versions: %v", lom, err)
+		return
+	}
+	if n == 0 && lom.IsDelMarker() {
+		// nothing left to hide
+		if err := lom.Remove(); err != nil {
+			glog.Errorf("%s: failed to remove delete marker: %v", lom, err)
+		} else {
+			t.quotas.sub(lom.Bck(), 0)
+		}
+	}
+	if archived == "" {
+		ec.ECM.SyncVersions(lom)
+	} else {
+		ec.ECM.SyncVersions(lom, archived)
+	}
+}
+
+// DELETE (current): the current version becomes non-current and the delete marker -
+// current (caller holds wlock and has loaded the lom)
+func (t *target) delobjRetain(lom *cluster.LOM) (int, error) {
+	if lom.IsDelMarker() {
+		return http.StatusNotFound, cmn.NewErrNotFound("%s: object %s", t, lom.Cname())
+	}
+	size := lom.SizeBytes()
+	archived, err := lom.ArchiveCurrent()
+	if err != nil {
+		return 0, err
+	}
+	if archived == "" {
+		return http.StatusNotFound, cmn.NewErrNotFound("%s: object %s", t, lom.Cname())
+	}
+	if err := t.putDelMarker(lom, archived); err != nil {
+		return 0, err
+	}
+	t.quotas.add(lom.Bck(), usageDelta{size: -size}) // (delete marker is zero-size)
+	t.pruneVersions(lom, archived)
+	return 0, nil
+}
+
+func (*target) putDelMarker(lom *cluster.LOM, prev string) error {
+	fh, err := cos.CreateFile(lom.FQN)
+	if err != nil {
+		return err
+	}
+	cos.Close(fh)
+	lom.SetSize(0)
+	lom.SetCksum(cos.NoneCksum)
+	lom.SetCustomMD(cos.StrKVs{cmn.DelMarkerObjMD: "true"})
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	lom.SetVersion(prev)
+	if err := lom.IncVersion(); err != nil {
+		return err
+	}
+	return lom.PersistMain()
+}
+
+// DELETE a given version: when the version is current, the most recent non-current
+// version (if any) takes its place; locked versions cannot be deleted (see tgtlock.go)
+func (t *target) delObjVersion(lom *cluster.LOM, ver string, bypass bool) (int, error) {
+	lom.Lock(true)
+	errCode, err := t._delObjVersion(lom, ver, bypass)
+	lom.Unlock(true)
+	if err == nil {
+		event.EmitVersion(lom, ver)
+	}
+	return errCode, err
+}
+
+func (t *target) _delObjVersion(lom *cluster.LOM, ver string, bypass bool) (int, error) {
+	err := lom.Load(false /*cache it*/, true /*locked*/)
+	if err != nil && !cmn.IsObjNotExist(err) {
+		return 0, err
+	}
+	exists := err == nil
+	if exists && lom.Version() == ver {
+		if err := checkObjLock(lom, bypass); err != nil {
+			return http.StatusForbidden, err
+		}
+		size := lom.SizeBytes()
+		if err := lom.Remove(); err != nil {
+			return 0, err
+		}
+		t.quotas.sub(lom.Bck(), size)
+		etl.DropCached(lom)
+		latest, err := lom.LatestVersion()
+		if err != nil {
+			return 0, err
+		}
+		if latest == "" {
+			ec.ECM.CleanupObject(lom)
+			ec.ECM.SyncVersions(lom)
+			return 0, nil
+		}
+		if err := lom.RestoreVersion(latest); err != nil {
+			return 0, err
+		}
+		t.quotas.add(lom.Bck(), usageDelta{size: lom.SizeBytes(), cnt: 1})
+		// erasure code the restored version (a delete marker has nothing to protect)
+		if lom.IsDelMarker() {
+			ec.ECM.CleanupObject(lom)
+		} else if err := ec.ECM.EncodeObject(lom); err != nil && err != ec.ErrorECDisabled {
+			glog.Errorf("%s: failed to erasure code restored version %q: %v", t, latest, err)
+		}
+	} else if err := t.delVersion(lom, ver, bypass); err != nil {
+		if cmn.IsErrObjLocked(err) {
+			return http.StatusForbidden, err
+		}
+		if cmn.IsErrNotFound(err) {
+			return http.StatusNotFound, err
+		}
+		return 0, err
+	}
+	if exists {
+		t.pruneVersions(lom, "")
+	}
+	return 0, nil
+}
+
+func (*target) delVersion(lom *cluster.LOM, ver string, bypass bool) error {
+	if lom.Bprops().ObjLock.Enabled {
+		v, err := lom.LoadVersion(ver)
+		if err != nil {
+			return err
+		}
+		if err := v.CheckLocked(lom.Cname()+" version "+ver, time.Now(), bypass); err != nil {
+			return err
+		}
+	}
+	return lom.DelVersion(ver)
+}
+
+// GET a given non-current version (the current one is handled by the regular GET);
+// returns false when the version in question is current
+func (t *target) getObjVersion(w http.ResponseWriter, lom *cluster.LOM, ver string,
+	rangeHdr string) (done bool, errCode int, err error) {
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if lom.Load(true /*cache it*/, true /*locked*/) == nil && lom.Version() == ver {
+		if lom.IsDelMarker() {
+			w.Header().Set(cos.S3HdrDeleteMarker, "true")
+			return true, http.StatusMethodNotAllowed, fmt.Errorf("%s: version %q is a delete marker", lom, ver)
+		}
+		return false, 0, nil
+	}
+	v, err := lom.LoadVersion(ver)
+	if err != nil {
+		return true, http.StatusNotFound, err
+	}
+	if v.IsDelMarker() {
+		w.Header().Set(cos.S3HdrDeleteMarker, "true")
+		return true, http.StatusMethodNotAllowed, fmt.Errorf("%s: version %q is a delete marker", lom, ver)
+	}
+	var (
+		hdr    = w.Header()
+		size   = v.Size
+		reader io.Reader
+	)
+	cmn.ToHeader(&v.ObjAttrs, hdr)
+	var hrng *htrange
+	if rangeHdr != "" {
+		if hrng, errCode, err = parseRangeHdr(hdr, rangeHdr, v.Size, lom.Cname()); err != nil {
+			return true, errCode, err
+		}
+	}
+	fh, err := lom.OpenVersion(v)
+	if err != nil {
+		return true, 0, err
+	}
+	defer cos.Close(fh)
+	reader = fh
+	if hrng != nil {
+		// (the checksum is the entire version's)
+		hdr.Del(apc.HdrObjCksumVal)
+		hdr.Del(apc.HdrObjCksumType)
+		reader, size = io.NewSectionReader(fh, hrng.Start, hrng.Length), hrng.Length
+	}
+	hdr.Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+	buf, slab := t.gmm.AllocSize(size)
+	written, err := io.CopyBuffer(w, reader, buf)
+	slab.Free(buf)
+	if err != nil {
+		return true, 0, errSendingResp
+	}
+	t.statsT.AddMany(
+		cos.NamedVal64{Name: stats.GetCount, Value: 1},
+		cos.NamedVal64{Name: stats.GetSize, Value: written},
+	)
+	return true, 0, nil
+}
+
+// HEAD a given non-current version (see objhead)
+func (t *target) headObjVersion(lom *cluster.LOM, ver string) (*cmn.ObjAttrs, int, error) {
+	lom.Lock(false)
+	v, err := lom.LoadVersion(ver)
+	lom.Unlock(false)
+	if err != nil {
+		return nil, http.StatusNotFound, cmn.NewErrNotFound("%s: object %s version %q", t, lom.Cname(), ver)
+	}
+	if v.IsDelMarker() {
+		return nil, http.StatusMethodNotAllowed, fmt.Errorf("%s: version %q is a delete marker", lom, ver)
+	}
+	return &v.ObjAttrs, 0, nil
+}
@@ -172,6 +172,11 @@ func (t *target) xstart(r *http.Request, args *xact.ArgsMsg, bck *cluster.Bck) e
 		wg.Add(1)
 		go t.runLRU(args.ID, wg, force, args.Buckets...)
 		wg.Wait()
+	case apc.ActLifecycle:
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		go t.runLifecycle(args.ID, wg, args.Buckets...)
+		wg.Wait()
 	case apc.ActStoreCleanup:
 		wg := &sync.WaitGroup{}
 		wg.Add(1)
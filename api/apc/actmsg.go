@@ -19,6 +19,7 @@ const (
 	ActDestroyBck     = "destroy-bck" // destroy bucket data and metadata
 	ActSummaryBck     = "summary-bck"
 	ActCopyBck        = "copy-bck"
+	ActDelObjTags     = "delete-obj-tags" // PATCH(object): remove tags (all or selected keys)
 	ActDownload       = "download"
 	ActECEncode       = "ec-encode" // erasure code a bucket
 	ActECGet          = "ec-get"    // erasure decode objects
@@ -29,6 +30,7 @@ const (
 	ActElection       = "election"
 	ActEvictRemoteBck = "evict-remote-bck" // evict remote bucket's data
 	ActInvalListCache = "inval-listobj-cache"
+	ActLifecycle      = "lifecycle" // execute bucket lifecycle rules (see cmn.LifecycleConf)
 	ActLRU            = "lru"
 	ActList           = "list"
 	ActLoadLomCache   = "load-lom-cache"
@@ -46,6 +48,9 @@ const (
 	ActResyncBprops   = "resync-bprops"
 	ActSetBprops      = "set-bprops"
 	ActSetConfig      = "set-config"
+	ActSetObjTags     = "set-obj-tags"   // PATCH(object): add or update tags
+	ActObjRetention   = "obj-retention"  // PATCH(object): set, extend, or remove retention (object lock)
+	ActObjLegalHold   = "obj-legal-hold" // PATCH(object): set or remove legal hold (object lock)
 	ActShutdown       = "shutdown"
 	ActStartGFN       = "start-gfn"
 	ActStoreCleanup   = "cleanup-store"
@@ -56,7 +61,9 @@ const (
 	ActETLObjects      = "etl-listrange"
 	ActEvictObjects    = "evict-listrange"
 	ActPrefetchObjects = "prefetch-listrange"
-	ActArchive         = "archive" // see ArchiveMsg
+	ActArchive         = "archive"   // see ArchiveMsg
+	ActGetBatch        = "get-batch" // multi-object GET (see GetBatchMsg)
+	ActCompose         = "compose"   // concatenate objects (or their ranges) into a new object (see ComposeMsg)
 
 	ActAttachRemAis = "attach"
 	ActDetachRemAis = "detach"
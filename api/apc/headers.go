@@ -52,6 +52,9 @@ const (
 	// Query objects handle header.
 	HdrHandle = HeaderPrefix + "query-handle"
 
+	// ETL arguments (transformer-specific), e.g. pipeline stage arguments for hpush:// transformers
+	HdrETLArgs = HeaderPrefix + "etl-args"
+
 	// Reverse proxy headers.
 	HdrNodeID  = HeaderPrefix + "node-id"
 	HdrNodeURL = HeaderPrefix + "node-url"
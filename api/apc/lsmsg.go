@@ -80,6 +80,11 @@ const (
 	// - update AIS CLI to support non-recursive list-objects operation
 	// - when listing remote bucket, call backend (`Backend()`) to list non-recursively
 	LsNoRecursion
+
+	// Include non-current versions and delete markers (ais buckets that retain versions -
+	// see cmn.VersionConf.Retain); non-current versions of a given object precede the object
+	// in the resulting list and are ordered from oldest to newest.
+	LsVersions
 )
 
 // List objects default page size
@@ -100,6 +105,9 @@ const (
 	EntryIsCached = 1 << (EntryStatusBits + 1)
 	EntryInArch   = 1 << (EntryStatusBits + 2)
 	EntryIsDir    = 1 << (EntryStatusBits + 3)
+
+	EntryIsNoncurrent = 1 << (EntryStatusBits + 4) // non-current version (see LsVersions)
+	EntryIsDelMarker  = 1 << (EntryStatusBits + 5) // delete marker (ditto)
 )
 
 // ObjEntry.Flags field
@@ -121,6 +129,7 @@ const (
 	GetPropsEC       = "ec"
 	GetPropsCustom   = "custom"
 	GetPropsLocation = "location" // advanced usage
+	GetPropsOffset   = "offset"   // archived file's offset in the archive (LsArchDir only; not included in GetPropsAll)
 )
 
 // NOTE: update when changing any of the above :NOTE
@@ -139,6 +148,8 @@ type LsoMsg struct {
 	Props             string `json:"props"`              // comma-delimited, e.g. "checksum,size,custom" (see GetProps* enum)
 	TimeFormat        string `json:"time_format"`        // RFC822 is the default
 	Prefix            string `json:"prefix"`             // objname filter: return names starting with prefix
+	CustomFilter      string `json:"custom_filter"`      // tags/custom-md filter, e.g. "split=train,owner" (in-cluster objects only)
+	ArchRegex         string `json:"arch_regex"`         // LsArchDir only: select archived files by full name ("<archive>/<filename>")
 	StartAfter        string `json:"start_after"`        // start listing after (AIS buckets only)
 	ContinuationToken string `json:"continuation_token"` // BucketList.ContinuationToken
 	SID               string `json:"target"`             // selected target to solely execute backend.list-objects
@@ -207,6 +218,7 @@ func (lsmsg *LsoMsg) PropsSet() (s cos.StrSet) {
 }
 
 func (lsmsg *LsoMsg) SetFlag(flag uint64)         { lsmsg.Flags |= flag }
+func (lsmsg *LsoMsg) ClearFlag(flag uint64)       { lsmsg.Flags &^= flag }
 func (lsmsg *LsoMsg) IsFlagSet(flags uint64) bool { return lsmsg.Flags&flags == flags }
 
 func (lsmsg *LsoMsg) Clone() *LsoMsg {
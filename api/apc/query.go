@@ -14,6 +14,7 @@ const (
 	QparamUUID    = "uuid"     // xaction
 	QparamJobID   = "jobid"    // job
 	QparamETLName = "etl_name" // etl
+	QparamETLArgs = "etl_args" // etl: transformer-specific arguments (see also: HdrETLArgs)
 
 	QparamRegex      = "regex"       // dsort: list regex
 	QparamOnlyActive = "only_active" // dsort: list only active
@@ -49,6 +50,14 @@ const (
 	QparamAppendType   = "append_type"
 	QparamAppendHandle = "append_handle"
 
+	// GET, HEAD, or DELETE a given (possibly, non-current) version of the object
+	// (ais buckets that retain versions - see cmn.VersionConf.Retain)
+	QparamObjVersion = "obj_version"
+
+	// DELETE (or change retention of) an object under governance retention
+	// (requires admin permission - see cmn.ObjLockGovernance)
+	QparamBypassGovernance = "bypass_governance"
+
 	// HTTP bucket support.
 	QparamOrigURL = "original_url"
 
@@ -139,6 +148,7 @@ const (
 	QparamTotalCompressedSize       = "tcs"
 	QparamTotalInputShardsExtracted = "tise"
 	QparamTotalUncompressedSize     = "tunc"
+	QparamResumePhase               = "rphase" // last phase completed by all targets (resumable jobs)
 
 	// 2PC transactions - control plane
 	QparamNetwTimeout  = "xnt" // [begin, start-commit] timeout
@@ -22,6 +22,7 @@ type (
 	Transform struct {
 		Name    string       `json:"id,omitempty"`
 		Timeout cos.Duration `json:"request_timeout,omitempty"`
+		Args    string       `json:"args,omitempty"` // opaque transformer arguments (see etl.MaxArgsSize)
 	}
 	TCBMsg struct {
 		// NOTE: resulting object names will have this extension, if specified.
@@ -33,6 +33,8 @@ const (
 	Clusters  = "clusters" // AuthN
 	Roles     = "roles"    // AuthN
 	IC        = "ic"       // information center
+	Batch     = "batch"    // multi-object GET
+	Compose   = "compose"  // (intra-cluster) compose objects
 
 	// l3
 	SyncSmap = "syncsmap" // legacy
@@ -63,6 +65,9 @@ const (
 	FinishedAck = "finished_ack"
 	List        = "list"
 	Remove      = "remove"
+	Resume      = "resume"
+	Pause       = "pause"
+	Schedule    = "schedule"
 	Next        = "next"
 	Peek        = "peek"
 	Discard     = "discard"
@@ -102,6 +107,8 @@ var (
 	URLPathHealth    = urlpath(Version, Health)
 	URLPathMetasync  = urlpath(Version, Metasync)
 	URLPathRebalance = urlpath(Version, Rebalance)
+	URLPathBatch     = urlpath(Version, Batch)
+	URLPathCompose   = urlpath(Version, Compose)
 
 	URLPathClu        = urlpath(Version, Cluster)
 	URLPathCluProxy   = urlpath(Version, Cluster, Proxy)
@@ -137,10 +144,12 @@ var (
 	URLPathdSortMetrics = urlpath(Version, Sort, Metrics)
 	URLPathdSortAck     = urlpath(Version, Sort, FinishedAck)
 	URLPathdSortRemove  = urlpath(Version, Sort, Remove)
+	URLPathdSortResume  = urlpath(Version, Sort, Resume)
 
 	URLPathDownload       = urlpath(Version, Download)
 	URLPathDownloadAbort  = urlpath(Version, Download, Abort)
 	URLPathDownloadRemove = urlpath(Version, Download, Remove)
+	URLPathDownloadSched  = urlpath(Version, Download, Schedule)
 
 	URLPathETL       = urlpath(Version, ETL)
 	URLPathETLObject = urlpath(Version, ETL, ETLObject)
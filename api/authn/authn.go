@@ -272,6 +272,20 @@ func RevokeToken(bp api.BaseParams, token string) error {
 	return reqParams.DoRequest()
 }
 
+// GetS3Creds exchanges the token (`bp.Token`) for S3 credentials to sign
+// S3 requests and presigned URLs with (see also api.PresignS3URL)
+func GetS3Creds(bp api.BaseParams) (creds *S3Creds, err error) {
+	bp.Method = http.MethodPost
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathTokens.S
+	}
+	_, err = reqParams.DoReqAny(&creds)
+	return
+}
+
 func GetConfig(bp api.BaseParams) (*Config, error) {
 	bp.Method = http.MethodGet
 	reqParams := api.AllocRp()
@@ -37,6 +37,14 @@ type (
 	TokenMsg struct {
 		Token string `json:"token"`
 	}
+	// S3 credentials (in exchange for a token); secret access key is derived
+	// from the session token, and both expire along with the original token
+	S3Creds struct {
+		AccessKeyID     string    `json:"access_key_id"`
+		SecretAccessKey string    `json:"secret_access_key"`
+		SessionToken    string    `json:"session_token"`
+		Expires         time.Time `json:"expires"`
+	}
 	LoginMsg struct {
 		Password  string         `json:"password"`
 		ExpiresIn *time.Duration `json:"expires_in"`
@@ -77,6 +77,20 @@ func DownloadBackend(bp BaseParams, descr string, bck cmn.Bck, prefix, suffix st
 	return DownloadWithParam(bp, dload.TypeBackend, dlBody)
 }
 
+// DownloadManifest downloads the links listed in the manifest object (e.g., `SHA256SUMS`)
+// and validates the downloaded content against the manifest's checksums (and sizes, if any).
+// Relative links resolve against the `baseURL`.
+func DownloadManifest(bp BaseParams, descr string, bck, manifestBck cmn.Bck, manifestName, baseURL string,
+	ivals ...time.Duration) (string, error) {
+	dlBody := dload.ManifestBody{ManifestBck: manifestBck, ManifestName: manifestName, BaseURL: baseURL}
+	if len(ivals) > 0 {
+		dlBody.ProgressInterval = ivals[0].String()
+	}
+	dlBody.Bck = bck
+	dlBody.Description = descr
+	return DownloadWithParam(bp, dload.TypeManifest, dlBody)
+}
+
 func DownloadStatus(bp BaseParams, id string, onlyActive bool) (dlStatus *dload.StatusResp, err error) {
 	dlBody := dload.AdminBody{ID: id, OnlyActive: onlyActive}
 	bp.Method = http.MethodGet
@@ -140,6 +154,93 @@ func RemoveDownload(bp BaseParams, id string) error {
 	return err
 }
 
+//
+// scheduled (recurring) downloads
+//
+
+// DownloadSchedule creates a new download schedule that periodically runs the
+// specified download request. Exactly one of the `cron` (e.g., "0 3 * * *")
+// and `every` (e.g., 6 hours) must be defined. Returns the schedule's ID.
+func DownloadSchedule(bp BaseParams, id, cron string, every time.Duration, dlt dload.Type, body any) (string, error) {
+	sb := dload.ScheduleBody{
+		ID:   id,
+		Cron: cron,
+		Req:  dload.Body{Type: dlt, RawMessage: cos.MustMarshal(body)},
+	}
+	if every != 0 {
+		sb.Every = every.String()
+	}
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathDownloadSched.S
+		reqParams.Body = cos.MustMarshal(sb)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	id, err := reqParams.doDlDownloadRequest()
+	FreeRp(reqParams)
+	return id, err
+}
+
+func ListDownloadSchedules(bp BaseParams) (infos dload.ScheduleInfos, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathDownloadSched.S
+	}
+	_, err = reqParams.DoReqAny(&infos)
+	FreeRp(reqParams)
+	return
+}
+
+// GetDownloadSchedule returns the schedule along with the history of its runs.
+func GetDownloadSchedule(bp BaseParams, id string) (info *dload.ScheduleInfo, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathDownloadSched.Join(id)
+	}
+	info = &dload.ScheduleInfo{}
+	_, err = reqParams.DoReqAny(info)
+	FreeRp(reqParams)
+	return
+}
+
+func PauseDownloadSchedule(bp BaseParams, id string) error {
+	return dlschedAction(bp, id, apc.Pause)
+}
+
+func ResumeDownloadSchedule(bp BaseParams, id string) error {
+	return dlschedAction(bp, id, apc.Resume)
+}
+
+func dlschedAction(bp BaseParams, id, action string) error {
+	bp.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathDownloadSched.Join(id, action)
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
+func RemoveDownloadSchedule(bp BaseParams, id string) error {
+	bp.Method = http.MethodDelete
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathDownloadSched.Join(id)
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // TODO: simplify `dload.DlPostResp` => string
 func (reqParams *ReqParams) doDlDownloadRequest() (string, error) {
 	var resp dload.DlPostResp
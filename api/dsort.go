@@ -40,6 +40,21 @@ func AbortDSort(bp BaseParams, managerUUID string) error {
 	return err
 }
 
+// ResumeDSort resumes aborted (e.g., due to target restart) resumable job
+// from the last phase completed by all targets
+func ResumeDSort(bp BaseParams, managerUUID string) error {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathdSortResume.S
+		reqParams.Query = url.Values{apc.QparamUUID: []string{managerUUID}}
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 func MetricsDSort(bp BaseParams, managerUUID string) (metrics map[string]*dsort.Metrics, err error) {
 	bp.Method = http.MethodGet
 	reqParams := AllocRp()
@@ -146,10 +146,17 @@ func etlPostAction(bp BaseParams, etlName, action string) (err error) {
 
 // TODO: add ETL-specific query param and change the examples/docs (!4455)
 func ETLObject(bp BaseParams, etlName string, bck cmn.Bck, objName string, w io.Writer) (err error) {
-	_, err = GetObject(bp, bck, objName, &GetArgs{
-		Writer: w,
-		Query:  url.Values{apc.QparamETLName: []string{etlName}},
-	})
+	return ETLObjectWithArgs(bp, etlName, "" /*args*/, bck, objName, w)
+}
+
+// Same as above, with transformer-specific `args` (opaque to AIS; see etl.MaxArgsSize)
+// passed along with the request.
+func ETLObjectWithArgs(bp BaseParams, etlName, args string, bck cmn.Bck, objName string, w io.Writer) (err error) {
+	query := url.Values{apc.QparamETLName: []string{etlName}}
+	if args != "" {
+		query.Set(apc.QparamETLArgs, args)
+	}
+	_, err = GetObject(bp, bck, objName, &GetArgs{Writer: w, Query: query})
 	return
 }
 
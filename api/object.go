@@ -229,6 +229,29 @@ func GetObjectReader(bp BaseParams, bck cmn.Bck, object string, args *GetArgs) (
 	return
 }
 
+// GetBatch reads multiple objects and/or archived files (possibly, from different buckets)
+// and writes them into `w` as a single archive, in the request order (see cmn.GetBatchMsg).
+// Entries that cannot be read are written as placeholders named
+// `cmn.GetBatchMissingPrefix` + `cmn.GetBatchEntry.NameInArch()`.
+// Returns the number of bytes written.
+func GetBatch(bp BaseParams, msg *cmn.GetBatchMsg, w io.Writer) (n int64, err error) {
+	var wresp *wrappedResp
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBatch.S
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	wresp, err = reqParams.doWriter(w)
+	FreeRp(reqParams)
+	if err == nil {
+		n = wresp.n
+	}
+	return
+}
+
 /////////////
 // PutArgs //
 /////////////
@@ -357,6 +380,78 @@ func SetObjectCustomProps(bp BaseParams, bck cmn.Bck, object string, custom cos.
 	return err
 }
 
+// SetObjectTags adds new or updates existing object tags; use `replace` to
+// remove all existing tags first.
+// Tags are stored as custom properties with `cmn.TagObjMDPrefix` and are subject
+// to the limits listed in cmn/objmeta.go
+func SetObjectTags(bp BaseParams, bck cmn.Bck, object string, tags cos.StrKVs, replace bool) error {
+	q := bck.AddToQuery(make(url.Values, 4))
+	if replace {
+		q.Set(apc.QparamNewCustom, "true")
+	}
+	return patchObject(bp, bck, object, &apc.ActMsg{Action: apc.ActSetObjTags, Value: tags}, q)
+}
+
+// DeleteObjectTags removes the specified tags or, if none specified, all object tags.
+func DeleteObjectTags(bp BaseParams, bck cmn.Bck, object string, keys ...string) error {
+	return patchObject(bp, bck, object, &apc.ActMsg{Action: apc.ActDelObjTags, Value: keys}, bck.AddToQuery(nil))
+}
+
+// GetObjectTags returns object tags (nil if there are none).
+func GetObjectTags(bp BaseParams, bck cmn.Bck, object string) (cos.StrKVs, error) {
+	op, err := HeadObject(bp, bck, object, apc.FltPresent)
+	if err != nil {
+		return nil, err
+	}
+	return op.Tags(), nil
+}
+
+// SetObjectRetention sets, extends, or (nil `retention`) removes object retention
+// in a bucket with object lock enabled (see cmn.ObjLockConf).
+// Shortening or removing governance retention requires `bypassGovernance` (and admin permission);
+// compliance retention can only be extended.
+func SetObjectRetention(bp BaseParams, bck cmn.Bck, object string, retention *cmn.ObjRetention,
+	bypassGovernance bool) error {
+	q := bck.AddToQuery(make(url.Values, 4))
+	if bypassGovernance {
+		q.Set(apc.QparamBypassGovernance, "true")
+	}
+	actMsg := &apc.ActMsg{Action: apc.ActObjRetention}
+	if retention != nil {
+		actMsg.Value = retention
+	}
+	return patchObject(bp, bck, object, actMsg, q)
+}
+
+// SetObjectLegalHold sets or removes legal hold (object lock).
+func SetObjectLegalHold(bp BaseParams, bck cmn.Bck, object string, on bool) error {
+	return patchObject(bp, bck, object, &apc.ActMsg{Action: apc.ActObjLegalHold, Value: on}, bck.AddToQuery(nil))
+}
+
+// GetObjectLock returns object retention (nil if none) and legal hold status.
+func GetObjectLock(bp BaseParams, bck cmn.Bck, object string) (*cmn.ObjRetention, bool, error) {
+	op, err := HeadObject(bp, bck, object, apc.FltPresent)
+	if err != nil {
+		return nil, false, err
+	}
+	return op.Retention(), op.LegalHold(), nil
+}
+
+func patchObject(bp BaseParams, bck cmn.Bck, object string, actMsg *apc.ActMsg, q url.Values) error {
+	bp.Method = http.MethodPatch
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, object)
+		reqParams.Body = cos.MustMarshal(actMsg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = q
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // DeleteObject deletes an object specified by bucket/object.
 func DeleteObject(bp BaseParams, bck cmn.Bck, object string) error {
 	bp.Method = http.MethodDelete
@@ -371,6 +466,23 @@ func DeleteObject(bp BaseParams, bck cmn.Bck, object string) error {
 	return err
 }
 
+// DeleteObjectBypassGovernance deletes an object under governance retention
+// (requires admin permission).
+func DeleteObjectBypassGovernance(bp BaseParams, bck cmn.Bck, object string) error {
+	bp.Method = http.MethodDelete
+	q := bck.AddToQuery(make(url.Values, 4))
+	q.Set(apc.QparamBypassGovernance, "true")
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, object)
+		reqParams.Query = q
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // EvictObject evicts an object specified by bucket/object.
 func EvictObject(bp BaseParams, bck cmn.Bck, object string) error {
 	bp.Method = http.MethodDelete
@@ -420,7 +532,8 @@ func PutObject(args PutArgs) (oah ObjAttrs, err error) {
 // Append the content of a reader (`args.Reader` - e.g., an open file) to an existing
 // object formatted as one of the supported archives.
 // In other words, append to an existing archive.
-// For supported archival (mime) types, see cmn/cos/archive.go.
+// An archived file with the same name (`args.ArchPath`), if exists, gets replaced.
+// Supported archival (mime) types: TAR, TGZ, and ZIP (see cmn/cos/archive.go).
 // NOTE: compare with:
 //   - `api.CreateArchMultiObj`
 //   - `api.AppendObject`
@@ -447,6 +560,23 @@ func AppendToArch(args AppendToArchArgs) (err error) {
 	return
 }
 
+// DeleteFromArch removes archived file (`archPath`) from an existing archive (TAR, TGZ, or ZIP).
+func DeleteFromArch(bp BaseParams, bck cmn.Bck, objName, archPath string) error {
+	q := make(url.Values, 4)
+	q = bck.AddToQuery(q)
+	q.Set(apc.QparamArchpath, archPath)
+	bp.Method = http.MethodDelete
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, objName)
+		reqParams.Query = q
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // AppendObject adds a reader (`args.Reader` - e.g., an open file) to an object.
 // The API can be called multiple times - each call returns a handle
 // that may be used for subsequent append requests.
@@ -522,6 +652,28 @@ func RenameObject(bp BaseParams, bck cmn.Bck, oldName, newName string) error {
 	return err
 }
 
+// ComposeObjects concatenates existing objects, or byte ranges thereof, into a new (or
+// overwritten) destination object: sources are concatenated in the specified order and may
+// reside in different buckets (an empty source bucket means the destination bucket);
+// the destination's owning target assembles the result by pulling the sources from their
+// respective owners - no data is transferred through the client.
+// Optionally (`ComposeMsg.DeleteSrc`), deletes the sources upon success.
+// See also: cmn.ComposeMsg, cmn.ComposeMaxSources
+func ComposeObjects(bp BaseParams, bck cmn.Bck, objName string, msg *cmn.ComposeMsg) error {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, objName)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActCompose, Value: msg})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.AddToQuery(nil)
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // promote files and directories to ais objects
 func Promote(args *PromoteArgs) (xid string, err error) {
 	actMsg := apc.ActMsg{Action: apc.ActPromote, Name: args.SrcFQN}
@@ -8,11 +8,22 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/sigv4"
 )
 
+// PresignS3Args: S3 credentials (when AuthN is enabled, see authn.GetS3Creds)
+// and presigned URL's method and validity
+type PresignS3Args struct {
+	sigv4.Creds
+	Method  string        // default: GET
+	Region  string        // default: sigv4.DfltRegion
+	Expires time.Duration // default: one hour (max: 7 days)
+}
+
 // s3/<bucket-name>/<object-name>
 func GetObjectS3(bp BaseParams, bck cmn.Bck, objectName string, args ...GetArgs) (int64, error) {
 	var (
@@ -39,3 +50,28 @@ func GetObjectS3(bp BaseParams, bck cmn.Bck, objectName string, args ...GetArgs)
 	}
 	return wresp.n, nil
 }
+
+// PresignS3URL returns presigned (SigV4, query-string authenticated) URL
+// to GET, PUT, HEAD, or DELETE a given object via S3 API, e.g.:
+// $ curl -L -X PUT -T file.bin "<presigned URL>"
+func PresignS3URL(bp BaseParams, bck cmn.Bck, objName string, args *PresignS3Args) (string, error) {
+	u, err := url.Parse(bp.URL)
+	if err != nil {
+		return "", err
+	}
+	u.Path = apc.URLPathS3.Join(bck.Name, objName)
+	var (
+		method  = args.Method
+		expires = args.Expires
+	)
+	if method == "" {
+		method = http.MethodGet
+	}
+	if expires == 0 {
+		expires = time.Hour
+	}
+	if err := sigv4.Presign(u, method, &args.Creds, args.Region, expires, time.Now()); err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
@@ -78,13 +78,18 @@ func (lom *LOM) AddCopy(copyFQN string, mpi *fs.Mountpath) error {
 }
 
 func (lom *LOM) DelCopies(copiesFQN ...string) (err error) {
-	numCopies := lom.NumCopies()
+	var (
+		numCopies = lom.NumCopies()
+		mpaths    = make([]*fs.Mountpath, 0, len(copiesFQN))
+	)
 	// 1. Delete all copies from the metadata
 	for _, copyFQN := range copiesFQN {
-		if _, ok := lom.md.copies[copyFQN]; !ok {
+		mi, ok := lom.md.copies[copyFQN]
+		if !ok {
 			return fmt.Errorf("lom %s(num: %d): copy %s does not exist", lom, numCopies, copyFQN)
 		}
 		lom.delCopyMd(copyFQN)
+		mpaths = append(mpaths, mi)
 	}
 
 	// 2. Update metadata on remaining copies, if any
@@ -93,12 +98,17 @@ func (lom *LOM) DelCopies(copiesFQN ...string) (err error) {
 		return err
 	}
 
-	// 3. Remove the copies
-	for _, copyFQN := range copiesFQN {
+	// 3. Remove the copies (and their archive indexes, if any)
+	for i, copyFQN := range copiesFQN {
 		if err1 := cos.RemoveFile(copyFQN); err1 != nil {
 			glog.Error(err1) // TODO: LRU should take care of that later.
 			continue
 		}
+		if mpaths[i].Path != lom.mi.Path {
+			if err1 := cos.RemoveFile(lom.archIdxFQN(mpaths[i])); err1 != nil {
+				glog.Error(err1)
+			}
+		}
 	}
 	return
 }
@@ -257,7 +267,9 @@ add:
 		glog.Error(err)
 		return err
 	}
-	err = lom.syncMetaWithCopies()
+	if err = lom.syncMetaWithCopies(); err == nil {
+		lom.copyArchIndex(lom.archIdxFQN(mi), buf)
+	}
 	return
 }
 
@@ -283,7 +295,8 @@ func (lom *LOM) copy2fqn(dst *LOM, buf []byte) (err error) {
 		srcCksum  = lom.Checksum()
 		cksumType = cos.ChecksumNone
 	)
-	if !srcCksum.IsEmpty() {
+	// (encrypted content is copied as is - can only validate plaintext checksum upon reading)
+	if !srcCksum.IsEmpty() && !lom.IsEncrypted() {
 		cksumType = srcCksum.Ty()
 	}
 	if dst.isMirror(lom) && lom.md.copies != nil {
@@ -343,6 +356,9 @@ func (lom *LOM) copy2fqn(dst *LOM, buf []byte) (err error) {
 			glog.Errorf("nested err: %v", errRemove)
 		}
 	}
+	if err == nil {
+		lom.copyArchIndex(dst.archIdxFQN(dst.mi), buf)
+	}
 	return
 }
 
@@ -333,11 +333,11 @@ func (lom *LOM) ComputeSetCksum() (*cos.Cksum, error) {
 }
 
 func (lom *LOM) ComputeCksum(cksumType string) (cksum *cos.CksumHash, err error) {
-	var file *os.File
+	var file cos.LomReader
 	if cksumType == cos.ChecksumNone {
 		return
 	}
-	if file, err = os.Open(lom.FQN); err != nil {
+	if file, err = lom.Open(lom.FQN); err != nil {
 		return
 	}
 	// No need to allocate `buf` as `io.Discard` has efficient `io.ReaderFrom` implementation.
@@ -468,7 +468,7 @@ func (lom *LOM) FromFS() error {
 		return err
 	}
 	// fstat & atime
-	if lom.md.Size != finfo.Size() { // corruption or tampering
+	if lom.StoredSize() != finfo.Size() { // corruption or tampering
 		return cmn.NewErrLmetaCorrupted(lom.whingeSize(finfo.Size()))
 	}
 	lom.md.Atime = atimefs
@@ -477,7 +477,7 @@ func (lom *LOM) FromFS() error {
 }
 
 func (lom *LOM) whingeSize(size int64) error {
-	return fmt.Errorf("errsize (%d != %d)", lom.md.Size, size)
+	return fmt.Errorf("errsize (%d != %d)", lom.StoredSize(), size)
 }
 
 func (lom *LOM) Remove(force ...bool) (err error) {
@@ -496,6 +496,7 @@ func (lom *LOM) Remove(force ...bool) (err error) {
 			err = erc
 		}
 	}
+	lom.DelArchIndex()
 	lom.md.bckID = 0
 	return
 }
@@ -0,0 +1,362 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/transport"
+)
+
+// Archive index:
+// - maps archived files to their respective (offset, size) within the (uncompressed) TAR object;
+// - stored as fs.ArchIndexType content "<object-name>" next to the object and each of its copies;
+// - built lazily upon the first read of an archived file or, optionally, upon PUT
+//   (see feat.IndexArchOnPUT) and dSort shard creation;
+// - valid only as long as the object's size and checksum match those at the time of indexing
+//   (an index that arrives ahead of its object - e.g., via rebalance - is therefore not lost);
+// - never built for encrypted objects (the index would reveal the names of archived files);
+// - once loaded (or built), kept in memory keyed by the object's uname and validated
+//   against its size and checksum, same as the persisted one (see archIdxCache).
+// All methods below require the caller to hold the object's lock (rlock, at least).
+
+type (
+	ArchEntry struct {
+		Name   string `json:"n"`
+		Offset int64  `json:"o"` // archived file's data offset (in the object)
+		Size   int64  `json:"s"`
+	}
+	ArchIndex struct {
+		m          map[string]int // name => entry
+		CksumType  string         `json:"cksum_type,omitempty"`  // object's checksum at the time of indexing
+		CksumValue string         `json:"cksum_value,omitempty"` // ditto
+		Entries    []ArchEntry    `json:"entries"`               // in the archive order
+		Size       int64          `json:"size"`                  // object's size
+	}
+)
+
+// in-memory cache of decoded archive indexes
+type (
+	archIdxEntry struct {
+		idx *ArchIndex
+		fqn string
+	}
+	archIdxCache struct {
+		m  map[string]archIdxEntry // by uname
+		mu sync.RWMutex
+	}
+)
+
+const maxArchIdxCached = 1024
+
+var errArchIdxEncrypted = errors.New("cannot index encrypted archive")
+
+var archIdxCached = &archIdxCache{m: make(map[string]archIdxEntry, 64)}
+
+var archIdxJspOpts = jsp.CCSign(cmn.MetaverArchIndex)
+
+func (*ArchIndex) JspOpts() jsp.Options { return archIdxJspOpts }
+
+// Lookup returns the entry for a given archived file
+// (in re leading separator, see also ais/archive.go archNamesEq)
+func (idx *ArchIndex) Lookup(filename string) (*ArchEntry, bool) {
+	if idx.m == nil {
+		idx.init()
+	}
+	i, ok := idx.m[strings.TrimPrefix(filename, string(filepath.Separator))]
+	if !ok {
+		return nil, false
+	}
+	return &idx.Entries[i], true
+}
+
+// (cached indexes are shared and must be initialized prior to caching)
+func (idx *ArchIndex) init() {
+	idx.m = make(map[string]int, len(idx.Entries))
+	for i := range idx.Entries {
+		name := strings.TrimPrefix(idx.Entries[i].Name, string(filepath.Separator))
+		if _, ok := idx.m[name]; !ok { // first one wins (same as sequential scan)
+			idx.m[name] = i
+		}
+	}
+}
+
+func (idx *ArchIndex) valid(lom *LOM) bool {
+	if idx.Size != lom.SizeBytes() {
+		return false
+	}
+	cksum := lom.Checksum()
+	if cksum.IsEmpty() {
+		return idx.CksumValue == ""
+	}
+	return idx.CksumType == cksum.Ty() && idx.CksumValue == cksum.Val()
+}
+
+// NewArchIndex reads TAR headers (seeking over archived content) and returns the resulting
+// index that, in particular, includes only regular (and non-sparse) files
+func NewArchIndex(r io.ReadSeeker) (*ArchIndex, error) {
+	var (
+		idx = &ArchIndex{Entries: make([]ArchEntry, 0, 64)}
+		tr  = tar.NewReader(r)
+	)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				return idx, nil
+			}
+			return nil, err
+		}
+		if !hdr.FileInfo().Mode().IsRegular() || isSparse(hdr) {
+			continue
+		}
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		idx.Entries = append(idx.Entries, ArchEntry{Name: hdr.Name, Offset: offset, Size: hdr.Size})
+	}
+}
+
+func isSparse(hdr *tar.Header) bool {
+	if hdr.Typeflag == tar.TypeGNUSparse {
+		return true
+	}
+	for k := range hdr.PAXRecords {
+		if strings.HasPrefix(k, "GNU.sparse.") {
+			return true
+		}
+	}
+	return false
+}
+
+func (lom *LOM) archIdxFQN(mi *fs.Mountpath) string {
+	return mi.MakePathFQN(lom.Bucket(), fs.ArchIndexType, lom.ObjName)
+}
+
+// the object's mountpath goes first, followed by those of its copies, if any
+func (lom *LOM) archIdxMpaths() []*fs.Mountpath {
+	mpaths := make([]*fs.Mountpath, 0, len(lom.md.copies)+1)
+	mpaths = append(mpaths, lom.mi)
+	for _, mi := range lom.md.copies {
+		if mi.Path != lom.mi.Path {
+			mpaths = append(mpaths, mi)
+		}
+	}
+	return mpaths
+}
+
+// ArchIndexFQN returns the location of the object's valid archive index or empty string if there's none.
+func (lom *LOM) ArchIndexFQN() string {
+	_, fqn := lom.loadArchIndex()
+	return fqn
+}
+
+// ArchIndex returns the object's archive index or nil if the object has not been indexed
+// (or has changed since).
+func (lom *LOM) ArchIndex() *ArchIndex {
+	idx, _ := lom.loadArchIndex()
+	return idx
+}
+
+func (lom *LOM) loadArchIndex() (*ArchIndex, string) {
+	if idx, fqn := archIdxCached.get(lom); idx != nil {
+		return idx, fqn
+	}
+	for _, mi := range lom.archIdxMpaths() {
+		var (
+			idx = &ArchIndex{}
+			fqn = lom.archIdxFQN(mi)
+		)
+		if _, err := jsp.Load(fqn, idx, idx.JspOpts()); err != nil {
+			if !os.IsNotExist(err) {
+				glog.Errorf("%s: failed to load archive index: %v", lom, err)
+			}
+			continue
+		}
+		if idx.valid(lom) {
+			archIdxCached.put(lom, idx, fqn)
+			return idx, fqn
+		}
+		// stale (the object has been overwritten since)
+		if err := cos.RemoveFile(fqn); err != nil {
+			glog.Errorf("%s: failed to remove stale archive index: %v", lom, err)
+		}
+	}
+	return nil, ""
+}
+
+// BuildArchIndex indexes the object (that must be formatted as an uncompressed TAR)
+// and persists the result. The `fh`, if provided, is the object's open content that
+// is read (and seeked) in the process; otherwise, the object gets opened and closed.
+func (lom *LOM) BuildArchIndex(fh cos.LomReader) (idx *ArchIndex, err error) {
+	if lom.IsEncrypted() {
+		return nil, errArchIdxEncrypted
+	}
+	if fh == nil {
+		if fh, err = lom.Open(lom.FQN); err != nil {
+			return nil, err
+		}
+		defer cos.Close(fh)
+	}
+	if idx, err = NewArchIndex(fh); err != nil {
+		return nil, err
+	}
+	idx.Size = lom.SizeBytes()
+	if cksum := lom.Checksum(); !cksum.IsEmpty() {
+		idx.CksumType, idx.CksumValue = cksum.Get()
+	}
+	fqn := lom.archIdxFQN(lom.mi)
+	if err = jsp.Save(fqn, idx, idx.JspOpts(), nil); err == nil {
+		archIdxCached.put(lom, idx, fqn)
+	} else {
+		archIdxCached.del(lom)
+	}
+	return idx, err
+}
+
+// DelArchIndex removes the object's archive index from all mountpaths
+// (where it may still reside, e.g., after the object's copies were deleted).
+func (lom *LOM) DelArchIndex() {
+	archIdxCached.del(lom)
+	avail := fs.GetAvail()
+	for _, mi := range avail {
+		if err := cos.RemoveFile(lom.archIdxFQN(mi)); err != nil {
+			glog.Errorf("%s: failed to remove archive index: %v", lom, err)
+		}
+	}
+}
+
+// InvalidateArchIndex removes the object's archive index, if any, unless the latter
+// corresponds to the object's current content (in particular, remains valid upon
+// migration and copying).
+func (lom *LOM) InvalidateArchIndex() {
+	archIdxCached.del(lom)
+	avail := fs.GetAvail()
+	for _, mi := range avail {
+		var (
+			idx = &ArchIndex{}
+			fqn = lom.archIdxFQN(mi)
+		)
+		_, err := jsp.Load(fqn, idx, idx.JspOpts())
+		if os.IsNotExist(err) {
+			continue
+		}
+		// (without checksum, same size does not mean same content)
+		if err != nil || !idx.valid(lom) || lom.md.Cksum.IsEmpty() {
+			if err := cos.RemoveFile(fqn); err != nil {
+				glog.Errorf("%s: failed to remove archive index: %v", lom, err)
+			}
+		}
+	}
+}
+
+// OpenArchIndex opens the object's archive index, if any, to send it (e.g., to
+// another target upon rebalance or bucket copy) as `objName` in a given bucket.
+// Returns nil when there's no (valid) index; otherwise, the caller fills in
+// the rest of the header (opaque, opcode) and the callback, and sends the result.
+func (lom *LOM) OpenArchIndex(bck *cmn.Bck, objName string) (*transport.Obj, cos.ReadOpenCloser, error) {
+	fqn := lom.ArchIndexFQN()
+	if fqn == "" {
+		return nil, nil, nil
+	}
+	fh, err := cos.NewFileHandle(fqn)
+	if err != nil {
+		return nil, nil, err
+	}
+	finfo, err := fh.Stat()
+	if err != nil {
+		cos.Close(fh)
+		return nil, nil, err
+	}
+	o := transport.AllocSend()
+	o.Hdr.Bck.Copy(bck)
+	o.Hdr.ObjName = objName
+	o.Hdr.ObjAttrs.Size = finfo.Size()
+	return o, fh, nil
+}
+
+// copyArchIndex copies the object's archive index, if any, to a given destination
+// (best-effort: the index can always be rebuilt)
+func (lom *LOM) copyArchIndex(dstFQN string, buf []byte) {
+	srcFQN := lom.ArchIndexFQN()
+	if srcFQN == "" || srcFQN == dstFQN {
+		return
+	}
+	if _, _, err := cos.CopyFile(srcFQN, dstFQN, buf, cos.ChecksumNone); err != nil {
+		glog.Errorf("%s: failed to copy archive index: %v", lom, err)
+	}
+}
+
+// RecvArchIndex writes the received (e.g., migrated by rebalance) archive index
+// that'll be validated upon loading (see above).
+func (lom *LOM) RecvArchIndex(r io.Reader) error {
+	archIdxCached.del(lom)
+	workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileRemote)
+	fh, err := cos.CreateFile(workFQN)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fh, r)
+	cos.Close(fh)
+	if err == nil {
+		if err = cos.Rename(workFQN, lom.archIdxFQN(lom.mi)); err == nil {
+			return nil
+		}
+	}
+	if errRm := cos.RemoveFile(workFQN); errRm != nil {
+		glog.Errorf(fmtNestedErr, errRm)
+	}
+	return err
+}
+
+//////////////////
+// archIdxCache //
+//////////////////
+
+func (c *archIdxCache) get(lom *LOM) (*ArchIndex, string) {
+	c.mu.RLock()
+	e, ok := c.m[lom.Uname()]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, ""
+	}
+	// (the object may have changed and the index file gone since)
+	if !e.idx.valid(lom) || cos.Stat(e.fqn) != nil {
+		c.del(lom)
+		return nil, ""
+	}
+	return e.idx, e.fqn
+}
+
+func (c *archIdxCache) put(lom *LOM, idx *ArchIndex, fqn string) {
+	idx.init()
+	c.mu.Lock()
+	if len(c.m) >= maxArchIdxCached {
+		for uname := range c.m { // evict random
+			delete(c.m, uname)
+			break
+		}
+	}
+	c.m[lom.Uname()] = archIdxEntry{idx: idx, fqn: fqn}
+	c.mu.Unlock()
+}
+
+func (c *archIdxCache) del(lom *LOM) {
+	c.mu.Lock()
+	delete(c.m, lom.Uname())
+	c.mu.Unlock()
+}
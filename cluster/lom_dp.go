@@ -42,6 +42,16 @@ func (r *deferROC) Close() (err error) {
 
 // is called under rlock; unlocks on fail
 func (lom *LOM) NewDeferROC() (cos.ReadOpenCloser, error) {
+	fh, err := lom.Open(lom.FQN)
+	if err == nil {
+		return &deferROC{fh, lom.LIF()}, nil
+	}
+	lom.Unlock(false)
+	return nil, cmn.NewErrFailedTo(T, "open", lom.FQN, err)
+}
+
+// same as above, without decrypting (stored content as is)
+func (lom *LOM) NewDeferRawROC() (cos.ReadOpenCloser, error) {
 	fh, err := cos.NewFileHandle(lom.FQN)
 	if err == nil {
 		return &deferROC{fh, lom.LIF()}, nil
@@ -0,0 +1,101 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/sse"
+)
+
+// Encrypted objects (see sse package) carry their key ID and wrapped data key
+// in the custom metadata. Stored content is ciphertext, while the object's size
+// and checksum are those of the plaintext. Therefore:
+// - readers must use lom.Open() rather than opening lom.FQN directly;
+// - copying the file as is (e.g., mirroring) retains the encryption along with the metadata.
+
+func (lom *LOM) IsEncrypted() bool {
+	_, ok := lom.GetCustomKey(cmn.SSEKeyObjMD)
+	return ok
+}
+
+// Size of the content on disk (that is, ciphertext size when encrypted).
+func (lom *LOM) StoredSize() int64 {
+	if !lom.IsEncrypted() {
+		return lom.md.Size
+	}
+	return sse.CipherSize(lom.md.Size)
+}
+
+// Open object's content at a given location (object, its copy, or workfile)
+// and decrypt it on the fly if need be.
+func (lom *LOM) Open(fqn string) (cos.LomReader, error) {
+	if !lom.IsEncrypted() {
+		return cos.NewFileHandle(fqn)
+	}
+	kid, _ := lom.GetCustomKey(cmn.SSEKeyIDObjMD)
+	wrapped, _ := lom.GetCustomKey(cmn.SSEKeyObjMD)
+	dek, err := sse.UnwrapDEK(kid, wrapped)
+	if err != nil {
+		return nil, cmn.NewErrFailedTo(T, "decrypt", lom, err)
+	}
+	return sse.NewReader(fqn, dek, lom.SizeBytes())
+}
+
+// Same as above, limited to a given section of the (plaintext) content.
+func (lom *LOM) OpenSection(fqn string, offset, size int64) (cos.ReadOpenCloser, error) {
+	if !lom.IsEncrypted() {
+		return cos.NewFileSectionHandle(fqn, offset, size)
+	}
+	lr, err := lom.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	return newLomSection(lr, offset, size), nil
+}
+
+// Generate data key to encrypt a new object (or new version thereof);
+// returns nil when the bucket is not configured to encrypt.
+func (lom *LOM) NewDEK() (*sse.DEK, error) {
+	conf := &lom.Bprops().SSE
+	if !conf.Enabled {
+		return nil, nil
+	}
+	return sse.NewDEK(conf.KeyID)
+}
+
+func (lom *LOM) SetDEK(dek *sse.DEK) {
+	if dek == nil {
+		lom.ObjAttrs().DelCustomKeys(cmn.SSEKeyIDObjMD, cmn.SSEKeyObjMD)
+		return
+	}
+	lom.SetCustomKey(cmn.SSEKeyIDObjMD, dek.KeyID)
+	lom.SetCustomKey(cmn.SSEKeyObjMD, dek.Wrapped)
+}
+
+////////////////
+// lomSection //
+////////////////
+
+type lomSection struct {
+	*cos.SectionHandle
+	lr     cos.LomReader
+	offset int64
+	size   int64
+}
+
+func newLomSection(lr cos.LomReader, offset, size int64) *lomSection {
+	return &lomSection{cos.NewSectionHandle(lr, offset, size, 0), lr, offset, size}
+}
+
+func (s *lomSection) Open() (cos.ReadOpenCloser, error) {
+	roc, err := s.lr.Open()
+	if err != nil {
+		return nil, err
+	}
+	return newLomSection(roc.(cos.LomReader), s.offset, s.size), nil
+}
+
+func (s *lomSection) Close() error { return s.lr.Close() }
@@ -5,11 +5,13 @@
 package cluster_test
 
 import (
+	"archive/tar"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -18,6 +20,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/sse"
 	"github.com/NVIDIA/aistore/tools/cryptorand"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -72,6 +75,9 @@ var _ = Describe("LOM", func() {
 	_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
 	_ = fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
 
+	sse.Reg(newTestKeys())
+	_ = sse.Activate(testKeysName)
+
 	bmd := mock.NewBaseBownerMock(
 		cluster.NewBck(
 			bucketLocalA, apc.AIS, cmn.NsGlobal,
@@ -542,6 +548,33 @@ var _ = Describe("LOM", func() {
 					Expect(lom2.SizeBytes()).To(BeEquivalentTo(testFileSize))
 					Expect(time.Unix(0, lom2.AtimeUnix()).After(startTime)).To(BeTrue())
 				})
+
+				It("should load encrypted object", func() {
+					plain := make([]byte, testFileSize)
+					_, _ = cryptorand.Read(plain)
+					dek, err := sse.NewDEK("")
+					Expect(err).NotTo(HaveOccurred())
+					createEncFile(localFQN, dek, plain)
+
+					lom1 := NewBasicLom(localFQN)
+					lom1.SetSize(int64(len(plain)))
+					lom1.SetDEK(dek)
+					lom1.IncVersion()
+					Expect(persist(lom1)).NotTo(HaveOccurred())
+					lom1.Uncache(false)
+
+					lom2 := NewBasicLom(localFQN)
+					Expect(lom2.Load(false, false)).NotTo(HaveOccurred()) // Calls `FromFS`.
+					Expect(lom2.IsEncrypted()).To(BeTrue())
+					Expect(lom2.SizeBytes()).To(BeEquivalentTo(len(plain)))
+
+					r, err := lom2.Open(lom2.FQN)
+					Expect(err).NotTo(HaveOccurred())
+					content, err := io.ReadAll(r)
+					r.Close()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(content).To(Equal(plain))
+				})
 			})
 		})
 
@@ -993,6 +1026,129 @@ var _ = Describe("LOM", func() {
 		})
 	})
 
+	Describe("archive index", func() {
+		const objName = "shards/shard-0.tar"
+		var files = map[string]int{"a.txt": 10, "dir/b.bin": 1000, "c": 0, "d/e/f.jpg": 513}
+
+		createTar := func(fqn string) {
+			fh, err := cos.CreateFile(fqn)
+			Expect(err).NotTo(HaveOccurred())
+			tw := tar.NewWriter(fh)
+			for name, size := range files {
+				hdr := &tar.Header{Typeflag: tar.TypeReg, Name: name, Size: int64(size), Mode: int64(cos.PermRWR)}
+				Expect(tw.WriteHeader(hdr)).NotTo(HaveOccurred())
+				_, err = tw.Write([]byte(strings.Repeat(name[:1], size)))
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(tw.Close()).NotTo(HaveOccurred())
+			Expect(fh.Close()).NotTo(HaveOccurred())
+		}
+		prepareTar := func(bck cmn.Bck) *cluster.LOM {
+			lom := &cluster.LOM{ObjName: objName}
+			Expect(lom.InitBck(&bck)).NotTo(HaveOccurred())
+			createTar(lom.FQN)
+			finfo, err := os.Stat(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			lom.SetSize(finfo.Size())
+			_, err = lom.ComputeSetCksum()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			return lom
+		}
+
+		It("should build, persist, and look up archived files", func() {
+			lom := prepareTar(localBckB)
+			lom.Lock(false)
+			defer lom.Unlock(false)
+			Expect(lom.ArchIndex()).To(BeNil())
+
+			idx, err := lom.BuildArchIndex(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(idx.Entries).To(HaveLen(len(files)))
+
+			idx = lom.ArchIndex()
+			Expect(idx).NotTo(BeNil())
+			fh, err := os.Open(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			defer fh.Close()
+			for name, size := range files {
+				e, ok := idx.Lookup("/" + name)
+				Expect(ok).To(BeTrue())
+				Expect(e.Size).To(BeEquivalentTo(size))
+				b := make([]byte, size)
+				_, err := fh.ReadAt(b, e.Offset)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal(strings.Repeat(name[:1], size)))
+			}
+			_, ok := idx.Lookup("nonexistent")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should keep the index valid only as long as the content does not change", func() {
+			lom := prepareTar(localBckB)
+			lom.Lock(true)
+			defer lom.Unlock(true)
+			_, err := lom.BuildArchIndex(nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			// same content (e.g., migrated): remains valid
+			lom.InvalidateArchIndex()
+			Expect(lom.ArchIndexFQN()).NotTo(BeEmpty())
+
+			// different content
+			createTestFile(lom.FQN, 1024)
+			lom.SetSize(1024)
+			_, err = lom.ComputeSetCksum()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lom.ArchIndex()).To(BeNil())
+
+			_, err = lom.BuildArchIndex(nil)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should cache the decoded index and open it for sending", func() {
+			lom := prepareTar(localBckB)
+			lom.Lock(false)
+			defer lom.Unlock(false)
+			built, err := lom.BuildArchIndex(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lom.ArchIndex()).To(BeIdenticalTo(built))
+			Expect(lom.ArchIndex()).To(BeIdenticalTo(built))
+
+			o, fh, err := lom.OpenArchIndex(&localBckA, "renamed")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(o).NotTo(BeNil())
+			defer fh.Close()
+			finfo, err := os.Stat(lom.ArchIndexFQN())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(o.Hdr.ObjAttrs.Size).To(Equal(finfo.Size()))
+			Expect(o.Hdr.ObjName).To(Equal("renamed"))
+			Expect(o.Hdr.Bck.Equal(&localBckA)).To(BeTrue())
+
+			lom.DelArchIndex()
+			Expect(lom.ArchIndex()).To(BeNil())
+			o, _, err = lom.OpenArchIndex(&localBckA, "renamed")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(o).To(BeNil())
+		})
+
+		It("should copy the index along with the object", func() {
+			lom := prepareTar(localBckB)
+			lom.Lock(true)
+			defer lom.Unlock(true)
+			_, err := lom.BuildArchIndex(nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			dst, err := lom.Copy2FQN(mis[0].MakePathFQN(&localBckA, fs.ObjectType, objName), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dst.ArchIndexFQN()).NotTo(BeEmpty())
+			cluster.FreeLOM(dst)
+
+			lom.DelArchIndex()
+			Expect(lom.ArchIndexFQN()).To(BeEmpty())
+		})
+	})
+
 	Describe("local and cloud bucket with the same name", func() {
 		It("should have different fqn", func() {
 			testObject := "foldr/test-obj.ext"
@@ -1071,6 +1227,39 @@ func createTestFile(fqn string, size int) {
 	}
 }
 
+func createEncFile(fqn string, dek *sse.DEK, plain []byte) {
+	_ = os.Remove(fqn)
+	fh, err := cos.CreateFile(fqn)
+	Expect(err).ShouldNot(HaveOccurred())
+	w, err := sse.NewWriter(fh, dek.Key)
+	Expect(err).ShouldNot(HaveOccurred())
+	_, err = w.Write(plain)
+	Expect(err).ShouldNot(HaveOccurred())
+	Expect(w.Close()).ShouldNot(HaveOccurred())
+	Expect(fh.Close()).ShouldNot(HaveOccurred())
+}
+
+// key provider with a single (random) KEK
+const testKeysName = "test"
+
+type testKeys struct {
+	kek []byte
+}
+
+func newTestKeys() *testKeys {
+	kek := make([]byte, sse.KeySize)
+	_, _ = cryptorand.Read(kek)
+	return &testKeys{kek: kek}
+}
+
+func (*testKeys) Name() string { return testKeysName }
+
+func (tk *testKeys) WrapKey(_ string, dek []byte) ([]byte, error) { return sse.Seal(tk.kek, dek) }
+
+func (tk *testKeys) UnwrapKey(_ string, wrapped []byte) ([]byte, error) {
+	return sse.Open(tk.kek, wrapped)
+}
+
 func getTestFileHash(fqn string) (hash string) {
 	reader, _ := os.Open(fqn)
 	_, cksum, err := cos.CopyAndChecksum(io.Discard, reader, nil, cos.ChecksumXXHash)
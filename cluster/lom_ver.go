@@ -0,0 +1,477 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Non-current versions (see cmn.VersionConf.Retain):
+// - stored as fs.VersionType content "<object-name>.v/<version>" on the object's mountpath(s),
+//   each with the object's metadata (xattr) at the time it was replaced;
+// - the file's mtime is the time the version became non-current;
+// - in mirrored buckets, each version has as many local copies as the object itself;
+// - delete marker is a zero-size current version with cmn.DelMarkerObjMD custom key.
+// All methods below that modify versions require the caller to hold the object's wlock.
+
+type LomVer struct {
+	cmn.ObjAttrs
+	FQN        string
+	Noncurrent int64 // when the version became non-current (Unix nanoseconds)
+	Copies     int   // number of local copies (mountpaths that have it)
+}
+
+func (v *LomVer) Version() string { return v.Ver }
+
+func (v *LomVer) IsDelMarker() bool {
+	_, ok := v.GetCustomKey(cmn.DelMarkerObjMD)
+	return ok
+}
+
+func (lom *LOM) IsDelMarker() bool {
+	_, ok := lom.GetCustomKey(cmn.DelMarkerObjMD)
+	return ok
+}
+
+func (lom *LOM) verFQN(mi *fs.Mountpath, ver string) string {
+	base := fs.CSM.Resolver(fs.VersionType).GenUniqueFQN(lom.ObjName, ver)
+	return mi.MakePathFQN(lom.Bucket(), fs.VersionType, base)
+}
+
+// ArchiveCurrent moves the current object (and its mirrored copies, if any)
+// into non-current versions. Returns the version of the archived object
+// or empty string if there's no current object.
+func (lom *LOM) ArchiveCurrent() (string, error) {
+	cur := AllocLOM(lom.ObjName)
+	defer FreeLOM(cur)
+	if err := cur.InitBck(lom.Bucket()); err != nil {
+		return "", err
+	}
+	if err := cur.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	ver := cur.md.Ver
+	if ver == "" {
+		ver = "0" // (written prior to enabling versioning)
+	}
+	cur.Uncache(true /*delDirty*/)
+	if cur.md.isDirty() {
+		// write-delayed: the metadata must travel with the version
+		buf, mm := cur.marshal()
+		err := fs.SetXattr(cur.FQN, XattrLOM, buf)
+		mm.Free(buf)
+		if err != nil {
+			return "", err
+		}
+	}
+	var (
+		now    = time.Now()
+		atime  = time.Unix(0, cur.md.Atime)
+		copies = cur.md.copies
+	)
+	if len(copies) == 0 {
+		copies = fs.MPI{cur.FQN: cur.mi}
+	}
+	for fqn, mi := range copies {
+		vfqn := cur.verFQN(mi, ver)
+		if err := cos.Rename(fqn, vfqn); err != nil {
+			if fqn != cur.FQN && os.IsNotExist(err) {
+				continue
+			}
+			return "", err
+		}
+		if err := os.Chtimes(vfqn, atime, now); err != nil {
+			return "", err
+		}
+	}
+	lom.md.copies = nil // (moved)
+	return ver, nil
+}
+
+// Versions returns the object's non-current versions sorted from oldest to newest.
+// All available mountpaths are checked (versions may temporarily reside elsewhere -
+// e.g., after a mountpath was added or mirroring was disabled).
+func (lom *LOM) Versions() ([]*LomVer, error) {
+	var (
+		vers  = make([]*LomVer, 0, 4)
+		avail = fs.GetAvail()
+	)
+	if err := lom._versions(lom.mi, &vers, nil); err != nil {
+		return nil, err
+	}
+	for _, mi := range avail {
+		if mi.Path == lom.mi.Path {
+			continue
+		}
+		if err := lom._versions(mi, &vers, vers); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(vers, func(i, j int) bool { return lessVer(vers[i].Ver, vers[j].Ver) })
+	return vers, nil
+}
+
+func (lom *LOM) _versions(mi *fs.Mountpath, vers *[]*LomVer, seen []*LomVer) error {
+	dir := filepath.Dir(lom.verFQN(mi, "0"))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+outer:
+	for _, de := range entries {
+		name := de.Name()
+		if de.IsDir() || !isVer(name) {
+			continue
+		}
+		for _, v := range seen {
+			if v.Ver == name {
+				v.Copies++
+				continue outer // (mirrored)
+			}
+		}
+		v, err := lom.loadVer(filepath.Join(dir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		*vers = append(*vers, v)
+	}
+	return nil
+}
+
+// (version IDs are decimal numbers - see lom.IncVersion)
+func isVer(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// numeric comparison of two version IDs
+func lessVer(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+func (lom *LOM) loadVer(fqn string) (*LomVer, error) {
+	vlom := AllocLOM(lom.ObjName)
+	defer FreeLOM(vlom)
+	vlom.bck, vlom.mi, vlom.FQN, vlom.HrwFQN = lom.bck, lom.mi, fqn, lom.HrwFQN
+	vlom.md.uname = lom.md.uname
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		return nil, err
+	}
+	if err := vlom.FromFS(); err != nil {
+		return nil, err
+	}
+	return &LomVer{ObjAttrs: vlom.md.ObjAttrs, FQN: fqn, Noncurrent: finfo.ModTime().UnixNano(), Copies: 1}, nil
+}
+
+// LoadVersion returns a given non-current version (from any mountpath that has it).
+func (lom *LOM) LoadVersion(ver string) (*LomVer, error) {
+	if !isVer(ver) {
+		return nil, cmn.NewErrNotFound("%s: version %q", lom, ver)
+	}
+	if v, err := lom.loadVer(lom.verFQN(lom.mi, ver)); err == nil || !os.IsNotExist(err) {
+		return v, err
+	}
+	for _, mi := range fs.GetAvail() {
+		if mi.Path == lom.mi.Path {
+			continue
+		}
+		if v, err := lom.loadVer(lom.verFQN(mi, ver)); err == nil || !os.IsNotExist(err) {
+			return v, err
+		}
+	}
+	return nil, cmn.NewErrNotFound("%s: version %q", lom, ver)
+}
+
+// LatestVersion returns the most recent non-current version ID, if any.
+func (lom *LOM) LatestVersion() (string, error) {
+	vers, err := lom.Versions()
+	if err != nil || len(vers) == 0 {
+		return "", err
+	}
+	return vers[len(vers)-1].Ver, nil
+}
+
+// OpenVersion opens (and decrypts, if need be) the content of a given version.
+func (lom *LOM) OpenVersion(v *LomVer) (cos.LomReader, error) {
+	vlom := AllocLOM(lom.ObjName)
+	defer FreeLOM(vlom)
+	vlom.bck = lom.bck
+	vlom.md.ObjAttrs = v.ObjAttrs
+	return vlom.Open(v.FQN)
+}
+
+// MirrorVersions makes sure that each non-current version has exactly `copies`
+// local copies (or as many as there are available mountpaths) - the same number
+// as the object itself (see mirror package); returns the number of bytes copied.
+func (lom *LOM) MirrorVersions(copies int, buf []byte) (size int64, err error) {
+	vers, err := lom.Versions()
+	if err != nil || len(vers) == 0 {
+		return 0, err
+	}
+	var (
+		avail = fs.GetAvail()
+		have  = make([]*fs.Mountpath, 0, len(avail))
+	)
+	for _, v := range vers {
+		have = have[:0]
+		for _, mi := range avail {
+			if err := cos.Stat(lom.verFQN(mi, v.Ver)); err == nil {
+				have = append(have, mi)
+			}
+		}
+		if len(have) == 0 {
+			continue // (deleted in the meantime)
+		}
+		// remove extra copies, starting with the ones that are not on the object's mountpath
+		for i := len(have) - 1; i >= 0 && len(have) > copies; i-- {
+			if have[i].Path == lom.mi.Path && len(have) > 1 {
+				continue
+			}
+			if err := cos.RemoveFile(lom.verFQN(have[i], v.Ver)); err != nil {
+				return size, err
+			}
+			have = append(have[:i], have[i+1:]...)
+		}
+		// add missing copies
+		for len(have) < copies {
+			mi := leastUtilNot(avail, have)
+			if mi == nil {
+				break
+			}
+			n, err := lom.copyVer(v, lom.verFQN(have[0], v.Ver), mi, buf)
+			if err != nil {
+				return size, err
+			}
+			size += n
+			have = append(have, mi)
+		}
+	}
+	return size, nil
+}
+
+// copy the version's file, including its metadata and mtime (the time it became non-current)
+func (lom *LOM) copyVer(v *LomVer, srcFQN string, mi *fs.Mountpath, buf []byte) (int64, error) {
+	var (
+		dstFQN  = lom.verFQN(mi, v.Ver)
+		workFQN = mi.MakePathFQN(lom.Bucket(), fs.WorkfileType, fs.WorkfileCopy+"."+filepath.Base(filepath.Dir(dstFQN))+"."+v.Ver)
+	)
+	md, err := fs.GetXattr(srcFQN, XattrLOM)
+	if err != nil {
+		return 0, err
+	}
+	n, _, err := cos.CopyFile(srcFQN, workFQN, buf, cos.ChecksumNone)
+	if err != nil {
+		return 0, err
+	}
+	if err = fs.SetXattr(workFQN, XattrLOM, md); err == nil {
+		if err = cos.Rename(workFQN, dstFQN); err == nil {
+			mtime := time.Unix(0, v.Noncurrent)
+			return n, os.Chtimes(dstFQN, mtime, mtime)
+		}
+	}
+	if errRm := cos.RemoveFile(workFQN); errRm != nil {
+		glog.Errorf(fmtNestedErr, errRm)
+	}
+	return 0, err
+}
+
+// the least utilized available mountpath that's not in the `exclude` list
+func leastUtilNot(avail fs.MPI, exclude []*fs.Mountpath) (mi *fs.Mountpath) {
+	var (
+		mpathUtils = fs.GetAllMpathUtils()
+		minUtil    = int64(101) // to motivate the first assignment
+	)
+outer:
+	for mpath, mpathInfo := range avail {
+		if mpathInfo.IsAnySet(fs.FlagWaitingDD) {
+			continue
+		}
+		for _, ex := range exclude {
+			if ex.Path == mpath {
+				continue outer
+			}
+		}
+		if util := mpathUtils.Get(mpath); util < minUtil {
+			minUtil, mi = util, mpathInfo
+		}
+	}
+	return
+}
+
+// DelVersion removes a given non-current version from all mountpaths.
+func (lom *LOM) DelVersion(ver string) error {
+	if !isVer(ver) {
+		return cmn.NewErrNotFound("%s: version %q", lom, ver)
+	}
+	var found bool
+	for _, mi := range fs.GetAvail() {
+		fqn := lom.verFQN(mi, ver)
+		err := os.Remove(fqn)
+		switch {
+		case err == nil:
+			found = true
+			os.Remove(filepath.Dir(fqn)) // ok to fail when not empty
+		case !os.IsNotExist(err):
+			return err
+		}
+	}
+	if !found {
+		return cmn.NewErrNotFound("%s: version %q", lom, ver)
+	}
+	return nil
+}
+
+// RestoreVersion makes a given non-current version current (there must be no
+// current object at this point); other copies of the version are removed.
+func (lom *LOM) RestoreVersion(ver string) error {
+	v, err := lom.LoadVersion(ver)
+	if err != nil {
+		return err
+	}
+	if err := cos.Rename(v.FQN, lom.FQN); err != nil {
+		return err
+	}
+	for _, mi := range fs.GetAvail() {
+		if fqn := lom.verFQN(mi, ver); fqn != v.FQN {
+			if err := cos.RemoveFile(fqn); err != nil {
+				glog.Error(err)
+			}
+		}
+	}
+	// reload and drop (no longer valid) copies
+	lom.Uncache(true /*delDirty*/)
+	if err := lom.FromFS(); err != nil {
+		return err
+	}
+	lom.md.copies = nil
+	lom.md.bckID = lom.Bprops().BID
+	if lom.md.Atime == 0 {
+		lom.md.Atime = time.Now().UnixNano()
+	}
+	return lom.Persist()
+}
+
+// PruneVersions removes non-current versions beyond the configured retention
+// (count and/or time); returns the number of remaining versions.
+func (lom *LOM) PruneVersions() (int, error) {
+	conf := lom.VersionConf()
+	vers, err := lom.Versions()
+	if err != nil {
+		return 0, err
+	}
+	var (
+		now    = time.Now().UnixNano()
+		remain = len(vers)
+	)
+	for i := len(vers) - 1; i >= 0; i-- {
+		var (
+			v   = vers[i]
+			nth = len(vers) - 1 - i // 0 is the newest
+		)
+		if (conf.Retain > 0 && nth >= conf.Retain) || (conf.RetainTime > 0 && now-v.Noncurrent > int64(conf.RetainTime)) {
+			if lom.verLocked(v) {
+				continue
+			}
+			if err := lom.DelVersion(v.Ver); err != nil && !cmn.IsErrNotFound(err) {
+				return remain, err
+			}
+			remain--
+		}
+	}
+	debug.Assert(remain >= 0)
+	return remain, nil
+}
+
+// versions under retention or legal hold are never removed (see cmn.ObjLockConf)
+func (lom *LOM) verLocked(v *LomVer) bool {
+	return lom.Bprops().ObjLock.Enabled && v.CheckLocked(lom.Cname(), time.Now(), false) != nil
+}
+
+// DelNoncurrent removes non-current versions that became non-current before `before`;
+// returns the number of removed versions and their total size.
+func (lom *LOM) DelNoncurrent(before time.Time) (n int, size int64, err error) {
+	vers, err := lom.Versions()
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, v := range vers {
+		if v.Noncurrent >= before.UnixNano() || lom.verLocked(v) {
+			continue
+		}
+		if err := lom.DelVersion(v.Ver); err != nil && !cmn.IsErrNotFound(err) {
+			return n, size, err
+		}
+		n++
+		size += v.Size
+	}
+	return n, size, nil
+}
+
+// RecvVersion writes a received (e.g., migrated by rebalance) non-current version.
+// The content is written as is (in particular, it remains encrypted when the
+// bucket has SSE enabled).
+func (lom *LOM) RecvVersion(r io.Reader, attrs *cmn.ObjAttrs, noncurrent int64) error {
+	if !isVer(attrs.Ver) {
+		return fmt.Errorf("%s: invalid version %q", lom, attrs.Ver)
+	}
+	workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileRemote)
+	fh, err := cos.CreateFile(workFQN)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fh, r)
+	cos.Close(fh)
+	if err == nil {
+		vlom := AllocLOM(lom.ObjName)
+		vlom.md.ObjAttrs = *attrs
+		vlom.md.uname = lom.md.uname
+		buf, mm := vlom.marshal()
+		err = fs.SetXattr(workFQN, XattrLOM, buf)
+		mm.Free(buf)
+		FreeLOM(vlom)
+	}
+	if err == nil {
+		vfqn := lom.verFQN(lom.mi, attrs.Ver)
+		if err = cos.Rename(workFQN, vfqn); err == nil {
+			mtime := time.Unix(0, noncurrent)
+			return os.Chtimes(vfqn, mtime, mtime)
+		}
+	}
+	if errRm := cos.RemoveFile(workFQN); errRm != nil {
+		glog.Errorf("nested error: %v (%v)", errRm, err)
+	}
+	return err
+}
@@ -118,6 +118,7 @@ type (
 		WorkTag    string // (=> work fqn)
 		OWT        cmn.OWT
 		SkipEncode bool // don't run erasure-code when finalizing
+		Sealed     bool // content is encrypted with the LOM's data key (see sse) - store as is
 	}
 	CopyObjectParams struct {
 		DM        DataMover
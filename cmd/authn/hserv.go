@@ -92,8 +92,10 @@ func (h *hserv) tokenHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodDelete:
 		h.httpRevokeToken(w, r)
+	case http.MethodPost:
+		h.httpS3Creds(w, r)
 	default:
-		cmn.WriteErr405(w, r, http.MethodDelete)
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodPost)
 	}
 }
 
@@ -133,6 +135,24 @@ func (h *hserv) httpRevokeToken(w http.ResponseWriter, r *http.Request) {
 	h.mgr.revokeToken(msg.Token)
 }
 
+// Exchanges the token (from the request header) for S3 credentials
+func (h *hserv) httpS3Creds(w http.ResponseWriter, r *http.Request) {
+	if _, err := checkRESTItems(w, r, 0, apc.URLPathTokens.L); err != nil {
+		return
+	}
+	token, err := tok.ExtractToken(r.Header)
+	if err != nil {
+		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
+		return
+	}
+	creds, err := h.mgr.issueS3Creds(token)
+	if err != nil {
+		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, creds, "s3 credentials")
+}
+
 func (h *hserv) httpUserDel(w http.ResponseWriter, r *http.Request) {
 	apiItems, err := checkRESTItems(w, r, 1, apc.URLPathUsers.L)
 	if err != nil {
@@ -258,6 +278,10 @@ func validateAdminPerms(w http.ResponseWriter, r *http.Request) error {
 		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
 		return err
 	}
+	if tk.S3 {
+		cmn.WriteErr(w, r, tok.ErrS3Token, http.StatusUnauthorized)
+		return tok.ErrS3Token
+	}
 	if tk.Expires.Before(time.Now()) {
 		err := fmt.Errorf("not authorized: %s", tk)
 		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
@@ -436,6 +436,37 @@ func (m *mgr) issueToken(userID, pwd string, msg *authn.LoginMsg) (string, error
 	return token, err
 }
 
+// Issues S3 credentials in exchange for a valid (non-expired and not revoked)
+// token: the user's identity, permissions, and expiration remain the same.
+// S3 credentials are revoked by revoking their session token.
+func (m *mgr) issueS3Creds(token string) (*authn.S3Creds, error) {
+	secret := Conf.Secret()
+	tk, err := tok.DecryptToken(token, secret)
+	if err != nil {
+		return nil, err
+	}
+	if tk.S3 {
+		return nil, tok.ErrS3Token
+	}
+	if tk.Expires.Before(time.Now()) {
+		return nil, fmt.Errorf("%v: %s", tok.ErrTokenExpired, tk)
+	}
+	if _, err := m.db.GetString(revokedCollection, token); err == nil {
+		return nil, fmt.Errorf("%v: %s", tok.ErrTokenRevoked, tk)
+	}
+	sessionToken, err := tok.IssueS3JWT(tk, secret)
+	if err != nil {
+		return nil, err
+	}
+	creds := &authn.S3Creds{
+		AccessKeyID:     tk.UserID,
+		SecretAccessKey: tok.S3Secret(sessionToken, secret),
+		SessionToken:    sessionToken,
+		Expires:         tk.Expires,
+	}
+	return creds, nil
+}
+
 // Before putting a list of cluster permissions to a token, cluster aliases
 // must be replaced with their IDs.
 func (m *mgr) fixClusterIDs(lst []*authn.CluACL) {
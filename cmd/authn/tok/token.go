@@ -6,6 +6,9 @@
 package tok
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
@@ -26,6 +29,7 @@ type Token struct {
 	ClusterACLs []*authn.CluACL `json:"clusters"`
 	BucketACLs  []*authn.BckACL `json:"buckets,omitempty"`
 	IsAdmin     bool            `json:"admin"`
+	S3          bool            `json:"s3,omitempty"` // S3 session token (see IssueS3JWT)
 }
 
 var (
@@ -35,6 +39,7 @@ var (
 	ErrNoBearerToken = errors.New("invalid token: no bearer")
 	ErrTokenExpired  = errors.New("token expired")
 	ErrTokenRevoked  = errors.New("token revoked")
+	ErrS3Token       = errors.New("invalid token: S3 session token cannot be used as bearer")
 )
 
 func IssueAdminJWT(expires time.Time, userID, secret string) (string, error) {
@@ -57,6 +62,34 @@ func IssueJWT(expires time.Time, userID string, bucketACLs []*authn.BckACL, clus
 	return t.SignedString([]byte(secret))
 }
 
+// IssueS3JWT issues S3 session token - a copy of the user's token (same identity,
+// permissions, and expiration) marked as such. The session token is only valid
+// within S3 requests that are signed with the corresponding secret (see S3Secret).
+func IssueS3JWT(tk *Token, secret string) (string, error) {
+	claims := jwt.MapClaims{
+		"expires":  tk.Expires,
+		"username": tk.UserID,
+		"s3":       true,
+	}
+	if tk.IsAdmin {
+		claims["admin"] = true
+	} else {
+		claims["buckets"] = tk.BucketACLs
+		claims["clusters"] = tk.ClusterACLs
+	}
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return t.SignedString([]byte(secret))
+}
+
+// S3Secret derives S3 secret access key from the session token. Both AuthN
+// (issuing S3 credentials) and AIS gateways (verifying S3 signatures) share
+// the `secret`, and so S3 credentials do not need to be stored or distributed.
+func S3Secret(sessionToken, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("s3:" + sessionToken))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
 // Header format: 'Authorization: Bearer <token>'
 func ExtractToken(hdr http.Header) (string, error) {
 	s := hdr.Get(apc.HdrAuthorization)
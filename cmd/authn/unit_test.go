@@ -178,6 +178,21 @@ func TestToken(t *testing.T) {
 		t.Errorf("Invalid user %s returned for token of %s", info.UserID, users[1])
 	}
 
+	// S3 credentials
+	creds, err := mgr.issueS3Creds(token)
+	tassert.CheckFatal(t, err)
+	s3tk, err := tok.DecryptToken(creds.SessionToken, secret)
+	tassert.CheckFatal(t, err)
+	if !s3tk.S3 || s3tk.UserID != users[1] || creds.AccessKeyID != users[1] || !s3tk.Expires.Equal(info.Expires) {
+		t.Errorf("Invalid S3 session token %+v for %+v", s3tk, info)
+	}
+	if creds.SecretAccessKey != tok.S3Secret(creds.SessionToken, secret) {
+		t.Errorf("S3 secret mismatch")
+	}
+	if _, err := mgr.issueS3Creds(creds.SessionToken); err == nil {
+		t.Errorf("Issued S3 credentials in exchange for S3 session token")
+	}
+
 	// incorrect user creds
 	loginMsg = &authn.LoginMsg{}
 	tokenInval, err := mgr.issueToken(users[1], passs[0], loginMsg)
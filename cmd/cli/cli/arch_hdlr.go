@@ -7,11 +7,15 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/urfave/cli"
 )
 
@@ -29,15 +33,22 @@ var (
 		cmdAppend: {
 			archpathRequiredFlag,
 		},
+		commandRemove: {
+			archpathRequiredFlag,
+		},
 		cmdList: {
 			objPropsFlag,
 			allPropsFlag,
 		},
+		cmdGetBatch: {
+			batchSpecFlag,
+			yesFlag,
+		},
 	}
 
 	archCmd = cli.Command{
 		Name:  commandArch,
-		Usage: "Create multi-object archive, append files to an existing archive",
+		Usage: "Create multi-object archive, append (or remove) files to (from) an existing archive",
 		Subcommands: []cli.Command{
 			{
 				Name:         commandCreate,
@@ -49,13 +60,23 @@ var (
 			},
 			{
 				Name: cmdAppend,
-				Usage: "append file to an existing .tar archive, e.g.: " +
-					"'append src-filename bucket/shard.tar --archpath dst-name'",
+				Usage: "append file to an existing (" + cos.ExtTar + ", " + cos.ExtTgz + ", " + cos.ExtZip + ") archive\n" +
+					indent4 + "\t(replacing archived file with the same name, if exists), e.g.:\n" +
+					indent4 + "\t'append src-filename bucket/shard.tar --archpath dst-name'",
 				ArgsUsage:    appendToArchArgument,
 				Flags:        archCmdsFlags[cmdAppend],
 				Action:       appendArchHandler,
 				BashComplete: putPromoteObjectCompletions,
 			},
+			{
+				Name: commandRemove,
+				Usage: "remove archived file from an existing (" + cos.ExtTar + ", " + cos.ExtTgz + ", " + cos.ExtZip + ") archive, e.g.:\n" +
+					indent4 + "\t'rm bucket/shard.tar --archpath labels/001.cls'",
+				ArgsUsage:    objectArgument,
+				Flags:        archCmdsFlags[commandRemove],
+				Action:       rmArchHandler,
+				BashComplete: bucketCompletions(bcmplop{separator: true}),
+			},
 			{
 				Name:         cmdList,
 				Usage:        "list archived content",
@@ -64,6 +85,17 @@ var (
 				Action:       listArchHandler,
 				BashComplete: bucketCompletions(bcmplop{}),
 			},
+			{
+				Name: cmdGetBatch,
+				Usage: "get multiple objects and/or archived files (possibly, from different buckets) as a single\n" +
+					indent4 + "\t(" + cos.ExtTar + " or " + cos.ExtMsgpack + ", depending on the destination) archive, e.g.:\n" +
+					indent4 + "\t'get-batch ais://abc/1.jpg gs://xyz/2.jpg /tmp/batch.tar' or\n" +
+					indent4 + "\t'get-batch --spec /tmp/spec.json - | tar tv'",
+				ArgsUsage:    getBatchArgument,
+				Flags:        archCmdsFlags[cmdGetBatch],
+				Action:       getBatchHandler,
+				BashComplete: bucketCompletions(bcmplop{separator: true}),
+			},
 		},
 	}
 )
@@ -112,6 +144,22 @@ func appendArchHandler(c *cli.Context) error {
 	return nil
 }
 
+func rmArchHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	bck, objName, err := parseBckObjectURI(c, c.Args().First(), false /*optional objName*/)
+	if err != nil {
+		return err
+	}
+	archPath := parseStrFlag(c, archpathRequiredFlag)
+	if err := api.DeleteFromArch(apiBP, bck, objName, archPath); err != nil {
+		return err
+	}
+	actionDone(c, fmt.Sprintf("Removed %q from %s\n", archPath, bck.Cname(objName)))
+	return nil
+}
+
 func listArchHandler(c *cli.Context) error {
 	bck, objName, err := parseBckObjectURI(c, c.Args().First(), true)
 	if err != nil {
@@ -119,3 +167,64 @@ func listArchHandler(c *cli.Context) error {
 	}
 	return listObjects(c, bck, objName, true /*list arch*/)
 }
+
+func getBatchHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	var (
+		msg     = &cmn.GetBatchMsg{}
+		outFile = c.Args().Get(c.NArg() - 1)
+		uris    = c.Args()[:c.NArg()-1]
+	)
+	if flagIsSet(c, batchSpecFlag) {
+		b, err := os.ReadFile(parseStrFlag(c, batchSpecFlag))
+		if err != nil {
+			return err
+		}
+		if err := jsoniter.Unmarshal(b, msg); err != nil {
+			return fmt.Errorf("invalid %s: %v", flprn(batchSpecFlag), err)
+		}
+	}
+	for _, uri := range uris {
+		bck, objName, err := parseBckObjectURI(c, uri, false /*optional objName*/)
+		if err != nil {
+			return err
+		}
+		msg.Entries = append(msg.Entries, cmn.GetBatchEntry{Bck: bck, ObjName: objName})
+	}
+	if len(msg.Entries) == 0 {
+		return missingArgumentsError(c, "objects to read (as "+objectArgument+" or via "+flprn(batchSpecFlag)+")")
+	}
+
+	// output format: by destination's extension unless specified
+	if msg.Mime == "" && strings.HasSuffix(outFile, cos.ExtMsgpack) {
+		msg.Mime = cos.ExtMsgpack
+	}
+
+	var w io.Writer = os.Stdout
+	if outFile != fileStdIO {
+		if finfo, err := os.Stat(outFile); err == nil && finfo.Mode().IsRegular() && !flagIsSet(c, yesFlag) {
+			if ok := confirm(c, fmt.Sprintf("overwrite existing %q", outFile)); !ok {
+				return nil
+			}
+		}
+		file, err := os.Create(outFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	n, err := api.GetBatch(apiBP, msg, w)
+	if err != nil {
+		if outFile != fileStdIO {
+			os.Remove(outFile)
+		}
+		return err
+	}
+	if outFile != fileStdIO {
+		actionDone(c, fmt.Sprintf("GET batch (%d entries) => %q (size %s)\n", len(msg.Entries), outFile, cos.ToSizeIEC(n, 2)))
+	}
+	return nil
+}
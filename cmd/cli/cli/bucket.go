@@ -376,8 +376,15 @@ func listObjects(c *cli.Context, bck cmn.Bck, prefix string, listArch bool) erro
 	if flagIsSet(c, listAnonymousFlag) {
 		msg.SetFlag(apc.LsTryHeadRemote)
 	}
+	if flagIsSet(c, listCustomFilterFlag) {
+		// filtering by tags and custom metadata selects in-cluster objects only
+		msg.CustomFilter = parseStrFlag(c, listCustomFilterFlag)
+		addCachedCol = false
+	}
 	if listArch {
 		msg.SetFlag(apc.LsArchDir)
+		// select archived files on the server side (the archives themselves are filtered below)
+		msg.ArchRegex = parseStrFlag(c, regexLsAnyFlag)
 	}
 	if flagIsSet(c, allObjsOrBcksFlag) {
 		msg.SetFlag(apc.LsAll)
@@ -406,11 +413,19 @@ func listObjects(c *cli.Context, bck cmn.Bck, prefix string, listArch bool) erro
 	} else {
 		if cos.StringInSlice(allPropsFlag.GetName(), props) {
 			msg.AddProps(apc.GetPropsAll...)
+			if listArch {
+				msg.AddProps(apc.GetPropsOffset)
+			}
 		} else {
 			msg.AddProps(apc.GetPropsName)
 			msg.AddProps(props...)
 		}
 	}
+	if flagIsSet(c, listVersionsFlag) {
+		// an object name can then be displayed multiple times - the version tells one from another
+		msg.SetFlag(apc.LsVersions)
+		msg.AddProps(apc.GetPropsVersion)
+	}
 	if flagIsSet(c, allObjsOrBcksFlag) {
 		// Show status. Object name can then be displayed multiple times
 		// (due to mirroring, EC). The status helps to tell an object from its replica(s).
@@ -81,6 +81,7 @@ var (
 			regexLsAnyFlag,
 			templateFlag,
 			listObjPrefixFlag,
+			listCustomFilterFlag,
 			pageSizeFlag,
 			pagedFlag,
 			objLimitFlag,
@@ -92,6 +93,7 @@ var (
 			bckSummaryFlag,
 			listAnonymousFlag,
 			listArchFlag,
+			listVersionsFlag,
 			unitsFlag,
 		},
 
@@ -594,6 +594,20 @@ func suggestDownloadID(c *cli.Context, filter func(*dload.Job) bool, shift int)
 	}
 }
 
+func dlschedCompletions(c *cli.Context) {
+	if c.NArg() > 0 {
+		return
+	}
+	infos, err := api.ListDownloadSchedules(apiBP)
+	if err != nil {
+		completionErr(c, err)
+		return
+	}
+	for _, info := range infos {
+		fmt.Println(info.ID)
+	}
+}
+
 func dsortIDFinishedCompletions(c *cli.Context) { suggestDsortID(c, (*dsort.JobInfo).IsFinished, 0) }
 
 func suggestDsortID(c *cli.Context, filter func(*dsort.JobInfo) bool, shift int) {
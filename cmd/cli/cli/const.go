@@ -8,6 +8,7 @@
 package cli
 
 import (
+	"net/http"
 	"strings"
 	"time"
 
@@ -56,16 +57,19 @@ const (
 // - 3rd level subcommands
 const (
 	commandCat       = "cat"
+	commandCompose   = "compose"
 	commandConcat    = "concat"
 	commandCopy      = "cp"
 	commandCreate    = "create"
 	commandGet       = "get"
 	commandList      = "ls"
+	commandPresign   = "presign"
 	commandSetCustom = "set-custom"
 	commandPut       = "put"
 	commandRemove    = "rm"
 	commandRename    = "mv"
 	commandSet       = "set"
+	commandTag       = "tag"
 	commandStart     = apc.ActXactStart
 	commandStop      = apc.ActXactStop
 	commandWait      = "wait"
@@ -144,7 +148,13 @@ const (
 	cmdResetBprops = cmdReset
 
 	// Archive subcommands
-	cmdAppend = "append"
+	cmdAppend   = "append"
+	cmdGetBatch = "get-batch"
+
+	// Download schedule subcommands
+	cmdSchedule = "schedule"
+	cmdPause    = "pause"
+	cmdResume   = "resume"
 
 	// AuthN subcommands
 	cmdAuthAdd     = "add"
@@ -170,6 +180,8 @@ const (
 	cmdCode = "code"
 	cmdSrc  = "source"
 
+	cmdPipeline = "pipeline"
+
 	// config subcommands
 	cmdCLI        = "cli"
 	cmdCLIShow    = commandShow
@@ -223,6 +235,9 @@ const (
 	optionalJobIDArgument         = "[JOB_ID]"
 	optionalJobIDDaemonIDArgument = "[JOB_ID [NODE_ID]]"
 
+	scheduleIDArgument         = "SCHEDULE_ID"
+	optionalScheduleIDArgument = "[SCHEDULE_ID]"
+
 	jobShowStopWaitArgument  = "[NAME] [JOB_ID] [NODE_ID] [BUCKET]"
 	jobShowRebalanceArgument = "[REB_ID] [NODE_ID]"
 
@@ -232,6 +247,7 @@ const (
 	// ETL
 	etlNameArgument     = "ETL_NAME"
 	etlNameListArgument = "ETL_NAME [ETL_NAME ...]"
+	etlPipelineArgument = "PIPELINE_NAME ETL_NAME[=ARGS] [ETL_NAME[=ARGS] ...]"
 
 	// key/value
 	keyValuePairsArgument = "KEY=VALUE [KEY=VALUE...]"
@@ -253,14 +269,20 @@ const (
 	putObjectArgument       = "[-|FILE|DIRECTORY[/PATTERN]] BUCKET[/OBJECT_NAME]"
 	promoteObjectArgument   = "FILE|DIRECTORY[/PATTERN] BUCKET[/OBJECT_NAME]"
 	concatObjectArgument    = "FILE|DIRECTORY[/PATTERN] [ FILE|DIRECTORY[/PATTERN] ...] BUCKET/OBJECT_NAME"
+	composeObjectArgument   = "SRC_BUCKET/OBJECT_NAME [SRC_BUCKET/OBJECT_NAME ...] DST_BUCKET/OBJECT_NAME"
 	objectArgument          = "BUCKET/OBJECT_NAME"
 	optionalObjectsArgument = "BUCKET[/OBJECT_NAME]..."
 	renameObjectArgument    = "BUCKET/OBJECT_NAME NEW_OBJECT_NAME"
 	appendToArchArgument    = "FILE BUCKET[/OBJECT_NAME]"
+	getBatchArgument        = "[BUCKET/OBJECT_NAME ...] OUT_FILE|-"
 
 	setCustomArgument = objectArgument + " " + jsonKeyValueArgument + " | " + keyValuePairsArgument + ", e.g.:\n" +
 		indent1 +
 		"mykey1=value1 mykey2=value2 OR '{\"mykey1\":\"value1\", \"mykey2\":\"value2\"}'"
+	setTagsArgument = objectArgument + " " + jsonKeyValueArgument + " | " + keyValuePairsArgument + ", e.g.:\n" +
+		indent1 +
+		"split=train owner=alice OR '{\"split\":\"train\", \"owner\":\"alice\"}'"
+	rmTagsArgument = objectArgument + " [KEY ...]"
 
 	// nodes
 	nodeIDArgument            = "NODE_ID"
@@ -364,7 +386,8 @@ var (
 		Usage: "comma-separated list of object properties including name, size, version, copies, and more; e.g.:\n" +
 			indent4 + "\t--props all\n" +
 			indent4 + "\t--props name,size,cached\n" +
-			indent4 + "\t--props \"ec, copies, custom, location\"",
+			indent4 + "\t--props \"ec, copies, custom, location\"\n" +
+			indent4 + "\t--props name,size,offset\t- with '--archive': include archived files' offsets",
 	}
 
 	// prefix (to match)
@@ -374,6 +397,12 @@ var (
 			indent4 + "\t'--prefix a/b/c' - list virtual directory a/b/c and/or objects from the virtual directory\n" +
 			indent4 + "\ta/b that have their names (relative to this directory) starting with the letter c",
 	}
+	listCustomFilterFlag = cli.StringFlag{
+		Name: "custom-filter",
+		Usage: "list only (in-cluster) objects with matching tags or custom properties, e.g.:\n" +
+			indent4 + "\t'--custom-filter split=train' - objects tagged 'split=train';\n" +
+			indent4 + "\t'--custom-filter split=train,owner' - same, and in addition having the 'owner' tag (any value)",
+	}
 	getObjPrefixFlag = cli.StringFlag{
 		Name: listObjPrefixFlag.Name,
 		Usage: "get objects that start with the specified prefix, e.g.:\n" +
@@ -537,11 +566,32 @@ var (
 	}
 	syncFlag = cli.BoolFlag{Name: "sync", Usage: "sync bucket with Cloud"}
 
+	dloadScheduleFlag = cli.StringFlag{
+		Name: "schedule",
+		Usage: "instead of running the download once, create a recurring download schedule;\n" +
+			indent4 + "\teither interval (e.g., '6h', '30m') or cron expression (e.g., '0 3 * * *', '@daily');\n" +
+			indent4 + "\tsee also: 'ais job schedule'",
+	}
+
+	dloadManifestFlag = cli.StringFlag{
+		Name: "manifest",
+		Usage: "local file or object (e.g., ais://manifests/SHA256SUMS) that lists the links to download\n" +
+			indent4 + "\tand their expected checksums; supported formats: 'sha256sum' (and 'md5sum') output, JSON, CSV",
+	}
+	dloadCksumFlag = cli.StringFlag{
+		Name:  "cksum",
+		Usage: "expected checksum of the downloaded file, e.g.: 'sha256:9f86d081884c7d65...' (supported: md5, sha256, crc32c, sha512)",
+	}
+
 	// dSort
 	dsortFsizeFlag  = cli.StringFlag{Name: "fsize", Value: "1024", Usage: "size of the files in a shard"}
 	dsortLogFlag    = cli.StringFlag{Name: "log", Usage: "path to file where the metrics will be saved"}
 	dsortFcountFlag = cli.IntFlag{Name: "fcount", Value: 5, Usage: "number of files inside single shard"}
 	dsortSpecFlag   = cli.StringFlag{Name: "file,f", Value: "", Usage: "path to file with dSort specification"}
+	dsortResumeFlag = cli.StringFlag{
+		Name:  "resume",
+		Usage: "resume aborted (e.g., due to node restart) dSort job with a given ID (requires 'resumable' job specification)",
+	}
 
 	cleanupFlag = cli.BoolFlag{
 		Name:  "cleanup",
@@ -660,6 +710,23 @@ var (
 	deleteSrcFlag = cli.BoolFlag{Name: "delete-src", Usage: "delete successfully promoted source"}
 	targetIDFlag  = cli.StringFlag{Name: "target-id", Usage: "ais target designated to carry out the entire operation"}
 
+	composeDelSrcFlag = cli.BoolFlag{Name: "delete-src", Usage: "delete source objects upon successful compose"}
+
+	replaceTagsFlag = cli.BoolFlag{Name: "replace", Usage: "remove all existing tags (if any) and store the new ones"}
+
+	// presign
+	presignMethodFlag = cli.StringFlag{
+		Name:  "method",
+		Usage: "HTTP method the presigned URL is valid for: GET, PUT, HEAD, or DELETE",
+		Value: http.MethodGet,
+	}
+	presignExpireFlag = DurationFlag{
+		Name: "expire,e",
+		Usage: "presigned URL validity (maximum 7 days, and not longer than the credentials);\n" +
+			indent4 + "\tvalid time units: " + timeUnits,
+		Value: time.Hour,
+	}
+
 	notFshareFlag = cli.BoolFlag{
 		Name: "not-file-share",
 		Usage: "each target must act autonomously skipping file-share auto-detection and promoting the entire source " +
@@ -702,6 +769,12 @@ var (
 	listArchFlag   = cli.BoolFlag{Name: "archive", Usage: "list archived content (see docs/archive.md for details)"}
 	createArchFlag = cli.BoolFlag{Name: "archive", Usage: "archive a given list ('--list') or range ('--template') of objects"}
 
+	// versions
+	listVersionsFlag = cli.BoolFlag{
+		Name:  "versions",
+		Usage: "list non-current versions and delete markers as well (buckets that retain non-current versions)",
+	}
+
 	archpathOptionalFlag = cli.StringFlag{
 		Name:  "archpath",
 		Usage: "filename in archive",
@@ -712,6 +785,13 @@ var (
 		Required: true,
 	}
 
+	batchSpecFlag = cli.StringFlag{
+		Name: "spec",
+		Usage: "path to JSON file that lists objects and/or archived files to read, e.g.:\n" +
+			indent4 + "\t{\"entries\": [{\"bck\": {\"name\": \"abc\", \"provider\": \"ais\"}, \"objname\": \"1.jpg\"},\n" +
+			indent4 + "\t{\"bck\": {\"name\": \"abc\", \"provider\": \"ais\"}, \"objname\": \"shard.tar\", \"archpath\": \"2.jpg\"}]}",
+	}
+
 	includeSrcBucketNameFlag = cli.BoolFlag{
 		Name:  "include-src-bck",
 		Usage: "prefix names of archived objects with the source bucket name",
@@ -758,6 +838,16 @@ var (
 		Usage:    "unique ETL name (leaving this field empty will have unique ID auto-generated)",
 		Required: true,
 	}
+	etlCacheSizeFlag = cli.StringFlag{
+		Name: "cache-size",
+		Usage: "enable transform cache: per-target capacity in IEC or SI units, or \"raw\" bytes (e.g.: 10GiB);\n" +
+			indent4 + "\tcached results of inline transformations are invalidated upon object overwrite and ETL re-init",
+	}
+	etlArgsFlag = cli.StringFlag{
+		Name: "args",
+		Usage: "transformer-specific arguments passed with each transformation request (opaque to AIS), e.g.:\n" +
+			indent4 + "\t--args='resize=224x224'",
+	}
 	etlBucketRequestTimeout = DurationFlag{
 		Name: "etl-timeout",
 		Usage: "server-side timeout transforming a single object;\n" +
@@ -780,6 +870,20 @@ var (
 		Name:  "comm-type",
 		Usage: "communication type which should be used when running the provided code",
 	}
+	// WebAssembly (in-process) runtime limits
+	wasmMemLimitFlag = cli.StringFlag{
+		Name:  "mem-limit",
+		Usage: "(--runtime=wasm only) max linear memory per WebAssembly instance, e.g.: 64MiB (default 256MiB)",
+	}
+	wasmExecTimeoutFlag = DurationFlag{
+		Name: "exec-timeout",
+		Usage: "(--runtime=wasm only) max time to transform a single object (default 1m);\n" +
+			indent4 + "\tvalid time units: " + timeUnits,
+	}
+	wasmMaxInstancesFlag = cli.IntFlag{
+		Name:  "max-instances",
+		Usage: "(--runtime=wasm only) max number of concurrently running WebAssembly instances per target (default: number of CPUs)",
+	}
 	funcTransformFlag = cli.StringFlag{
 		Name:  "transform",
 		Value: "transform", // NOTE: default name of the transform() function
@@ -36,16 +36,27 @@ var (
 			unitsFlag,
 			waitPodReadyTimeoutFlag,
 			etlNameFlag,
+			etlCacheSizeFlag,
+			wasmMemLimitFlag,
+			wasmExecTimeoutFlag,
+			wasmMaxInstancesFlag,
 		},
 		cmdSpec: {
 			fromFileFlag,
 			commTypeFlag,
 			etlNameFlag,
 			waitPodReadyTimeoutFlag,
+			etlCacheSizeFlag,
+		},
+		cmdPipeline: {
+			etlCacheSizeFlag,
 		},
 		cmdStop: {
 			allRunningJobsFlag,
 		},
+		cmdObject: {
+			etlArgsFlag,
+		},
 		cmdBucket: {
 			copyAllObjsFlag,
 			continueOnErrorFlag,
@@ -55,6 +66,7 @@ var (
 			copyObjPrefixFlag,
 			copyDryRunFlag,
 			etlBucketRequestTimeout,
+			etlArgsFlag,
 			templateFlag,
 			listFlag,
 			// TODO: progressFlag,
@@ -108,6 +120,15 @@ var (
 				Flags:  etlSubFlags[cmdCode],
 				Action: etlInitCodeHandler,
 			},
+			{
+				Name: cmdPipeline,
+				Usage: "start ETL pipeline: chain existing ETLs (stages), with optional per-stage arguments, e.g.:\n" +
+					indent4 + "\t'ais etl init pipeline my-pipeline decode resize=224x224 normalize'",
+				ArgsUsage:    etlPipelineArgument,
+				Flags:        etlSubFlags[cmdPipeline],
+				Action:       etlInitPipelineHandler,
+				BashComplete: etlPipelineCompletions,
+			},
 		},
 	}
 	objCmdETL = cli.Command{
@@ -115,6 +136,7 @@ var (
 		Usage:        "transform object",
 		ArgsUsage:    etlNameArgument + " " + objectArgument + " OUTPUT",
 		Action:       etlObjectHandler,
+		Flags:        etlSubFlags[cmdObject],
 		BashComplete: etlIDCompletions,
 	}
 	bckCmdETL = cli.Command{
@@ -165,6 +187,22 @@ func suggestEtlName(c *cli.Context, shift int) {
 	}
 }
 
+// (pipeline name is new; stages are existing ETLs)
+func etlPipelineCompletions(c *cli.Context) {
+	if c.NArg() == 0 {
+		return
+	}
+	list, err := api.ETLList(apiBP)
+	if err != nil {
+		return
+	}
+	for _, l := range list {
+		if len(l.Stages) == 0 {
+			fmt.Println(l.Name)
+		}
+	}
+}
+
 func etlAlreadyExists(etlName string) (err error) {
 	if l := findETL(etlName, ""); l != nil {
 		return fmt.Errorf("ETL[%s] already exists", etlName)
@@ -205,6 +243,9 @@ func etlInitSpecHandler(c *cli.Context) (err error) {
 		msg.CommTypeX = parseStrFlag(c, commTypeFlag)
 		msg.Spec = spec
 	}
+	if msg.CacheSize, err = parseCacheSizeFlag(c); err != nil {
+		return err
+	}
 	if err = msg.Validate(); err != nil {
 		return err
 	}
@@ -273,10 +314,24 @@ func etlInitCodeHandler(c *cli.Context) (err error) {
 		}
 	}
 	msg.Timeout = cos.Duration(parseDurationFlag(c, waitPodReadyTimeoutFlag))
+	if msg.CacheSize, err = parseCacheSizeFlag(c); err != nil {
+		return err
+	}
 
 	// funcs
 	msg.Funcs.Transform = parseStrFlag(c, funcTransformFlag)
 
+	// in-process limits
+	if flagIsSet(c, wasmMemLimitFlag) {
+		size, err := parseSizeFlag(c, wasmMemLimitFlag)
+		if err != nil {
+			return err
+		}
+		msg.Limits.MemSize = cos.SizeIEC(size)
+	}
+	msg.Limits.ExecTimeout = cos.Duration(parseDurationFlag(c, wasmExecTimeoutFlag))
+	msg.Limits.MaxInstances = parseIntFlag(c, wasmMaxInstancesFlag)
+
 	// validate
 	if err := msg.Validate(); err != nil {
 		return err
@@ -291,6 +346,45 @@ func etlInitCodeHandler(c *cli.Context) (err error) {
 	return nil
 }
 
+func etlInitPipelineHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "pipeline name")
+	}
+	if c.NArg() == 1 {
+		return missingArgumentsError(c, "pipeline stages (ETL names)")
+	}
+	msg := &etl.PipelineMsg{IDX: c.Args().Get(0)}
+	for _, arg := range c.Args().Tail() {
+		name, args, _ := strings.Cut(arg, "=")
+		msg.Stages = append(msg.Stages, etl.PipelineStage{Name: name, Args: args})
+	}
+	cacheSize, err := parseCacheSizeFlag(c)
+	if err != nil {
+		return err
+	}
+	msg.CacheSize = cacheSize
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	if err := etlAlreadyExists(msg.Name()); err != nil {
+		return err
+	}
+	xid, err := api.ETLInit(apiBP, msg)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "ETL[%s]: job %q\n", msg.Name(), xid)
+	return nil
+}
+
+func parseCacheSizeFlag(c *cli.Context) (cos.SizeIEC, error) {
+	if !flagIsSet(c, etlCacheSizeFlag) {
+		return 0, nil
+	}
+	size, err := parseSizeFlag(c, etlCacheSizeFlag)
+	return cos.SizeIEC(size), err
+}
+
 func etlListHandler(c *cli.Context) (err error) {
 	_, err = etlList(c, false)
 	return
@@ -344,6 +438,16 @@ func etlPrintInitMsg(c *cli.Context, id string) error {
 		fmt.Fprintln(c.App.Writer, string(initMsg.Spec))
 		return nil
 	}
+	if initMsg, ok := msg.(*etl.PipelineMsg); ok {
+		for i, stage := range initMsg.Stages {
+			if stage.Args == "" {
+				fmt.Fprintf(c.App.Writer, "%d: %s\n", i, stage.Name)
+			} else {
+				fmt.Fprintf(c.App.Writer, "%d: %s (args: %s)\n", i, stage.Name, stage.Args)
+			}
+		}
+		return nil
+	}
 	err = fmt.Errorf("invalid response [%+v, %T]", msg, msg)
 	debug.AssertNoErr(err)
 	return err
@@ -467,6 +571,6 @@ func etlObjectHandler(c *cli.Context) error {
 		defer f.Close()
 	}
 
-	err := api.ETLObject(apiBP, etlName, bck, objName, w)
+	err := api.ETLObjectWithArgs(apiBP, etlName, parseStrFlag(c, etlArgsFlag), bck, objName, w)
 	return handleETLHTTPError(err, etlName)
 }
@@ -16,6 +16,7 @@ import (
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmd/cli/teb"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -41,6 +42,7 @@ var (
 		jobStopSub,
 		jobWaitSub,
 		jobRemoveSub,
+		jobScheduleSub,
 		makeAlias(showCmdJob, "", true, commandShow), // alias for `ais show`
 	}
 )
@@ -57,6 +59,8 @@ var (
 			descJobFlag,
 			limitConnectionsFlag,
 			objectsListFlag,
+			dloadManifestFlag,
+			dloadCksumFlag,
 			dloadProgressFlag,
 			progressFlag,
 			waitFlag,
@@ -64,9 +68,11 @@ var (
 			limitBytesPerHourFlag,
 			syncFlag,
 			unitsFlag,
+			dloadScheduleFlag,
 		},
 		cmdDsort: {
 			dsortSpecFlag,
+			dsortResumeFlag,
 		},
 		commandPrefetch: append(
 			listrangeFlags,
@@ -205,6 +211,52 @@ var (
 	}
 )
 
+// ais job schedule
+var (
+	jobScheduleSub = cli.Command{
+		Name:  cmdSchedule,
+		Usage: "show, pause, resume, and remove scheduled (recurring) downloads (see 'ais start download --schedule')",
+		Subcommands: []cli.Command{
+			{
+				Name:         commandShow,
+				Usage:        "show download schedule(s) and the history of scheduled runs",
+				ArgsUsage:    optionalScheduleIDArgument,
+				Flags:        []cli.Flag{noHeaderFlag, jsonFlag},
+				Action:       showDlSchedHandler,
+				BashComplete: dlschedCompletions,
+			},
+			{
+				Name:         commandList,
+				Usage:        "list download schedules",
+				Flags:        []cli.Flag{noHeaderFlag, jsonFlag},
+				Action:       showDlSchedHandler,
+				BashComplete: dlschedCompletions,
+			},
+			{
+				Name:         cmdPause,
+				Usage:        "pause download schedule (scheduled runs won't start until resumed)",
+				ArgsUsage:    scheduleIDArgument,
+				Action:       pauseDlSchedHandler,
+				BashComplete: dlschedCompletions,
+			},
+			{
+				Name:         cmdResume,
+				Usage:        "resume paused download schedule",
+				ArgsUsage:    scheduleIDArgument,
+				Action:       resumeDlSchedHandler,
+				BashComplete: dlschedCompletions,
+			},
+			{
+				Name:         commandRemove,
+				Usage:        "remove download schedule and its history (jobs that are already running are not affected)",
+				ArgsUsage:    scheduleIDArgument,
+				Action:       removeDlSchedHandler,
+				BashComplete: dlschedCompletions,
+			},
+		},
+	}
+)
+
 func jobName(xname, xid string) string { return xname + "[" + xid + "]" }
 
 func appendJobSub(jobcmd *cli.Command) {
@@ -334,13 +386,14 @@ func startDownloadHandler(c *cli.Context) error {
 		description      = parseStrFlag(c, descJobFlag)
 		timeout          = parseStrFlag(c, dloadTimeoutFlag)
 		objectsListPath  = parseStrFlag(c, objectsListFlag)
+		manifest         = parseStrFlag(c, dloadManifestFlag)
 		progressInterval = parseStrFlag(c, dloadProgressFlag)
 		id               string
 	)
 	if c.NArg() == 0 {
 		return missingArgumentsError(c, c.Command.ArgsUsage)
 	}
-	if c.NArg() == 1 {
+	if c.NArg() == 1 && manifest == "" {
 		return missingArgumentsError(c, "destination")
 	}
 	if c.NArg() > 2 {
@@ -354,10 +407,19 @@ func startDownloadHandler(c *cli.Context) error {
 		}
 	}
 
-	src, dst := c.Args().Get(0), c.Args().Get(1)
-	source, err := parseSource(src)
-	if err != nil {
-		return err
+	var (
+		src, dst = c.Args().Get(0), c.Args().Get(1)
+		source   dlSource
+		err      error
+	)
+	if c.NArg() == 1 {
+		// manifest with absolute links - no source
+		src, dst = "", src
+	}
+	if src != "" {
+		if source, err = parseSource(src); err != nil {
+			return err
+		}
 	}
 	bck, pathSuffix, err := parseDest(c, dst)
 	if err != nil {
@@ -395,7 +457,9 @@ func startDownloadHandler(c *cli.Context) error {
 
 	// Heuristics to determine the download type.
 	var dlType dload.Type
-	if objectsListPath != "" {
+	if manifest != "" {
+		dlType = dload.TypeManifest
+	} else if objectsListPath != "" {
 		dlType = dload.TypeMulti
 	} else if strings.Contains(source.link, "{") && strings.Contains(source.link, "}") {
 		dlType = dload.TypeRange
@@ -444,9 +508,10 @@ func startDownloadHandler(c *cli.Context) error {
 			SingleObj: dload.SingleObj{
 				Link:    source.link,
 				ObjName: pathSuffix, // in this case pathSuffix is a full name of the object
+				Cksum:   parseStrFlag(c, dloadCksumFlag),
 			},
 		}
-		id, err = api.DownloadWithParam(apiBP, dlType, payload)
+		id, err = startDownload(c, dlType, payload)
 	case dload.TypeMulti:
 		var objects []string
 		{
@@ -465,21 +530,23 @@ func startDownloadHandler(c *cli.Context) error {
 			Base:           basePayload,
 			ObjectsPayload: objects,
 		}
-		id, err = api.DownloadWithParam(apiBP, dlType, payload)
+		id, err = startDownload(c, dlType, payload)
 	case dload.TypeRange:
 		payload := dload.RangeBody{
 			Base:     basePayload,
 			Subdir:   pathSuffix, // in this case pathSuffix is a subdirectory in which the objects are to be saved
 			Template: source.link,
 		}
-		id, err = api.DownloadWithParam(apiBP, dlType, payload)
+		id, err = startDownload(c, dlType, payload)
 	case dload.TypeBackend:
 		payload := dload.BackendBody{
 			Base:   basePayload,
 			Sync:   flagIsSet(c, syncFlag),
 			Prefix: source.backend.prefix,
 		}
-		id, err = api.DownloadWithParam(apiBP, dlType, payload)
+		id, err = startDownload(c, dlType, payload)
+	case dload.TypeManifest:
+		id, err = startManifestDownload(c, manifest, source.link, basePayload)
 	default:
 		debug.Assert(false)
 	}
@@ -488,6 +555,11 @@ func startDownloadHandler(c *cli.Context) error {
 		return err
 	}
 
+	if flagIsSet(c, dloadScheduleFlag) {
+		actionDone(c, fmt.Sprintf("Created download schedule %s. %s", id,
+			"To show the schedule and its runs, run 'ais job schedule show "+id+"'"))
+		return nil
+	}
 	fmt.Fprintf(c.App.Writer, "Started download job %s\n", id)
 
 	if flagIsSet(c, progressFlag) {
@@ -501,6 +573,61 @@ func startDownloadHandler(c *cli.Context) error {
 	return bgDownload(c, id)
 }
 
+// manifest is either an object (that each target reads on its own) or a local file
+// (that gets parsed right here); in both cases relative links resolve against the source
+func startManifestDownload(c *cli.Context, manifest, baseURL string, basePayload dload.Base) (string, error) {
+	format, cksumType := dload.ManifestFormat(manifest)
+	if basePayload.CksumType == "" {
+		basePayload.CksumType = cksumType
+	}
+	if strings.Contains(manifest, apc.BckProviderSeparator) && !isWebURL(manifest) {
+		mbck, objName, err := parseBckObjectURI(c, manifest)
+		if err != nil {
+			return "", err
+		}
+		payload := dload.ManifestBody{
+			Base:         basePayload,
+			ManifestBck:  mbck,
+			ManifestName: objName,
+			Format:       format,
+			BaseURL:      baseURL,
+		}
+		return startDownload(c, dload.TypeManifest, payload)
+	}
+	file, err := os.Open(manifest)
+	if err != nil {
+		return "", err
+	}
+	entries, err := dload.ParseManifest(file, format, baseURL)
+	file.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to parse manifest %q: %v", manifest, err)
+	}
+	payload := dload.MultiBody{
+		Base:           basePayload,
+		ObjectsPayload: entries,
+	}
+	return startDownload(c, dload.TypeMulti, payload)
+}
+
+// start download job or, if requested, create download schedule that runs it
+// periodically - the latter takes either interval or cron expression
+func startDownload(c *cli.Context, dlType dload.Type, payload any) (string, error) {
+	if !flagIsSet(c, dloadScheduleFlag) {
+		return api.DownloadWithParam(apiBP, dlType, payload)
+	}
+	var (
+		cron  string
+		every time.Duration
+		err   error
+		s     = parseStrFlag(c, dloadScheduleFlag)
+	)
+	if every, err = time.ParseDuration(s); err != nil {
+		every, cron = 0, s
+	}
+	return api.DownloadSchedule(apiBP, "" /*generate ID*/, cron, every, dlType, payload)
+}
+
 func pbDownload(c *cli.Context, id string) (err error) {
 	refreshRate := _refreshRate(c)
 	downloadingResult, err := newDownloaderPB(apiBP, id, refreshRate).run()
@@ -608,6 +735,17 @@ func startDsortHandler(c *cli.Context) (err error) {
 		id       string
 		specPath = parseStrFlag(c, dsortSpecFlag)
 	)
+	if flagIsSet(c, dsortResumeFlag) {
+		if c.NArg() > 0 || specPath != "" {
+			return incorrectUsageMsg(c, "%s cannot be used together with job specification", qflprn(dsortResumeFlag))
+		}
+		id = parseStrFlag(c, dsortResumeFlag)
+		if err = api.ResumeDSort(apiBP, id); err != nil {
+			return
+		}
+		fmt.Fprintln(c.App.Writer, id)
+		return
+	}
 	if c.NArg() == 0 && specPath == "" {
 		return missingArgumentsError(c, c.Command.ArgsUsage)
 	} else if c.NArg() > 0 && specPath != "" {
@@ -1314,3 +1452,66 @@ func xid2Name(xid string) (name, otherID string) {
 	}
 	return
 }
+
+//
+// job schedule (scheduled downloads)
+//
+
+func showDlSchedHandler(c *cli.Context) error {
+	opts := teb.Jopts(flagIsSet(c, jsonFlag))
+	if c.NArg() > 0 {
+		info, err := api.GetDownloadSchedule(apiBP, c.Args().First())
+		if err != nil {
+			return err
+		}
+		return teb.Print(info, teb.DlSchedTmpl, opts)
+	}
+	infos, err := api.ListDownloadSchedules(apiBP)
+	if err != nil {
+		return err
+	}
+	if len(infos) == 0 && !opts.UseJSON {
+		fmt.Fprintln(c.App.Writer, "No download schedules")
+		return nil
+	}
+	if flagIsSet(c, noHeaderFlag) {
+		return teb.Print(infos, teb.DlSchedListNoHdrTmpl, opts)
+	}
+	return teb.Print(infos, teb.DlSchedListTmpl, opts)
+}
+
+func pauseDlSchedHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	id := c.Args().First()
+	if err := api.PauseDownloadSchedule(apiBP, id); err != nil {
+		return err
+	}
+	actionDone(c, fmt.Sprintf("Paused download schedule %q", id))
+	return nil
+}
+
+func resumeDlSchedHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	id := c.Args().First()
+	if err := api.ResumeDownloadSchedule(apiBP, id); err != nil {
+		return err
+	}
+	actionDone(c, fmt.Sprintf("Resumed download schedule %q", id))
+	return nil
+}
+
+func removeDlSchedHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	id := c.Args().First()
+	if err := api.RemoveDownloadSchedule(apiBP, id); err != nil {
+		return err
+	}
+	actionDone(c, fmt.Sprintf("Removed download schedule %q", id))
+	return nil
+}
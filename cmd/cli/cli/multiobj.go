@@ -60,6 +60,7 @@ func multiobjTCO(c *cli.Context, bckFrom, bckTo cmn.Bck, listObjs, tmplObjs, etl
 	)
 	if etlName != "" {
 		msg.Name = etlName
+		msg.Args = parseStrFlag(c, etlArgsFlag)
 		text = "Transforming objects"
 		xkind = apc.ActETLObjects
 		xid, err = api.ETLMultiObj(apiBP, bckFrom, msg)
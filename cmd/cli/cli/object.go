@@ -68,27 +68,9 @@ func promote(c *cli.Context, bck cmn.Bck, objName, fqn string) error {
 }
 
 func setCustomProps(c *cli.Context, bck cmn.Bck, objName string) (err error) {
-	props := make(cos.StrKVs)
-	propArgs := c.Args().Tail()
-
-	if len(propArgs) == 1 && isJSON(propArgs[0]) {
-		if err = jsoniter.Unmarshal([]byte(propArgs[0]), &props); err != nil {
-			return
-		}
-	} else {
-		if len(propArgs) == 0 {
-			err = missingArgumentsError(c, "property key-value pairs")
-			return
-		}
-		for _, pair := range propArgs {
-			nv := strings.Split(pair, "=")
-			if len(nv) != 2 {
-				return fmt.Errorf("invalid custom property %q (Hint: use syntax key1=value1 key2=value2 ...)", nv)
-			}
-			nv[0] = strings.TrimSpace(nv[0])
-			nv[1] = strings.TrimSpace(nv[1])
-			props[nv[0]] = nv[1]
-		}
+	props, err := parseKeyValueArgs(c, "property")
+	if err != nil {
+		return
 	}
 	setNewCustom := flagIsSet(c, setNewCustomMDFlag)
 	if err = api.SetObjectCustomProps(apiBP, bck, objName, props, setNewCustom); err != nil {
@@ -100,6 +82,31 @@ func setCustomProps(c *cli.Context, bck cmn.Bck, objName string) (err error) {
 	return nil
 }
 
+// parse (the tail of) command line as either JSON or key1=value1 key2=value2 ... pairs
+func parseKeyValueArgs(c *cli.Context, what string) (kvs cos.StrKVs, err error) {
+	kvs = make(cos.StrKVs)
+	args := c.Args().Tail()
+
+	if len(args) == 1 && isJSON(args[0]) {
+		err = jsoniter.Unmarshal([]byte(args[0]), &kvs)
+		return
+	}
+	if len(args) == 0 {
+		err = missingArgumentsError(c, what+" key-value pairs")
+		return
+	}
+	for _, pair := range args {
+		nv := strings.Split(pair, "=")
+		if len(nv) != 2 {
+			return nil, fmt.Errorf("invalid %s %q (Hint: use syntax key1=value1 key2=value2 ...)", what, nv)
+		}
+		nv[0] = strings.TrimSpace(nv[0])
+		nv[1] = strings.TrimSpace(nv[1])
+		kvs[nv[0]] = nv[1]
+	}
+	return
+}
+
 // replace common abbreviations (such as `~/`) and return an absolute path
 func absPath(fileName string) (path string, err error) {
 	path = cos.ExpandPath(fileName)
@@ -6,12 +6,19 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/api/env"
+	"github.com/NVIDIA/aistore/cmd/cli/teb"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -68,6 +75,9 @@ var (
 		commandSetCustom: {
 			setNewCustomMDFlag,
 		},
+		commandTag + commandSet: {
+			replaceTagsFlag,
+		},
 		commandPromote: {
 			recursFlag,
 			overwriteFlag,
@@ -81,6 +91,13 @@ var (
 			unitsFlag,
 			progressFlag,
 		},
+		commandCompose: {
+			composeDelSrcFlag,
+		},
+		commandPresign: {
+			presignMethodFlag,
+			presignExpireFlag,
+		},
 		commandCat: {
 			offsetFlag,
 			lengthFlag,
@@ -124,6 +141,35 @@ var (
 		Action:    setCustomPropsHandler,
 	}
 
+	objectCmdTag = cli.Command{
+		Name:  commandTag,
+		Usage: "show, set, and remove object tags (user-defined key-value pairs that can also be used to filter 'ais ls')",
+		Subcommands: []cli.Command{
+			{
+				Name:         commandShow,
+				Usage:        "show object tags",
+				ArgsUsage:    objectArgument,
+				Action:       showObjTagsHandler,
+				BashComplete: bucketCompletions(bcmplop{separator: true}),
+			},
+			{
+				Name:         commandSet,
+				Usage:        "add new or update existing object tags (use '--replace' to remove all existing tags first)",
+				ArgsUsage:    setTagsArgument,
+				Flags:        objectCmdsFlags[commandTag+commandSet],
+				Action:       setObjTagsHandler,
+				BashComplete: bucketCompletions(bcmplop{separator: true}),
+			},
+			{
+				Name:         commandRemove,
+				Usage:        "remove the specified object tags or, if none specified, all tags",
+				ArgsUsage:    rmTagsArgument,
+				Action:       rmObjTagsHandler,
+				BashComplete: bucketCompletions(bcmplop{separator: true}),
+			},
+		},
+	}
+
 	objectCmd = cli.Command{
 		Name:  commandObject,
 		Usage: "put, get, list, rename, remove, and other operations on objects",
@@ -132,6 +178,7 @@ var (
 			bucketsObjectsCmdList,
 			objectCmdPut,
 			objectCmdSetCustom,
+			objectCmdTag,
 			bucketObjCmdEvict,
 			makeAlias(showCmdObject, "", true, commandShow), // alias for `ais show`
 			{
@@ -165,6 +212,26 @@ var (
 				Flags:     objectCmdsFlags[commandConcat],
 				Action:    concatHandler,
 			},
+			{
+				Name: commandCompose,
+				Usage: "compose (concatenate) existing objects, in the specified order, into a new object\n" +
+					indent4 + "\t- the cluster assembles the result - no data is transferred via the client;\n" +
+					indent4 + "\t- sources may reside in different buckets; use '--delete-src' to remove them upon success.",
+				ArgsUsage:    composeObjectArgument,
+				Flags:        objectCmdsFlags[commandCompose],
+				Action:       composeHandler,
+				BashComplete: bucketCompletions(bcmplop{multiple: true, separator: true}),
+			},
+			{
+				Name: commandPresign,
+				Usage: "generate presigned S3 URL to GET, PUT, HEAD, or DELETE a given object without AIS credentials\n" +
+					indent4 + "\t- when AuthN is enabled, the URL is signed with S3 credentials issued for the logged-in user;\n" +
+					indent4 + "\t- e.g., 'ais object presign ais://abc/obj --method PUT --expire 2h' and then 'curl -L -X PUT -T file <URL>'.",
+				ArgsUsage:    objectArgument,
+				Flags:        objectCmdsFlags[commandPresign],
+				Action:       presignHandler,
+				BashComplete: bucketCompletions(bcmplop{separator: true}),
+			},
 			{
 				Name:         commandCat,
 				Usage:        "cat an object (i.e., print its contents to STDOUT)",
@@ -415,6 +482,72 @@ func concatHandler(c *cli.Context) (err error) {
 	return concatObject(c, bck, objName, fileNames)
 }
 
+func composeHandler(c *cli.Context) error {
+	if c.NArg() < 2 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	var (
+		args = c.Args()
+		msg  = &cmn.ComposeMsg{DeleteSrc: flagIsSet(c, composeDelSrcFlag)}
+	)
+	bck, objName, err := parseBckObjectURI(c, args.Get(c.NArg()-1))
+	if err != nil {
+		return err
+	}
+	for i := 0; i < c.NArg()-1; i++ {
+		srcBck, srcObj, err := parseBckObjectURI(c, args.Get(i))
+		if err != nil {
+			return err
+		}
+		msg.Sources = append(msg.Sources, cmn.ComposeSrc{Bck: srcBck, ObjName: srcObj})
+	}
+	if err := api.ComposeObjects(apiBP, bck, objName, msg); err != nil {
+		return err
+	}
+	n := len(msg.Sources)
+	fmt.Fprintf(c.App.Writer, "Composed %s from %d source object%s\n", bck.Cname(objName), n, cos.Plural(n))
+	return nil
+}
+
+func presignHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	uri := c.Args().Get(0)
+	bck, objName, err := parseBckObjectURI(c, uri)
+	if err != nil {
+		return err
+	}
+	if objName == "" {
+		return incorrectUsageMsg(c, "no object specified in %q", uri)
+	}
+	method := strings.ToUpper(parseStrFlag(c, presignMethodFlag))
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodHead, http.MethodDelete:
+	default:
+		return incorrectUsageMsg(c, "invalid %s %q (expecting GET, PUT, HEAD, or DELETE)", qflprn(presignMethodFlag), method)
+	}
+	args := &api.PresignS3Args{Method: method, Expires: parseDurationFlag(c, presignExpireFlag)}
+
+	// with AuthN disabled, signatures are ignored (no credentials required)
+	if loggedUserToken != "" {
+		if authnHTTPClient == nil {
+			return errors.New(env.AuthN.URL + " is not set")
+		}
+		creds, err := authn.GetS3Creds(authParams)
+		if err != nil {
+			return err
+		}
+		args.AccessKeyID, args.SecretAccessKey, args.SessionToken = creds.AccessKeyID, creds.SecretAccessKey, creds.SessionToken
+	}
+	u, err := api.PresignS3URL(apiBP, bck, objName, args)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.App.Writer, u)
+	return nil
+}
+
 func promoteHandler(c *cli.Context) (err error) {
 	if c.NArg() < 1 {
 		return missingArgumentsError(c, "source file|directory to promote")
@@ -453,3 +586,77 @@ func setCustomPropsHandler(c *cli.Context) (err error) {
 	}
 	return setCustomProps(c, bck, objName)
 }
+
+//
+// object tags
+//
+
+func showObjTagsHandler(c *cli.Context) error {
+	bck, objName, err := parseObjTagsURI(c)
+	if err != nil {
+		return err
+	}
+	tags, err := api.GetObjectTags(apiBP, bck, objName)
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		fmt.Fprintf(c.App.Writer, "%s has no tags\n", bck.Cname(objName))
+		return nil
+	}
+	keys := tags.Keys()
+	sort.Strings(keys)
+	nvs := make(nvpairList, 0, len(keys))
+	for _, k := range keys {
+		nvs = append(nvs, nvpair{Name: k, Value: tags[k]})
+	}
+	return teb.Print(nvs, teb.ObjTagsTmpl)
+}
+
+func setObjTagsHandler(c *cli.Context) error {
+	bck, objName, err := parseObjTagsURI(c)
+	if err != nil {
+		return err
+	}
+	tags, err := parseKeyValueArgs(c, "tag")
+	if err != nil {
+		return err
+	}
+	if err := api.SetObjectTags(apiBP, bck, objName, tags, flagIsSet(c, replaceTagsFlag)); err != nil {
+		return err
+	}
+	actionDone(c, fmt.Sprintf("Tagged %s (to show, run 'ais object tag show %s')", bck.Cname(objName), bck.Cname(objName)))
+	return nil
+}
+
+func rmObjTagsHandler(c *cli.Context) error {
+	bck, objName, err := parseObjTagsURI(c)
+	if err != nil {
+		return err
+	}
+	keys := c.Args().Tail()
+	if err := api.DeleteObjectTags(apiBP, bck, objName, keys...); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		actionDone(c, "Removed all tags from "+bck.Cname(objName))
+	} else {
+		actionDone(c, fmt.Sprintf("Removed %d tag%s from %s", len(keys), cos.Plural(len(keys)), bck.Cname(objName)))
+	}
+	return nil
+}
+
+func parseObjTagsURI(c *cli.Context) (bck cmn.Bck, objName string, err error) {
+	if c.NArg() == 0 {
+		err = missingArgumentsError(c, objectArgument)
+		return
+	}
+	uri := c.Args().Get(0)
+	if bck, objName, err = parseBckObjectURI(c, uri); err != nil {
+		return
+	}
+	if objName == "" {
+		err = incorrectUsageMsg(c, "no object specified in %q", uri)
+	}
+	return
+}
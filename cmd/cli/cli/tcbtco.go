@@ -130,7 +130,7 @@ func etlBucket(c *cli.Context, etlName string, bckFrom, bckTo cmn.Bck) error {
 		return missingArgumentsError(c, c.Command.ArgsUsage)
 	}
 	msg := &apc.TCBMsg{
-		Transform: apc.Transform{Name: etlName},
+		Transform: apc.Transform{Name: etlName, Args: parseStrFlag(c, etlArgsFlag)},
 		CopyBckMsg: apc.CopyBckMsg{
 			Prepend: parseStrFlag(c, copyPrependFlag),
 			Prefix:  parseStrFlag(c, copyObjPrefixFlag),
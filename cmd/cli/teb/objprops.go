@@ -17,12 +17,13 @@ var (
 		apc.GetPropsSize:     "{{FormatBytesSig $obj.Size 2}}",
 		apc.GetPropsChecksum: "{{$obj.Checksum}}",
 		apc.GetPropsAtime:    "{{$obj.Atime}}",
-		apc.GetPropsVersion:  "{{$obj.Version}}",
+		apc.GetPropsVersion:  "{{FormatObjVersion $obj}}",
 		apc.GetPropsLocation: "{{$obj.Location}}",
 		apc.GetPropsCustom:   "{{FormatObjCustom $obj.Custom}}",
 		apc.GetPropsStatus:   "{{FormatObjStatus $obj}}",
 		apc.GetPropsCopies:   "{{$obj.Copies}}",
 		apc.GetPropsCached:   "{{FormatObjIsCached $obj}}",
+		apc.GetPropsOffset:   "{{FormatArchOffset $obj}}",
 	}
 )
 
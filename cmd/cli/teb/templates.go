@@ -96,6 +96,11 @@ const (
 		"{{$p.Name}}\t {{$p.Value}}\n" +
 		"{{end}}"
 
+	ObjTagsTmpl = "KEY\t VALUE\n" +
+		"{{range $p := . }}" +
+		"{{$p.Name}}\t {{$p.Value}}\n" +
+		"{{end}}"
+
 	//
 	// special xactions & dsort
 	//
@@ -109,6 +114,24 @@ const (
 	DownloadListNoHdrTmpl = "{{ range $key, $value := . }}" + downloadListBody + "{{end}}"
 	DownloadListTmpl      = downloadListHdr + DownloadListNoHdrTmpl
 
+	// scheduled downloads
+	dlschedListHdr  = "SCHEDULE ID\t BUCKET\t TYPE\t SCHEDULE\t NEXT RUN\t LAST JOB\t DESCRIPTION\n"
+	dlschedListBody = "{{$value.ID}}\t {{FormatBckName $value.Bck}}\t {{$value.Type}}\t " +
+		"{{if $value.Cron}}{{$value.Cron}}{{else}}every {{$value.Every}}{{end}}\t " +
+		"{{if $value.Paused}}paused{{else}}{{FormatTime $value.NextRun}}{{end}}\t " +
+		"{{if $value.LastJobID}}{{$value.LastJobID}}{{else}}-{{end}}\t {{$value.Description}}\n"
+	DlSchedListNoHdrTmpl = "{{ range $value := . }}" + dlschedListBody + "{{end}}"
+	DlSchedListTmpl      = dlschedListHdr + DlSchedListNoHdrTmpl
+
+	dlschedRunsHdr  = "JOB ID\t START\t FINISH\t ADDED\t UPDATED\t DELETED\t ERRORS\t STATUS\n"
+	dlschedRunsBody = "{{$value.ID}}\t " +
+		"{{FormatStart $value.StartedTime $value.FinishedTime}}\t " +
+		"{{FormatEnd $value.StartedTime $value.FinishedTime}}\t " +
+		"{{$value.AddedCnt}}\t {{$value.UpdatedCnt}}\t {{$value.DeletedCnt}}\t {{$value.ErrorCnt}}\t " +
+		"{{if $value.Aborted}}Aborted{{else}}Finished{{end}}\n"
+	DlSchedTmpl = dlschedListHdr + "{{$value := .}}" + dlschedListBody +
+		"{{if .Runs}}\n" + dlschedRunsHdr + "{{range $value := .Runs}}" + dlschedRunsBody + "{{end}}{{end}}"
+
 	dsortListHdr  = "JOB ID\t STATUS\t START\t FINISH\t DESCRIPTION\n"
 	dsortListBody = "{{$value.ID}}\t " +
 		"{{if $value.Aborted}}Aborted" +
@@ -121,9 +144,15 @@ const (
 	DSortListNoHdrTmpl = "{{ range $value := . }}" + dsortListBody + "{{end}}"
 	DSortListTmpl      = dsortListHdr + DSortListNoHdrTmpl
 
-	transformListHdr  = "ETL NAME\t XACTION\t OBJECTS\n"
+	transformListHdr  = "ETL NAME\t XACTION\t OBJECTS\t CACHE HIT/MISS\n"
 	transformListBody = "{{$value.Name}}\t {{$value.XactID}}\t " +
-		"{{if (eq $value.ObjCount 0) }}-{{else}}{{$value.ObjCount}}{{end}}\n"
+		"{{if (eq $value.ObjCount 0) }}-{{else}}{{$value.ObjCount}}{{end}}\t " +
+		"{{if (or $value.CacheHits $value.CacheMisses)}}{{$value.CacheHits}}/{{$value.CacheMisses}}{{else}}-{{end}}\n" +
+		"{{range $i, $stage := $value.Stages}}" + transformStageBody + "{{end}}"
+	// pipeline stages
+	transformStageBody = "  {{$i}}: {{$stage.Name}}\t -\t " +
+		"{{if (eq $stage.ObjCount 0) }}-{{else}}{{$stage.ObjCount}}{{end}}" +
+		"{{if $stage.ErrCount}} ({{$stage.ErrCount}} errors){{end}}\n"
 	TransformListNoHdrTmpl = "{{ range $value := . }}" + transformListBody + "{{end}}"
 	TransformListTmpl      = transformListHdr + TransformListNoHdrTmpl
 
@@ -391,6 +420,7 @@ var (
 		"FormatMilli":       func(dur cos.Duration) string { return fmtMilli(dur, cos.UnitsIEC) },
 		"FormatStart":       func(s, e time.Time) string { res, _ := FmtStartEnd(s, e); return res },
 		"FormatEnd":         func(s, e time.Time) string { _, res := FmtStartEnd(s, e); return res },
+		"FormatTime":        fmtTime,
 		"FormatEC":          FmtEC,
 		"FormatObjStatus":   fmtObjStatus,
 		"FormatObjCustom":   fmtObjCustom,
@@ -404,6 +434,8 @@ var (
 		"FormatBckName":     func(bck cmn.Bck) string { return bck.Cname("") },
 		"FormatACL":         fmtACL,
 		"FormatNameArch":    fmtNameArch,
+		"FormatArchOffset":  fmtArchOffset,
+		"FormatObjVersion":  fmtObjVersion,
 		"FormatXactState":   FmtXactStatus,
 		//  misc. helpers
 		"IsUnsetTime":   isUnsetTime,
@@ -6,6 +6,7 @@ package teb
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -175,6 +176,25 @@ func fmtNameArch(val string, flags uint16) string {
 	return "    " + val
 }
 
+func fmtArchOffset(obj *cmn.LsoEntry) string {
+	if !obj.IsInsideArch() {
+		return NotSetVal
+	}
+	return strconv.FormatInt(obj.Offset, 10)
+}
+
+// (non-current versions and delete markers are listed with `apc.LsVersions`)
+func fmtObjVersion(obj *cmn.LsoEntry) string {
+	switch {
+	case obj.IsDelMarker():
+		return obj.Version + " (delete marker)"
+	case obj.IsNoncurrent():
+		return obj.Version + " (non-current)"
+	default:
+		return obj.Version
+	}
+}
+
 //
 // cluster.Snap helpers
 //
@@ -233,6 +253,14 @@ func isUnsetTime(t time.Time) bool {
 	return t.IsZero()
 }
 
+// with date (compare w/ FmtStartEnd)
+func fmtTime(t time.Time) string {
+	if t.IsZero() {
+		return NotSetVal
+	}
+	return cos.FormatTime(t, time.Stamp)
+}
+
 func FmtStartEnd(start, end time.Time) (startS, endS string) {
 	startS, endS = NotSetVal, NotSetVal
 	if start.IsZero() {
@@ -6,11 +6,16 @@
 package cmn
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"net/url"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -44,6 +49,108 @@ type (
 		BID         uint64          `json:"bid,string" list:"omit"`         // unique ID
 		Created     int64           `json:"created,string" list:"readonly"` // creation timestamp
 		Versioning  VersionConf     `json:"versioning"`                     // versioning (see "inherit" here and elsewhere)
+		Quota       QuotaConf       `json:"quota"`                          // capacity and object-count limits
+		SSE         SSEConf         `json:"sse"`                            // server-side encryption at rest
+		Lifecycle   LifecycleConf   `json:"lifecycle"`                      // age-based expiration, eviction, and cleanup
+		ObjLock     ObjLockConf     `json:"object_lock"`                    // WORM: default retention (see ais/tgtlock.go)
+		Notif       NotifConf       `json:"notification"`                   // bucket event notifications
+		Download    DownloadConf    `json:"download"`                       // scheduled (recurring) downloads into the bucket
+	}
+
+	// recurring downloads (see ext/dload and ais/prxdlsched.go)
+	// NOTE: `ais start download ... --schedule`; `ais job schedule`
+	DownloadConf struct {
+		Schedules []DlSchedule `json:"schedules"`
+	}
+	DownloadConfToUpdate struct {
+		Schedules *[]DlSchedule `json:"schedules,omitempty"`
+	}
+	// each schedule runs its download request (any download type, see ext/dload/api.go)
+	// either per cron expression or every so often (but not both);
+	// intervals are counted from the time the schedule was created
+	DlSchedule struct {
+		ID      string          `json:"id"`
+		Cron    string          `json:"cron,omitempty"`  // e.g. "0 3 * * *" (see cos.ParseCron)
+		Every   cos.Duration    `json:"every,omitempty"` // e.g. "6h"
+		Request json.RawMessage `json:"request"`         // download request (body)
+		Created int64           `json:"created,string"`
+		Paused  bool            `json:"paused,omitempty"`
+	}
+
+	// bucket event notifications (see event package)
+	// NOTE: `ais bucket props set ... notification.targets=<JSON array>`
+	NotifConf struct {
+		Targets []NotifTarget `json:"targets"`
+	}
+	NotifConfToUpdate struct {
+		Targets *[]NotifTarget `json:"targets,omitempty"`
+	}
+	// each target receives the specified events for the objects that have
+	// the prefix and the suffix (if specified)
+	NotifTarget struct {
+		ID       string   `json:"id"`
+		URL      string   `json:"url"`    // http(s)://... (webhook), file:///path (JSON lines), unix:///path (socket)
+		Events   []string `json:"events"` // EventObjCreated, et al.
+		Prefix   string   `json:"prefix,omitempty"`
+		Suffix   string   `json:"suffix,omitempty"`
+		Disabled bool     `json:"disabled,omitempty"`
+	}
+
+	// object lock (write-once-read-many): when enabled, objects can be protected
+	// by retention (until a given time) and/or legal hold (until removed);
+	// new objects inherit the (optional) default retention
+	// NOTE: once enabled, object lock cannot be disabled
+	ObjLockConf struct {
+		Mode    string `json:"mode"`    // default retention mode: ObjLockGovernance | ObjLockCompliance | "" (none)
+		Days    int    `json:"days"`    // default retention period
+		Enabled bool   `json:"enabled"` // allow retention and legal hold for the bucket's objects
+	}
+	ObjLockConfToUpdate struct {
+		Mode    *string `json:"mode,omitempty"`
+		Days    *int    `json:"days,omitempty"`
+		Enabled *bool   `json:"enabled,omitempty"`
+	}
+
+	// lifecycle rules (see space/lifecycle.go)
+	// NOTE: `ais bucket props set ... lifecycle.rules=<JSON array>`
+	LifecycleConf struct {
+		Rules []LifecycleRule `json:"rules"`
+	}
+	LifecycleConfToUpdate struct {
+		Rules *[]LifecycleRule `json:"rules,omitempty"`
+	}
+	// each rule applies to objects that have the prefix _and_ all the tags (if specified);
+	// "days" are counted from the object's last modification (expiration - ais buckets only) or
+	// last access (eviction), and from the upload's last activity (multipart)
+	LifecycleRule struct {
+		ID             string     `json:"id"`
+		Prefix         string     `json:"prefix,omitempty"`
+		Tags           cos.StrKVs `json:"tags,omitempty"`
+		ExpirationDays int        `json:"expiration_days,omitempty"` // delete objects
+		NoncurrentDays int        `json:"noncurrent_days,omitempty"` // delete non-current object versions
+		AbortMptDays   int        `json:"abort_mpt_days,omitempty"`  // abort incomplete multipart uploads
+		EvictDays      int        `json:"evict_days,omitempty"`      // evict cached copies of remote objects
+		Disabled       bool       `json:"disabled,omitempty"`
+	}
+
+	// server-side encryption (see sse package)
+	SSEConf struct {
+		KeyID   string `json:"key_id"` // key-encryption key; empty means default
+		Enabled bool   `json:"enabled"`
+	}
+	SSEConfToUpdate struct {
+		KeyID   *string `json:"key_id,omitempty"`
+		Enabled *bool   `json:"enabled,omitempty"`
+	}
+
+	// per-bucket quota (zero value means no limit)
+	QuotaConf struct {
+		MaxSize    cos.SizeIEC `json:"max_size"`    // total size of the bucket's objects
+		MaxObjects int64       `json:"max_objects"` // number of objects in the bucket
+	}
+	QuotaConfToUpdate struct {
+		MaxSize    *cos.SizeIEC `json:"max_size,omitempty"`
+		MaxObjects *int64       `json:"max_objects,omitempty"`
 	}
 
 	ExtraProps struct {
@@ -99,6 +206,12 @@ type (
 		Access      *apc.AccessAttrs         `json:"access,string,omitempty"`
 		WritePolicy *WritePolicyConfToUpdate `json:"write_policy,omitempty"`
 		Extra       *ExtraToUpdate           `json:"extra,omitempty"`
+		Quota       *QuotaConfToUpdate       `json:"quota,omitempty"`
+		SSE         *SSEConfToUpdate         `json:"sse,omitempty"`
+		Lifecycle   *LifecycleConfToUpdate   `json:"lifecycle,omitempty"`
+		ObjLock     *ObjLockConfToUpdate     `json:"object_lock,omitempty"`
+		Notif       *NotifConfToUpdate       `json:"notification,omitempty"`
+		Download    *DownloadConfToUpdate    `json:"download,omitempty"`
 		Force       bool                     `json:"force,omitempty" copy:"skip" list:"omit"`
 	}
 
@@ -165,6 +278,35 @@ type (
 		ContinueOnError       bool `json:"coer"` // on err, keep running arc xaction in a any given multi-object transaction
 	}
 
+	// GetBatchMsg is used in GetBatch (multi-object GET) requests: named objects and/or
+	// files archived in those objects, possibly across buckets, are returned in the
+	// request order as a single (streamed) archive in one of the supported formats
+	// (cos.ExtTar, cos.ExtMsgpack); missing entries are returned as placeholders
+	// (see GetBatchMissingPrefix)
+	GetBatchMsg struct {
+		Entries []GetBatchEntry `json:"entries"`
+		Mime    string          `json:"mime,omitempty"` // output format; default: cos.ExtTar
+	}
+	GetBatchEntry struct {
+		Bck      Bck    `json:"bck"`
+		ObjName  string `json:"objname"`
+		ArchPath string `json:"archpath,omitempty"` // extract this file from the (archived) object
+	}
+
+	// ComposeMsg is used to compose (concatenate) existing objects, or byte ranges
+	// thereof, into a new destination object (see apc.ActCompose); the sources,
+	// possibly across buckets, are concatenated in the specified order
+	ComposeMsg struct {
+		Sources   []ComposeSrc `json:"sources"`
+		DeleteSrc bool         `json:"delete_src,omitempty"` // delete source objects upon success
+	}
+	ComposeSrc struct {
+		Bck     Bck    `json:"bck"` // empty: same as destination
+		ObjName string `json:"objname"`
+		Offset  int64  `json:"offset,string,omitempty"`
+		Length  int64  `json:"length,string,omitempty"` // zero: through the end of the object
+	}
+
 	//  Multi-object copy & transform (see also: TCBMsg)
 	TCObjsMsg struct {
 		ToBck Bck `json:"tobck"`
@@ -255,12 +397,14 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 		}
 	}
 	var softErr error
-	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy} {
+	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy, &bp.Quota, &bp.Lifecycle, &bp.ObjLock, &bp.Notif, &bp.Download} {
 		var err error
 		if pv == &bp.EC {
 			err = bp.EC.ValidateAsProps(targetCnt)
 		} else if pv == &bp.Extra {
 			err = bp.Extra.ValidateAsProps(bp.Provider)
+		} else if pv == &bp.Lifecycle {
+			err = bp.Lifecycle.ValidateAsProps(apc.IsRemoteProvider(bp.Provider) || !bp.BackendBck.IsEmpty())
 		} else {
 			err = pv.ValidateAsProps()
 		}
@@ -274,6 +418,13 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 	if bp.Mirror.Enabled && bp.EC.Enabled {
 		return fmt.Errorf("cannot enable mirroring and ec at the same time for the same bucket")
 	}
+	if bp.Versioning.Retain < 0 || bp.Versioning.RetainTime < 0 {
+		return fmt.Errorf("invalid versioning.retain (%d) or versioning.retain_time (%v)",
+			bp.Versioning.Retain, bp.Versioning.RetainTime)
+	}
+	if bp.Versioning.Retains() && (bp.Provider != apc.AIS || !bp.BackendBck.IsEmpty()) {
+		return fmt.Errorf("retaining non-current versions is only supported for ais buckets (have %q)", bp.Provider)
+	}
 	return softErr
 }
 
@@ -321,6 +472,328 @@ func (c *ExtraProps) ValidateAsProps(arg ...any) error {
 	return nil
 }
 
+// quota kinds (see ErrQuotaExceeded)
+const (
+	QuotaSize    = "size"
+	QuotaObjects = "objects"
+)
+
+func (c *QuotaConf) ValidateAsProps(...any) error {
+	if c.MaxSize < 0 {
+		return fmt.Errorf("invalid quota.max_size %d (expecting non-negative)", c.MaxSize)
+	}
+	if c.MaxObjects < 0 {
+		return fmt.Errorf("invalid quota.max_objects %d (expecting non-negative)", c.MaxObjects)
+	}
+	return nil
+}
+
+func (c *QuotaConf) IsSet() bool { return c.MaxSize > 0 || c.MaxObjects > 0 }
+
+//
+// lifecycle
+//
+
+const MaxLifecycleRules = 1000 // (same as S3)
+
+func (c *LifecycleConf) ValidateAsProps(arg ...any) error {
+	remote, ok := arg[0].(bool)
+	debug.Assert(ok)
+	if len(c.Rules) > MaxLifecycleRules {
+		return fmt.Errorf("too many lifecycle rules (%d, max %d)", len(c.Rules), MaxLifecycleRules)
+	}
+	ids := make(cos.StrSet, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.ID == "" {
+			return fmt.Errorf("lifecycle rule #%d: missing ID", i)
+		}
+		if ids.Contains(rule.ID) {
+			return fmt.Errorf("duplicate lifecycle rule ID %q", rule.ID)
+		}
+		ids.Set(rule.ID)
+		if rule.ExpirationDays < 0 || rule.NoncurrentDays < 0 || rule.AbortMptDays < 0 || rule.EvictDays < 0 {
+			return fmt.Errorf("lifecycle rule %q: invalid (negative) number of days", rule.ID)
+		}
+		if rule.ExpirationDays == 0 && rule.NoncurrentDays == 0 && rule.AbortMptDays == 0 && rule.EvictDays == 0 {
+			return fmt.Errorf("lifecycle rule %q: no action specified", rule.ID)
+		}
+		if rule.EvictDays > 0 && !remote {
+			return fmt.Errorf("lifecycle rule %q: evict_days requires a bucket with remote backend", rule.ID)
+		}
+		if rule.ExpirationDays > 0 && remote {
+			return fmt.Errorf("lifecycle rule %q: expiration_days is not supported for buckets with remote backend", rule.ID)
+		}
+		if rule.AbortMptDays > 0 && len(rule.Tags) > 0 {
+			return fmt.Errorf("lifecycle rule %q: abort_mpt_days cannot be used with tags", rule.ID)
+		}
+		if err := ValidateTags(rule.Tags); err != nil {
+			return fmt.Errorf("lifecycle rule %q: %v", rule.ID, err)
+		}
+	}
+	return nil
+}
+
+func (c *LifecycleConf) IsSet() bool {
+	for i := range c.Rules {
+		if !c.Rules[i].Disabled {
+			return true
+		}
+	}
+	return false
+}
+
+// whether the rule applies to a given object (tags are matched iff specified)
+func (rule *LifecycleRule) Match(objName string, oa *ObjAttrs) bool {
+	if rule.Disabled || !strings.HasPrefix(objName, rule.Prefix) {
+		return false
+	}
+	for k, v := range rule.Tags {
+		if tv, ok := oa.GetCustomKey(TagObjMDPrefix + k); !ok || tv != v {
+			return false
+		}
+	}
+	return true
+}
+
+//
+// object lock
+//
+
+// retention modes (see ObjLockConf and ObjAttrs.Retention)
+// - governance: can be shortened or removed by users with (admin) permission to bypass it;
+// - compliance: cannot be shortened or removed by anyone until it expires.
+const (
+	ObjLockGovernance = "governance"
+	ObjLockCompliance = "compliance"
+
+	MaxObjLockDays = 100 * 365
+)
+
+func (c *ObjLockConf) ValidateAsProps(...any) error {
+	if c.Mode != "" && c.Mode != ObjLockGovernance && c.Mode != ObjLockCompliance {
+		return fmt.Errorf("invalid object_lock.mode %q (expecting %q, %q, or none)", c.Mode,
+			ObjLockGovernance, ObjLockCompliance)
+	}
+	if c.Days < 0 || c.Days > MaxObjLockDays {
+		return fmt.Errorf("invalid object_lock.days %d (expecting 0 to %d)", c.Days, MaxObjLockDays)
+	}
+	if (c.Mode == "") != (c.Days == 0) {
+		return fmt.Errorf("default retention requires both object_lock.mode and object_lock.days (have %q, %d)",
+			c.Mode, c.Days)
+	}
+	if c.Mode != "" && !c.Enabled {
+		return errors.New("default retention requires object lock to be enabled")
+	}
+	return nil
+}
+
+// default retention for new objects (nil if none)
+func (c *ObjLockConf) DefaultRetention(now time.Time) *ObjRetention {
+	if !c.Enabled || c.Mode == "" {
+		return nil
+	}
+	return &ObjRetention{Mode: c.Mode, RetainUntil: now.Add(time.Duration(c.Days) * 24 * time.Hour).UTC()}
+}
+
+func (c *ObjLockConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	if c.Mode == "" {
+		return "Enabled"
+	}
+	return fmt.Sprintf("Enabled | %s, %d days", c.Mode, c.Days)
+}
+
+//
+// event notifications
+//
+
+// event kinds (see NotifTarget.Events)
+const (
+	EventObjCreated = "object-created" // PUT, APPEND, multipart upload, promote
+	EventObjRemoved = "object-removed" // DELETE, lifecycle expiration
+	EventObjEvicted = "object-evicted" // remote buckets: evict, LRU, lifecycle eviction
+	EventObjCopied  = "object-copied"  // copy or transform (destination bucket)
+)
+
+const MaxNotifTargets = 16
+
+var EventKinds = []string{EventObjCreated, EventObjRemoved, EventObjEvicted, EventObjCopied}
+
+func (c *NotifConf) ValidateAsProps(...any) error {
+	if len(c.Targets) > MaxNotifTargets {
+		return fmt.Errorf("too many notification targets (%d, max %d)", len(c.Targets), MaxNotifTargets)
+	}
+	ids := make(cos.StrSet, len(c.Targets))
+	for i := range c.Targets {
+		nt := &c.Targets[i]
+		if nt.ID == "" {
+			return fmt.Errorf("notification target #%d: missing ID", i)
+		}
+		if ids.Contains(nt.ID) {
+			return fmt.Errorf("duplicate notification target ID %q", nt.ID)
+		}
+		ids.Set(nt.ID)
+		if err := ValidateNotifURL(nt.URL); err != nil {
+			return fmt.Errorf("notification target %q: %v", nt.ID, err)
+		}
+		if len(nt.Events) == 0 {
+			return fmt.Errorf("notification target %q: no events specified", nt.ID)
+		}
+		for _, kind := range nt.Events {
+			if !cos.StringInSlice(kind, EventKinds) {
+				return fmt.Errorf("notification target %q: invalid event %q (expecting one of %v)",
+					nt.ID, kind, EventKinds)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *NotifConf) IsSet() bool {
+	for i := range c.Targets {
+		if !c.Targets[i].Disabled {
+			return true
+		}
+	}
+	return false
+}
+
+// webhook (http, https) or local sink (file, unix socket)
+func ValidateNotifURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %v", s, err)
+	}
+	switch u.Scheme {
+	case "http", "https":
+		if u.Host == "" {
+			return fmt.Errorf("invalid URL %q: missing host", s)
+		}
+	case "file", "unix":
+		if u.Host != "" || !filepath.IsAbs(u.Path) {
+			return fmt.Errorf("invalid URL %q: expecting %s:///absolute/path", s, u.Scheme)
+		}
+	default:
+		return fmt.Errorf("invalid URL %q: unsupported scheme (expecting http, https, file, or unix)", s)
+	}
+	return nil
+}
+
+// whether the target subscribes to a given event for a given object
+func (nt *NotifTarget) Match(kind, objName string) bool {
+	if nt.Disabled || !strings.HasPrefix(objName, nt.Prefix) || !strings.HasSuffix(objName, nt.Suffix) {
+		return false
+	}
+	return cos.StringInSlice(kind, nt.Events)
+}
+
+//
+// scheduled downloads
+//
+
+const (
+	MaxDlSchedules = 64
+	MinDlEvery     = time.Minute
+)
+
+func (c *DownloadConf) ValidateAsProps(...any) error {
+	if len(c.Schedules) > MaxDlSchedules {
+		return fmt.Errorf("too many download schedules (%d, max %d)", len(c.Schedules), MaxDlSchedules)
+	}
+	ids := make(cos.StrSet, len(c.Schedules))
+	for i := range c.Schedules {
+		sched := &c.Schedules[i]
+		if sched.ID == "" {
+			return fmt.Errorf("download schedule #%d: missing ID", i)
+		}
+		if ids.Contains(sched.ID) {
+			return fmt.Errorf("duplicate download schedule ID %q", sched.ID)
+		}
+		ids.Set(sched.ID)
+		if err := sched.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *DownloadConf) IsSet() bool {
+	for i := range c.Schedules {
+		if !c.Schedules[i].Paused {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *DownloadConf) Find(id string) *DlSchedule {
+	for i := range c.Schedules {
+		if c.Schedules[i].ID == id {
+			return &c.Schedules[i]
+		}
+	}
+	return nil
+}
+
+func (sched *DlSchedule) Validate() error {
+	switch {
+	case sched.Cron == "" && sched.Every == 0:
+		return fmt.Errorf("download schedule %q: either cron expression or interval must be specified", sched.ID)
+	case sched.Cron != "" && sched.Every != 0:
+		return fmt.Errorf("download schedule %q: cron expression and interval are mutually exclusive", sched.ID)
+	case sched.Cron != "":
+		if _, err := cos.ParseCron(sched.Cron); err != nil {
+			return fmt.Errorf("download schedule %q: %v", sched.ID, err)
+		}
+	case sched.Every.D() < MinDlEvery:
+		return fmt.Errorf("download schedule %q: interval %v is too short (min %v)", sched.ID, sched.Every, MinDlEvery)
+	}
+	if len(sched.Request) == 0 {
+		return fmt.Errorf("download schedule %q: missing download request", sched.ID)
+	}
+	return nil
+}
+
+// the time of the next run after `now` (zero time if none)
+func (sched *DlSchedule) Next(now time.Time) time.Time {
+	if sched.Cron != "" {
+		cron, err := cos.ParseCron(sched.Cron)
+		if err != nil {
+			debug.AssertNoErr(err) // validated
+			return time.Time{}
+		}
+		return cron.Next(now)
+	}
+	var (
+		every   = sched.Every.D()
+		created = time.Unix(0, sched.Created)
+	)
+	if every <= 0 {
+		return time.Time{}
+	}
+	if now.Before(created) {
+		return created.Add(every)
+	}
+	n := now.Sub(created)/every + 1
+	return created.Add(n * every)
+}
+
+func (sched DlSchedule) String() string {
+	var s string
+	if sched.Cron != "" {
+		s = sched.ID + "[" + sched.Cron + "]"
+	} else {
+		s = sched.ID + "[every " + sched.Every.String() + "]"
+	}
+	if sched.Paused {
+		s += "(paused)"
+	}
+	return s
+}
+
 //
 // bucket summary
 //
@@ -397,3 +870,80 @@ func (lrm *ListRange) HasTemplate() bool { return lrm.Template != "" }
 ////////////////
 
 func (msg *ArchiveMsg) Cname() string { return msg.ToBck.Cname(msg.ArchName) }
+
+/////////////////
+// GetBatchMsg //
+/////////////////
+
+// placeholder entries: GetBatchMissingPrefix + GetBatchEntry.NameInArch()
+const GetBatchMissingPrefix = "__404__/"
+
+func (msg *GetBatchMsg) Validate() error {
+	if len(msg.Entries) == 0 {
+		return errors.New("get-batch: empty list of entries")
+	}
+	switch msg.Mime {
+	case "":
+		msg.Mime = cos.ExtTar
+	case cos.ExtTar, cos.ExtMsgpack:
+	default:
+		return fmt.Errorf("get-batch: unsupported output format %q (expecting %q or %q)", msg.Mime, cos.ExtTar, cos.ExtMsgpack)
+	}
+	for i := range msg.Entries {
+		e := &msg.Entries[i]
+		if e.ObjName == "" {
+			return fmt.Errorf("get-batch: entry #%d (%s) has no object name", i, e.Bck)
+		}
+		if err := e.Bck.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// name of the entry in the resulting archive: "<bucket>/<object>[/<archpath>]"
+func (e *GetBatchEntry) NameInArch() string {
+	name := e.Bck.Name + "/" + e.ObjName
+	if e.ArchPath != "" {
+		name += "/" + e.ArchPath
+	}
+	return name
+}
+
+func (e *GetBatchEntry) Cname() string {
+	if e.ArchPath == "" {
+		return e.Bck.Cname(e.ObjName)
+	}
+	return e.Bck.Cname(e.ObjName) + "/" + e.ArchPath
+}
+
+////////////////
+// ComposeMsg //
+////////////////
+
+const ComposeMaxSources = 1024
+
+func (msg *ComposeMsg) Validate() error {
+	switch l := len(msg.Sources); {
+	case l == 0:
+		return errors.New("compose: empty list of sources")
+	case l > ComposeMaxSources:
+		return fmt.Errorf("compose: too many sources (%d, max %d)", l, ComposeMaxSources)
+	}
+	for i := range msg.Sources {
+		src := &msg.Sources[i]
+		if src.ObjName == "" {
+			return fmt.Errorf("compose: source #%d (%s) has no object name", i, src.Bck)
+		}
+		if src.Offset < 0 || src.Length < 0 {
+			return fmt.Errorf("compose: source #%d (%s): invalid range (offset %d, length %d)",
+				i, src.Cname(), src.Offset, src.Length)
+		}
+		if err := src.Bck.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (src *ComposeSrc) Cname() string { return src.Bck.Cname(src.ObjName) }
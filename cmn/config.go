@@ -224,6 +224,9 @@ type (
 		Startup         cos.Duration `json:"startup_time"`
 		JoinAtStartup   cos.Duration `json:"join_startup_time"` // (join cluster at startup) timeout
 		SendFile        cos.Duration `json:"send_file_time"`
+		// incomplete S3 multipart uploads with no activity for longer than
+		// this time get aborted (0 (zero) - use the default)
+		MptAbort cos.Duration `json:"mpt_abort_time"`
 	}
 	TimeoutConfToUpdate struct {
 		CplaneOperation *cos.Duration `json:"cplane_operation,omitempty"`
@@ -232,6 +235,7 @@ type (
 		Startup         *cos.Duration `json:"startup_time,omitempty"`
 		JoinAtStartup   *cos.Duration `json:"join_startup_time,omitempty"`
 		SendFile        *cos.Duration `json:"send_file_time,omitempty"`
+		MptAbort        *cos.Duration `json:"mpt_abort_time,omitempty"`
 	}
 
 	ClientConf struct {
@@ -371,10 +375,18 @@ type (
 
 		// Validate object version upon warm GET.
 		ValidateWarmGet bool `json:"validate_warm_get"`
+
+		// Retain up to `Retain` non-current (previous) versions of objects in ais buckets
+		// and/or non-current versions that are younger than `RetainTime`;
+		// zero in both fields (default) means no retention: PUT overwrites, DELETE deletes.
+		Retain     int          `json:"retain"`
+		RetainTime cos.Duration `json:"retain_time"`
 	}
 	VersionConfToUpdate struct {
-		Enabled         *bool `json:"enabled,omitempty"`
-		ValidateWarmGet *bool `json:"validate_warm_get,omitempty"`
+		Enabled         *bool         `json:"enabled,omitempty"`
+		ValidateWarmGet *bool         `json:"validate_warm_get,omitempty"`
+		Retain          *int          `json:"retain,omitempty"`
+		RetainTime      *cos.Duration `json:"retain_time,omitempty"`
 	}
 
 	TestFSPConf struct {
@@ -625,6 +637,10 @@ var (
 	_ PropsValidator = (*MirrorConf)(nil)
 	_ PropsValidator = (*ECConf)(nil)
 	_ PropsValidator = (*WritePolicyConf)(nil)
+	_ PropsValidator = (*QuotaConf)(nil)
+	_ PropsValidator = (*LifecycleConf)(nil)
+	_ PropsValidator = (*ObjLockConf)(nil)
+	_ PropsValidator = (*NotifConf)(nil)
 
 	_ json.Marshaler   = (*BackendConf)(nil)
 	_ json.Unmarshaler = (*BackendConf)(nil)
@@ -1039,9 +1055,18 @@ func (c *VersionConf) Validate() error {
 	if !c.Enabled && c.ValidateWarmGet {
 		return errors.New("versioning.validate_warm_get requires versioning to be enabled")
 	}
+	if c.Retain < 0 || c.RetainTime < 0 {
+		return fmt.Errorf("invalid versioning.retain (%d) or versioning.retain_time (%v)", c.Retain, c.RetainTime)
+	}
+	if !c.Enabled && (c.Retain > 0 || c.RetainTime > 0) {
+		return errors.New("retaining non-current versions requires versioning to be enabled")
+	}
 	return nil
 }
 
+// whether to keep non-current object versions
+func (c *VersionConf) Retains() bool { return c.Enabled && (c.Retain > 0 || c.RetainTime > 0) }
+
 func (c *VersionConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
@@ -1053,6 +1078,12 @@ func (c *VersionConf) String() string {
 	} else {
 		text += "no"
 	}
+	if c.Retain > 0 {
+		text += " | Retain: " + strconv.Itoa(c.Retain)
+	}
+	if c.RetainTime > 0 {
+		text += " | Retain time: " + c.RetainTime.String()
+	}
 
 	return text
 }
@@ -1551,6 +1582,9 @@ func (c *TimeoutConf) Validate() error {
 	if c.SendFile.D() < time.Minute {
 		return fmt.Errorf("invalid timeout.send_file_time=%s (cannot be less than 1m)", c.SendFile)
 	}
+	if c.MptAbort != 0 && c.MptAbort.D() < time.Hour {
+		return fmt.Errorf("invalid timeout.mpt_abort_time=%s (cannot be less than 1h)", c.MptAbort)
+	}
 	return nil
 }
 
@@ -0,0 +1,169 @@
+// Package cos provides common low-level types and utilities for all aistore projects.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed standard (5-field) cron expression:
+//
+//	minute (0-59) hour (0-23) day-of-month (1-31) month (1-12) day-of-week (0-6, Sunday = 0 or 7)
+//
+// Each field is either "*", a number, a range ("1-5"), or a comma-separated list
+// of those, with an optional step ("*/15", "0-30/10"). Also supported are the
+// shortcuts: "@yearly" ("@annually"), "@monthly", "@weekly", "@daily" ("@midnight"),
+// and "@hourly". Time is evaluated in the location of the time passed to `Next`.
+type Cron struct {
+	fields  [5]uint64 // bitmasks: minute, hour, day-of-month, month, day-of-week
+	anyDom  bool      // day-of-month is "*"
+	anyDow  bool      // day-of-week is "*"
+	literal string
+}
+
+const (
+	cronMinute = iota
+	cronHour
+	cronDom
+	cronMonth
+	cronDow
+)
+
+// lookahead limit (e.g., "0 0 30 2 *" never happens)
+const cronMaxYears = 5
+
+var (
+	cronBounds = [5]struct {
+		name     string
+		min, max int
+	}{
+		{"minute", 0, 59},
+		{"hour", 0, 23},
+		{"day-of-month", 1, 31},
+		{"month", 1, 12},
+		{"day-of-week", 0, 7},
+	}
+	cronShortcuts = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+func ParseCron(s string) (*Cron, error) {
+	expr := strings.TrimSpace(s)
+	if sc, ok := cronShortcuts[strings.ToLower(expr)]; ok {
+		expr = sc
+	}
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronBounds) {
+		return nil, fmt.Errorf("invalid cron expression %q: expecting %d fields (minute, hour, day-of-month, month, day-of-week)",
+			s, len(cronBounds))
+	}
+	c := &Cron{literal: s}
+	for i, part := range parts {
+		mask, err := parseCronField(part, cronBounds[i].min, cronBounds[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %s: %v", s, cronBounds[i].name, err)
+		}
+		c.fields[i] = mask
+	}
+	if c.fields[cronDow]&(1<<7) != 0 { // Sunday
+		c.fields[cronDow] |= 1
+	}
+	c.anyDom, c.anyDow = parts[cronDom] == "*", parts[cronDow] == "*"
+	return c, nil
+}
+
+func parseCronField(s string, lo, hi int) (mask uint64, err error) {
+	for _, item := range strings.Split(s, ",") {
+		var (
+			start, end = lo, hi
+			step       = 1
+			rng        = item
+		)
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			rng = item[:i]
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", item)
+			}
+		}
+		switch {
+		case rng == "*":
+		case strings.IndexByte(rng, '-') > 0:
+			i := strings.IndexByte(rng, '-')
+			if start, err = strconv.Atoi(rng[:i]); err != nil {
+				return 0, fmt.Errorf("invalid range %q", item)
+			}
+			if end, err = strconv.Atoi(rng[i+1:]); err != nil {
+				return 0, fmt.Errorf("invalid range %q", item)
+			}
+		default:
+			if start, err = strconv.Atoi(rng); err != nil {
+				return 0, fmt.Errorf("invalid value %q", item)
+			}
+			if rng == item { // single value (w/o step)
+				end = start
+			}
+		}
+		if start < lo || end > hi || start > end {
+			return 0, fmt.Errorf("%q is out of range [%d, %d]", item, lo, hi)
+		}
+		for v := start; v <= end; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+func (c *Cron) String() string { return c.literal }
+
+// Next returns the earliest time (minute) after `t` that matches the expression,
+// or zero time if there's none within a few years.
+func (c *Cron) Next(t time.Time) time.Time {
+	var (
+		loc   = t.Location()
+		limit = t.AddDate(cronMaxYears, 0, 0)
+	)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for t.Before(limit) {
+		if !c.match(cronMonth, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.match(cronHour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !c.match(cronMinute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c *Cron) match(field, v int) bool { return c.fields[field]&(1<<uint(v)) != 0 }
+
+// when both day-of-month and day-of-week are restricted, either one matches (as in cron(8))
+func (c *Cron) matchDay(t time.Time) bool {
+	dom, dow := c.match(cronDom, t.Day()), c.match(cronDow, int(t.Weekday()))
+	if c.anyDom || c.anyDow {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,56 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos_test
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+	// Wednesday
+	now := time.Date(2023, time.March, 15, 10, 17, 30, 0, time.UTC)
+
+	DescribeTable("next run",
+		func(expr string, expected time.Time) {
+			cron, err := cos.ParseCron(expr)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(cron.Next(now)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2023, time.March, 15, 10, 18, 0, 0, time.UTC)),
+		Entry("step", "*/15 * * * *", time.Date(2023, time.March, 15, 10, 30, 0, 0, time.UTC)),
+		Entry("daily", "0 3 * * *", time.Date(2023, time.March, 16, 3, 0, 0, 0, time.UTC)),
+		Entry("shortcut", "@hourly", time.Date(2023, time.March, 15, 11, 0, 0, 0, time.UTC)),
+		Entry("list and range", "5,45 8-10 * * *", time.Date(2023, time.March, 15, 10, 45, 0, 0, time.UTC)),
+		Entry("day of week", "0 0 * * 0", time.Date(2023, time.March, 19, 0, 0, 0, 0, time.UTC)),
+		Entry("Sunday as 7", "0 0 * * 7", time.Date(2023, time.March, 19, 0, 0, 0, 0, time.UTC)),
+		Entry("day of month", "30 6 1 * *", time.Date(2023, time.April, 1, 6, 30, 0, 0, time.UTC)),
+		Entry("day of month or week", "0 0 1 * 5", time.Date(2023, time.March, 17, 0, 0, 0, 0, time.UTC)),
+		Entry("leap day", "0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)),
+	)
+
+	It("should not find impossible dates", func() {
+		cron, err := cos.ParseCron("0 0 31 2 *")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cron.Next(now).IsZero()).To(BeTrue())
+	})
+
+	DescribeTable("invalid expressions",
+		func(expr string) {
+			_, err := cos.ParseCron(expr)
+			Expect(err).Should(HaveOccurred())
+		},
+		Entry("too few fields", "* * * *"),
+		Entry("out of range", "60 * * * *"),
+		Entry("invalid range", "0 10-2 * * *"),
+		Entry("zero step", "*/0 * * * *"),
+		Entry("not a number", "a * * * *"),
+		Entry("day zero", "0 0 0 * *"),
+	)
+})
@@ -12,6 +12,7 @@ const (
 	// range to read:
 	HdrRange          = "Range" // Ref: https://www.rfc-editor.org/rfc/rfc7233#section-2.1
 	HdrRangeValPrefix = "bytes="
+	HdrIfRange        = "If-Range" // Ref: https://www.rfc-editor.org/rfc/rfc7233#section-3.2
 	// range read response:
 	HdrContentRange          = "Content-Range"
 	HdrContentRangeValPrefix = "bytes " // Ref: https://tools.ietf.org/html/rfc7233#section-4.2
@@ -28,6 +29,8 @@ const (
 	HdrLocation  = "Location"
 	HdrServer    = "Server"
 	HdrETag      = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Hdrs/ETag
+
+	HdrLastModified = "Last-Modified"
 )
 
 // provider-specific headers (=> custom props, and more)
@@ -43,9 +46,23 @@ const (
 
 	// s3 api request headers
 	S3HdrObjSrc        = "x-amz-copy-source"
+	S3HdrObjSrcRange   = "x-amz-copy-source-range"
 	S3HdrMptCnt        = "x-amz-mp-parts-count"
 	S3HdrContentSHA256 = "x-amz-content-sha256"
 	S3HdrBckRegion     = "x-amz-bucket-region"
+	S3HdrSSE           = "x-amz-server-side-encryption"
+	S3HdrSSEKeyID      = "x-amz-server-side-encryption-aws-kms-key-id"
+	S3HdrDate          = "x-amz-date"
+	S3HdrSecurityToken = "x-amz-security-token"
+	S3HdrTagging       = "x-amz-tagging"
+	S3HdrTaggingCount  = "x-amz-tagging-count"
+	S3HdrDeleteMarker  = "x-amz-delete-marker"
+
+	S3HdrObjLockMode      = "x-amz-object-lock-mode"
+	S3HdrObjLockUntil     = "x-amz-object-lock-retain-until-date"
+	S3HdrObjLegalHold     = "x-amz-object-lock-legal-hold"
+	S3HdrBckObjLock       = "x-amz-bucket-object-lock-enabled"
+	S3HdrBypassGovernance = "x-amz-bypass-governance-retention"
 
 	S3ChecksumCRC32  = "x-amz-checksum-crc32"
 	S3ChecksumCRC32C = "x-amz-checksum-crc32c"
@@ -27,6 +27,13 @@ type (
 		io.ReadCloser
 		Open() (ReadOpenCloser, error)
 	}
+	// LomReader is opened object content: file handle or a wrapper thereof
+	// (e.g., decrypting reader - see sse package) that supports range reads.
+	LomReader interface {
+		ReadOpenCloser
+		io.ReaderAt
+		io.Seeker
+	}
 	// ReadSizer is the interface that adds Size method to io.Reader.
 	ReadSizer interface {
 		io.Reader
@@ -140,6 +147,7 @@ type (
 var (
 	_ io.Reader      = (*nopReader)(nil)
 	_ ReadOpenCloser = (*FileHandle)(nil)
+	_ LomReader      = (*FileHandle)(nil)
 	_ ReadOpenCloser = (*CallbackROC)(nil)
 	_ ReadSizer      = (*sizedReader)(nil)
 	_ ReadOpenCloser = (*SectionHandle)(nil)
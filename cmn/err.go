@@ -80,6 +80,19 @@ type (
 		usedPct        int32
 		oos            bool
 	}
+	ErrQuotaExceeded struct {
+		bck   Bck
+		what  string // "size" | "objects"
+		limit int64
+		used  int64
+	}
+	ErrInvalidTag struct {
+		msg string
+	}
+	ErrObjLocked struct {
+		name   string
+		reason string
+	}
 	ErrBucketAccessDenied struct{ errAccessDenied }
 	ErrObjectAccessDenied struct{ errAccessDenied }
 	errAccessDenied       struct {
@@ -392,6 +405,51 @@ func IsErrCapacityExceeded(err error) bool {
 	return ok
 }
 
+// ErrQuotaExceeded
+
+func NewErrQuotaExceeded(bck *Bck, what string, limit, used int64) *ErrQuotaExceeded {
+	return &ErrQuotaExceeded{bck: *bck, what: what, limit: limit, used: used}
+}
+
+func (e *ErrQuotaExceeded) Error() string {
+	if e.what == QuotaObjects {
+		return fmt.Sprintf("bucket %s: exceeded quota.max_objects (%d objects, limit %d)", e.bck, e.used, e.limit)
+	}
+	return fmt.Sprintf("bucket %s: exceeded quota.max_size (%s, limit %s)", e.bck,
+		cos.ToSizeIEC(e.used, 2), cos.ToSizeIEC(e.limit, 2))
+}
+
+func IsErrQuotaExceeded(err error) bool {
+	_, ok := err.(*ErrQuotaExceeded)
+	return ok
+}
+
+// ErrInvalidTag
+
+func NewErrInvalidTag(format string, a ...any) *ErrInvalidTag {
+	return &ErrInvalidTag{msg: fmt.Sprintf(format, a...)}
+}
+
+func (e *ErrInvalidTag) Error() string { return "invalid object tag: " + e.msg }
+
+func IsErrInvalidTag(err error) bool {
+	_, ok := err.(*ErrInvalidTag)
+	return ok
+}
+
+// ErrObjLocked
+
+func NewErrObjLocked(name, reason string) *ErrObjLocked {
+	return &ErrObjLocked{name: name, reason: reason}
+}
+
+func (e *ErrObjLocked) Error() string { return "object " + e.name + " is locked: " + e.reason }
+
+func IsErrObjLocked(err error) bool {
+	_, ok := err.(*ErrObjLocked)
+	return ok
+}
+
 // ErrInvalidCksum
 
 func (e *ErrInvalidCksum) Error() string {
@@ -24,6 +24,8 @@ const (
 	DontAutoDetectFshare      // when promoting NFS shares to AIS
 	ProvideS3APIViaRoot       // handle s3 compat via `aistore-hostname/` (default: `aistore-hostname/s3`)
 	FsyncPUT                  // when finalizing PUT(obj) fflush prior to (close, rename) sequence
+	LocalETL                  // run ETL transformers as local processes when not deployed in Kubernetes (see ext/etl/local.go)
+	IndexArchOnPUT            // index TAR objects upon PUT (default: upon the first read of an archived file)
 )
 
 var All = []string{
@@ -33,6 +35,8 @@ var All = []string{
 	"Do-not-Auto-Detect-FileShare",
 	"Provide-S3-API-via-Root",
 	"Fsync-PUT",
+	"Enable-Local-ETL",
+	"Index-Archives-on-PUT",
 }
 
 func (f Flags) IsSet(flag Flags) bool { return cos.BitFlags(f).IsSet(cos.BitFlags(flag)) }
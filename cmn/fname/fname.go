@@ -30,6 +30,7 @@ const (
 	BmdPrevious = Bmd + ".prev" // bmd previous version
 	Vmd         = ".ais.vmd"    // vmd persistent file basename
 	Emd         = ".ais.emd"    // emd persistent file basename
+	QuotaUsage  = ".ais.quota"  // per-target bucket usage (see ais/tgtquota.go)
 
 	// CLI config
 	CliConfig = "cli.json" // see jsp/app.go
@@ -46,4 +47,7 @@ const (
 	ResilverMarker      = "resilver"
 	RebalanceMarker     = "rebalance"
 	NodeRestartedMarker = "node_restarted"
+
+	// dSort checkpoints: per mountpath
+	DsortDir = ".ais.dsort"
 )
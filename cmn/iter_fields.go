@@ -15,6 +15,7 @@ import (
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	jsoniter "github.com/json-iterator/go"
 )
 
 const (
@@ -353,7 +354,7 @@ func (f *field) SetValue(src any, force ...bool) error {
 					var d time.Duration
 					d, err = time.ParseDuration(s)
 					n = int64(d)
-				} else if dst.Type().Name() == "Size" /*cos.Size*/ {
+				} else if dst.Type().Name() == "SizeIEC" /*cos.SizeIEC*/ {
 					n, err = cos.ParseSize(s, cos.UnitsIEC)
 				}
 			}
@@ -384,6 +385,13 @@ func (f *field) SetValue(src any, force ...bool) error {
 			dst = dst.Elem()                        // dereference pointer
 			goto reflectDst
 		case reflect.Slice:
+			// A slice of structs is JSON-encoded, e.g.: `[{"id": "a", ...}, {...}]`
+			if dst.Type().Elem().Kind() == reflect.Struct {
+				if err := jsoniter.Unmarshal([]byte(s), dst.Addr().Interface()); err != nil {
+					return fmt.Errorf("invalid value %q for property %q: %v", s, f.name, err)
+				}
+				break
+			}
 			// A slice value looks like: "[value1 value2]"
 			s := strings.TrimPrefix(srcVal.String(), "[")
 			s = strings.TrimSuffix(s, "]")
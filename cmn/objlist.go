@@ -23,14 +23,15 @@ const MsgpLsoBufSize = 32 * cos.KiB
 // (all statuses are mutually exclusive)
 type (
 	LsoEntry struct {
-		Name     string `json:"name" msg:"n"`                            // object name
-		Checksum string `json:"checksum,omitempty" msg:"cs,omitempty"`   // checksum
-		Atime    string `json:"atime,omitempty" msg:"a,omitempty"`       // last access time; formatted as ListObjsMsg.TimeFormat
-		Version  string `json:"version,omitempty" msg:"v,omitempty"`     // e.g., GCP int64 generation, AWS version (string), etc.
-		Location string `json:"location,omitempty" msg:"t,omitempty"`    // [tnode:mountpath]
-		Custom   string `json:"custom-md,omitempty" msg:"m,omitempty"`   // custom metadata: ETag, MD5, CRC, user-defined ...
-		Size     int64  `json:"size,string,omitempty" msg:"s,omitempty"` // size in bytes
-		Copies   int16  `json:"copies,omitempty" msg:"c,omitempty"`      // ## copies (NOTE: for non-replicated object copies == 1)
+		Name     string `json:"name" msg:"n"`                              // object name
+		Checksum string `json:"checksum,omitempty" msg:"cs,omitempty"`     // checksum
+		Atime    string `json:"atime,omitempty" msg:"a,omitempty"`         // last access time; formatted as ListObjsMsg.TimeFormat
+		Version  string `json:"version,omitempty" msg:"v,omitempty"`       // e.g., GCP int64 generation, AWS version (string), etc.
+		Location string `json:"location,omitempty" msg:"t,omitempty"`      // [tnode:mountpath]
+		Custom   string `json:"custom-md,omitempty" msg:"m,omitempty"`     // custom metadata: ETag, MD5, CRC, user-defined ...
+		Size     int64  `json:"size,string,omitempty" msg:"s,omitempty"`   // size in bytes
+		Offset   int64  `json:"offset,string,omitempty" msg:"o,omitempty"` // archived file offset (see apc.LsArchDir)
+		Copies   int16  `json:"copies,omitempty" msg:"c,omitempty"`        // ## copies (NOTE: for non-replicated object copies == 1)
 		Flags    uint16 `json:"flags,omitempty" msg:"f,omitempty"`
 	}
 
@@ -55,6 +56,8 @@ func (be *LsoEntry) SetPresent()       { be.Flags |= apc.EntryIsCached }
 func (be *LsoEntry) IsStatusOK() bool   { return be.Status() == 0 }
 func (be *LsoEntry) Status() uint16     { return be.Flags & apc.EntryStatusMask }
 func (be *LsoEntry) IsInsideArch() bool { return be.Flags&apc.EntryInArch != 0 }
+func (be *LsoEntry) IsNoncurrent() bool { return be.Flags&apc.EntryIsNoncurrent != 0 }
+func (be *LsoEntry) IsDelMarker() bool  { return be.Flags&apc.EntryIsDelMarker != 0 }
 func (be *LsoEntry) String() string     { return "{" + be.Name + "}" }
 
 func (be *LsoEntry) CopyWithProps(propsSet cos.StrSet) (ne *LsoEntry) {
@@ -80,5 +83,8 @@ func (be *LsoEntry) CopyWithProps(propsSet cos.StrSet) (ne *LsoEntry) {
 	if propsSet.Contains(apc.GetPropsCopies) {
 		ne.Copies = be.Copies
 	}
+	if propsSet.Contains(apc.GetPropsOffset) {
+		ne.Offset = be.Offset
+	}
 	return
 }
@@ -68,6 +68,12 @@ func (z *LsoEntry) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "Size")
 				return
 			}
+		case "o":
+			z.Offset, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "Offset")
+				return
+			}
 		case "c":
 			z.Copies, err = dc.ReadInt16()
 			if err != nil {
@@ -94,8 +100,8 @@ func (z *LsoEntry) DecodeMsg(dc *msgp.Reader) (err error) {
 // EncodeMsg implements msgp.Encodable
 func (z *LsoEntry) EncodeMsg(en *msgp.Writer) (err error) {
 	// omitempty: check for empty values
-	zb0001Len := uint32(9)
-	var zb0001Mask uint16 /* 9 bits */
+	zb0001Len := uint32(10)
+	var zb0001Mask uint16 /* 10 bits */
 	if z.Checksum == "" {
 		zb0001Len--
 		zb0001Mask |= 0x2
@@ -120,14 +126,18 @@ func (z *LsoEntry) EncodeMsg(en *msgp.Writer) (err error) {
 		zb0001Len--
 		zb0001Mask |= 0x40
 	}
-	if z.Copies == 0 {
+	if z.Offset == 0 {
 		zb0001Len--
 		zb0001Mask |= 0x80
 	}
-	if z.Flags == 0 {
+	if z.Copies == 0 {
 		zb0001Len--
 		zb0001Mask |= 0x100
 	}
+	if z.Flags == 0 {
+		zb0001Len--
+		zb0001Mask |= 0x200
+	}
 	// variable map header, size zb0001Len
 	err = en.Append(0x80 | uint8(zb0001Len))
 	if err != nil {
@@ -219,6 +229,18 @@ func (z *LsoEntry) EncodeMsg(en *msgp.Writer) (err error) {
 		}
 	}
 	if (zb0001Mask & 0x80) == 0 { // if not empty
+		// write "o"
+		err = en.Append(0xa1, 0x6f)
+		if err != nil {
+			return
+		}
+		err = en.WriteInt64(z.Offset)
+		if err != nil {
+			err = msgp.WrapError(err, "Offset")
+			return
+		}
+	}
+	if (zb0001Mask & 0x100) == 0 { // if not empty
 		// write "c"
 		err = en.Append(0xa1, 0x63)
 		if err != nil {
@@ -230,7 +252,7 @@ func (z *LsoEntry) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	}
-	if (zb0001Mask & 0x100) == 0 { // if not empty
+	if (zb0001Mask & 0x200) == 0 { // if not empty
 		// write "f"
 		err = en.Append(0xa1, 0x66)
 		if err != nil {
@@ -247,7 +269,7 @@ func (z *LsoEntry) EncodeMsg(en *msgp.Writer) (err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *LsoEntry) Msgsize() (s int) {
-	s = 1 + 2 + msgp.StringPrefixSize + len(z.Name) + 3 + msgp.StringPrefixSize + len(z.Checksum) + 2 + msgp.StringPrefixSize + len(z.Atime) + 2 + msgp.StringPrefixSize + len(z.Version) + 2 + msgp.StringPrefixSize + len(z.Location) + 2 + msgp.StringPrefixSize + len(z.Custom) + 2 + msgp.Int64Size + 2 + msgp.Int16Size + 2 + msgp.Uint16Size
+	s = 1 + 2 + msgp.StringPrefixSize + len(z.Name) + 3 + msgp.StringPrefixSize + len(z.Checksum) + 2 + msgp.StringPrefixSize + len(z.Atime) + 2 + msgp.StringPrefixSize + len(z.Version) + 2 + msgp.StringPrefixSize + len(z.Location) + 2 + msgp.StringPrefixSize + len(z.Custom) + 2 + msgp.Int64Size + 2 + msgp.Int64Size + 2 + msgp.Int16Size + 2 + msgp.Uint16Size
 	return
 }
 
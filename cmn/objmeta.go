@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -35,9 +36,36 @@ const (
 
 	OrigURLObjMD = "orig_url"
 
+	// server-side encryption (see sse package): key ID and wrapped data key
+	SSEKeyIDObjMD = "sse-kid"
+	SSEKeyObjMD   = "sse-dek"
+
 	// additional backend
 	LastModified    = "LastModified"
 	ContentEncoding = "ContentEncoding"
+
+	// user-defined object tags are custom keys with this prefix (e.g. "tag.split" => "train")
+	TagObjMDPrefix = "tag."
+
+	// object lock (see BucketProps.ObjLock): retention mode, retain-until time (RFC3339),
+	// and legal hold ("ON" when set)
+	LockModeObjMD   = "lock.mode"
+	LockUntilObjMD  = "lock.retain-until"
+	LegalHoldObjMD  = "lock.legal-hold"
+	LockObjMDPrefix = "lock."
+
+	// delete marker: (zero-size) current version of a deleted object in a bucket
+	// that retains non-current versions (see VersionConf.Retain)
+	DelMarkerObjMD = "delete-marker"
+)
+
+// object tagging limits (same as S3 except `MaxTagsSize` that makes sure
+// tags fit into the LOM metadata xattr - see cluster/lom_xattr.go)
+const (
+	MaxTagsPerObj = 10
+	MaxTagKeyLen  = 128
+	MaxTagValLen  = 256
+	MaxTagsSize   = 1536 // total length of all keys and values
 )
 
 // object properties
@@ -128,6 +156,250 @@ func (oa *ObjAttrs) DelCustomKeys(keys ...string) {
 	}
 }
 
+// system-maintained custom keys that users can neither set nor remove
+// (e.g., via apc.ActSetCustomProps)
+func IsReservedObjMD(key string) bool {
+	switch key {
+	case SSEKeyIDObjMD, SSEKeyObjMD, DelMarkerObjMD:
+		return true
+	}
+	return strings.HasPrefix(key, LockObjMDPrefix)
+}
+
+// custom metadata as seen by clients - that is, without the (wrapped) data key
+func PublicCustomMD(md cos.StrKVs) cos.StrKVs {
+	if _, ok := md[SSEKeyObjMD]; !ok {
+		return md
+	}
+	pub := make(cos.StrKVs, len(md)-1)
+	for k, v := range md {
+		if k != SSEKeyObjMD {
+			pub[k] = v
+		}
+	}
+	return pub
+}
+
+//
+// tags
+//
+
+// returns user-defined tags with `TagObjMDPrefix` stripped
+func (oa *ObjAttrs) Tags() (tags cos.StrKVs) {
+	for k, v := range oa.CustomMD {
+		if !strings.HasPrefix(k, TagObjMDPrefix) {
+			continue
+		}
+		if tags == nil {
+			tags = make(cos.StrKVs, 4)
+		}
+		tags[k[len(TagObjMDPrefix):]] = v
+	}
+	return
+}
+
+// replaces all existing tags (if any) with the specified ones
+// (NOTE: allocates new custom metadata - the current map may be shared with the cached LOM)
+func (oa *ObjAttrs) SetTags(tags cos.StrKVs) {
+	md := make(cos.StrKVs, len(oa.CustomMD)+len(tags))
+	for k, v := range oa.CustomMD {
+		if !strings.HasPrefix(k, TagObjMDPrefix) {
+			md[k] = v
+		}
+	}
+	for k, v := range tags {
+		md[TagObjMDPrefix+k] = v
+	}
+	oa.CustomMD = md
+}
+
+func ValidateTags(tags cos.StrKVs) error {
+	if len(tags) > MaxTagsPerObj {
+		return NewErrInvalidTag("number of tags (%d) exceeds the limit (%d)", len(tags), MaxTagsPerObj)
+	}
+	var size int
+	for k, v := range tags {
+		if k == "" {
+			return NewErrInvalidTag("empty key")
+		}
+		if len(k) > MaxTagKeyLen {
+			return NewErrInvalidTag("key %q is too long (max %d)", k, MaxTagKeyLen)
+		}
+		if len(v) > MaxTagValLen {
+			return NewErrInvalidTag("value of %q is too long (max %d)", k, MaxTagValLen)
+		}
+		if strings.IndexByte(k, '=') >= 0 || !_tagPrintable(k) || !_tagPrintable(v) {
+			return NewErrInvalidTag("%q=%q contains invalid characters", k, v)
+		}
+		size += len(k) + len(v)
+	}
+	if size > MaxTagsSize {
+		return NewErrInvalidTag("total size (%d) exceeds the limit (%d)", size, MaxTagsSize)
+	}
+	return nil
+}
+
+// CustomFilter selects objects by tags and custom metadata (see `apc.LsoMsg.CustomFilter`).
+// Conditions are ANDed; each condition key matches an object tag or, if there's no
+// such tag, a custom metadata key.
+type (
+	CustomFilter []customCond
+	customCond   struct {
+		key, val string
+		exists   bool // "key" without "=value"
+	}
+)
+
+func ParseCustomFilter(s string) (CustomFilter, error) {
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, apc.LsPropsSepa)
+	filter := make(CustomFilter, 0, len(parts))
+	for _, part := range parts {
+		k, v, ok := strings.Cut(part, "=")
+		k = strings.TrimSpace(k)
+		if k == "" {
+			return nil, fmt.Errorf("invalid custom filter %q: expecting comma-separated key=value pairs", s)
+		}
+		filter = append(filter, customCond{key: k, val: v, exists: !ok})
+	}
+	return filter, nil
+}
+
+func (f CustomFilter) Match(md cos.StrKVs) bool {
+	for _, c := range f {
+		v, ok := md[TagObjMDPrefix+c.key]
+		if !ok {
+			v, ok = md[c.key]
+		}
+		if !ok || (!c.exists && v != c.val) {
+			return false
+		}
+	}
+	return true
+}
+
+func _tagPrintable(s string) bool {
+	for _, c := range s {
+		if c < 0x20 || c == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+//
+// object lock
+//
+
+type ObjRetention struct {
+	Mode        string    `json:"mode"` // ObjLockGovernance | ObjLockCompliance
+	RetainUntil time.Time `json:"retain_until"`
+}
+
+func (r *ObjRetention) Validate(now time.Time) error {
+	if r.Mode != ObjLockGovernance && r.Mode != ObjLockCompliance {
+		return fmt.Errorf("invalid retention mode %q (expecting %q or %q)", r.Mode, ObjLockGovernance, ObjLockCompliance)
+	}
+	if !r.RetainUntil.After(now) {
+		return fmt.Errorf("invalid retain-until time %s (must be in the future)", r.RetainUntil.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (r *ObjRetention) Active(now time.Time) bool { return r != nil && r.RetainUntil.After(now) }
+
+// returns nil when the object has no retention
+func (oa *ObjAttrs) Retention() *ObjRetention {
+	mode, ok := oa.CustomMD[LockModeObjMD]
+	if !ok {
+		return nil
+	}
+	until, err := time.Parse(time.RFC3339, oa.CustomMD[LockUntilObjMD])
+	if err != nil {
+		debug.AssertNoErr(err)
+		return nil
+	}
+	return &ObjRetention{Mode: mode, RetainUntil: until}
+}
+
+func (oa *ObjAttrs) LegalHold() bool {
+	_, ok := oa.CustomMD[LegalHoldObjMD]
+	return ok
+}
+
+// nil `r` removes retention
+// (NOTE: allocates new custom metadata - see SetTags)
+func (oa *ObjAttrs) SetRetention(r *ObjRetention) {
+	md := oa._cloneMD(LockModeObjMD, LockUntilObjMD)
+	if r != nil {
+		md[LockModeObjMD] = r.Mode
+		md[LockUntilObjMD] = r.RetainUntil.UTC().Format(time.RFC3339)
+	}
+	oa.CustomMD = md
+}
+
+func (oa *ObjAttrs) SetLegalHold(on bool) {
+	md := oa._cloneMD(LegalHoldObjMD)
+	if on {
+		md[LegalHoldObjMD] = "ON"
+	}
+	oa.CustomMD = md
+}
+
+func (oa *ObjAttrs) _cloneMD(skip ...string) cos.StrKVs {
+	md := make(cos.StrKVs, len(oa.CustomMD)+2)
+	for k, v := range oa.CustomMD {
+		md[k] = v
+	}
+	for _, k := range skip {
+		delete(md, k)
+	}
+	return md
+}
+
+// CheckLocked returns ErrObjLocked if the object cannot be deleted or overwritten;
+// `bypass` (governance) requires permission that is checked by the caller
+func (oa *ObjAttrs) CheckLocked(name string, now time.Time, bypass bool) error {
+	if oa.LegalHold() {
+		return NewErrObjLocked(name, "legal hold")
+	}
+	r := oa.Retention()
+	if !r.Active(now) {
+		return nil
+	}
+	if r.Mode == ObjLockGovernance && bypass {
+		return nil
+	}
+	return NewErrObjLocked(name, r.Mode+" retention until "+r.RetainUntil.Format(time.RFC3339))
+}
+
+// CheckRetention validates a change of the object's retention:
+// compliance retention can only be extended; governance retention can be
+// shortened, removed, or changed to compliance - the former two require `bypass`
+func (oa *ObjAttrs) CheckRetention(name string, nr *ObjRetention, now time.Time, bypass bool) error {
+	if nr != nil {
+		if err := nr.Validate(now); err != nil {
+			return err
+		}
+	}
+	r := oa.Retention()
+	if !r.Active(now) {
+		return nil
+	}
+	extended := nr != nil && !nr.RetainUntil.Before(r.RetainUntil)
+	switch {
+	case r.Mode == ObjLockCompliance:
+		if !extended || nr.Mode != ObjLockCompliance {
+			return NewErrObjLocked(name, "compliance retention can only be extended")
+		}
+	case !extended && !bypass:
+		return NewErrObjLocked(name, "shortening or removing governance retention requires bypass")
+	}
+	return nil
+}
+
 // clone ObjAttrsHolder => ObjAttrs (see also lom.CopyAttrs)
 func (oa *ObjAttrs) CopyFrom(oah ObjAttrsHolder, skipCksum ...bool) {
 	oa.Atime = oah.AtimeUnix()
@@ -160,7 +432,7 @@ func ToHeader(oah ObjAttrsHolder, hdr http.Header) {
 	if v := oah.Version(true); v != "" {
 		hdr.Set(apc.HdrObjVersion, v)
 	}
-	custom := oah.GetCustomMD()
+	custom := PublicCustomMD(oah.GetCustomMD())
 	for k, v := range custom {
 		debug.Assert(k != "")
 		hdr.Add(apc.HdrObjCustomMD, k+"="+v)
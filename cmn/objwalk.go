@@ -17,28 +17,53 @@ type LsoEntries []*LsoEntry // separately from (code-generated) objlist* - no ne
 
 func SortLso(bckEntries LsoEntries) {
 	entryLess := func(i, j int) bool {
-		if bckEntries[i].Name == bckEntries[j].Name {
-			return bckEntries[i].Flags&apc.EntryStatusMask < bckEntries[j].Flags&apc.EntryStatusMask
+		ei, ej := bckEntries[i], bckEntries[j]
+		if ei.Name != ej.Name {
+			return ei.Name < ej.Name
 		}
-		return bckEntries[i].Name < bckEntries[j].Name
+		// non-current versions (oldest first) precede the current one (see apc.LsVersions)
+		if ni, nj := ei.IsNoncurrent(), ej.IsNoncurrent(); ni != nj {
+			return ni
+		} else if ni && ei.Version != ej.Version {
+			return lessVersion(ei.Version, ej.Version)
+		}
+		return ei.Flags&apc.EntryStatusMask < ej.Flags&apc.EntryStatusMask
 	}
 	sort.Slice(bckEntries, entryLess)
 }
 
+// numeric comparison of (ais) version IDs
+func lessVersion(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// NOTE: non-current versions are not counted toward `maxSize` (the page always ends
+// with a current entry, so that its name can serve as continuation token)
 func DedupLso(entries LsoEntries, maxSize uint) ([]*LsoEntry, string) {
 	var (
 		token    string
 		j        int
+		cnt      uint
 		objCount = uint(len(entries))
 	)
 	for _, obj := range entries {
-		if j > 0 && entries[j-1].Name == obj.Name {
-			continue
+		if j > 0 {
+			prev := entries[j-1]
+			if prev.Name == obj.Name && prev.IsNoncurrent() == obj.IsNoncurrent() &&
+				(!obj.IsNoncurrent() || prev.Version == obj.Version) {
+				continue
+			}
 		}
 		entries[j] = obj
 		j++
-
-		if maxSize > 0 && j == int(maxSize) {
+		if obj.IsNoncurrent() {
+			continue
+		}
+		cnt++
+		if maxSize > 0 && cnt == maxSize {
 			break
 		}
 	}
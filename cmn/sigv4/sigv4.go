@@ -0,0 +1,425 @@
+// Package sigv4 implements AWS Signature Version 4 (S3 flavor): signing and verification
+// of both header-based (Authorization) and query-string (presigned URL) requests
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package sigv4
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// See: https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
+
+const (
+	Algorithm       = "AWS4-HMAC-SHA256"
+	Service         = "s3"
+	DfltRegion      = "us-east-1"
+	UnsignedPayload = "UNSIGNED-PAYLOAD"
+	streamingPrefix = "STREAMING-" // chunk-signed payloads (e.g., STREAMING-AWS4-HMAC-SHA256-PAYLOAD)
+
+	TimeFormat = "20060102T150405Z"
+	dateFormat = "20060102"
+	scopeTerm  = "aws4_request"
+
+	MaxExpires = 7 * 24 * time.Hour // presigned URL, max validity
+	MaxSkew    = 15 * time.Minute   // max clock difference between client and server
+)
+
+// presigned URL (query-string) parameters
+const (
+	QparamAlgorithm     = "X-Amz-Algorithm"
+	QparamCredential    = "X-Amz-Credential"
+	QparamDate          = "X-Amz-Date"
+	QparamExpires       = "X-Amz-Expires"
+	QparamSignedHeaders = "X-Amz-SignedHeaders"
+	QparamSignature     = "X-Amz-Signature"
+	QparamSecurityToken = "X-Amz-Security-Token"
+	QparamContentSHA256 = "X-Amz-Content-Sha256"
+)
+
+// S3 error codes
+const (
+	ErrCodeAccessDenied      = "AccessDenied"
+	ErrCodeSignatureMismatch = "SignatureDoesNotMatch"
+	ErrCodeHeaderMalformed   = "AuthorizationHeaderMalformed"
+	ErrCodeQueryMalformed    = "AuthorizationQueryParametersError"
+	ErrCodeTimeSkewed        = "RequestTimeTooSkewed"
+	ErrCodeInvalidToken      = "InvalidToken"
+	ErrCodeExpiredToken      = "ExpiredToken"
+	ErrCodeSHA256Mismatch    = "XAmzContentSHA256Mismatch"
+)
+
+type (
+	Creds struct {
+		AccessKeyID     string
+		SecretAccessKey string
+		SessionToken    string // optional (temporary credentials)
+	}
+	// parsed signature (and related parameters) of a given request
+	Auth struct {
+		Date          time.Time
+		AccessKeyID   string
+		SessionToken  string
+		Region        string
+		signature     string
+		payloadHash   string
+		signedHeaders []string
+		expires       time.Duration // presigned only
+		presigned     bool
+	}
+	// request body that gets checked against x-amz-content-sha256 (see NewPayloadReader)
+	payloadReader struct {
+		r           io.ReadCloser
+		h           hash.Hash
+		payloadHash string
+	}
+	// Error carries S3 error code (to respond with)
+	Error struct {
+		Code string
+		msg  string
+	}
+)
+
+func NewErr(code, format string, a ...any) *Error {
+	return &Error{Code: code, msg: "sigv4: " + fmt.Sprintf(format, a...)}
+}
+
+func (e *Error) Error() string { return e.msg }
+
+//
+// verify
+//
+
+// Parse extracts SigV4 signature from the request's Authorization header or,
+// for presigned URLs, from the query; returns (nil, nil) if the request is not signed.
+// NOTE: must be called prior to modifying the request's URL in any way.
+func Parse(r *http.Request) (*Auth, error) {
+	if h := r.Header.Get("Authorization"); h != "" {
+		return parseHeader(r, h)
+	}
+	q := r.URL.Query()
+	if q.Get(QparamAlgorithm) != "" || q.Get(QparamSignature) != "" {
+		return parseQuery(q)
+	}
+	return nil, nil
+}
+
+func parseHeader(r *http.Request, h string) (a *Auth, err error) {
+	if !strings.HasPrefix(h, Algorithm+" ") {
+		if strings.HasPrefix(h, "AWS ") {
+			return nil, NewErr(ErrCodeHeaderMalformed, "signature version 2 is not supported")
+		}
+		return nil, nil // not SigV4 (e.g., bearer token)
+	}
+	var cred, signed string
+	a = &Auth{}
+	for _, kv := range strings.Split(h[len(Algorithm)+1:], ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(kv), "=")
+		switch k {
+		case "Credential":
+			cred = v
+		case "SignedHeaders":
+			signed = v
+		case "Signature":
+			a.signature = v
+		}
+	}
+	if cred == "" || signed == "" || a.signature == "" {
+		return nil, NewErr(ErrCodeHeaderMalformed, "invalid Authorization header %q", cos.SHead(h))
+	}
+	if d := r.Header.Get(cos.S3HdrDate); d != "" {
+		a.Date, err = time.Parse(TimeFormat, d)
+	} else {
+		a.Date, err = http.ParseTime(r.Header.Get("Date"))
+	}
+	if err != nil {
+		return nil, NewErr(ErrCodeHeaderMalformed, "missing or invalid request date: %v", err)
+	}
+	if err := a.credential(cred, ErrCodeHeaderMalformed); err != nil {
+		return nil, err
+	}
+	a.signedHeaders = strings.Split(signed, ";")
+	for _, name := range []string{"host", cos.S3HdrContentSHA256} {
+		if !cos.StringInSlice(name, a.signedHeaders) {
+			return nil, NewErr(ErrCodeAccessDenied, "%q header must be signed", name)
+		}
+	}
+	a.SessionToken = r.Header.Get(cos.S3HdrSecurityToken)
+	a.payloadHash = r.Header.Get(cos.S3HdrContentSHA256)
+	if a.payloadHash == "" {
+		return nil, NewErr(ErrCodeHeaderMalformed, "missing %s header", cos.S3HdrContentSHA256)
+	}
+	return a, nil
+}
+
+func parseQuery(q url.Values) (a *Auth, err error) {
+	if algo := q.Get(QparamAlgorithm); algo != Algorithm {
+		return nil, NewErr(ErrCodeQueryMalformed, "unsupported algorithm %q (expecting %q)", algo, Algorithm)
+	}
+	a = &Auth{presigned: true, signature: q.Get(QparamSignature), SessionToken: q.Get(QparamSecurityToken)}
+	for _, p := range []string{QparamCredential, QparamDate, QparamExpires, QparamSignedHeaders, QparamSignature} {
+		if q.Get(p) == "" {
+			return nil, NewErr(ErrCodeQueryMalformed, "missing %s", p)
+		}
+	}
+	if a.Date, err = time.Parse(TimeFormat, q.Get(QparamDate)); err != nil {
+		return nil, NewErr(ErrCodeQueryMalformed, "invalid %s: %v", QparamDate, err)
+	}
+	secs, err := strconv.ParseInt(q.Get(QparamExpires), 10, 64)
+	if err != nil || secs <= 0 || time.Duration(secs)*time.Second > MaxExpires {
+		return nil, NewErr(ErrCodeQueryMalformed, "%s must be a number of seconds between 1 and %d",
+			QparamExpires, int64(MaxExpires/time.Second))
+	}
+	a.expires = time.Duration(secs) * time.Second
+	if err := a.credential(q.Get(QparamCredential), ErrCodeQueryMalformed); err != nil {
+		return nil, err
+	}
+	a.signedHeaders = strings.Split(q.Get(QparamSignedHeaders), ";")
+	if a.payloadHash = q.Get(QparamContentSHA256); a.payloadHash == "" {
+		a.payloadHash = UnsignedPayload
+	}
+	return a, nil
+}
+
+// <access-key-id>/<yyyymmdd>/<region>/s3/aws4_request
+func (a *Auth) credential(cred, code string) error {
+	parts := strings.Split(cred, "/")
+	if len(parts) != 5 || parts[0] == "" || parts[4] != scopeTerm {
+		return NewErr(code, "invalid credential %q", cred)
+	}
+	if parts[1] != a.Date.Format(dateFormat) {
+		return NewErr(code, "credential date %q does not match request date %s", parts[1], a.Date.Format(TimeFormat))
+	}
+	if parts[3] != Service {
+		return NewErr(code, "invalid service %q (expecting %q)", parts[3], Service)
+	}
+	a.AccessKeyID, a.Region = parts[0], parts[2]
+	return nil
+}
+
+// Verify checks request's expiration (or clock skew) and validates its signature
+// against the secret access key that corresponds to a.AccessKeyID.
+func (a *Auth) Verify(r *http.Request, secret string, now time.Time) error {
+	if a.presigned {
+		if now.After(a.Date.Add(a.expires)) {
+			return NewErr(ErrCodeAccessDenied, "request has expired (at %s)", a.Date.Add(a.expires).Format(time.RFC3339))
+		}
+		if a.Date.After(now.Add(MaxSkew)) {
+			return NewErr(ErrCodeAccessDenied, "request is not yet valid (date %s)", a.Date.Format(TimeFormat))
+		}
+	} else if d := now.Sub(a.Date); d > MaxSkew || d < -MaxSkew {
+		return NewErr(ErrCodeTimeSkewed, "the difference between the request time and the server's time is too large (%v)", d)
+	}
+	if !cos.StringInSlice("host", a.signedHeaders) {
+		return NewErr(ErrCodeAccessDenied, "'host' header must be signed")
+	}
+	host := r.Host
+	if host == "" {
+		host = r.URL.Host
+	}
+	creq := canonicalRequest(r.Method, r.URL.Path, r.URL.RawQuery, host, r.Header, a.signedHeaders, a.payloadHash)
+	sig := signature(secret, a.Date, a.Region, creq)
+	if !hmac.Equal([]byte(sig), []byte(a.signature)) {
+		return NewErr(ErrCodeSignatureMismatch, "the request signature does not match (access key %q)", a.AccessKeyID)
+	}
+	return nil
+}
+
+// NewPayloadReader wraps request body to compute its SHA256 while the body is being read
+// (e.g., written into a new object) and fail the final read - in place of io.EOF - if the
+// result does not match `payloadHash` (x-amz-content-sha256); unsigned and streaming
+// (chunk-signed) payloads are returned as is.
+// NOTE: signature verification (see Verify) covers the header but not the payload itself.
+func NewPayloadReader(body io.ReadCloser, payloadHash string) io.ReadCloser {
+	if body == nil || payloadHash == "" || payloadHash == UnsignedPayload || strings.HasPrefix(payloadHash, streamingPrefix) {
+		return body
+	}
+	return &payloadReader{r: body, h: sha256.New(), payloadHash: payloadHash}
+}
+
+func (pr *payloadReader) Read(b []byte) (n int, err error) {
+	n, err = pr.r.Read(b)
+	pr.h.Write(b[:n])
+	if err == io.EOF {
+		if computed := hex.EncodeToString(pr.h.Sum(nil)); computed != pr.payloadHash {
+			err = NewErr(ErrCodeSHA256Mismatch, "the provided %s %q does not match the computed %q",
+				cos.S3HdrContentSHA256, pr.payloadHash, computed)
+		}
+	}
+	return
+}
+
+func (pr *payloadReader) Close() error { return pr.r.Close() }
+
+//
+// sign
+//
+
+// Presign adds SigV4 query parameters (including the signature) to the URL,
+// to make it valid for a given `method` for the `expires` duration starting `now`.
+func Presign(u *url.URL, method string, creds *Creds, region string, expires time.Duration, now time.Time) error {
+	if expires <= 0 || expires > MaxExpires {
+		return fmt.Errorf("sigv4: invalid expiration %v (must be positive and not exceed %v)", expires, MaxExpires)
+	}
+	if region == "" {
+		region = DfltRegion
+	}
+	now = now.UTC()
+	q := u.Query()
+	q.Set(QparamAlgorithm, Algorithm)
+	q.Set(QparamCredential, creds.AccessKeyID+"/"+scope(now, region))
+	q.Set(QparamDate, now.Format(TimeFormat))
+	q.Set(QparamExpires, strconv.FormatInt(int64(expires/time.Second), 10))
+	q.Set(QparamSignedHeaders, "host")
+	if creds.SessionToken != "" {
+		q.Set(QparamSecurityToken, creds.SessionToken)
+	}
+	q.Del(QparamSignature)
+	u.RawQuery = q.Encode()
+
+	creq := canonicalRequest(method, u.Path, u.RawQuery, u.Host, nil, []string{"host"}, UnsignedPayload)
+	u.RawQuery += "&" + QparamSignature + "=" + signature(creds.SecretAccessKey, now, region, creq)
+	return nil
+}
+
+// SignRequest adds SigV4 Authorization header (and the headers it covers) to the request.
+func SignRequest(r *http.Request, creds *Creds, region, payloadHash string, now time.Time) {
+	if region == "" {
+		region = DfltRegion
+	}
+	if payloadHash == "" {
+		payloadHash = UnsignedPayload
+	}
+	now = now.UTC()
+	r.Header.Set(cos.S3HdrDate, now.Format(TimeFormat))
+	r.Header.Set(cos.S3HdrContentSHA256, payloadHash)
+	signed := []string{"host", cos.S3HdrContentSHA256, cos.S3HdrDate}
+	if creds.SessionToken != "" {
+		r.Header.Set(cos.S3HdrSecurityToken, creds.SessionToken)
+		signed = append(signed, cos.S3HdrSecurityToken)
+	}
+	sort.Strings(signed)
+	host := r.Host
+	if host == "" {
+		host = r.URL.Host
+	}
+	creq := canonicalRequest(r.Method, r.URL.Path, r.URL.RawQuery, host, r.Header, signed, payloadHash)
+	r.Header.Set("Authorization", Algorithm+" Credential="+creds.AccessKeyID+"/"+scope(now, region)+
+		", SignedHeaders="+strings.Join(signed, ";")+", Signature="+signature(creds.SecretAccessKey, now, region, creq))
+}
+
+//
+// internal
+//
+
+func scope(tm time.Time, region string) string {
+	return tm.Format(dateFormat) + "/" + region + "/" + Service + "/" + scopeTerm
+}
+
+func canonicalRequest(method, path, rawQuery, host string, hdr http.Header, signed []string, payloadHash string) string {
+	var sb strings.Builder
+	sb.WriteString(method)
+	sb.WriteByte('\n')
+	if path == "" {
+		path = "/"
+	}
+	sb.WriteString(uriEncode(path, false))
+	sb.WriteByte('\n')
+	sb.WriteString(canonicalQuery(rawQuery))
+	sb.WriteByte('\n')
+	for _, name := range signed {
+		sb.WriteString(name)
+		sb.WriteByte(':')
+		if name == "host" {
+			sb.WriteString(host)
+		} else {
+			vals := hdr.Values(name)
+			for i, v := range vals {
+				if i > 0 {
+					sb.WriteByte(',')
+				}
+				sb.WriteString(strings.Join(strings.Fields(v), " "))
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	sb.WriteByte('\n')
+	sb.WriteString(strings.Join(signed, ";"))
+	sb.WriteByte('\n')
+	sb.WriteString(payloadHash)
+	return sb.String()
+}
+
+func canonicalQuery(rawQuery string) string {
+	q, _ := url.ParseQuery(rawQuery)
+	delete(q, QparamSignature)
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for _, k := range keys {
+		vals := q[k]
+		sort.Strings(vals)
+		for _, v := range vals {
+			if sb.Len() > 0 {
+				sb.WriteByte('&')
+			}
+			sb.WriteString(uriEncode(k, true))
+			sb.WriteByte('=')
+			sb.WriteString(uriEncode(v, true))
+		}
+	}
+	return sb.String()
+}
+
+// encode all but unreserved characters (RFC 3986) and, optionally, forward slash
+func uriEncode(s string, encodeSlash bool) string {
+	const hexUpper = "0123456789ABCDEF"
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9'),
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !encodeSlash:
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte('%')
+			sb.WriteByte(hexUpper[c>>4])
+			sb.WriteByte(hexUpper[c&0xf])
+		}
+	}
+	return sb.String()
+}
+
+func signature(secret string, tm time.Time, region, creq string) string {
+	h := sha256.Sum256([]byte(creq))
+	sts := Algorithm + "\n" + tm.Format(TimeFormat) + "\n" + scope(tm, region) + "\n" + hex.EncodeToString(h[:])
+
+	key := hmacSHA256([]byte("AWS4"+secret), tm.Format(dateFormat))
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, Service)
+	key = hmacSHA256(key, scopeTerm)
+	return hex.EncodeToString(hmacSHA256(key, sts))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -54,6 +55,19 @@ var _ = Describe("API", func() {
 					},
 				},
 			),
+			Entry("quota",
+				cmn.BucketProps{},
+				cmn.BucketPropsToUpdate{
+					Quota: &cmn.QuotaConfToUpdate{
+						MaxObjects: api.Int64(1000),
+					},
+				},
+				cmn.BucketProps{
+					Quota: cmn.QuotaConf{
+						MaxObjects: 1000,
+					},
+				},
+			),
 			Entry("multiple nested fields",
 				cmn.BucketProps{},
 				cmn.BucketPropsToUpdate{
@@ -186,4 +200,17 @@ var _ = Describe("API", func() {
 			),
 		)
 	})
+
+	Describe("NewBucketPropsToUpdate", func() {
+		It("should parse quota with size suffixes", func() {
+			props, err := cmn.NewBucketPropsToUpdate(map[string]string{
+				"quota.max_size":    "10GiB",
+				"quota.max_objects": "1000",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(props.Quota).NotTo(BeNil())
+			Expect(*props.Quota.MaxSize).To(Equal(cos.SizeIEC(10 * cos.GiB)))
+			Expect(*props.Quota.MaxObjects).To(Equal(int64(1000)))
+		})
+	})
 })
@@ -0,0 +1,57 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestDlScheduleValidate(t *testing.T) {
+	req := []byte(`{"type": "backend", "bucket": {"name": "b", "provider": "aws"}, "sync": true}`)
+	tests := []struct {
+		schedules []cmn.DlSchedule
+		valid     bool
+	}{
+		{nil, true},
+		{[]cmn.DlSchedule{{ID: "a", Cron: "0 3 * * *", Request: req}}, true},
+		{[]cmn.DlSchedule{{ID: "a", Every: cos.Duration(time.Hour), Request: req}}, true},
+		{[]cmn.DlSchedule{{ID: "a", Request: req}}, false},
+		{[]cmn.DlSchedule{{ID: "a", Cron: "@daily", Every: cos.Duration(time.Hour), Request: req}}, false},
+		{[]cmn.DlSchedule{{ID: "a", Cron: "0 25 * * *", Request: req}}, false},
+		{[]cmn.DlSchedule{{ID: "a", Every: cos.Duration(time.Second), Request: req}}, false},
+		{[]cmn.DlSchedule{{ID: "a", Cron: "@daily"}}, false},
+		{[]cmn.DlSchedule{{Cron: "@daily", Request: req}}, false},
+		{[]cmn.DlSchedule{{ID: "a", Cron: "@daily", Request: req}, {ID: "a", Cron: "@hourly", Request: req}}, false},
+	}
+	for i, test := range tests {
+		conf := cmn.DownloadConf{Schedules: test.schedules}
+		err := conf.ValidateAsProps()
+		if test.valid {
+			tassert.Errorf(t, err == nil, "#%d: unexpected error: %v", i, err)
+		} else {
+			tassert.Errorf(t, err != nil, "#%d: expected an error", i)
+		}
+	}
+}
+
+func TestDlScheduleNext(t *testing.T) {
+	created := time.Date(2023, time.March, 15, 10, 0, 0, 0, time.UTC)
+	sched := cmn.DlSchedule{ID: "a", Every: cos.Duration(6 * time.Hour), Created: created.UnixNano()}
+
+	// intervals are counted from creation - e.g., when taking over as a new primary
+	next := sched.Next(created.Add(13 * time.Hour))
+	tassert.Errorf(t, next.Equal(created.Add(18*time.Hour)), "unexpected next run %v", next)
+	next = sched.Next(created.Add(12 * time.Hour))
+	tassert.Errorf(t, next.Equal(created.Add(18*time.Hour)), "unexpected next run %v", next)
+
+	sched = cmn.DlSchedule{ID: "b", Cron: "30 * * * *"}
+	next = sched.Next(created)
+	tassert.Errorf(t, next.Equal(created.Add(30*time.Minute)), "unexpected next run %v", next)
+}
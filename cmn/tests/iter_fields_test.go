@@ -80,6 +80,8 @@ var _ = Describe("IterFields", func() {
 
 					"versioning.enabled":           false,
 					"versioning.validate_warm_get": false,
+					"versioning.retain":            0,
+					"versioning.retain_time":       cos.Duration(0),
 
 					"checksum.type":              cos.ChecksumXXHash,
 					"checksum.validate_warm_get": false,
@@ -99,6 +101,22 @@ var _ = Describe("IterFields", func() {
 
 					"write_policy.data": apc.WritePolicy(""),
 					"write_policy.md":   apc.WritePolicy(""),
+
+					"quota.max_size":    cos.SizeIEC(0),
+					"quota.max_objects": int64(0),
+
+					"sse.key_id":  "",
+					"sse.enabled": false,
+
+					"lifecycle.rules": []cmn.LifecycleRule(nil),
+
+					"object_lock.mode":    "",
+					"object_lock.days":    0,
+					"object_lock.enabled": false,
+
+					"notification.targets": []cmn.NotifTarget(nil),
+
+					"download.schedules": []cmn.DlSchedule(nil),
 				},
 			),
 			Entry("list BucketPropsToUpdate fields",
@@ -134,6 +152,8 @@ var _ = Describe("IterFields", func() {
 
 					"versioning.enabled":           (*bool)(nil),
 					"versioning.validate_warm_get": (*bool)(nil),
+					"versioning.retain":            (*int)(nil),
+					"versioning.retain_time":       (*cos.Duration)(nil),
 
 					"checksum.type":              api.String(cos.ChecksumXXHash),
 					"checksum.validate_warm_get": (*bool)(nil),
@@ -150,6 +170,22 @@ var _ = Describe("IterFields", func() {
 					"write_policy.data": (*apc.WritePolicy)(nil),
 					"write_policy.md":   api.WritePolicy(apc.WriteDelayed),
 
+					"quota.max_size":    (*cos.SizeIEC)(nil),
+					"quota.max_objects": (*int64)(nil),
+
+					"sse.key_id":  (*string)(nil),
+					"sse.enabled": (*bool)(nil),
+
+					"lifecycle.rules": (*[]cmn.LifecycleRule)(nil),
+
+					"object_lock.mode":    (*string)(nil),
+					"object_lock.days":    (*int)(nil),
+					"object_lock.enabled": (*bool)(nil),
+
+					"notification.targets": (*[]cmn.NotifTarget)(nil),
+
+					"download.schedules": (*[]cmn.DlSchedule)(nil),
+
 					"extra.hdfs.ref_directory": (*string)(nil),
 					"extra.aws.cloud_region":   (*string)(nil),
 					"extra.aws.endpoint":       (*string)(nil),
@@ -230,6 +266,8 @@ var _ = Describe("IterFields", func() {
 
 					"access":          "12", // type == uint64
 					"write_policy.md": apc.WriteNever,
+
+					"lifecycle.rules": `[{"id": "tmp", "prefix": "tmp/", "expiration_days": 7}]`,
 				},
 				&cmn.BucketProps{
 					Mirror: cmn.MirrorConf{
@@ -251,6 +289,9 @@ var _ = Describe("IterFields", func() {
 					},
 					Access:      12,
 					WritePolicy: cmn.WritePolicyConf{MD: apc.WriteNever},
+					Lifecycle: cmn.LifecycleConf{
+						Rules: []cmn.LifecycleRule{{ID: "tmp", Prefix: "tmp/", ExpirationDays: 7}},
+					},
 				},
 			),
 			Entry("update some BucketPropsToUpdate",
@@ -275,6 +316,8 @@ var _ = Describe("IterFields", func() {
 
 					"access":          "12", // type == uint64
 					"write_policy.md": apc.WriteNever,
+
+					"lifecycle.rules": `[{"id": "tmp", "prefix": "tmp/", "expiration_days": 7}]`,
 				},
 				&cmn.BucketPropsToUpdate{
 					Versioning: &cmn.VersionConfToUpdate{
@@ -299,6 +342,9 @@ var _ = Describe("IterFields", func() {
 					WritePolicy: &cmn.WritePolicyConfToUpdate{
 						MD: api.WritePolicy(apc.WriteNever),
 					},
+					Lifecycle: &cmn.LifecycleConfToUpdate{
+						Rules: &[]cmn.LifecycleRule{{ID: "tmp", Prefix: "tmp/", ExpirationDays: 7}},
+					},
 				},
 			),
 		)
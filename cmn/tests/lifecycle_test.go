@@ -0,0 +1,60 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestLifecycleValidate(t *testing.T) {
+	tests := []struct {
+		rules  []cmn.LifecycleRule
+		remote bool
+		valid  bool
+	}{
+		{nil, false, true},
+		{[]cmn.LifecycleRule{{ID: "a", Prefix: "tmp/", ExpirationDays: 7}}, false, true},
+		{[]cmn.LifecycleRule{{ID: "a", Tags: cos.StrKVs{"split": "train"}, ExpirationDays: 1, AbortMptDays: 1}}, false, false},
+		{[]cmn.LifecycleRule{{ID: "a", EvictDays: 30}}, false, false},
+		{[]cmn.LifecycleRule{{ID: "a", EvictDays: 30}}, true, true},
+		{[]cmn.LifecycleRule{{ID: "a", ExpirationDays: 7}}, true, false},
+		{[]cmn.LifecycleRule{{ID: "a"}}, false, false},
+		{[]cmn.LifecycleRule{{ExpirationDays: 1}}, false, false},
+		{[]cmn.LifecycleRule{{ID: "a", ExpirationDays: -1}}, false, false},
+		{[]cmn.LifecycleRule{{ID: "a", ExpirationDays: 1}, {ID: "a", AbortMptDays: 1}}, false, false},
+		{[]cmn.LifecycleRule{{ID: "a", Tags: cos.StrKVs{"k=v": ""}, ExpirationDays: 1}}, false, false},
+	}
+	for i, test := range tests {
+		conf := &cmn.LifecycleConf{Rules: test.rules}
+		err := conf.ValidateAsProps(test.remote)
+		tassert.Errorf(t, (err == nil) == test.valid, "test #%d: expected valid=%t, got %v", i, test.valid, err)
+	}
+}
+
+func TestLifecycleMatch(t *testing.T) {
+	oa := &cmn.ObjAttrs{}
+	oa.SetTags(cos.StrKVs{"split": "train", "owner": "bob"})
+	tests := []struct {
+		rule  cmn.LifecycleRule
+		name  string
+		match bool
+	}{
+		{cmn.LifecycleRule{Prefix: "tmp/"}, "tmp/a", true},
+		{cmn.LifecycleRule{Prefix: "tmp/"}, "data/a", false},
+		{cmn.LifecycleRule{Prefix: "tmp/", Disabled: true}, "tmp/a", false},
+		{cmn.LifecycleRule{Tags: cos.StrKVs{"split": "train"}}, "a", true},
+		{cmn.LifecycleRule{Tags: cos.StrKVs{"split": "train", "owner": "alice"}}, "a", false},
+		{cmn.LifecycleRule{Prefix: "a", Tags: cos.StrKVs{"owner": "bob"}}, "abc", true},
+	}
+	for i, test := range tests {
+		tassert.Errorf(t, test.rule.Match(test.name, oa) == test.match, "test #%d: expected match=%t", i, test.match)
+	}
+	conf := &cmn.LifecycleConf{Rules: []cmn.LifecycleRule{{ID: "a", Disabled: true}}}
+	tassert.Errorf(t, !conf.IsSet(), "disabled rules only - expected not set")
+}
@@ -10,9 +10,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/tinylib/msgp/msgp"
 	"github.com/vmihailenco/msgpack/v5"
 )
 
@@ -124,3 +126,39 @@ func cmpShard(t *testing.T, in cmn.GenShard, dst any) {
 		}
 	}
 }
+
+// list-objects result (tinylib/msgp) including archived files and their offsets
+func TestMsgpLsoResult(t *testing.T) {
+	in := &cmn.LsoResult{
+		UUID:              "uuid",
+		ContinuationToken: "shard.tar/b.jpg",
+		Entries: []*cmn.LsoEntry{
+			{Name: "obj", Size: 10, Checksum: "cksum", Copies: 2, Flags: apc.EntryIsCached},
+			{Name: "shard.tar", Size: 4096, Flags: apc.EntryIsCached},
+			{Name: "shard.tar/a.jpg", Size: 100, Offset: 512, Flags: apc.EntryIsCached | apc.EntryInArch},
+			{Name: "shard.tar/b.jpg", Size: 200, Offset: 1536, Flags: apc.EntryIsCached | apc.EntryInArch},
+		},
+	}
+	var (
+		out = &cmn.LsoResult{}
+		buf bytes.Buffer
+		w   = msgp.NewWriter(&buf)
+	)
+	if err := in.EncodeMsg(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := out.DecodeMsg(msgp.NewReader(&buf)); err != nil {
+		t.Fatal(err)
+	}
+	if out.UUID != in.UUID || out.ContinuationToken != in.ContinuationToken || len(out.Entries) != len(in.Entries) {
+		t.Fatalf("not ok: %+v vs %+v", in, out)
+	}
+	for i, e := range in.Entries {
+		if *e != *out.Entries[i] {
+			t.Fatalf("entry %d: %+v != %+v", i, *e, *out.Entries[i])
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestNotifValidate(t *testing.T) {
+	created := []string{cmn.EventObjCreated}
+	tests := []struct {
+		targets []cmn.NotifTarget
+		valid   bool
+	}{
+		{nil, true},
+		{[]cmn.NotifTarget{{ID: "a", URL: "http://host:8000/hook", Events: created}}, true},
+		{[]cmn.NotifTarget{{ID: "a", URL: "file:///tmp/events.jsonl", Events: cmn.EventKinds}}, true},
+		{[]cmn.NotifTarget{{ID: "a", URL: "unix:///run/events.sock", Events: created}}, true},
+		{[]cmn.NotifTarget{{URL: "http://host/hook", Events: created}}, false},
+		{[]cmn.NotifTarget{{ID: "a", URL: "http://host/hook"}}, false},
+		{[]cmn.NotifTarget{{ID: "a", URL: "http://host/hook", Events: []string{"object-renamed"}}}, false},
+		{[]cmn.NotifTarget{{ID: "a", URL: "ftp://host/hook", Events: created}}, false},
+		{[]cmn.NotifTarget{{ID: "a", URL: "file://relative/path", Events: created}}, false},
+		{[]cmn.NotifTarget{{ID: "a", URL: "http:///hook", Events: created}}, false},
+		{[]cmn.NotifTarget{
+			{ID: "a", URL: "http://host/hook", Events: created},
+			{ID: "a", URL: "file:///tmp/events.jsonl", Events: created},
+		}, false},
+	}
+	for i, test := range tests {
+		conf := &cmn.NotifConf{Targets: test.targets}
+		err := conf.ValidateAsProps()
+		tassert.Errorf(t, (err == nil) == test.valid, "test #%d: expected valid=%t, got %v", i, test.valid, err)
+	}
+}
+
+func TestNotifMatch(t *testing.T) {
+	nt := &cmn.NotifTarget{Events: []string{cmn.EventObjCreated}, Prefix: "img/", Suffix: ".jpg"}
+	tassert.Errorf(t, nt.Match(cmn.EventObjCreated, "img/1.jpg"), "expected match")
+	tassert.Errorf(t, !nt.Match(cmn.EventObjRemoved, "img/1.jpg"), "unexpected event match")
+	tassert.Errorf(t, !nt.Match(cmn.EventObjCreated, "img/1.png"), "unexpected suffix match")
+	tassert.Errorf(t, !nt.Match(cmn.EventObjCreated, "txt/1.jpg"), "unexpected prefix match")
+	nt.Disabled = true
+	tassert.Errorf(t, !nt.Match(cmn.EventObjCreated, "img/1.jpg"), "disabled target must not match")
+}
@@ -0,0 +1,74 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestObjLockValidate(t *testing.T) {
+	tests := []struct {
+		conf  cmn.ObjLockConf
+		valid bool
+	}{
+		{cmn.ObjLockConf{}, true},
+		{cmn.ObjLockConf{Enabled: true}, true},
+		{cmn.ObjLockConf{Enabled: true, Mode: cmn.ObjLockCompliance, Days: 30}, true},
+		{cmn.ObjLockConf{Mode: cmn.ObjLockGovernance, Days: 30}, false},
+		{cmn.ObjLockConf{Enabled: true, Mode: cmn.ObjLockGovernance}, false},
+		{cmn.ObjLockConf{Enabled: true, Days: 30}, false},
+		{cmn.ObjLockConf{Enabled: true, Mode: "strict", Days: 30}, false},
+		{cmn.ObjLockConf{Enabled: true, Mode: cmn.ObjLockGovernance, Days: -1}, false},
+	}
+	for i, test := range tests {
+		err := test.conf.ValidateAsProps()
+		tassert.Errorf(t, (err == nil) == test.valid, "test #%d: expected valid=%t, got %v", i, test.valid, err)
+	}
+}
+
+func TestObjLockCheck(t *testing.T) {
+	var (
+		now  = time.Now()
+		oa   = &cmn.ObjAttrs{}
+		gov  = &cmn.ObjRetention{Mode: cmn.ObjLockGovernance, RetainUntil: now.Add(time.Hour)}
+		comp = &cmn.ObjRetention{Mode: cmn.ObjLockCompliance, RetainUntil: now.Add(time.Hour)}
+	)
+	tassert.CheckFatal(t, oa.CheckLocked("o", now, false))
+
+	oa.SetRetention(gov)
+	r := oa.Retention()
+	tassert.Fatalf(t, r != nil && r.Mode == gov.Mode && r.RetainUntil.Equal(gov.RetainUntil.Truncate(time.Second)),
+		"expected %+v, got %+v", gov, r)
+	tassert.Errorf(t, cmn.IsErrObjLocked(oa.CheckLocked("o", now, false)), "expected governance lock")
+	tassert.CheckError(t, oa.CheckLocked("o", now, true))
+	tassert.CheckError(t, oa.CheckLocked("o", now.Add(2*time.Hour), false)) // expired
+
+	// governance: shorten or remove with bypass only; change to compliance
+	tassert.Errorf(t, oa.CheckRetention("o", nil, now, false) != nil, "expected failure to remove")
+	tassert.CheckError(t, oa.CheckRetention("o", nil, now, true))
+	tassert.CheckError(t, oa.CheckRetention("o", comp, now, false))
+
+	// compliance: extend only
+	oa.SetRetention(comp)
+	tassert.Errorf(t, oa.CheckLocked("o", now, true) != nil, "compliance cannot be bypassed")
+	tassert.Errorf(t, oa.CheckRetention("o", nil, now, true) != nil, "expected failure to remove")
+	tassert.Errorf(t, oa.CheckRetention("o", gov, now, true) != nil, "expected failure to change mode")
+	longer := &cmn.ObjRetention{Mode: cmn.ObjLockCompliance, RetainUntil: now.Add(2 * time.Hour)}
+	tassert.CheckError(t, oa.CheckRetention("o", longer, now, false))
+	past := &cmn.ObjRetention{Mode: cmn.ObjLockCompliance, RetainUntil: now.Add(-time.Hour)}
+	tassert.Errorf(t, oa.CheckRetention("o", past, now, false) != nil, "expected invalid (past) retention")
+
+	// legal hold
+	oa.SetRetention(nil)
+	oa.SetLegalHold(true)
+	tassert.Errorf(t, oa.LegalHold() && oa.Retention() == nil, "expected legal hold only")
+	tassert.Errorf(t, cmn.IsErrObjLocked(oa.CheckLocked("o", now, true)), "legal hold cannot be bypassed")
+	oa.SetLegalHold(false)
+	tassert.CheckError(t, oa.CheckLocked("o", now, false))
+}
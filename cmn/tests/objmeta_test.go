@@ -0,0 +1,41 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestReservedObjMD(t *testing.T) {
+	for _, key := range []string{cmn.SSEKeyIDObjMD, cmn.SSEKeyObjMD, cmn.DelMarkerObjMD, cmn.LockModeObjMD, cmn.LegalHoldObjMD} {
+		tassert.Errorf(t, cmn.IsReservedObjMD(key), "expected %q to be reserved", key)
+	}
+	for _, key := range []string{cmn.ETag, cmn.TagObjMDPrefix + "split", "user-key"} {
+		tassert.Errorf(t, !cmn.IsReservedObjMD(key), "expected %q to be user-settable", key)
+	}
+}
+
+func TestPublicCustomMD(t *testing.T) {
+	md := cos.StrKVs{cmn.ETag: "abc"}
+	tassert.Errorf(t, len(cmn.PublicCustomMD(md)) == 1, "expected %v as is", md)
+
+	md[cmn.SSEKeyIDObjMD], md[cmn.SSEKeyObjMD] = "kid", "wrapped"
+	pub := cmn.PublicCustomMD(md)
+	_, ok := pub[cmn.SSEKeyObjMD]
+	tassert.Errorf(t, !ok && len(pub) == 2, "wrapped data key must not be exposed: %v", pub)
+	tassert.Errorf(t, len(md) == 3, "original metadata must not be modified: %v", md)
+
+	hdr := make(http.Header)
+	cmn.ToHeader(&cmn.ObjAttrs{CustomMD: md, Cksum: cos.NoneCksum}, hdr)
+	for _, kv := range hdr.Values(apc.HdrObjCustomMD) {
+		tassert.Errorf(t, kv != cmn.SSEKeyObjMD+"=wrapped", "wrapped data key in %v", hdr)
+	}
+}
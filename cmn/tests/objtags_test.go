@@ -0,0 +1,87 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestObjTags(t *testing.T) {
+	oa := &cmn.ObjAttrs{}
+	oa.SetCustomKey(cmn.ETag, "abc")
+	oa.SetCustomKey(cmn.TagObjMDPrefix+"old", "x")
+	md := oa.CustomMD
+
+	oa.SetTags(cos.StrKVs{"split": "train", "owner": "bob"})
+	tags := oa.Tags()
+	tassert.Fatalf(t, len(tags) == 2 && tags["split"] == "train" && tags["owner"] == "bob", "unexpected tags %v", tags)
+	tassert.Errorf(t, oa.CustomMD[cmn.ETag] == "abc", "custom metadata must be preserved: %v", oa.CustomMD)
+	tassert.Errorf(t, len(md) == 2, "original metadata must not be modified: %v", md)
+
+	oa.SetTags(nil)
+	tassert.Errorf(t, oa.Tags() == nil && len(oa.CustomMD) == 1, "expected no tags: %v", oa.CustomMD)
+}
+
+func TestValidateTags(t *testing.T) {
+	many := make(cos.StrKVs, cmn.MaxTagsPerObj+1)
+	for i := 0; i <= cmn.MaxTagsPerObj; i++ {
+		many[fmt.Sprintf("k%d", i)] = "v"
+	}
+	large := make(cos.StrKVs, cmn.MaxTagsPerObj)
+	for i := 0; i < cmn.MaxTagsPerObj; i++ {
+		large[fmt.Sprintf("%03d", i)] = strings.Repeat("v", cmn.MaxTagValLen)
+	}
+	tests := []struct {
+		tags  cos.StrKVs
+		valid bool
+	}{
+		{nil, true},
+		{cos.StrKVs{"split": "train", "empty": ""}, true},
+		{cos.StrKVs{"": "v"}, false},
+		{cos.StrKVs{"a=b": "v"}, false},
+		{cos.StrKVs{"k": "v\x01"}, false},
+		{cos.StrKVs{strings.Repeat("k", cmn.MaxTagKeyLen+1): "v"}, false},
+		{cos.StrKVs{"k": strings.Repeat("v", cmn.MaxTagValLen+1)}, false},
+		{many, false},
+		{large, false},
+	}
+	for _, test := range tests {
+		err := cmn.ValidateTags(test.tags)
+		if test.valid {
+			tassert.Errorf(t, err == nil, "expected %v to be valid, got %v", test.tags, err)
+		} else {
+			tassert.Errorf(t, cmn.IsErrInvalidTag(err), "expected %v to be invalid", test.tags)
+		}
+	}
+}
+
+func TestCustomFilter(t *testing.T) {
+	md := cos.StrKVs{cmn.TagObjMDPrefix + "split": "train", "source": "aws", cmn.TagObjMDPrefix + "source": "web"}
+	tests := []struct {
+		filter string
+		match  bool
+	}{
+		{"split=train", true},
+		{"split=val", false},
+		{"split", true},
+		{"split=train,source=web", true}, // tag takes precedence
+		{"source=aws", false},
+		{"split=train,owner", false},
+		{"split=", false},
+	}
+	for _, test := range tests {
+		f, err := cmn.ParseCustomFilter(test.filter)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, f.Match(md) == test.match, "filter %q: expected match=%t", test.filter, test.match)
+	}
+	_, err := cmn.ParseCustomFilter("split=train,,x")
+	tassert.Errorf(t, err != nil, "expected error parsing empty condition")
+}
@@ -0,0 +1,60 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestVersionConfValidate(t *testing.T) {
+	tests := []struct {
+		conf    cmn.VersionConf
+		valid   bool
+		retains bool
+	}{
+		{cmn.VersionConf{Enabled: true}, true, false},
+		{cmn.VersionConf{Enabled: true, Retain: 3}, true, true},
+		{cmn.VersionConf{Enabled: true, RetainTime: cos.Duration(time.Hour)}, true, true},
+		{cmn.VersionConf{Enabled: false, Retain: 3}, false, false},
+		{cmn.VersionConf{Enabled: true, Retain: -1}, false, false},
+	}
+	for i, test := range tests {
+		err := test.conf.Validate()
+		tassert.Errorf(t, (err == nil) == test.valid, "test #%d: expected valid=%t, got %v", i, test.valid, err)
+		tassert.Errorf(t, test.conf.Retains() == test.retains, "test #%d: expected retains=%t", i, test.retains)
+	}
+}
+
+func TestSortDedupLsoVersions(t *testing.T) {
+	var (
+		nc      = uint16(apc.EntryIsNoncurrent)
+		entries = cmn.LsoEntries{
+			{Name: "b", Version: "1"},
+			{Name: "a", Version: "10", Flags: nc},
+			{Name: "a", Version: "11"},
+			{Name: "a", Version: "9", Flags: nc},
+			{Name: "a", Version: "10", Flags: nc}, // (duplicate)
+			{Name: "c", Version: "2"},
+		}
+	)
+	cmn.SortLso(entries)
+	expected := []string{"a/9", "a/10", "a/10", "a/11", "b/1", "c/2"}
+	for i, e := range entries {
+		tassert.Fatalf(t, e.Name+"/"+e.Version == expected[i], "sort #%d: expected %s, got %s/%s",
+			i, expected[i], e.Name, e.Version)
+	}
+	tassert.Fatalf(t, !entries[3].IsNoncurrent() && entries[2].IsNoncurrent(), "expected current version last")
+
+	// non-current versions are not counted toward max size
+	deduped, token := cmn.DedupLso(entries, 2)
+	tassert.Fatalf(t, len(deduped) == 4, "expected 4 entries, got %d", len(deduped))
+	tassert.Errorf(t, token == "b", "expected token %q, got %q", "b", token)
+}
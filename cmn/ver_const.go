@@ -37,7 +37,9 @@ const (
 	MetaverVMD   = 1 // Volume MD (jsp)
 	MetaverEtlMD = 1 // ETL MD (jsp)
 
-	MetaverLOM = 1 // LOM
+	MetaverLOM        = 1 // LOM
+	MetaverArchIndex  = 1 // archive index (jsp) - see cluster/lom_arch.go
+	MetaverQuotaUsage = 1 // per-target bucket usage (jsp) - see ais/tgtquota.go
 
 	MetaverConfig      = 2 // Global Configuration (jsp)
 	MetaverAuthNConfig = 1 // Authn config (jsp) // ditto
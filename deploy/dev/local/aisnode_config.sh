@@ -46,7 +46,8 @@ cat > $AIS_CONF_FILE <<EOL
 		"max_host_busy":        "20s",
 		"startup_time":         "1m",
 		"join_startup_time":    "3m",
-		"send_file_time":       "5m"
+		"send_file_time":       "5m",
+		"mpt_abort_time":       "168h"
 	},
 	"client": {
 		"client_timeout":      "10s",
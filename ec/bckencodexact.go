@@ -140,6 +140,13 @@ func (r *XactBckEncode) bckEncode(lom *cluster.LOM, _ []byte) error {
 	if !local {
 		return nil
 	}
+	if lom.Bprops().Versioning.Retains() {
+		r.syncVersions(lom)
+		// delete marker: nothing to encode
+		if lom.IsDelMarker() {
+			return nil
+		}
+	}
 	mdFQN, _, err := cluster.HrwFQN(lom.Bck().Bucket(), fs.ECMetaType, lom.ObjName)
 	if err != nil {
 		glog.Warningf("metadata FQN generation failed %q: %v", lom, err)
@@ -169,6 +176,22 @@ func (r *XactBckEncode) bckEncode(lom *cluster.LOM, _ []byte) error {
 	return nil
 }
 
+// replicate all non-current versions of the object (see versions.go)
+func (*XactBckEncode) syncVersions(lom *cluster.LOM) {
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	vers, err := lom.Versions()
+	if err != nil {
+		glog.Errorf("%s: failed to list versions: %v", lom, err)
+		return
+	}
+	send := make([]string, 0, len(vers))
+	for _, v := range vers {
+		send = append(send, v.Ver)
+	}
+	ECM.SyncVersions(lom, send...)
+}
+
 func (r *XactBckEncode) Snap() (snap *cluster.Snap) {
 	snap = &cluster.Snap{}
 	r.ToSnap(snap)
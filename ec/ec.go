@@ -377,7 +377,8 @@ func RequestECMeta(bck *cmn.Bck, objName string, si *cluster.Snode, client *http
 }
 
 // Saves the main replica to local drives
-func writeObject(t cluster.Target, lom *cluster.LOM, reader io.Reader, size int64, xctn cluster.Xact) error {
+// (`sealed` - encrypted content is stored as is, see Metadata.SSEKey)
+func writeObject(t cluster.Target, lom *cluster.LOM, reader io.Reader, size int64, xctn cluster.Xact, sealed bool) error {
 	if size > 0 {
 		reader = io.LimitReader(reader, size)
 	}
@@ -387,6 +388,7 @@ func writeObject(t cluster.Target, lom *cluster.LOM, reader io.Reader, size int6
 		params.WorkTag = "ec"
 		params.Reader = readCloser
 		params.SkipEncode = true
+		params.Sealed = sealed
 		params.Atime = time.Now()
 		params.Xact = xctn
 		// to avoid changing version; TODO: introduce cmn.OwtEC
@@ -460,7 +462,16 @@ func WriteReplicaAndMeta(t cluster.Target, lom *cluster.LOM, args *WriteArgs) (e
 		}
 	}
 	lom.Unlock(false)
-	if err = writeObject(t, lom, args.Reader, lom.SizeBytes(true), args.Xact); err != nil {
+	md := &Metadata{}
+	if err = cos.NewUnpacker(args.MD).ReadAny(md); err != nil {
+		return
+	}
+	size := lom.SizeBytes(true)
+	sealed := md.sealLOM(lom)
+	if sealed {
+		size = lom.StoredSize()
+	}
+	if err = writeObject(t, lom, args.Reader, size, args.Xact, sealed); err != nil {
 		return
 	}
 	if !args.Cksum.IsEmpty() && args.Cksum.Value() != "" { // NOTE: empty value
@@ -197,7 +197,7 @@ func (c *getJogger) copyMissingReplicas(ctx *restoreCtx, reader cos.ReadOpenClos
 	}
 	src := &dataSource{
 		reader:   srcReader,
-		size:     ctx.lom.SizeBytes(),
+		size:     ctx.lom.StoredSize(),
 		metadata: ctx.meta,
 		reqType:  reqPut,
 	}
@@ -287,6 +287,7 @@ func (c *getJogger) restoreReplicatedFromDisk(ctx *restoreCtx) error {
 				break
 			}
 			ctx.lom.SetSize(n)
+			ctx.meta.sealLOM(ctx.lom)
 			writer = w
 			break
 		}
@@ -467,7 +468,7 @@ func (c *getJogger) restoreMainObj(ctx *restoreCtx) ([]*slice, error) {
 	var (
 		err       error
 		sliceCnt  = ctx.meta.Data + ctx.meta.Parity
-		sliceSize = SliceSize(ctx.meta.Size, ctx.meta.Data)
+		sliceSize = SliceSize(ctx.meta.StoredSize(), ctx.meta.Data)
 		readers   = make([]io.Reader, sliceCnt)
 		writers   = make([]io.Writer, sliceCnt)
 		restored  = make([]*slice, sliceCnt)
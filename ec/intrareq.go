@@ -23,6 +23,9 @@ const (
 	// a target cleans up the object and notifies all other targets to do
 	// cleanup as well. Destinations do not have to respond
 	reqDel
+	// the main target replicates object's non-current versions (see versions.go)
+	// Destinations do not have to respond
+	reqVer
 )
 
 type (
@@ -264,6 +264,8 @@ func (mgr *Manager) recvResponse(hdr transport.ObjHdr, object io.Reader, err err
 		// Process the request even if the number of targets is insufficient
 		// (might've started when we had enough)
 		mgr.RestoreBckGetXact(bck).DispatchResp(iReq, &hdr, bck, object)
+	case reqVer:
+		mgr.recvVersions(&hdr, unpacker, bck, object)
 	default:
 		debug.Assertf(false, "unknown EC response action %d", hdr.Opcode)
 	}
@@ -10,12 +10,17 @@ import (
 	"os"
 
 	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/sse"
 	"github.com/OneOfOne/xxhash"
 )
 
-const MDVersionLast = 1 // current version of metadata
+const (
+	MDVersionLast = 2 // current version of metadata
+	mdVersionSSE  = 2 // (adds SSEKeyID and SSEKey)
+)
 
 // Metadata - EC information stored in metafiles for every encoded object
 type Metadata struct {
@@ -32,6 +37,8 @@ type Metadata struct {
 	SliceID     int              `json:"slice_id"`      // 0 for full replica, 1 to N for slices
 	MDVersion   uint32           `json:"md_version"`    // Metadata format version
 	IsCopy      bool             `json:"is_copy"`       // object is replicated(true) or encoded(false)
+	SSEKeyID    string           `json:"sse_kid"`       // encrypted object: key ID (see sse package)
+	SSEKey      string           `json:"sse_key"`       // encrypted object: wrapped data key
 }
 
 // interface guard
@@ -125,7 +132,7 @@ func (md *Metadata) Unpack(unpacker *cos.ByteUnpack) (err error) {
 		return
 	}
 	switch md.MDVersion {
-	case MDVersionLast:
+	case 1, MDVersionLast:
 		err = md.unpackLastVersion(unpacker)
 	default:
 		err = fmt.Errorf("unsupported metadata format version %d. Only %d supported",
@@ -184,7 +191,16 @@ func (md *Metadata) unpackLastVersion(unpacker *cos.ByteUnpack) (err error) {
 	if md.CksumValue, err = unpacker.ReadString(); err != nil {
 		return
 	}
-	md.Daemons, err = unpacker.ReadMapStrUint16()
+	if md.Daemons, err = unpacker.ReadMapStrUint16(); err != nil {
+		return
+	}
+	if md.MDVersion < mdVersionSSE {
+		return
+	}
+	if md.SSEKeyID, err = unpacker.ReadString(); err != nil {
+		return
+	}
+	md.SSEKey, err = unpacker.ReadString()
 	return
 }
 
@@ -202,6 +218,8 @@ func (md *Metadata) Pack(packer *cos.BytePack) {
 	packer.WriteString(md.CksumType)
 	packer.WriteString(md.CksumValue)
 	packer.WriteMapStrUint16(md.Daemons)
+	packer.WriteString(md.SSEKeyID)
+	packer.WriteString(md.SSEKey)
 	h := xxhash.Checksum64S(packer.Bytes(), cos.MLCG32)
 	packer.WriteUint64(h)
 }
@@ -214,5 +232,39 @@ func (md *Metadata) PackedSize() int {
 	return cos.SizeofI32 + cos.SizeofI64*2 + cos.SizeofI16*3 + 1 /*isCopy*/ +
 		cos.PackedStrLen(md.ObjCksum) + cos.PackedStrLen(md.ObjVersion) +
 		cos.PackedStrLen(md.CksumType) + cos.PackedStrLen(md.CksumValue) +
-		cos.PackedStrLen(md.FullReplica) + daemonListSz + cos.SizeofI64 /*md cksum*/
+		cos.PackedStrLen(md.FullReplica) + daemonListSz +
+		cos.PackedStrLen(md.SSEKeyID) + cos.PackedStrLen(md.SSEKey) + cos.SizeofI64 /*md cksum*/
+}
+
+// Encrypted objects are erasure coded (and replicated) as stored - that is, slices
+// and replicas contain ciphertext, while the metadata carries the object's data key.
+
+func (md *Metadata) IsEncrypted() bool { return md.SSEKey != "" }
+
+// size of the encoded (stored) content
+func (md *Metadata) StoredSize() int64 {
+	if !md.IsEncrypted() {
+		return md.Size
+	}
+	return sse.CipherSize(md.Size)
+}
+
+func (md *Metadata) setSSE(lom *cluster.LOM) {
+	md.SSEKeyID, _ = lom.GetCustomKey(cmn.SSEKeyIDObjMD)
+	md.SSEKey, _ = lom.GetCustomKey(cmn.SSEKeyObjMD)
+}
+
+// encrypted object: restore its (plaintext) size, checksum, and data key
+// that the ciphertext is stored with; returns false if not encrypted
+func (md *Metadata) sealLOM(lom *cluster.LOM) bool {
+	if !md.IsEncrypted() {
+		return false
+	}
+	lom.SetSize(md.Size)
+	if md.ObjCksum != "" {
+		lom.SetCksum(cos.NewCksum(md.CksumType, md.ObjCksum))
+	}
+	lom.SetCustomKey(cmn.SSEKeyIDObjMD, md.SSEKeyID)
+	lom.SetCustomKey(cmn.SSEKeyObjMD, md.SSEKey)
+	return true
 }
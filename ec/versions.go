@@ -0,0 +1,202 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/transport"
+)
+
+// Non-current versions (see cmn.VersionConf.Retain) of erasure coded objects.
+//
+// Slices and replicas always belong to the current version. Non-current versions,
+// on the other hand, are replicated as is (encrypted content remains encrypted)
+// to the #ParitySlices targets that follow the main target in the HRW order -
+// the same targets that, one after another, take over the object (and its
+// versions) should the main target go away. Which is why non-current versions
+// tolerate the same number of target failures as the object itself.
+//
+// The main target calls SyncVersions every time the set of object's versions
+// changes; each message carries the complete list of remaining versions so that
+// the receiver removes the ones that are no longer retained.
+
+type verSync struct {
+	vers       []string // all remaining non-current versions
+	noncurrent int64    // when the replicated version (if any) became non-current
+	size       int64    // its size (vs on-disk size that may differ - e.g., when encrypted)
+}
+
+// interface guard
+var (
+	_ cos.Packer   = (*verSync)(nil)
+	_ cos.Unpacker = (*verSync)(nil)
+)
+
+func (vs *verSync) PackedSize() int {
+	return cos.PackedStrLen(strings.Join(vs.vers, ",")) + cos.SizeofI64*2
+}
+
+func (vs *verSync) Pack(packer *cos.BytePack) {
+	packer.WriteString(strings.Join(vs.vers, ","))
+	packer.WriteInt64(vs.noncurrent)
+	packer.WriteInt64(vs.size)
+}
+
+func (vs *verSync) Unpack(unpacker *cos.ByteUnpack) (err error) {
+	var s string
+	if s, err = unpacker.ReadString(); err != nil {
+		return
+	}
+	if s != "" {
+		vs.vers = strings.Split(s, ",")
+	}
+	if vs.noncurrent, err = unpacker.ReadInt64(); err != nil {
+		return
+	}
+	vs.size, err = unpacker.ReadInt64()
+	return
+}
+
+// SyncVersions replicates the specified non-current versions of the `lom` (none,
+// when only removing) and makes the remote replicas consistent with the local ones.
+// The caller (main target) holds the object's lock.
+func (mgr *Manager) SyncVersions(lom *cluster.LOM, send ...string) {
+	if !lom.Bprops().EC.Enabled || !lom.Bprops().Versioning.Retains() {
+		return
+	}
+	smap := mgr.t.Sowner().Get()
+	sis, err := cluster.HrwTargetList(lom.Uname(), smap, lom.Bprops().EC.ParitySlices+1)
+	if err != nil {
+		glog.Errorf("%s: failed to sync versions of %s: %v", mgr.t, lom, err)
+		return
+	}
+	nodes := make([]*cluster.Snode, 0, len(sis))
+	for _, si := range sis {
+		if si.ID() != mgr.t.SID() {
+			nodes = append(nodes, si)
+		}
+	}
+	if len(nodes) == 0 {
+		return
+	}
+	vers, err := lom.Versions()
+	if err != nil {
+		glog.Errorf("%s: failed to sync versions of %s: %v", mgr.t, lom, err)
+		return
+	}
+	vs := &verSync{vers: make([]string, 0, len(vers))}
+	for _, v := range vers {
+		vs.vers = append(vs.vers, v.Ver)
+	}
+	var sent bool
+	for _, ver := range send {
+		for _, v := range vers {
+			if v.Ver != ver {
+				continue
+			}
+			vs.noncurrent, vs.size = v.Noncurrent, v.Size
+			if err := mgr.sendVersion(lom, v, vs, nodes); err != nil {
+				glog.Errorf("%s: failed to replicate %s version %q: %v", mgr.t, lom, ver, err)
+			} else {
+				sent = true
+			}
+			break
+		}
+	}
+	if !sent {
+		vs.noncurrent, vs.size = 0, 0
+		if err := mgr.sendVersion(lom, nil, vs, nodes); err != nil {
+			glog.Errorf("%s: failed to sync versions of %s: %v", mgr.t, lom, err)
+		}
+	}
+}
+
+func (mgr *Manager) sendVersion(lom *cluster.LOM, v *cluster.LomVer, vs *verSync, nodes []*cluster.Snode) error {
+	var (
+		roc   cos.ReadOpenCloser
+		finfo os.FileInfo
+		err   error
+		mm    = mgr.t.ByteMM()
+	)
+	if v != nil {
+		if finfo, err = os.Stat(v.FQN); err == nil && finfo.Size() > 0 {
+			roc, err = cos.NewFileHandle(v.FQN)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	o := transport.AllocSend()
+	if v != nil {
+		o.Hdr.ObjAttrs.CopyFrom(&v.ObjAttrs)
+		o.Hdr.ObjAttrs.Size = finfo.Size()
+	}
+	var (
+		ireq   = newIntraReq(reqVer, nil, lom.Bck())
+		l      = ireq.PackedSize() + vs.PackedSize()
+		buf, _ = mm.AllocSize(int64(l))
+		packer = cos.NewPacker(buf, l)
+	)
+	packer.WriteAny(ireq)
+	packer.WriteAny(vs)
+	o.Hdr.Bck.Copy(lom.Bucket())
+	o.Hdr.ObjName = lom.ObjName
+	o.Hdr.Opaque = packer.Bytes()
+	o.Hdr.Opcode = reqVer
+	o.Callback = func(hdr transport.ObjHdr, _ io.ReadCloser, _ any, err error) {
+		mm.Free(hdr.Opaque)
+		if err != nil {
+			glog.Errorf("failed to sync %s versions: %v", hdr.Cname(), err)
+		}
+	}
+	return mgr.resp().Send(o, roc, nodes...)
+}
+
+// (replica target) store the replicated version, if any, and remove the ones that are gone
+func (mgr *Manager) recvVersions(hdr *transport.ObjHdr, unpacker *cos.ByteUnpack, bck *cluster.Bck, object io.Reader) {
+	vs := &verSync{}
+	if err := unpacker.ReadAny(vs); err != nil {
+		glog.Errorf("%s: failed to unpack %s versions: %v", mgr.t, hdr.Cname(), err)
+		return
+	}
+	lom := cluster.AllocLOM(hdr.ObjName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		glog.Error(err)
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if hdr.ObjAttrs.Ver != "" {
+		attrs := hdr.ObjAttrs
+		attrs.Size = vs.size
+		if object == nil {
+			object = bytes.NewReader(nil) // (delete marker)
+		}
+		if err := lom.RecvVersion(object, &attrs, vs.noncurrent); err != nil {
+			glog.Errorf("%s: failed to store %s version %q: %v", mgr.t, lom, attrs.Ver, err)
+		}
+	}
+	vers, err := lom.Versions()
+	if err != nil {
+		glog.Errorf("%s: failed to list versions of %s: %v", mgr.t, lom, err)
+		return
+	}
+	for _, v := range vers {
+		if cos.StringInSlice(v.Ver, vs.vers) {
+			continue
+		}
+		if err := lom.DelVersion(v.Ver); err != nil {
+			glog.Errorf("%s: failed to remove %s version %q: %v", mgr.t, lom, v.Ver, err)
+		}
+	}
+}
@@ -161,10 +161,10 @@ func newReplicaResponse(attrs *cmn.ObjAttrs, bck *cluster.Bck, objName string) (
 	if err != nil {
 		return nil, err
 	}
-	if lom.SizeBytes() == 0 {
+	if lom.StoredSize() == 0 {
 		return nil, nil
 	}
-	attrs.Size = lom.SizeBytes()
+	attrs.Size = lom.StoredSize() // (ciphertext, if encrypted - see Metadata.SSEKey)
 	attrs.Ver = lom.Version()
 	attrs.Atime = lom.AtimeUnix()
 	attrs.Cksum = lom.Checksum()
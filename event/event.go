@@ -0,0 +1,234 @@
+// Package event delivers bucket event notifications to webhooks and local sinks.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package event
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Bucket event notifications (see cmn.NotifConf):
+// - targets emit events asynchronously: Emit does not wait for delivery;
+// - each destination (URL) gets its own sink with a bounded on-disk queue (see queue.go)
+//   that survives restarts;
+// - delivery is at-least-once: records are removed from the queue only after they
+//   have been delivered; failed deliveries are retried with exponential backoff;
+// - Emit never blocks: each sink appends emitted events to its queue in the background
+//   (see sink.persist); when the queue is full or the sink falls behind, events get
+//   dropped and counted (see stats.EventDropCount);
+// - callers emit after releasing the object's lock;
+// - sinks that are no longer referenced by any bucket get removed, along with their
+//   queues, upon BMD change (see BucketsMDChanged).
+
+const QueueDir = "events" // (in the node's config directory)
+
+type (
+	// event record: one JSON line (file, unix socket) or an element of the
+	// `{"records": [...]}` array POST-ed to webhooks
+	Record struct {
+		Event       string `json:"event"`          // cmn.EventObjCreated, et al.
+		Time        string `json:"time"`           // RFC3339Nano
+		Bucket      string `json:"bucket"`         // e.g. "ais://abc"
+		Object      string `json:"object"`         // object name
+		Size        int64  `json:"size,omitempty"` // (created and copied objects)
+		Version     string `json:"version,omitempty"`
+		Checksum    string `json:"checksum,omitempty"` // "type:value"
+		Source      string `json:"source,omitempty"`   // copied (transformed) object: source bucket/object
+		Transformed bool   `json:"transformed,omitempty"`
+		ID          string `json:"id"`   // notification target ID (cmn.NotifTarget.ID)
+		Node        string `json:"node"` // target that emitted the event
+	}
+	manager struct {
+		statsT cos.StatsUpdater
+		sinks  map[string]*sink // by URL
+		stopCh cos.StopCh
+		dir    string
+		tid    string
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+	}
+)
+
+var mgr *manager
+
+// Init resumes delivery of the events that remain queued from the previous run
+func Init(tid, confDir string, statsT cos.StatsUpdater) {
+	m := &manager{
+		statsT: statsT,
+		sinks:  make(map[string]*sink, 4),
+		dir:    filepath.Join(confDir, QueueDir),
+		tid:    tid,
+	}
+	m.stopCh.Init()
+	if err := cos.CreateDir(m.dir); err != nil {
+		glog.Errorf("events: %v", err)
+		return
+	}
+	dirents, err := os.ReadDir(m.dir)
+	if err != nil {
+		glog.Errorf("events: %v", err)
+		return
+	}
+	for _, de := range dirents {
+		if !de.IsDir() {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(m.dir, de.Name(), urlFname))
+		if err != nil {
+			glog.Errorf("events: skipping %q: %v", de.Name(), err)
+			continue
+		}
+		m.sink(string(b))
+	}
+	mgr = m
+}
+
+// Stop terminates delivery; undelivered events remain queued
+func Stop() {
+	m := mgr
+	if m == nil {
+		return
+	}
+	m.stopCh.Close()
+	m.wg.Wait()
+}
+
+// BucketsMDChanged removes the sinks (and their queued events) that are no longer
+// referenced by any bucket's notification config
+func BucketsMDChanged(bmd *cluster.BMD) {
+	if m := mgr; m != nil {
+		m.prune(bmd)
+	}
+}
+
+// object created, removed, or evicted
+func Emit(kind string, lom *cluster.LOM) {
+	if mgr == nil || len(lom.Bprops().Notif.Targets) == 0 {
+		return
+	}
+	rec := &Record{Event: kind, Bucket: lom.Bck().Cname(""), Object: lom.ObjName, Version: lom.Version(true)}
+	if kind == cmn.EventObjCreated {
+		rec.Size = lom.SizeBytes(true)
+		if cksum := lom.Checksum(); !cksum.IsEmpty() {
+			rec.Checksum = cksum.Type() + ":" + cksum.Value()
+		}
+	}
+	mgr.emit(lom.Bck(), rec)
+}
+
+// a given (current or non-current) version removed
+func EmitVersion(lom *cluster.LOM, ver string) {
+	if mgr == nil || len(lom.Bprops().Notif.Targets) == 0 {
+		return
+	}
+	rec := &Record{Event: cmn.EventObjRemoved, Bucket: lom.Bck().Cname(""), Object: lom.ObjName, Version: ver}
+	mgr.emit(lom.Bck(), rec)
+}
+
+// object copied or transformed (notifying the destination bucket)
+func EmitCopy(src *cluster.LOM, bckTo *cluster.Bck, objNameTo string, size int64, transformed bool) {
+	if mgr == nil || bckTo.Props == nil || len(bckTo.Props.Notif.Targets) == 0 {
+		return
+	}
+	rec := &Record{
+		Event:       cmn.EventObjCopied,
+		Bucket:      bckTo.Cname(""),
+		Object:      objNameTo,
+		Size:        size,
+		Source:      src.Cname(),
+		Transformed: transformed,
+	}
+	mgr.emit(bckTo, rec)
+}
+
+func (m *manager) emit(bck *cluster.Bck, rec *Record) {
+	rec.Time, rec.Node = time.Now().UTC().Format(time.RFC3339Nano), m.tid
+	targets := bck.Props.Notif.Targets
+	for i := range targets {
+		nt := &targets[i]
+		if !nt.Match(rec.Event, rec.Object) {
+			continue
+		}
+		rec.ID = nt.ID
+		b, err := jsoniter.Marshal(rec)
+		if err != nil {
+			glog.Errorf("events: %v", err)
+			return
+		}
+		if s := m.sink(nt.URL); s != nil {
+			s.enqueue(b)
+		}
+	}
+}
+
+func (m *manager) sink(url string) *sink {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, ok := m.sinks[url]; ok {
+		return s
+	}
+	s, err := newSink(m, url)
+	if err != nil {
+		glog.Errorf("events: failed to create sink %q: %v", url, err)
+		return nil
+	}
+	m.sinks[url] = s
+	m.wg.Add(1)
+	go s.run()
+	return s
+}
+
+func (m *manager) prune(bmd *cluster.BMD) {
+	urls := make(cos.StrSet, 4)
+	bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+		for i := range bck.Props.Notif.Targets {
+			urls.Set(bck.Props.Notif.Targets[i].URL)
+		}
+		return false
+	})
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	dirs := make(cos.StrSet, len(m.sinks))
+	for url, s := range m.sinks {
+		if urls.Contains(url) {
+			dirs.Set(s.dir)
+			continue
+		}
+		dirs.Set(s.dir)
+		if !s.removing.CAS(false, true) {
+			continue
+		}
+		// (the sink remains in the map until removed - see m.sink)
+		go func(url string, s *sink) {
+			s.remove()
+			glog.Infof("%s: removed", s)
+			m.mu.Lock()
+			if m.sinks[url] == s {
+				delete(m.sinks, url)
+			}
+			m.mu.Unlock()
+		}(url, s)
+	}
+	// leftovers (e.g., queues that could not be resumed by Init)
+	dirents, err := os.ReadDir(m.dir)
+	if err != nil {
+		return
+	}
+	for _, de := range dirents {
+		if dir := filepath.Join(m.dir, de.Name()); de.IsDir() && !dirs.Contains(dir) {
+			if err := os.RemoveAll(dir); err != nil {
+				glog.Errorf("events: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,231 @@
+// Package event delivers bucket event notifications to webhooks and local sinks.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package event
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	jsoniter "github.com/json-iterator/go"
+)
+
+func TestQueue(t *testing.T) {
+	dir := t.TempDir()
+	q, err := openQueue(dir, 4*segSize)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, q.empty(), "expected empty queue")
+
+	// fill more than one segment
+	rec := make([]byte, 1000)
+	for i := range rec {
+		rec[i] = 'a'
+	}
+	num := int(segSize/int64(len(rec))) + 10
+	for i := 0; i < num; i++ {
+		tassert.CheckFatal(t, q.put(append([]byte(fmt.Sprintf("%06d", i)), rec[6:]...)))
+	}
+	tassert.Fatalf(t, len(q.segs) == 2, "expected 2 segments, got %d", len(q.segs))
+
+	// consume some, reopen, consume the rest
+	recs, next, err := q.peek(10)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(recs) == 10 && string(recs[0][:6]) == "000000", "unexpected peek: %d", len(recs))
+	tassert.CheckFatal(t, q.ack(next))
+	q.close()
+
+	q, err = openQueue(dir, 4*segSize)
+	tassert.CheckFatal(t, err)
+	cnt := 10
+	for !q.empty() {
+		recs, next, err = q.peek(100)
+		tassert.CheckFatal(t, err)
+		for _, r := range recs {
+			tassert.Fatalf(t, string(r[:6]) == fmt.Sprintf("%06d", cnt), "out of order: %q at %d", r[:6], cnt)
+			cnt++
+		}
+		tassert.CheckFatal(t, q.ack(next))
+	}
+	tassert.Fatalf(t, cnt == num, "expected %d records, got %d", num, cnt)
+	tassert.Errorf(t, len(q.segs) == 0, "expected no segments, got %d", len(q.segs))
+
+	// bounded
+	q2, err := openQueue(t.TempDir(), 10*int64(len(rec)))
+	tassert.CheckFatal(t, err)
+	for i := 0; i < 9; i++ {
+		tassert.CheckFatal(t, q2.put(rec))
+	}
+	tassert.Errorf(t, q2.put(rec) == errQueueFull, "expected queue full")
+}
+
+func TestEmitFileAndWebhook(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []*Record
+		fails    = 1
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if fails > 0 { // first delivery fails (and gets retried)
+			fails--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var batch struct {
+			Records []*Record `json:"records"`
+		}
+		b, _ := io.ReadAll(r.Body)
+		if err := jsoniter.Unmarshal(b, &batch); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, batch.Records...)
+	}))
+	defer srv.Close()
+
+	var (
+		dir   = t.TempDir()
+		fpath = filepath.Join(dir, "events.jsonl")
+		m     = &manager{statsT: mock.NewStatsTracker(), sinks: map[string]*sink{}, dir: filepath.Join(dir, QueueDir), tid: "t1"}
+		props = &cmn.BucketProps{Notif: cmn.NotifConf{Targets: []cmn.NotifTarget{
+			{ID: "file", URL: "file://" + fpath, Events: []string{cmn.EventObjCreated, cmn.EventObjRemoved}},
+			{ID: "hook", URL: srv.URL, Events: []string{cmn.EventObjRemoved}, Prefix: "a/"},
+		}}}
+		bck = cluster.NewBck("abc", apc.AIS, cmn.NsGlobal, props)
+	)
+	tassert.CheckFatal(t, props.Notif.ValidateAsProps())
+	m.stopCh.Init()
+	defer func() { m.stopCh.Close(); m.wg.Wait() }()
+
+	m.emit(bck, &Record{Event: cmn.EventObjCreated, Bucket: bck.Cname(""), Object: "a/1"})
+	m.emit(bck, &Record{Event: cmn.EventObjRemoved, Bucket: bck.Cname(""), Object: "a/1"})
+	m.emit(bck, &Record{Event: cmn.EventObjRemoved, Bucket: bck.Cname(""), Object: "b/2"})
+	m.emit(bck, &Record{Event: cmn.EventObjEvicted, Bucket: bck.Cname(""), Object: "a/3"})
+
+	// file: 3 records
+	var lines []string
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if fh, err := os.Open(fpath); err == nil {
+			lines = lines[:0]
+			for sc := bufio.NewScanner(fh); sc.Scan(); {
+				lines = append(lines, sc.Text())
+			}
+			fh.Close()
+			if len(lines) == 3 {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	tassert.Fatalf(t, len(lines) == 3, "expected 3 records in %s, got %d", fpath, len(lines))
+	rec := &Record{}
+	tassert.CheckFatal(t, jsoniter.Unmarshal([]byte(lines[0]), rec))
+	tassert.Errorf(t, rec.Event == cmn.EventObjCreated && rec.Object == "a/1" && rec.ID == "file" && rec.Node == "t1",
+		"unexpected record %+v", rec)
+
+	// webhook: 1 record, delivered after retry (minBackoff)
+	deadline = time.Now().Add(minBackoff + 5*time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		n := len(received)
+		mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	tassert.Fatalf(t, len(received) == 1, "expected 1 webhook record, got %d", len(received))
+	tassert.Errorf(t, received[0].Object == "a/1" && received[0].ID == "hook", "unexpected record %+v", received[0])
+}
+
+func TestOverflow(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		url    = "file://" + filepath.Join(dir, "out", "events.jsonl") // (no such directory - deliveries fail)
+		m      = &manager{statsT: mock.NewStatsTracker(), sinks: map[string]*sink{}, dir: filepath.Join(dir, QueueDir), tid: "t1"}
+		rec    = []byte(`{"event":"ObjectCreated"}`)
+		s, err = newSink(m, url)
+	)
+	tassert.CheckFatal(t, err)
+	s.q.maxSize = 4 * int64(len(rec)+1)
+	m.stopCh.Init()
+	defer func() { m.stopCh.Close(); m.wg.Wait() }()
+
+	// not running yet: the handoff fills up, emitters never block
+	started := time.Now()
+	for i := 0; i < inChanCap+10; i++ {
+		s.enqueue(rec)
+	}
+	tassert.Errorf(t, time.Since(started) < time.Second, "expected enqueue to never block")
+	tassert.Fatalf(t, s.drops.Load() == 10, "expected 10 drops, got %d", s.drops.Load())
+
+	// running: the on-disk queue takes 4 records, the rest is dropped
+	m.sinks[url] = s
+	m.wg.Add(1)
+	go s.run()
+	expected := int64(10 + inChanCap - 4)
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) && s.drops.Load() < expected {
+		time.Sleep(10 * time.Millisecond)
+	}
+	tassert.Errorf(t, s.drops.Load() == expected, "expected %d drops, got %d", expected, s.drops.Load())
+	tassert.Errorf(t, !s.empty(), "expected undelivered records to remain queued")
+}
+
+func TestPruneSinks(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		keep = "file://" + filepath.Join(dir, "keep.jsonl")
+		gone = "file://" + filepath.Join(dir, "gone.jsonl")
+		m    = &manager{statsT: mock.NewStatsTracker(), sinks: map[string]*sink{}, dir: filepath.Join(dir, QueueDir), tid: "t1"}
+	)
+	m.stopCh.Init()
+	defer func() { m.stopCh.Close(); m.wg.Wait() }()
+	sk, sg := m.sink(keep), m.sink(gone)
+	tassert.Fatalf(t, sk != nil && sg != nil, "failed to create sinks")
+
+	props := &cmn.BucketProps{Notif: cmn.NotifConf{Targets: []cmn.NotifTarget{
+		{ID: "keep", URL: keep, Events: []string{cmn.EventObjCreated}},
+	}}}
+	bmd := &cluster.BMD{Providers: make(cluster.Providers)}
+	bmd.Add(cluster.NewBck("abc", apc.AIS, cmn.NsGlobal, props))
+	m.prune(bmd)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		m.mu.Lock()
+		_, ok := m.sinks[gone]
+		m.mu.Unlock()
+		if !ok {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	m.mu.Lock()
+	_, okKeep := m.sinks[keep]
+	_, okGone := m.sinks[gone]
+	m.mu.Unlock()
+	tassert.Errorf(t, okKeep && !okGone, "expected only %q to remain", keep)
+	_, err := os.Stat(sg.dir)
+	tassert.Errorf(t, os.IsNotExist(err), "expected %s removed, err: %v", sg.dir, err)
+	_, err = os.Stat(sk.dir)
+	tassert.CheckError(t, err)
+}
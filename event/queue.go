@@ -0,0 +1,215 @@
+// Package event delivers bucket event notifications to webhooks and local sinks.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package event
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Bounded on-disk FIFO of event records (JSON lines):
+// - records are appended to the tail segment that rotates upon reaching segSize;
+// - the reader consumes the head segment starting at the cursor (persisted upon
+//   each ack); fully consumed segments are removed;
+// - on restart, writing always resumes with a new segment (a partially written
+//   last record, if any, gets skipped by the reader).
+
+const (
+	segSize      = cos.MiB
+	segSuffix    = ".seg"
+	cursorFname  = "cursor"
+	MaxQueueSize = 64 * cos.MiB // per sink
+)
+
+var (
+	errQueueFull = errors.New("event queue is full")
+	errSinkBusy  = errors.New("event sink is busy")
+)
+
+type queue struct {
+	w       *os.File
+	dir     string
+	segs    []uint64 // ascending
+	wsize   int64    // tail segment size (when open for writing)
+	roff    int64    // head segment read offset
+	pending int64    // bytes not yet consumed
+	maxSize int64
+}
+
+func openQueue(dir string, maxSize int64) (*queue, error) {
+	q := &queue{dir: dir, maxSize: maxSize}
+	if err := cos.CreateDir(dir); err != nil {
+		return nil, err
+	}
+	dirents, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, de := range dirents {
+		name := de.Name()
+		if de.IsDir() || !strings.HasSuffix(name, segSuffix) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, segSuffix), 16, 64)
+		if err != nil {
+			continue
+		}
+		finfo, err := de.Info()
+		if err != nil {
+			return nil, err
+		}
+		q.segs = append(q.segs, seq)
+		q.pending += finfo.Size()
+	}
+	sort.Slice(q.segs, func(i, j int) bool { return q.segs[i] < q.segs[j] })
+	if len(q.segs) > 0 {
+		q.loadCursor()
+	}
+	return q, nil
+}
+
+func (q *queue) loadCursor() {
+	b, err := os.ReadFile(filepath.Join(q.dir, cursorFname))
+	if err != nil {
+		return
+	}
+	var seq uint64
+	var off int64
+	if _, err := fmt.Sscanf(string(b), "%x %d", &seq, &off); err != nil || seq != q.segs[0] {
+		return
+	}
+	q.roff = off
+	q.pending -= off
+}
+
+func (q *queue) segPath(seq uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%016x%s", seq, segSuffix))
+}
+
+func (q *queue) empty() bool { return q.pending <= 0 }
+
+func (q *queue) put(b []byte) (err error) {
+	if q.pending+int64(len(b))+1 > q.maxSize {
+		return errQueueFull
+	}
+	if q.w == nil || q.wsize >= segSize {
+		if err = q.rotate(); err != nil {
+			return
+		}
+	}
+	b = append(b, '\n')
+	n, err := q.w.Write(b)
+	q.wsize += int64(n)
+	q.pending += int64(n)
+	return
+}
+
+func (q *queue) rotate() (err error) {
+	if q.w != nil {
+		cos.Close(q.w)
+		q.w = nil
+	}
+	var seq uint64
+	if l := len(q.segs); l > 0 {
+		seq = q.segs[l-1] + 1
+	}
+	if q.w, err = os.OpenFile(q.segPath(seq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, cos.PermRWR); err != nil {
+		return
+	}
+	q.segs = append(q.segs, seq)
+	q.wsize = 0
+	return
+}
+
+// returns up to `max` records from the head segment and the offset to ack
+func (q *queue) peek(max int) (recs [][]byte, next int64, err error) {
+	if len(q.segs) == 0 {
+		return nil, q.roff, nil
+	}
+	fh, err := os.Open(q.segPath(q.segs[0]))
+	if err != nil {
+		return nil, q.roff, err
+	}
+	defer cos.Close(fh)
+	if _, err = fh.Seek(q.roff, io.SeekStart); err != nil {
+		return nil, q.roff, err
+	}
+	var (
+		br   = bufio.NewReader(fh)
+		line []byte
+	)
+	next = q.roff
+	for len(recs) < max {
+		line, err = br.ReadBytes('\n')
+		if err != nil {
+			if err == io.EOF {
+				// (a torn record, if any, is consumed but not returned)
+				next += int64(len(line))
+				err = nil
+			}
+			break
+		}
+		next += int64(len(line))
+		if len(line) > 1 {
+			recs = append(recs, line[:len(line)-1])
+		}
+	}
+	return
+}
+
+// advance the cursor; remove fully consumed head segment
+func (q *queue) ack(next int64) error {
+	q.pending -= next - q.roff
+	q.roff = next
+	var (
+		head = q.segs[0]
+		size = q.wsize
+		tail = len(q.segs) == 1 && q.w != nil
+	)
+	if !tail {
+		finfo, err := os.Stat(q.segPath(head))
+		if err != nil {
+			return err
+		}
+		size = finfo.Size()
+	}
+	if q.roff < size {
+		return q.saveCursor()
+	}
+	if tail {
+		q.close()
+	}
+	q.segs = q.segs[1:]
+	q.roff = 0
+	if err := cos.RemoveFile(q.segPath(head)); err != nil {
+		return err
+	}
+	if len(q.segs) == 0 {
+		q.pending = 0
+		return cos.RemoveFile(filepath.Join(q.dir, cursorFname))
+	}
+	return q.saveCursor()
+}
+
+func (q *queue) saveCursor() error {
+	s := fmt.Sprintf("%x %d", q.segs[0], q.roff)
+	return os.WriteFile(filepath.Join(q.dir, cursorFname), []byte(s), cos.PermRWR)
+}
+
+func (q *queue) close() {
+	if q.w != nil {
+		cos.Close(q.w)
+		q.w = nil
+	}
+}
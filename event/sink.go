@@ -0,0 +1,307 @@
+// Package event delivers bucket event notifications to webhooks and local sinks.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package event
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/OneOfOne/xxhash"
+)
+
+const (
+	urlFname = "url" // sink's destination (in the sink's queue directory)
+
+	inChanCap = 1024 // records handed over to (but not yet persisted by) the sink
+	maxBatch  = 100  // records per delivery
+
+	sendTimeout = 30 * time.Second
+	minBackoff  = time.Second
+	maxBackoff  = time.Minute
+)
+
+type (
+	sink struct {
+		m        *manager
+		q        *queue // protected by mu
+		u        *url.URL
+		client   *http.Client  // webhook
+		inCh     chan []byte   // emitted records (see persist)
+		workCh   chan struct{} // (new records in the queue)
+		doneCh   chan struct{} // run() exited
+		stopCh   cos.StopCh    // this sink only (see manager.prune)
+		dir      string
+		url      string
+		backoff  time.Duration
+		next     time.Time // next delivery attempt (when backing off)
+		drops    atomic.Int64
+		removing atomic.Bool
+		mu       sync.Mutex
+	}
+)
+
+func newSink(m *manager, rawURL string) (*sink, error) {
+	if err := cmn.ValidateNotifURL(rawURL); err != nil {
+		return nil, err
+	}
+	u, _ := url.Parse(rawURL)
+	dir := sinkDir(m.dir, rawURL)
+	q, err := openQueue(dir, MaxQueueSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, urlFname), []byte(rawURL), cos.PermRWR); err != nil {
+		return nil, err
+	}
+	s := &sink{
+		m:      m,
+		q:      q,
+		u:      u,
+		dir:    dir,
+		url:    rawURL,
+		inCh:   make(chan []byte, inChanCap),
+		workCh: make(chan struct{}, 1),
+		doneCh: make(chan struct{}),
+	}
+	s.stopCh.Init()
+	if u.Scheme == "http" || u.Scheme == "https" {
+		s.client = cmn.NewClient(cmn.TransportArgs{Timeout: sendTimeout, UseHTTPS: u.Scheme == "https"})
+	}
+	return s, nil
+}
+
+func sinkDir(dir, rawURL string) string {
+	return filepath.Join(dir, fmt.Sprintf("%016x", xxhash.ChecksumString64S(rawURL, cos.MLCG32)))
+}
+
+func (s *sink) String() string { return "event-sink[" + s.url + "]" }
+
+// Hands the record over to the sink (see persist) - never blocks; when the sink
+// falls behind by more than inChanCap records, the record gets dropped.
+func (s *sink) enqueue(b []byte) {
+	if s.removing.Load() {
+		return
+	}
+	select {
+	case s.inCh <- b:
+	default:
+		s.drop(errSinkBusy)
+	}
+}
+
+func (s *sink) drop(err error) {
+	s.m.statsT.Inc(stats.EventDropCount)
+	if n := s.drops.Inc(); n == 1 || n%1000 == 0 {
+		glog.Errorf("%s: dropped %d event(s): %v", s, n, err)
+	}
+}
+
+// Appends emitted records to the on-disk queue and wakes up the sender (run);
+// records that do not fit (the queue is full) get dropped.
+func (s *sink) persist(stopped chan struct{}) {
+	defer close(stopped)
+	for {
+		select {
+		case b := <-s.inCh:
+			s.put(b)
+		case <-s.stopCh.Listen():
+			s.drain()
+			return
+		case <-s.m.stopCh.Listen():
+			s.drain()
+			return
+		}
+	}
+}
+
+func (s *sink) put(b []byte) {
+	s.mu.Lock()
+	err := s.q.put(b)
+	s.mu.Unlock()
+	if err != nil {
+		s.drop(err)
+		return
+	}
+	select {
+	case s.workCh <- struct{}{}:
+	default:
+	}
+}
+
+// (upon stopping) persist what's been handed over so far
+func (s *sink) drain() {
+	for n := len(s.inCh); n > 0; n-- {
+		s.put(<-s.inCh)
+	}
+}
+
+// Delivers queued records, one batch at a time, backing off upon failures.
+func (s *sink) run() {
+	stopped := make(chan struct{})
+	go s.persist(stopped)
+	defer func() {
+		<-stopped
+		s.mu.Lock()
+		s.q.close()
+		s.mu.Unlock()
+		close(s.doneCh)
+		s.m.wg.Done()
+	}()
+	for {
+		var retry <-chan time.Time
+		if !s.empty() {
+			retry = time.After(time.Until(s.next))
+		}
+		select {
+		case <-s.workCh:
+		case <-retry:
+		case <-s.stopCh.Listen():
+			return
+		case <-s.m.stopCh.Listen():
+			return
+		}
+		for !s.empty() && !time.Now().Before(s.next) {
+			if !s.flush() {
+				break
+			}
+		}
+	}
+}
+
+func (s *sink) empty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.q.empty()
+}
+
+// stop delivering and remove the queue (see manager.prune)
+func (s *sink) remove() {
+	s.stopCh.Close()
+	<-s.doneCh
+	if err := os.RemoveAll(s.dir); err != nil {
+		glog.Errorf("%s: %v", s, err)
+	}
+}
+
+// deliver (up to maxBatch records) from the head of the queue;
+// returns true when delivered (possibly, nothing)
+func (s *sink) flush() bool {
+	s.mu.Lock()
+	recs, next, err := s.q.peek(maxBatch)
+	s.mu.Unlock()
+	if err == nil && len(recs) > 0 {
+		err = s.send(recs)
+	}
+	if err != nil {
+		s.m.statsT.Inc(stats.ErrEventCount)
+		s.backoff = cos.MinDuration(cos.MaxDuration(2*s.backoff, minBackoff), maxBackoff)
+		s.next = time.Now().Add(s.backoff)
+		glog.Errorf("%s: failed to deliver %d event(s), retrying in %v: %v", s, len(recs), s.backoff, err)
+		return false
+	}
+	s.backoff, s.next = 0, time.Time{}
+	s.mu.Lock()
+	if err := s.q.ack(next); err != nil {
+		glog.Errorf("%s: %v", s, err)
+	}
+	s.mu.Unlock()
+	if len(recs) > 0 {
+		s.m.statsT.Add(stats.EventSentCount, int64(len(recs)))
+	}
+	return true
+}
+
+func (s *sink) send(recs [][]byte) error {
+	switch s.u.Scheme {
+	case "file":
+		return s.sendFile(recs)
+	case "unix":
+		return s.sendSocket(recs)
+	default:
+		return s.sendHTTP(recs)
+	}
+}
+
+// POST {"records": [...]}
+func (s *sink) sendHTTP(recs [][]byte) error {
+	var body bytes.Buffer
+	body.WriteString(`{"records":[`)
+	for i, rec := range recs {
+		if i > 0 {
+			body.WriteByte(',')
+		}
+		body.Write(rec)
+	}
+	body.WriteString("]}")
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(cos.HdrContentType, cos.ContentJSON)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// append JSON lines
+func (s *sink) sendFile(recs [][]byte) error {
+	fh, err := os.OpenFile(s.u.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, cos.PermRWR)
+	if err != nil {
+		return err
+	}
+	if err = writeLines(fh, recs); err == nil {
+		err = fh.Sync()
+	}
+	if errC := fh.Close(); err == nil {
+		err = errC
+	}
+	return err
+}
+
+func (s *sink) sendSocket(recs [][]byte) error {
+	conn, err := net.DialTimeout("unix", s.u.Path, sendTimeout)
+	if err != nil {
+		return err
+	}
+	conn.SetWriteDeadline(time.Now().Add(sendTimeout))
+	err = writeLines(conn, recs)
+	if errC := conn.Close(); err == nil {
+		err = errC
+	}
+	return err
+}
+
+func writeLines(w io.Writer, recs [][]byte) error {
+	var buf bytes.Buffer
+	for _, rec := range recs {
+		buf.Write(rec)
+		buf.WriteByte('\n')
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
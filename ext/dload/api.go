@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -22,10 +23,11 @@ import (
 type Type string
 
 const (
-	TypeSingle  Type = "single"
-	TypeRange   Type = "range"
-	TypeMulti   Type = "multi"
-	TypeBackend Type = "backend"
+	TypeSingle   Type = "single"
+	TypeRange    Type = "range"
+	TypeMulti    Type = "multi"
+	TypeBackend  Type = "backend"
+	TypeManifest Type = "manifest"
 )
 
 const PrefixJobID = "dnl-"
@@ -54,9 +56,13 @@ type (
 		ScheduledCnt  int       `json:"scheduled_cnt"` // tasks being processed or already processed by dispatched
 		SkippedCnt    int       `json:"skipped_cnt"`   // number of tasks skipped
 		ErrorCnt      int       `json:"error_cnt"`
+		AddedCnt      int       `json:"added_cnt"`      // new objects
+		UpdatedCnt    int       `json:"updated_cnt"`    // existing objects that have been overwritten
+		DeletedCnt    int       `json:"deleted_cnt"`    // objects deleted (evicted) when syncing with remote backend
 		Total         int       `json:"total"`          // total number of tasks, negative if unknown
 		AllDispatched bool      `json:"all_dispatched"` // if true, dispatcher has already scheduled all tasks for given job
 		Aborted       bool      `json:"aborted"`
+		Schedule      string    `json:"schedule,omitempty"` // ID of the schedule that started this job (if any)
 	}
 
 	JobInfos []*Job
@@ -79,14 +85,30 @@ type (
 		Timeout          string  `json:"timeout"`
 		ProgressInterval string  `json:"progress_interval"`
 		Limits           Limits  `json:"limits"`
+		// default type of the expected checksums that are specified without one
+		// (see `ParseCksum`)
+		CksumType string `json:"cksum_type,omitempty"`
+		// set by the cluster when running scheduled downloads (see schedule.go)
+		Schedule string `json:"schedule,omitempty"`
 	}
 
 	SingleObj struct {
 		ObjName    string `json:"object_name"`
 		Link       string `json:"link"`
+		Cksum      string `json:"cksum,omitempty"` // expected checksum: "[type:]value"
 		FromRemote bool   `json:"from_remote"`
 	}
 
+	// Entry describes a single download: source link, destination object name and,
+	// optionally, the expected size and checksum of the downloaded content.
+	// Same structure is used for the records (lines) of download manifests.
+	Entry struct {
+		Link  string `json:"url"`
+		Name  string `json:"name,omitempty"`  // default: base of the link
+		Cksum string `json:"cksum,omitempty"` // "[type:]value"
+		Size  int64  `json:"size,omitempty"`
+	}
+
 	AdminBody struct {
 		ID         string `json:"id"`
 		Regex      string `json:"regex"`
@@ -131,11 +153,20 @@ type (
 		Base
 		ObjectsPayload any `json:"objects"`
 	}
+
+	// download links (and expected checksums) from the manifest object
+	ManifestBody struct {
+		Base
+		ManifestBck  cmn.Bck `json:"manifest_bck"`
+		ManifestName string  `json:"manifest_name"`
+		Format       string  `json:"format,omitempty"`   // one of the `ManifestFormats` (default: by manifest name)
+		BaseURL      string  `json:"base_url,omitempty"` // to resolve relative links
+	}
 )
 
 func IsType(a string) bool {
 	b := Type(a)
-	return b == TypeMulti || b == TypeBackend || b == TypeSingle || b == TypeRange
+	return b == TypeMulti || b == TypeBackend || b == TypeSingle || b == TypeRange || b == TypeManifest
 }
 
 /////////
@@ -147,6 +178,9 @@ func (j *Job) Aggregate(rhs *Job) {
 	j.ScheduledCnt += rhs.ScheduledCnt
 	j.SkippedCnt += rhs.SkippedCnt
 	j.ErrorCnt += rhs.ErrorCnt
+	j.AddedCnt += rhs.AddedCnt
+	j.UpdatedCnt += rhs.UpdatedCnt
+	j.DeletedCnt += rhs.DeletedCnt
 	j.Total += rhs.Total
 	j.AllDispatched = j.AllDispatched && rhs.AllDispatched
 	j.Aborted = j.Aborted || rhs.Aborted
@@ -241,6 +275,33 @@ func (db *Body) UnmarshalJSON(b []byte) error {
 	return db.RawMessage.UnmarshalJSON(b)
 }
 
+// Validate parses and validates type-specific download request
+func (db *Body) Validate() error {
+	_, err := db.parse()
+	return err
+}
+
+func (db *Body) parse() (payload interface{ Validate() error }, err error) {
+	switch db.Type {
+	case TypeBackend:
+		payload = &BackendBody{}
+	case TypeMulti:
+		payload = &MultiBody{}
+	case TypeRange:
+		payload = &RangeBody{}
+	case TypeSingle:
+		payload = &SingleBody{}
+	case TypeManifest:
+		payload = &ManifestBody{}
+	default:
+		return nil, errors.New("input does not match any of the supported formats (single, range, multi, backend, manifest)")
+	}
+	if err = jsoniter.Unmarshal(db.RawMessage, payload); err != nil {
+		return nil, err
+	}
+	return payload, payload.Validate()
+}
+
 //////////////
 // JobInfos //
 //////////////
@@ -303,6 +364,11 @@ func (b *Base) Validate() error {
 	if b.Limits.BytesPerHour < 0 {
 		return fmt.Errorf("'limit.bytes_per_hour' must be non-negative (got: %d)", b.Limits.BytesPerHour)
 	}
+	if b.CksumType != "" {
+		if err := validateCksumType(b.CksumType); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -327,6 +393,21 @@ func (b *SingleObj) Validate() error {
 	return nil
 }
 
+///////////
+// Entry //
+///////////
+
+func (e *Entry) objName() (string, error) {
+	if e.Name != "" {
+		return e.Name, nil
+	}
+	objName := path.Base(e.Link)
+	if objName == "." || objName == "/" {
+		return "", fmt.Errorf("failed to extract object name from the download %q", e.Link)
+	}
+	return objName, nil
+}
+
 ///////////////
 // AdminBody //
 ///////////////
@@ -364,13 +445,15 @@ func (b *SingleBody) Validate() error {
 	if err := b.Base.Validate(); err != nil {
 		return err
 	}
-	return b.SingleObj.Validate()
+	if err := b.SingleObj.Validate(); err != nil {
+		return err
+	}
+	_, err := ParseCksum(b.Cksum, b.CksumType)
+	return err
 }
 
-func (b *SingleBody) ExtractPayload() (cos.StrKVs, error) {
-	objects := make(cos.StrKVs, 1)
-	objects[b.ObjName] = b.Link
-	return objects, nil
+func (b *SingleBody) ExtractPayload() ([]Entry, error) {
+	return []Entry{{Link: b.Link, Name: b.ObjName, Cksum: b.Cksum}}, nil
 }
 
 func (b *SingleBody) Describe() string {
@@ -420,38 +503,53 @@ func (b *MultiBody) Validate() error {
 	return b.Base.Validate()
 }
 
-func (b *MultiBody) ExtractPayload() (cos.StrKVs, error) {
-	objects := make(cos.StrKVs, 10)
+// ExtractPayload supports the following formats of the `ObjectsPayload`:
+// - map (object name -> link)
+// - array of links
+// - array of entries (see `Entry`), each with its own expected checksum and size
+func (b *MultiBody) ExtractPayload() ([]Entry, error) {
+	var entries []Entry
 	switch ty := b.ObjectsPayload.(type) {
 	case map[string]any:
+		entries = make([]Entry, 0, len(ty))
 		for key, val := range ty {
 			switch v := val.(type) {
 			case string:
-				objects[key] = v
+				entries = append(entries, Entry{Link: v, Name: key})
 			default:
 				return nil, fmt.Errorf("values in map should be strings, found: %T", v)
 			}
 		}
 	case []any:
+		entries = make([]Entry, 0, len(ty))
 		// process all links
 		for _, val := range ty {
-			switch link := val.(type) {
+			var entry Entry
+			switch v := val.(type) {
 			case string:
-				objName := path.Base(link)
-				if objName == "." || objName == "/" {
-					err := fmt.Errorf("failed to extract object name from the download %q", link)
-					// TODO: ignore and continue?
+				entry.Link = v
+			case map[string]any:
+				if err := cos.MorphMarshal(v, &entry); err != nil {
 					return nil, err
 				}
-				objects[objName] = link
+				if entry.Link == "" {
+					return nil, fmt.Errorf("missing 'url' in the download entry %v", v)
+				}
 			default:
-				return nil, fmt.Errorf("expected download link to be a string, got: %T", link)
+				return nil, fmt.Errorf("expected download link to be a string or an object, got: %T", v)
 			}
+			objName, err := entry.objName()
+			if err != nil {
+				// TODO: ignore and continue?
+				return nil, err
+			}
+			entry.Name = objName
+			entries = append(entries, entry)
 		}
 	default:
-		return nil, fmt.Errorf("JSON body should be map (string -> string) or array of strings, found: %T", ty)
+		return nil, fmt.Errorf("JSON body should be map (string -> string) or array, found: %T", ty)
 	}
-	return objects, nil
+	return entries, nil
 }
 
 func (b *MultiBody) Describe() string {
@@ -477,3 +575,37 @@ func (b *BackendBody) Describe() string {
 	}
 	return fmt.Sprintf("remote bucket prefetch -> %s", b.Bck)
 }
+
+//////////////////
+// ManifestBody //
+//////////////////
+
+func (b *ManifestBody) Validate() error {
+	if err := b.Base.Validate(); err != nil {
+		return err
+	}
+	if b.ManifestBck.Name == "" {
+		return errors.New("missing 'manifest_bck.name'")
+	}
+	if b.ManifestBck.Provider == "" {
+		b.ManifestBck.Provider = apc.AIS
+	}
+	if b.ManifestName == "" {
+		return errors.New("missing 'manifest_name' in the request body")
+	}
+	if b.Format != "" && !cos.StringInSlice(b.Format, ManifestFormats) {
+		return fmt.Errorf("invalid manifest format %q (expecting one of %v)", b.Format, ManifestFormats)
+	}
+	return nil
+}
+
+func (b *ManifestBody) Describe() string {
+	if b.Description != "" {
+		return b.Description
+	}
+	return fmt.Sprintf("%s -> %s", b.ManifestBck.Cname(b.ManifestName), b.Bck)
+}
+
+func (b *ManifestBody) String() string {
+	return fmt.Sprintf("bucket: %q, manifest: %q", b.Bck, b.ManifestBck.Cname(b.ManifestName))
+}
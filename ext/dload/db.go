@@ -16,6 +16,7 @@ import (
 const (
 	downloaderErrors     = "errors"
 	downloaderTasks      = "tasks"
+	downloaderHistory    = "history"
 	downloaderCollection = "downloads"
 
 	// Number of errors stored in memory. When the number of errors exceeds
@@ -25,6 +26,9 @@ const (
 	// Number of tasks stored in memory. When the number of tasks exceeds
 	// this number, then all errors will be flushed to disk
 	taskInfoCacheSize = 1000
+
+	// Number of the most recent runs of a scheduled download to keep in the history
+	historySize = 100
 )
 
 var errJobNotFound = errors.New("job not found")
@@ -182,3 +186,52 @@ func (db *downloaderDB) delete(id string) {
 	db.driver.Delete(downloaderCollection, key)
 	db.mtx.Unlock()
 }
+
+//
+// run history of the scheduled downloads (see schedule.go)
+//
+
+func (db *downloaderDB) history(schedID string) (runs []Job, err error) {
+	key := path.Join(downloaderHistory, schedID)
+	if err := db.driver.Get(downloaderCollection, key, &runs); err != nil {
+		if !kvdb.IsErrNotFound(err) {
+			glog.Error(err)
+			return nil, err
+		}
+		return nil, nil
+	}
+	return
+}
+
+func (db *downloaderDB) getHistory(schedID string) (runs []Job, err error) {
+	db.mtx.RLock()
+	defer db.mtx.RUnlock()
+	return db.history(schedID)
+}
+
+func (db *downloaderDB) persistHistory(schedID string, run *Job) error {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	runs, err := db.history(schedID)
+	if err != nil {
+		return err
+	}
+	runs = append(runs, *run)
+	if len(runs) > historySize {
+		runs = runs[len(runs)-historySize:]
+	}
+	key := path.Join(downloaderHistory, schedID)
+	if err := db.driver.Set(downloaderCollection, key, runs); err != nil {
+		glog.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (db *downloaderDB) deleteHistory(schedID string) {
+	db.mtx.Lock()
+	key := path.Join(downloaderHistory, schedID)
+	db.driver.Delete(downloaderCollection, key)
+	db.mtx.Unlock()
+}
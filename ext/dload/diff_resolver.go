@@ -41,18 +41,24 @@ type (
 	}
 
 	BackendResource struct {
+		Cksum   *cos.Cksum // expected
 		ObjName string
+		Size    int64 // expected
 	}
 
 	WebResource struct {
+		Cksum   *cos.Cksum // expected
 		ObjName string
 		Link    string
+		Size    int64 // expected
 	}
 
 	DstElement struct {
+		Cksum   *cos.Cksum // expected
 		ObjName string
 		Version string
 		Link    string
+		Size    int64 // expected
 	}
 
 	DiffResolverResult struct {
@@ -163,11 +169,15 @@ func (dr *DiffResolver) PushDst(v any) {
 	case *BackendResource:
 		d = &DstElement{
 			ObjName: x.ObjName,
+			Cksum:   x.Cksum,
+			Size:    x.Size,
 		}
 	case *WebResource:
 		d = &DstElement{
 			ObjName: x.ObjName,
 			Link:    x.Link,
+			Cksum:   x.Cksum,
+			Size:    x.Size,
 		}
 	default:
 		debug.FailTypeCast(v)
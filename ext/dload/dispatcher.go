@@ -236,10 +236,14 @@ func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
 					diffResolver.PushDst(&WebResource{
 						ObjName: obj.objName,
 						Link:    obj.link,
+						Cksum:   obj.cksum,
+						Size:    obj.size,
 					})
 				} else {
 					diffResolver.PushDst(&BackendResource{
 						ObjName: obj.objName,
+						Cksum:   obj.cksum,
+						Size:    obj.size,
 					})
 				}
 			}
@@ -258,6 +262,8 @@ func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
 				obj = dlObj{
 					objName:    dst.ObjName,
 					link:       dst.Link,
+					cksum:      dst.Cksum,
+					size:       dst.Size,
 					fromRemote: dst.Link == "",
 				}
 			} else {
@@ -289,6 +295,7 @@ func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
 					task.markFailed(err.Error())
 				} else {
 					dlStore.incFinished(job.ID())
+					dlStore.incDeleted(job.ID())
 				}
 				continue
 			}
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/kvdb"
 	"github.com/NVIDIA/aistore/hk"
@@ -75,6 +76,7 @@ func (is *infoStore) setJob(job jobif) (njob *dljob) {
 		xid:         job.XactID(),
 		total:       job.Len(),
 		description: job.Description(),
+		schedule:    job.Schedule(),
 		startedTime: time.Now(),
 	}
 	is.Lock()
@@ -108,6 +110,25 @@ func (is *infoStore) incErrorCnt(id string) {
 	dljob.errorCnt.Inc()
 }
 
+// objects that did not exist prior to being downloaded
+func (is *infoStore) incAdded(id string) {
+	dljob, err := is.getJob(id)
+	debug.AssertNoErr(err)
+	dljob.addedCnt.Inc()
+}
+
+func (is *infoStore) incUpdated(id string) {
+	dljob, err := is.getJob(id)
+	debug.AssertNoErr(err)
+	dljob.updatedCnt.Inc()
+}
+
+func (is *infoStore) incDeleted(id string) {
+	dljob, err := is.getJob(id)
+	debug.AssertNoErr(err)
+	dljob.deletedCnt.Inc()
+}
+
 func (is *infoStore) setAllDispatched(id string, dispatched bool) {
 	dljob, err := is.getJob(id)
 	debug.AssertNoErr(err)
@@ -133,6 +154,19 @@ func (is *infoStore) setAborted(id string) {
 	//       that all tasks have been stopped and all resources were freed.
 }
 
+// record finished run of a scheduled download
+func (is *infoStore) persistRun(schedID, jobID string) {
+	dljob, err := is.getJob(jobID)
+	if err != nil {
+		debug.AssertNoErr(err)
+		return
+	}
+	run := dljob.clone()
+	if err := is.persistHistory(schedID, &run); err != nil {
+		glog.Errorf("failed to persist %s run of the scheduled download %q: %v", jobID, schedID, err)
+	}
+}
+
 func (is *infoStore) delJob(id string) {
 	delete(is.dljobs, id)
 	is.downloaderDB.delete(id)
@@ -31,18 +31,22 @@ var (
 	_ jobif = (*sliceDlJob)(nil)
 	_ jobif = (*backendDlJob)(nil)
 	_ jobif = (*rangeDlJob)(nil)
+	_ jobif = (*manifestDlJob)(nil)
 )
 
 type (
 	dlObj struct {
+		cksum      *cos.Cksum // expected checksum (optional)
 		objName    string
 		link       string
+		size       int64 // expected size (optional)
 		fromRemote bool
 	}
 
 	jobif interface {
 		ID() string
 		XactID() string
+		Schedule() string
 		Bck() *cmn.Bck
 		Description() string
 		Timeout() time.Duration
@@ -77,6 +81,7 @@ type (
 		xdl         *Xact
 		id          string
 		description string
+		schedule    string // see Base.Schedule
 		timeout     time.Duration
 		throt       throttler
 	}
@@ -92,6 +97,10 @@ type (
 	singleDlJob struct {
 		sliceDlJob
 	}
+	manifestDlJob struct {
+		sliceDlJob
+		manifest string
+	}
 
 	rangeDlJob struct {
 		baseDlJob
@@ -118,12 +127,16 @@ type (
 		id            string
 		xid           string
 		description   string
+		schedule      string
 		startedTime   time.Time
 		finishedTime  atomic.Time
 		finishedCnt   atomic.Int32
 		scheduledCnt  atomic.Int32
 		skippedCnt    atomic.Int32
 		errorCnt      atomic.Int32
+		addedCnt      atomic.Int32
+		updatedCnt    atomic.Int32
+		deletedCnt    atomic.Int32
 		total         int
 		aborted       atomic.Bool
 		allDispatched atomic.Bool
@@ -134,18 +147,20 @@ type (
 // baseDlJob //
 ///////////////
 
-func (j *baseDlJob) init(t cluster.Target, id string, bck *cluster.Bck, timeout, desc string, limits Limits, xdl *Xact) {
+func (j *baseDlJob) init(t cluster.Target, id string, bck *cluster.Bck, base *Base, desc string, xdl *Xact) {
 	// TODO: this might be inaccurate if we download 1 or 2 objects because then
 	//  other targets will have limits but will not use them.
+	limits := base.Limits
 	if limits.BytesPerHour > 0 {
 		limits.BytesPerHour /= t.Sowner().Get().CountActiveTs()
 	}
-	td, _ := time.ParseDuration(timeout)
+	td, _ := time.ParseDuration(base.Timeout)
 	{
 		j.id = id
 		j.bck = bck
 		j.timeout = td
 		j.description = desc
+		j.schedule = base.Schedule
 		j.throt.init(limits)
 		j.xdl = xdl
 	}
@@ -153,6 +168,7 @@ func (j *baseDlJob) init(t cluster.Target, id string, bck *cluster.Bck, timeout,
 
 func (j *baseDlJob) ID() string             { return j.id }
 func (j *baseDlJob) XactID() string         { return j.xdl.ID() }
+func (j *baseDlJob) Schedule() string       { return j.schedule }
 func (j *baseDlJob) Bck() *cmn.Bck          { return j.bck.Bucket() }
 func (j *baseDlJob) Timeout() time.Duration { return j.timeout }
 func (j *baseDlJob) Description() string    { return j.description }
@@ -198,6 +214,9 @@ func (j *baseDlJob) cleanup() {
 		glog.Errorf("%s: %v", j, err)
 	}
 	dlStore.flush(j.ID())
+	if j.schedule != "" {
+		dlStore.persistRun(j.schedule, j.ID())
+	}
 	nl.OnFinished(j.Notif(), err)
 }
 
@@ -205,8 +224,8 @@ func (j *baseDlJob) cleanup() {
 // sliceDlJob -- multiDlJob -- singleDlJob
 //
 
-func (j *sliceDlJob) init(t cluster.Target, bck *cluster.Bck, objects cos.StrKVs) error {
-	objs, err := buildDlObjs(t, bck, objects)
+func (j *sliceDlJob) init(t cluster.Target, bck *cluster.Bck, entries []Entry, cksumType string) error {
+	objs, err := buildDlObjs(t, bck, entries, cksumType)
 	if err != nil {
 		return err
 	}
@@ -232,30 +251,30 @@ func (j *sliceDlJob) genNext() (objs []dlObj, ok bool, err error) {
 }
 
 func newMultiDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *MultiBody, xdl *Xact) (mj *multiDlJob, err error) {
-	var objs cos.StrKVs
+	var entries []Entry
 
 	mj = &multiDlJob{}
-	mj.baseDlJob.init(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	mj.baseDlJob.init(t, id, bck, &payload.Base, payload.Describe(), xdl)
 
-	if objs, err = payload.ExtractPayload(); err != nil {
+	if entries, err = payload.ExtractPayload(); err != nil {
 		return nil, err
 	}
-	err = mj.sliceDlJob.init(t, bck, objs)
+	err = mj.sliceDlJob.init(t, bck, entries, payload.CksumType)
 	return
 }
 
 func (j *multiDlJob) String() (s string) { return "multi-" + j.baseDlJob.String() }
 
 func newSingleDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *SingleBody, xdl *Xact) (sj *singleDlJob, err error) {
-	var objs cos.StrKVs
+	var entries []Entry
 
 	sj = &singleDlJob{}
-	sj.baseDlJob.init(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	sj.baseDlJob.init(t, id, bck, &payload.Base, payload.Describe(), xdl)
 
-	if objs, err = payload.ExtractPayload(); err != nil {
+	if entries, err = payload.ExtractPayload(); err != nil {
 		return nil, err
 	}
-	err = sj.sliceDlJob.init(t, bck, objs)
+	err = sj.sliceDlJob.init(t, bck, entries, payload.CksumType)
 	return
 }
 
@@ -263,6 +282,46 @@ func (j *singleDlJob) String() (s string) {
 	return "single-" + j.baseDlJob.String()
 }
 
+// NOTE: each target reads the entire manifest and selects (via HRW) the objects it owns.
+func newManifestDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *ManifestBody, xdl *Xact) (mj *manifestDlJob, err error) {
+	var (
+		entries   []Entry
+		format    = payload.Format
+		cksumType = payload.CksumType
+	)
+	mbck := cluster.CloneBck(&payload.ManifestBck)
+	if err = mbck.Init(t.Bowner()); err != nil {
+		return nil, err
+	}
+	mj = &manifestDlJob{manifest: mbck.Cname(payload.ManifestName)}
+	mj.baseDlJob.init(t, id, bck, &payload.Base, payload.Describe(), xdl)
+
+	if format == "" || cksumType == "" {
+		f, ty := ManifestFormat(payload.ManifestName)
+		if format == "" {
+			format = f
+		}
+		if cksumType == "" {
+			cksumType = ty
+		}
+	}
+	r, err := getManifest(t, mbck, payload.ManifestName)
+	if err != nil {
+		return nil, err
+	}
+	entries, err = ParseManifest(r, format, payload.BaseURL)
+	cos.Close(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", mj.manifest, err)
+	}
+	err = mj.sliceDlJob.init(t, bck, entries, cksumType)
+	return
+}
+
+func (j *manifestDlJob) String() (s string) {
+	return fmt.Sprintf("manifest-%s-%s", &j.baseDlJob, j.manifest)
+}
+
 ////////////////
 // rangeDlJob //
 ////////////////
@@ -273,7 +332,7 @@ func newRangeDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *Range
 	if rj.pt, err = cos.ParseBashTemplate(payload.Template); err != nil {
 		return nil, err
 	}
-	rj.baseDlJob.init(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	rj.baseDlJob.init(t, id, bck, &payload.Base, payload.Describe(), xdl)
 
 	if rj.count, err = countObjects(t, rj.pt, payload.Subdir, rj.bck); err != nil {
 		return nil, err
@@ -337,7 +396,7 @@ func newBackendDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *Bac
 		return nil, errors.New("bucket download does not support HTTP buckets")
 	}
 	bj = &backendDlJob{}
-	bj.baseDlJob.init(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	bj.baseDlJob.init(t, id, bck, &payload.Base, payload.Describe(), xdl)
 	{
 		bj.t = t
 		bj.sync = payload.Sync
@@ -422,9 +481,13 @@ func (j *dljob) clone() Job {
 		ScheduledCnt:  int(j.scheduledCnt.Load()),
 		SkippedCnt:    int(j.skippedCnt.Load()),
 		ErrorCnt:      int(j.errorCnt.Load()),
+		AddedCnt:      int(j.addedCnt.Load()),
+		UpdatedCnt:    int(j.updatedCnt.Load()),
+		DeletedCnt:    int(j.deletedCnt.Load()),
 		Total:         j.total,
 		AllDispatched: j.allDispatched.Load(),
 		Aborted:       j.aborted.Load(),
+		Schedule:      j.schedule,
 		StartedTime:   j.startedTime,
 		FinishedTime:  j.finishedTime.Load(),
 	}
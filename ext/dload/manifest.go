@@ -0,0 +1,194 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// manifest formats
+const (
+	ManifestSums = "sums" // output of `sha256sum`, `md5sum`, etc.: "<digest>  <name>" per line
+	ManifestJSON = "json" // JSON array of entries (see `Entry`)
+	ManifestCSV  = "csv"  // url,name,size,cksum - one entry per line (header line is optional)
+)
+
+var ManifestFormats = []string{ManifestSums, ManifestJSON, ManifestCSV}
+
+// expected checksums: supported types
+var cksumTypes = []string{cos.ChecksumMD5, cos.ChecksumSHA256, cos.ChecksumCRC32C, cos.ChecksumSHA512}
+
+var errNoBaseURL = errors.New("relative link in the manifest requires 'base_url'")
+
+func validateCksumType(ty string) error {
+	if !cos.StringInSlice(ty, cksumTypes) {
+		return fmt.Errorf("invalid expected checksum type %q (expecting one of %v)", ty, cksumTypes)
+	}
+	return nil
+}
+
+// ParseCksum parses expected checksum in the "[type:]value" format, e.g.:
+// "sha256:9f86d081884c7d65..." or "9f86d081884c7d65..." - the latter requires
+// `dfltType` (that is, `Base.CksumType`). Empty string parses as nil checksum.
+func ParseCksum(s, dfltType string) (*cos.Cksum, error) {
+	if s == "" {
+		return nil, nil
+	}
+	ty, value := dfltType, s
+	if i := strings.IndexByte(s, ':'); i > 0 {
+		ty, value = s[:i], s[i+1:]
+	}
+	if ty == "" {
+		return nil, fmt.Errorf("checksum %q: unknown type (use \"type:value\" or specify 'cksum_type')", s)
+	}
+	if err := validateCksumType(ty); err != nil {
+		return nil, err
+	}
+	value = strings.ToLower(value)
+	if _, err := hex.DecodeString(value); err != nil {
+		return nil, fmt.Errorf("checksum %q: expecting hex-encoded value: %v", s, err)
+	}
+	if size := cos.NewCksumHash(ty).H.Size(); len(value) != 2*size {
+		return nil, fmt.Errorf("checksum %q: invalid %s length %d (expecting %d)", s, ty, len(value), 2*size)
+	}
+	return cos.NewCksum(ty, value), nil
+}
+
+// ManifestFormat derives manifest format and, possibly, the type of its
+// checksums from the manifest name, e.g.: "SHA256SUMS", "files.md5", "list.csv".
+func ManifestFormat(name string) (format, cksumType string) {
+	base := strings.ToLower(path.Base(name))
+	switch ext := path.Ext(base); ext {
+	case ".json":
+		return ManifestJSON, ""
+	case ".csv":
+		return ManifestCSV, ""
+	case "":
+	default:
+		if ty := strings.TrimPrefix(ext, "."); cos.StringInSlice(ty, cksumTypes) {
+			return ManifestSums, ty
+		}
+	}
+	for _, ty := range cksumTypes {
+		if strings.HasPrefix(base, ty+"sum") {
+			return ManifestSums, ty
+		}
+	}
+	return ManifestSums, ""
+}
+
+// ParseManifest reads manifest entries; relative links are resolved against `baseURL`.
+func ParseManifest(r io.Reader, format, baseURL string) (entries []Entry, err error) {
+	switch format {
+	case ManifestSums:
+		entries, err = parseSums(r)
+	case ManifestJSON:
+		err = jsoniter.NewDecoder(r).Decode(&entries)
+	case ManifestCSV:
+		entries, err = parseCSV(r)
+	default:
+		err = fmt.Errorf("invalid manifest format %q (expecting one of %v)", format, ManifestFormats)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Link == "" {
+			return nil, fmt.Errorf("manifest entry #%d: missing url", i)
+		}
+		if !strings.Contains(entry.Link, "://") {
+			if baseURL == "" {
+				return nil, fmt.Errorf("%v (%q)", errNoBaseURL, entry.Link)
+			}
+			rel := strings.TrimPrefix(strings.TrimPrefix(entry.Link, "./"), "/")
+			if entry.Name == "" {
+				entry.Name = rel
+			}
+			entry.Link = strings.TrimSuffix(baseURL, "/") + "/" + rel
+		}
+	}
+	return entries, nil
+}
+
+// "<digest> <name>" or "<digest> *<name>" (binary mode), one per line
+func parseSums(r io.Reader) (entries []Entry, err error) {
+	scanner := bufio.NewScanner(r)
+	for lno := 1; scanner.Scan(); lno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		i := strings.IndexAny(line, " \t")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid manifest line %d: %q", lno, line)
+		}
+		name := strings.TrimPrefix(strings.TrimLeft(line[i:], " \t"), "*")
+		if name == "" {
+			return nil, fmt.Errorf("invalid manifest line %d: %q", lno, line)
+		}
+		entries = append(entries, Entry{Link: name, Cksum: line[:i]})
+	}
+	return entries, scanner.Err()
+}
+
+func parseCSV(r io.Reader) (entries []Entry, err error) {
+	var (
+		records [][]string
+		cols    = map[string]int{"url": 0, "name": 1, "size": 2, "cksum": 3}
+	)
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+	if records, err = cr.ReadAll(); err != nil {
+		return nil, err
+	}
+	if len(records) > 0 && isCSVHeader(records[0]) {
+		// header line (any order of the columns)
+		cols = make(map[string]int, 4)
+		for i, col := range records[0] {
+			cols[strings.ToLower(col)] = i
+		}
+		records = records[1:]
+	}
+	get := func(record []string, col string) string {
+		if i, ok := cols[col]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+	entries = make([]Entry, 0, len(records))
+	for _, record := range records {
+		entry := Entry{Link: get(record, "url"), Name: get(record, "name"), Cksum: get(record, "cksum")}
+		if size := get(record, "size"); size != "" {
+			if entry.Size, err = strconv.ParseInt(size, 10, 64); err != nil {
+				return nil, fmt.Errorf("manifest entry %q: invalid size: %v", entry.Link, err)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func isCSVHeader(record []string) bool {
+	for _, col := range record {
+		if strings.EqualFold(col, "url") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+// Package dloader_test is a unit test
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dload"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+const (
+	sha256Hello = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	md5Hello    = "5d41402abc4b2a76b9719d911017c592"
+)
+
+func TestParseCksum(t *testing.T) {
+	tests := []struct {
+		s, dflt string
+		ty, val string
+		fail    bool
+	}{
+		{"", "", "", "", false},
+		{"sha256:" + sha256Hello, "", cos.ChecksumSHA256, sha256Hello, false},
+		{md5Hello, cos.ChecksumMD5, cos.ChecksumMD5, md5Hello, false},
+		{"md5:" + strings.ToUpper(md5Hello), cos.ChecksumSHA256, cos.ChecksumMD5, md5Hello, false},
+		{"crc32c:a9421b7b", "", cos.ChecksumCRC32C, "a9421b7b", false},
+		{md5Hello, "", "", "", true},                    // no type
+		{"xxhash:" + md5Hello, "", "", "", true},        // unsupported type
+		{"sha256:" + md5Hello, "", "", "", true},        // wrong length
+		{"md5:" + md5Hello[1:] + "z", "", "", "", true}, // not hex
+	}
+	for _, test := range tests {
+		cksum, err := dload.ParseCksum(test.s, test.dflt)
+		if test.fail {
+			tassert.Errorf(t, err != nil, "expected %q to fail", test.s)
+			continue
+		}
+		tassert.CheckFatal(t, err)
+		if test.s == "" {
+			tassert.Errorf(t, cksum == nil, "expected nil checksum")
+			continue
+		}
+		tassert.Errorf(t, cksum.Ty() == test.ty && cksum.Val() == test.val, "%q: got %s", test.s, cksum)
+	}
+}
+
+func TestManifestFormat(t *testing.T) {
+	tests := []struct {
+		name, format, ty string
+	}{
+		{"SHA256SUMS", dload.ManifestSums, cos.ChecksumSHA256},
+		{"dir/MD5SUMS.txt", dload.ManifestSums, cos.ChecksumMD5},
+		{"files.sha512", dload.ManifestSums, cos.ChecksumSHA512},
+		{"list.json", dload.ManifestJSON, ""},
+		{"list.CSV", dload.ManifestCSV, ""},
+		{"manifest", dload.ManifestSums, ""},
+	}
+	for _, test := range tests {
+		format, ty := dload.ManifestFormat(test.name)
+		tassert.Errorf(t, format == test.format && ty == test.ty, "%q: got (%q, %q)", test.name, format, ty)
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	const baseURL = "https://example.com/files/"
+	t.Run("sums", func(t *testing.T) {
+		manifest := "# comment\n" +
+			sha256Hello + "  hello.txt\n\n" +
+			sha256Hello + " *./dir/hello.bin\n"
+		entries, err := dload.ParseManifest(strings.NewReader(manifest), dload.ManifestSums, baseURL)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, len(entries) == 2, "expected 2 entries, got %d", len(entries))
+		tassert.Errorf(t, entries[0] == dload.Entry{Link: baseURL + "hello.txt", Name: "hello.txt", Cksum: sha256Hello},
+			"unexpected entry: %+v", entries[0])
+		tassert.Errorf(t, entries[1].Link == baseURL+"dir/hello.bin" && entries[1].Name == "dir/hello.bin",
+			"unexpected entry: %+v", entries[1])
+
+		_, err = dload.ParseManifest(strings.NewReader(manifest), dload.ManifestSums, "")
+		tassert.Errorf(t, err != nil, "expected relative links to fail w/o base URL")
+	})
+	t.Run("json", func(t *testing.T) {
+		manifest := `[{"url": "http://a.com/x", "size": 5, "cksum": "md5:` + md5Hello + `"}, {"url": "y", "name": "z"}]`
+		entries, err := dload.ParseManifest(strings.NewReader(manifest), dload.ManifestJSON, baseURL)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, len(entries) == 2, "expected 2 entries, got %d", len(entries))
+		tassert.Errorf(t, entries[0] == dload.Entry{Link: "http://a.com/x", Size: 5, Cksum: "md5:" + md5Hello},
+			"unexpected entry: %+v", entries[0])
+		tassert.Errorf(t, entries[1] == dload.Entry{Link: baseURL + "y", Name: "z"}, "unexpected entry: %+v", entries[1])
+	})
+	t.Run("csv", func(t *testing.T) {
+		positional := "http://a.com/x,x1,5," + md5Hello + "\nhttp://a.com/y\n"
+		entries, err := dload.ParseManifest(strings.NewReader(positional), dload.ManifestCSV, "")
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, len(entries) == 2, "expected 2 entries, got %d", len(entries))
+		tassert.Errorf(t, entries[0] == dload.Entry{Link: "http://a.com/x", Name: "x1", Size: 5, Cksum: md5Hello},
+			"unexpected entry: %+v", entries[0])
+		tassert.Errorf(t, entries[1] == dload.Entry{Link: "http://a.com/y"}, "unexpected entry: %+v", entries[1])
+
+		header := "cksum,url\n" + md5Hello + ",http://a.com/x\n"
+		entries, err = dload.ParseManifest(strings.NewReader(header), dload.ManifestCSV, "")
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, len(entries) == 1 && entries[0] == dload.Entry{Link: "http://a.com/x", Cksum: md5Hello},
+			"unexpected entries: %+v", entries)
+
+		_, err = dload.ParseManifest(strings.NewReader("http://a.com/x,x1,five\n"), dload.ManifestCSV, "")
+		tassert.Errorf(t, err != nil, "expected invalid size to fail")
+	})
+}
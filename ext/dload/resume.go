@@ -0,0 +1,249 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Large downloads from the servers that support range requests are written
+// into a work file that survives failed attempts. Each retry then resumes
+// from where the previous one stopped (via HTTP `Range` and `If-Range`)
+// unless the source has changed in the meantime, in which case the download
+// starts from scratch. The work file is finalized as an object only when
+// complete and validated.
+
+// the minimum size of a download that can be resumed
+var resumeMinSize int64 = 64 * cos.MiB
+
+const fmtErrResume = "%s: cannot resume at offset %d (size %d): %s"
+
+type partialWork struct {
+	fqn          string
+	etag         string // strong ETag, if any
+	lastModified string
+	off          int64 // current offset (that is, the size of the work file)
+	size         int64 // total
+}
+
+func isResumable(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK || resp.ContentLength < resumeMinSize {
+		return false
+	}
+	if resp.Header.Get(cos.HdrAcceptRanges) != "bytes" {
+		return false
+	}
+	etag, lastModified := validators(resp)
+	return etag != "" || lastModified != ""
+}
+
+// weak ETags are not to be used with range requests (see RFC 7232, section 2.1)
+func validators(resp *http.Response) (etag, lastModified string) {
+	etag = resp.Header.Get(cos.HdrETag)
+	if strings.HasPrefix(etag, "W/") {
+		etag = ""
+	}
+	return etag, resp.Header.Get(cos.HdrLastModified)
+}
+
+func newPartialWork(lom *cluster.LOM) *partialWork {
+	return &partialWork{fqn: fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileDownload)}
+}
+
+// prepare adds range headers to resume the download (noop if there's nothing to resume)
+func (p *partialWork) prepare(req *http.Request) {
+	if p == nil {
+		return
+	}
+	finfo, err := os.Stat(p.fqn)
+	if err != nil {
+		p.off = 0
+		return
+	}
+	p.off = finfo.Size()
+	if p.off == 0 || p.off >= p.size || (p.etag == "" && p.lastModified == "") {
+		p.discard()
+		return
+	}
+	req.Header.Set(cos.HdrRange, fmt.Sprintf("%s%d-", cos.HdrRangeValPrefix, p.off))
+	if p.etag != "" {
+		req.Header.Set(cos.HdrIfRange, p.etag)
+	} else {
+		req.Header.Set(cos.HdrIfRange, p.lastModified)
+	}
+}
+
+// accept validates the response and returns the offset to write from:
+// - 206 (Partial Content) means that the range request was honored;
+// - otherwise, the server returns the entire (and possibly, updated) content.
+func (p *partialWork) accept(resp *http.Response) (int64, error) {
+	if resp.StatusCode != http.StatusPartialContent {
+		p.off, p.size = 0, resp.ContentLength
+		p.etag, p.lastModified = validators(resp)
+		return 0, nil
+	}
+	start, total, err := parseContentRange(resp.Header.Get(cos.HdrContentRange))
+	if err != nil {
+		p.discard()
+		return 0, fmt.Errorf(fmtErrResume, p.fqn, p.off, p.size, err)
+	}
+	if start != p.off || total != p.size {
+		err = fmt.Errorf("unexpected content range [%d, total %d]", start, total)
+		p.discard()
+		return 0, fmt.Errorf(fmtErrResume, p.fqn, p.off, p.size, err)
+	}
+	// must be the same content
+	etag, lastModified := validators(resp)
+	if (etag != "" && p.etag != "" && etag != p.etag) ||
+		(lastModified != "" && p.lastModified != "" && lastModified != p.lastModified) {
+		p.discard()
+		return 0, fmt.Errorf(fmtErrResume, p.fqn, p.off, p.size, "source has changed")
+	}
+	return p.off, nil
+}
+
+func (p *partialWork) open() (*os.File, error) {
+	fh, err := os.OpenFile(p.fqn, os.O_CREATE|os.O_WRONLY, cos.PermRWR)
+	if err != nil {
+		return nil, err
+	}
+	if err = fh.Truncate(p.off); err == nil {
+		_, err = fh.Seek(p.off, io.SeekStart)
+	}
+	if err != nil {
+		fh.Close()
+		return nil, err
+	}
+	return fh, nil
+}
+
+// start from scratch
+func (p *partialWork) discard() {
+	if p == nil {
+		return
+	}
+	p.off = 0
+	if err := cos.RemoveFile(p.fqn); err != nil {
+		glog.Errorf("failed to remove partially downloaded %s: %v", p.fqn, err)
+	}
+}
+
+// e.g. "bytes 100-199/200"
+func parseContentRange(s string) (start, total int64, err error) {
+	if !strings.HasPrefix(s, cos.HdrContentRangeValPrefix) {
+		return 0, 0, fmt.Errorf("invalid %s %q", cos.HdrContentRange, s)
+	}
+	s = strings.TrimPrefix(s, cos.HdrContentRangeValPrefix)
+	i, j := strings.IndexByte(s, '-'), strings.IndexByte(s, '/')
+	if i <= 0 || j <= i {
+		return 0, 0, fmt.Errorf("invalid %s %q", cos.HdrContentRange, s)
+	}
+	if start, err = strconv.ParseInt(s[:i], 10, 64); err != nil {
+		return
+	}
+	total, err = strconv.ParseInt(s[j+1:], 10, 64)
+	return
+}
+
+////////////////
+// singleTask //
+////////////////
+
+func (task *singleTask) downloadPartial(lom *cluster.LOM, resp *http.Response) (bool /*err is fatal*/, error) {
+	p := task.partial
+	off, err := p.accept(resp)
+	if err != nil {
+		return false, err
+	}
+	if task.obj.size > 0 && p.size != task.obj.size {
+		return false, fmt.Errorf("%w: %s size %d (expected %d)", errSizeMismatch, task.obj.link, p.size, task.obj.size)
+	}
+	attrsFromLink(task.obj.link, resp, lom)
+	task.setTotalSize(p.size)
+	if off > 0 {
+		task.currentSize.Store(off) // progress includes resumed bytes
+		glog.Infof("%s: resuming at offset %d (size %d)", task, off, p.size)
+	}
+
+	fh, err := p.open()
+	if err != nil {
+		return true, err
+	}
+	buf, slab := task.xdl.t.PageMM().Alloc()
+	_, err = io.CopyBuffer(cos.WriterOnly{Writer: fh}, task.wrapReader(resp.Body), buf)
+	slab.Free(buf)
+	if errClose := fh.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return false, err // keeping the work file to resume
+	}
+	return task.finalizePartial(lom)
+}
+
+// compute (and validate) checksums, and finalize the work file as the object
+func (task *singleTask) finalizePartial(lom *cluster.LOM) (bool /*err is fatal*/, error) {
+	var (
+		p       = task.partial
+		expct   = task.obj.cksum
+		ckconf  = lom.CksumConf()
+		store   = cos.NewCksumHash(ckconf.Type)
+		compt   *cos.CksumHash
+		writers = []io.Writer{store.H}
+	)
+	if expct != nil {
+		if expct.Ty() == store.Ty() {
+			compt = store
+		} else {
+			compt = cos.NewCksumHash(expct.Ty())
+			writers = append(writers, compt.H)
+		}
+	}
+	fh, err := os.Open(p.fqn)
+	if err != nil {
+		return true, err
+	}
+	buf, slab := task.xdl.t.PageMM().Alloc()
+	n, err := io.CopyBuffer(cos.NewWriterMulti(writers...), fh, buf)
+	slab.Free(buf)
+	fh.Close()
+	if err != nil {
+		return true, err
+	}
+	if p.size > 0 && n != p.size {
+		p.discard()
+		return false, fmt.Errorf("%w: %s downloaded %d bytes (expected %d)", errSizeMismatch, task.obj.link, n, p.size)
+	}
+	if compt != nil {
+		compt.Finalize()
+		if !compt.Equal(expct) {
+			p.discard()
+			return false, cos.NewBadDataCksumError(expct, &compt.Cksum, task.obj.link)
+		}
+	}
+	if ckconf.Type == cos.ChecksumNone {
+		lom.SetCksum(cos.NoneCksum)
+	} else {
+		store.Finalize()
+		lom.SetCksum(store.Clone())
+	}
+	lom.SetSize(n)
+	lom.SetAtimeUnix(task.started.Load().UnixNano())
+	if _, err := task.xdl.t.FinalizeObj(lom, p.fqn, task.xdl); err != nil {
+		return true, err
+	}
+	task.partial = nil
+	return false, lom.Load(true /*cache it*/, false /*locked*/)
+}
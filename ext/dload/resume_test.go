@@ -0,0 +1,93 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestParseContentRange(t *testing.T) {
+	start, total, err := parseContentRange("bytes 100-199/200")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, start == 100 && total == 200, "got [%d, %d]", start, total)
+
+	for _, s := range []string{"", "bytes */200", "items 0-1/2", "bytes 1-2", "bytes a-1/2"} {
+		_, _, err := parseContentRange(s)
+		tassert.Errorf(t, err != nil, "expected %q to fail", s)
+	}
+}
+
+func TestPartialWork(t *testing.T) {
+	const (
+		etag = `"abc"`
+		size = 100
+	)
+	var (
+		fqn = filepath.Join(t.TempDir(), "partial")
+		p   = &partialWork{fqn: fqn}
+	)
+	newResp := func(status int, hdr ...string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}, ContentLength: size}
+		for i := 0; i < len(hdr); i += 2 {
+			resp.Header.Set(hdr[i], hdr[i+1])
+		}
+		return resp
+	}
+
+	// first attempt: full content
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/file", http.NoBody)
+	p.prepare(req)
+	tassert.Errorf(t, req.Header.Get(cos.HdrRange) == "", "unexpected range on the first attempt")
+	off, err := p.accept(newResp(http.StatusOK, cos.HdrETag, etag))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, off == 0 && p.size == size && p.etag == etag, "unexpected state: %+v", p)
+
+	// partial work file => range request
+	tassert.CheckFatal(t, os.WriteFile(fqn, make([]byte, 40), cos.PermRWR))
+	req, _ = http.NewRequest(http.MethodGet, "http://example.com/file", http.NoBody)
+	p.prepare(req)
+	tassert.Errorf(t, req.Header.Get(cos.HdrRange) == "bytes=40-", "range: %q", req.Header.Get(cos.HdrRange))
+	tassert.Errorf(t, req.Header.Get(cos.HdrIfRange) == etag, "if-range: %q", req.Header.Get(cos.HdrIfRange))
+
+	off, err = p.accept(newResp(http.StatusPartialContent, cos.HdrETag, etag,
+		cos.HdrContentRange, "bytes 40-99/"+strconv.Itoa(size)))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, off == 40, "expected to resume at 40, got %d", off)
+
+	// source has changed
+	_, err = p.accept(newResp(http.StatusPartialContent, cos.HdrETag, `"xyz"`,
+		cos.HdrContentRange, "bytes 40-99/"+strconv.Itoa(size)))
+	tassert.Errorf(t, err != nil, "expected changed ETag to fail")
+	_, err = os.Stat(fqn)
+	tassert.Errorf(t, os.IsNotExist(err), "expected work file to be removed")
+
+	// range not honored (e.g., because of `If-Range`) => start from scratch
+	tassert.CheckFatal(t, os.WriteFile(fqn, make([]byte, 40), cos.PermRWR))
+	req, _ = http.NewRequest(http.MethodGet, "http://example.com/file", http.NoBody)
+	p.prepare(req)
+	off, err = p.accept(newResp(http.StatusOK, cos.HdrETag, `"xyz"`))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, off == 0 && p.etag == `"xyz"`, "unexpected state: %+v", p)
+}
+
+func TestIsResumable(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, ContentLength: resumeMinSize}
+	tassert.Errorf(t, !isResumable(resp), "expected not resumable w/o Accept-Ranges")
+	resp.Header.Set(cos.HdrAcceptRanges, "bytes")
+	tassert.Errorf(t, !isResumable(resp), "expected not resumable w/o validators")
+	resp.Header.Set(cos.HdrETag, `W/"weak"`)
+	tassert.Errorf(t, !isResumable(resp), "expected not resumable with weak ETag")
+	resp.Header.Set(cos.HdrLastModified, "Wed, 21 Oct 2015 07:28:00 GMT")
+	tassert.Errorf(t, isResumable(resp), "expected resumable")
+	resp.ContentLength = resumeMinSize - 1
+	tassert.Errorf(t, !isResumable(resp), "expected small download not to be resumable")
+}
@@ -0,0 +1,163 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Scheduled (recurring) downloads, e.g. periodic `TypeBackend` sync with
+// a remote bucket. Schedules are stored in the destination bucket's properties
+// (see cmn.DlSchedule) and are, therefore, a part of the cluster metadata.
+// The primary proxy evaluates all schedules and starts each run as a regular
+// download job tagged with the schedule's ID (see `Base.Schedule`).
+// Each target records the results of the finished runs (see db.go).
+
+const PrefixScheduleID = "dls-"
+
+type (
+	// create download schedule
+	ScheduleBody struct {
+		ID    string `json:"id,omitempty"`    // optional (generated if empty)
+		Cron  string `json:"cron,omitempty"`  // e.g. "0 3 * * *" or "@daily" (see cos.ParseCron)
+		Every string `json:"every,omitempty"` // alternatively, interval, e.g. "6h"
+		Req   Body   `json:"request"`         // download request (any type)
+	}
+
+	ScheduleInfo struct {
+		cmn.DlSchedule
+		Bck         cmn.Bck   `json:"bucket"`
+		Type        Type      `json:"type"`
+		Description string    `json:"description,omitempty"`
+		NextRun     time.Time `json:"next_run"`
+		LastJobID   string    `json:"last_job_id,omitempty"`
+		Runs        []Job     `json:"runs,omitempty"` // run history (most recent first)
+	}
+	ScheduleInfos []*ScheduleInfo
+)
+
+//////////////////
+// ScheduleBody //
+//////////////////
+
+func (b *ScheduleBody) Validate() error {
+	if b.ID != "" {
+		if err := cos.ValidateNiceID(b.ID, 2, "download schedule ID"); err != nil {
+			return err
+		}
+	}
+	if b.Every != "" {
+		if _, err := time.ParseDuration(b.Every); err != nil {
+			return fmt.Errorf("invalid download schedule interval %q: %v", b.Every, err)
+		}
+	}
+	if b.Req.Type == "" {
+		return errors.New("download schedule: missing download request")
+	}
+	return b.Req.Validate()
+}
+
+// NOTE: the request is stored as is (see `Body.UnmarshalJSON`)
+func (b *ScheduleBody) ToSchedule() *cmn.DlSchedule {
+	sched := &cmn.DlSchedule{
+		ID:      b.ID,
+		Cron:    b.Cron,
+		Request: b.Req.RawMessage,
+		Created: time.Now().UnixNano(),
+	}
+	if sched.ID == "" {
+		sched.ID = PrefixScheduleID + cos.GenUUID()
+	}
+	if b.Every != "" {
+		d, _ := time.ParseDuration(b.Every) // validated
+		sched.Every = cos.Duration(d)
+	}
+	return sched
+}
+
+//////////
+// Body //
+//////////
+
+// SetSchedule tags the download request with the ID of the schedule that runs it.
+// NOTE: the values are kept as they are (i.e., numbers are not re-encoded).
+func (db *Body) SetSchedule(id string) error {
+	m := make(map[string]json.RawMessage, 8)
+	if err := jsoniter.Unmarshal(db.RawMessage, &m); err != nil {
+		return err
+	}
+	m["schedule"] = cos.MustMarshal(id)
+	db.RawMessage = cos.MustMarshal(m)
+	return nil
+}
+
+//////////////////
+// ScheduleInfo //
+//////////////////
+
+func NewScheduleInfo(sched *cmn.DlSchedule, bck *cmn.Bck) *ScheduleInfo {
+	info := &ScheduleInfo{DlSchedule: *sched, Bck: *bck}
+	dlb := Body{}
+	if err := jsoniter.Unmarshal(sched.Request, &dlb); err == nil {
+		info.Type = dlb.Type
+		base := Base{}
+		if err := jsoniter.Unmarshal(dlb.RawMessage, &base); err == nil {
+			info.Description = base.Description
+		}
+	}
+	return info
+}
+
+// MergeRuns combines per-target histories of a given schedule into
+// cluster-wide runs (that is, jobs), most recent first.
+func MergeRuns(histories ...[]Job) []Job {
+	var (
+		runs []Job
+		idx  = make(map[string]int, historySize)
+	)
+	for _, history := range histories {
+		for i := range history {
+			run := &history[i]
+			if j, ok := idx[run.ID]; ok {
+				runs[j].Aggregate(run)
+				continue
+			}
+			idx[run.ID] = len(runs)
+			runs = append(runs, *run)
+		}
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].StartedTime.After(runs[j].StartedTime) })
+	return runs
+}
+
+///////////////////
+// ScheduleInfos //
+///////////////////
+
+func (s ScheduleInfos) Len() int           { return len(s) }
+func (s ScheduleInfos) Less(i, j int) bool { return s[i].ID < s[j].ID }
+func (s ScheduleInfos) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+////////////////////////////
+// history (target-local) //
+////////////////////////////
+
+func ScheduleHistory(id string) ([]Job, error) {
+	initInfoStore(db)
+	return dlStore.getHistory(id)
+}
+
+func RemoveScheduleHistory(id string) {
+	initInfoStore(db)
+	dlStore.deleteHistory(id)
+}
@@ -0,0 +1,94 @@
+// Package dloader_test is a unit test
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/ext/dload"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const schedReq = `{"type": "backend", "bucket": {"name": "b", "provider": "aws"}, "sync": true, "limits": {"bytes_per_hour": 1073741824}}`
+
+func TestScheduleBody(t *testing.T) {
+	tests := []struct {
+		body  string
+		valid bool
+	}{
+		{`{"cron": "0 3 * * *", "request": ` + schedReq + `}`, true},
+		{`{"id": "nightly", "every": "6h", "request": ` + schedReq + `}`, true},
+		{`{"every": "6 hours", "request": ` + schedReq + `}`, false},
+		{`{"id": "a b", "every": "6h", "request": ` + schedReq + `}`, false},
+		{`{"every": "6h", "request": {"type": "range", "bucket": {"name": "b", "provider": "ais"}}}`, false},
+		{`{"every": "6h", "request": {"type": "unknown"}}`, false},
+	}
+	for i, test := range tests {
+		sb := &dload.ScheduleBody{}
+		err := jsoniter.Unmarshal([]byte(test.body), sb)
+		if err == nil {
+			err = sb.Validate()
+		}
+		if !test.valid {
+			tassert.Errorf(t, err != nil, "#%d: expected an error", i)
+			continue
+		}
+		tassert.CheckFatal(t, err)
+
+		sched := sb.ToSchedule()
+		tassert.Errorf(t, sched.ID != "", "#%d: expected schedule ID", i)
+		tassert.Errorf(t, sb.ID == "" || sched.ID == sb.ID, "#%d: unexpected schedule ID %q", i, sched.ID)
+		tassert.Errorf(t, sb.ID != "" || strings.HasPrefix(sched.ID, dload.PrefixScheduleID),
+			"#%d: unexpected generated schedule ID %q", i, sched.ID)
+		conf := cmn.DownloadConf{Schedules: []cmn.DlSchedule{*sched}}
+		tassert.CheckError(t, conf.ValidateAsProps())
+	}
+}
+
+func TestSetSchedule(t *testing.T) {
+	dlb := dload.Body{}
+	tassert.CheckFatal(t, jsoniter.Unmarshal([]byte(schedReq), &dlb))
+	tassert.CheckFatal(t, dlb.SetSchedule("nightly"))
+
+	// round trip
+	b, err := jsoniter.Marshal(dlb)
+	tassert.CheckFatal(t, err)
+	dlb = dload.Body{}
+	tassert.CheckFatal(t, jsoniter.Unmarshal(b, &dlb))
+	tassert.Fatalf(t, dlb.Type == dload.TypeBackend, "unexpected type %q", dlb.Type)
+
+	payload := dload.BackendBody{}
+	tassert.CheckFatal(t, jsoniter.Unmarshal(dlb.RawMessage, &payload))
+	tassert.Errorf(t, payload.Schedule == "nightly", "unexpected schedule %q", payload.Schedule)
+	tassert.Errorf(t, payload.Sync, "expected sync")
+	tassert.Errorf(t, payload.Limits.BytesPerHour == 1073741824, "unexpected limit %d", payload.Limits.BytesPerHour)
+}
+
+func TestMergeRuns(t *testing.T) {
+	var (
+		now     = time.Now()
+		target1 = []dload.Job{
+			{ID: "j1", StartedTime: now.Add(-2 * time.Hour), AddedCnt: 1, ErrorCnt: 1},
+			{ID: "j2", StartedTime: now.Add(-time.Hour), UpdatedCnt: 2},
+		}
+		target2 = []dload.Job{
+			{ID: "j1", StartedTime: now.Add(-2 * time.Hour), AddedCnt: 3, DeletedCnt: 1},
+			{ID: "j2", StartedTime: now.Add(-time.Hour), UpdatedCnt: 1},
+			{ID: "j3", StartedTime: now},
+		}
+	)
+	runs := dload.MergeRuns(target1, target2)
+	tassert.Fatalf(t, len(runs) == 3, "expected 3 runs, got %d", len(runs))
+	tassert.Errorf(t, runs[0].ID == "j3" && runs[1].ID == "j2" && runs[2].ID == "j1",
+		"expected most recent first: %s, %s, %s", runs[0].ID, runs[1].ID, runs[2].ID)
+	tassert.Errorf(t, runs[1].UpdatedCnt == 3, "expected 3 updated, got %d", runs[1].UpdatedCnt)
+	j1 := runs[2]
+	tassert.Errorf(t, j1.AddedCnt == 4 && j1.DeletedCnt == 1 && j1.ErrorCnt == 1,
+		"unexpected aggregated counters: %+v", j1)
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/event"
+	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/stats"
 )
@@ -28,22 +30,39 @@ const (
 
 const (
 	retryCnt         = 10  // number of retries to external resource
+	cksumRetryCnt    = 3   // number of retries upon checksum or size mismatch
 	reqTimeoutFactor = 1.2 // newTimeout = prevTimeout * reqTimeoutFactor
 	internalErrorMsg = "internal server error"
 )
 
-type singleTask struct {
-	xdl         *Xact
-	job         jobif
-	obj         dlObj
-	started     atomic.Time
-	ended       atomic.Time
-	currentSize atomic.Int64       // current file size (updated as the download progresses)
-	totalSize   atomic.Int64       // total size (nonzero iff Content-Length header was provided by the source)
-	downloadCtx context.Context    // w/ cancel function
-	getCtx      context.Context    // w/ timeout and size
-	cancel      context.CancelFunc // to cancel the download after the request commences
-}
+var errSizeMismatch = errors.New("size mismatch")
+
+type (
+	singleTask struct {
+		xdl         *Xact
+		job         jobif
+		obj         dlObj
+		started     atomic.Time
+		ended       atomic.Time
+		currentSize atomic.Int64       // current file size (updated as the download progresses)
+		totalSize   atomic.Int64       // total size (nonzero iff provided by the source via Content-Length or expected by the user)
+		downloadCtx context.Context    // w/ cancel function
+		getCtx      context.Context    // w/ timeout and size
+		cancel      context.CancelFunc // to cancel the download after the request commences
+		partial     *partialWork       // resumable download in progress
+	}
+
+	// validates downloaded content against expected checksum and/or size
+	// (and fails the last read - upon EOF - if it doesn't match)
+	validatingReader struct {
+		r     io.ReadCloser
+		expct *cos.Cksum
+		cksum *cos.CksumHash
+		link  string
+		size  int64 // expected
+		n     int64 // read so far
+	}
+)
 
 // List of HTTP status codes which we shouldn'task retry (just report the job failed).
 var terminalStatuses = map[int]struct{}{
@@ -75,6 +94,7 @@ func (task *singleTask) download(lom *cluster.LOM) {
 		task.markFailed(internalErrorMsg)
 		return
 	}
+	exists := err == nil
 
 	if glog.V(4) {
 		glog.Infof("Starting download for %v", task)
@@ -95,6 +115,11 @@ func (task *singleTask) download(lom *cluster.LOM) {
 	}
 
 	dlStore.incFinished(task.jobID())
+	if exists {
+		dlStore.incUpdated(task.jobID())
+	} else {
+		dlStore.incAdded(task.jobID())
+	}
 
 	task.xdl.statsT.AddMany(
 		cos.NamedVal64{Name: stats.DownloadSize, Value: task.currentSize.Load()},
@@ -120,6 +145,8 @@ func (task *singleTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Duration
 		req.Header.Add("User-Agent", gcsUA)
 	}
 
+	task.partial.prepare(req)
+
 	resp, err := clientForURL(task.obj.link).Do(req)
 	if err != nil {
 		return false, err
@@ -127,16 +154,32 @@ func (task *singleTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Duration
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= http.StatusBadRequest {
+		if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			task.partial.discard()
+		}
 		return false, cmn.NewErrHTTP(req, errors.New("nil error w/ bad status"), resp.StatusCode)
 	}
 
-	r := task.wrapReader(resp.Body)
+	if task.partial == nil && isResumable(resp) {
+		task.partial = newPartialWork(lom)
+	}
+	if task.partial != nil {
+		return task.downloadPartial(lom, resp)
+	}
+
 	size := attrsFromLink(task.obj.link, resp, lom)
+	if size > 0 && task.obj.size > 0 && size != task.obj.size {
+		return false, fmt.Errorf("%w: %s content length %d (expected %d)", errSizeMismatch, task.obj.link, size, task.obj.size)
+	}
+	if size <= 0 {
+		size = task.obj.size
+	}
 	task.setTotalSize(size)
+	r := task.validate(task.wrapReader(resp.Body))
 
 	params := cluster.AllocPutObjParams()
 	{
-		params.WorkTag = "dl"
+		params.WorkTag = fs.WorkfileDownload
 		params.Reader = r
 		params.OWT = cmn.OwtPut
 		params.Atime = task.started.Load()
@@ -150,6 +193,7 @@ func (task *singleTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Duration
 	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
 		return true, err
 	}
+	event.Emit(cmn.EventObjCreated, lom)
 	return false, nil
 }
 
@@ -157,7 +201,9 @@ func (task *singleTask) downloadLocal(lom *cluster.LOM) (err error) {
 	var (
 		timeout = task.initialTimeout()
 		fatal   bool
+		badCnt  int
 	)
+	defer func() { task.partial.discard() }() // (noop upon success)
 	for i := 0; i < retryCnt; i++ {
 		fatal, err = task.tryDownloadLocal(lom, timeout)
 		if err == nil || fatal {
@@ -167,7 +213,12 @@ func (task *singleTask) downloadLocal(lom *cluster.LOM) (err error) {
 			// Download was canceled or stopped, so just return.
 			return err
 		}
-		if errors.Is(err, context.DeadlineExceeded) {
+		if isErrBadContent(err) {
+			if badCnt++; badCnt > cksumRetryCnt {
+				return err
+			}
+			glog.Warningf("%s [retries: %d/%d]: %v - retrying...", task, badCnt, cksumRetryCnt, err)
+		} else if errors.Is(err, context.DeadlineExceeded) {
 			glog.Warningf("%s [retries: %d/%d]: timeout (%v) - increasing and retrying...",
 				task, i, retryCnt, timeout)
 			timeout = time.Duration(float64(timeout) * reqTimeoutFactor)
@@ -200,12 +251,25 @@ func (task *singleTask) reset() {
 	task.currentSize.Store(0)
 }
 
-func (task *singleTask) downloadRemote(lom *cluster.LOM) error {
+func (task *singleTask) downloadRemote(lom *cluster.LOM) (err error) {
+	for i := 0; i <= cksumRetryCnt; i++ {
+		if err = task.tryDownloadRemote(lom); err == nil || !isErrBadContent(err) {
+			break
+		}
+		if i < cksumRetryCnt {
+			glog.Warningf("%s [retries: %d/%d]: %v - retrying...", task, i+1, cksumRetryCnt, err)
+		}
+		task.reset()
+	}
+	return err
+}
+
+func (task *singleTask) tryDownloadRemote(lom *cluster.LOM) error {
 	// Set custom context values (used by `ais/backend/*`).
 	ctx, cancel := context.WithTimeout(task.downloadCtx, task.initialTimeout())
 	defer cancel()
 
-	ctx = context.WithValue(ctx, cos.CtxReadWrapper, cos.ReadWrapperFunc(task.wrapReader))
+	ctx = context.WithValue(ctx, cos.CtxReadWrapper, cos.ReadWrapperFunc(task.wrapValidate))
 	ctx = context.WithValue(ctx, cos.CtxSetSize, cos.SetSizeFunc(task.setTotalSize))
 	task.getCtx = ctx
 
@@ -237,6 +301,18 @@ func (task *singleTask) wrapReader(r io.ReadCloser) io.ReadCloser {
 	return r
 }
 
+// Validate the content if requested.
+func (task *singleTask) validate(r io.ReadCloser) io.ReadCloser {
+	if task.obj.cksum != nil || task.obj.size > 0 {
+		r = newValidatingReader(r, task.obj.cksum, task.obj.size, task.obj.link)
+	}
+	return r
+}
+
+func (task *singleTask) wrapValidate(r io.ReadCloser) io.ReadCloser {
+	return task.validate(task.wrapReader(r))
+}
+
 // Probably we need to extend the persistent database (db.go) so that it will contain
 // also information about specific tasks.
 func (task *singleTask) markFailed(statusMsg string) {
@@ -273,3 +349,51 @@ func (task *singleTask) String() (str string) {
 		task.jobID(), task.obj.objName, task.obj.link, task.obj.fromRemote, task.job.Bck(),
 	)
 }
+
+//////////////////////
+// validatingReader //
+//////////////////////
+
+func newValidatingReader(r io.ReadCloser, expct *cos.Cksum, size int64, link string) *validatingReader {
+	vr := &validatingReader{r: r, expct: expct, size: size, link: link}
+	if expct != nil {
+		vr.cksum = cos.NewCksumHash(expct.Ty())
+	}
+	return vr
+}
+
+func (vr *validatingReader) Read(p []byte) (n int, err error) {
+	n, err = vr.r.Read(p)
+	if n > 0 {
+		vr.n += int64(n)
+		if vr.cksum != nil {
+			vr.cksum.H.Write(p[:n])
+		}
+	}
+	if err == io.EOF {
+		if errV := vr.validate(); errV != nil {
+			err = errV
+		}
+	}
+	return
+}
+
+func (vr *validatingReader) validate() error {
+	if vr.size > 0 && vr.n != vr.size {
+		return fmt.Errorf("%w: %s downloaded %d bytes (expected %d)", errSizeMismatch, vr.link, vr.n, vr.size)
+	}
+	if vr.cksum != nil {
+		vr.cksum.Finalize()
+		if !vr.cksum.Equal(vr.expct) {
+			return cos.NewBadDataCksumError(vr.expct, &vr.cksum.Cksum, vr.link)
+		}
+	}
+	return nil
+}
+
+func (vr *validatingReader) Close() error { return vr.r.Close() }
+
+func isErrBadContent(err error) bool {
+	var errCksum *cos.ErrBadCksum
+	return errors.As(err, &errCksum) || errors.Is(err, errSizeMismatch)
+}
@@ -7,6 +7,8 @@ package dload
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
@@ -19,7 +21,6 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
-	jsoniter "github.com/json-iterator/go"
 )
 
 const headReqTimeout = 5 * time.Second
@@ -58,21 +59,41 @@ func countObjects(t cluster.Target, pt cos.ParsedTemplate, dir string, bck *clus
 }
 
 // buildDlObjs returns list of objects that must be downloaded by target.
-func buildDlObjs(t cluster.Target, bck *cluster.Bck, objects cos.StrKVs) ([]dlObj, error) {
+// Duplicate object names are resolved in favor of the last entry.
+func buildDlObjs(t cluster.Target, bck *cluster.Bck, entries []Entry, cksumType string) ([]dlObj, error) {
 	var (
 		smap = t.Sowner().Get()
 		sid  = t.SID()
+		idx  = make(map[string]int, len(entries))
 	)
 
-	objs := make([]dlObj, 0, len(objects))
-	for name, link := range objects {
-		obj, err := makeDlObj(smap, sid, bck, name, link)
+	objs := make([]dlObj, 0, len(entries))
+	for i := range entries {
+		entry := &entries[i]
+		name, err := entry.objName()
+		if err != nil {
+			return nil, err
+		}
+		cksum, err := ParseCksum(entry.Cksum, cksumType)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		if entry.Size < 0 {
+			return nil, fmt.Errorf("%s: invalid expected size %d", name, entry.Size)
+		}
+		obj, err := makeDlObj(smap, sid, bck, name, entry.Link)
 		if err != nil {
 			if err == errInvalidTarget {
 				continue
 			}
 			return nil, err
 		}
+		obj.cksum, obj.size = cksum, entry.Size
+		if j, ok := idx[obj.objName]; ok {
+			objs[j] = obj
+			continue
+		}
+		idx[obj.objName] = len(objs)
 		objs = append(objs, obj)
 	}
 	return objs, nil
@@ -116,49 +137,24 @@ func NormalizeObjName(objName string) (string, error) {
 }
 
 func ParseStartRequest(t cluster.Target, bck *cluster.Bck, id string, dlb Body, xdl *Xact) (jobif, error) {
-	switch dlb.Type {
-	case TypeBackend:
-		dp := &BackendBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
-		if err != nil {
-			return nil, err
-		}
-		if err := dp.Validate(); err != nil {
-			return nil, err
-		}
+	payload, err := dlb.parse()
+	if err != nil {
+		return nil, err
+	}
+	switch dp := payload.(type) {
+	case *BackendBody:
 		return newBackendDlJob(t, id, bck, dp, xdl)
-	case TypeMulti:
-		dp := &MultiBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
-		if err != nil {
-			return nil, err
-		}
-		if err := dp.Validate(); err != nil {
-			return nil, err
-		}
+	case *MultiBody:
 		return newMultiDlJob(t, id, bck, dp, xdl)
-	case TypeRange:
-		dp := &RangeBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
-		if err != nil {
-			return nil, err
-		}
-		if err := dp.Validate(); err != nil {
-			return nil, err
-		}
+	case *RangeBody:
 		return newRangeDlJob(t, id, bck, dp, xdl)
-	case TypeSingle:
-		dp := &SingleBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
-		if err != nil {
-			return nil, err
-		}
-		if err := dp.Validate(); err != nil {
-			return nil, err
-		}
+	case *SingleBody:
 		return newSingleDlJob(t, id, bck, dp, xdl)
+	case *ManifestBody:
+		return newManifestDlJob(t, id, bck, dp, xdl)
 	default:
-		return nil, errors.New("input does not match any of the supported formats (single, range, multi, backend)")
+		debug.FailTypeCast(payload)
+		return nil, nil
 	}
 }
 
@@ -236,8 +232,12 @@ func headLink(link string) (*http.Response, error) {
 
 // Use all available metadata including {size, version, ETag, MD5, CRC}
 // to compare local object with its remote counterpart.
+// Expected checksum (if provided) takes precedence when its type matches.
 func CompareObjects(lom *cluster.LOM, dst *DstElement) (equal bool, err error) {
 	var oa *cmn.ObjAttrs
+	if dst.Cksum != nil && lom.Checksum().Type() == dst.Cksum.Ty() {
+		return lom.Checksum().Equal(dst.Cksum), nil
+	}
 	if dst.Link != "" {
 		resp, errHead := headLink(dst.Link)
 		if errHead != nil {
@@ -258,6 +258,34 @@ func CompareObjects(lom *cluster.LOM, dst *DstElement) (equal bool, err error) {
 	return
 }
 
+// GET manifest object from the target that stores it (which may as well be this one)
+func getManifest(t cluster.Target, bck *cluster.Bck, objName string) (io.ReadCloser, error) {
+	smap := t.Sowner().Get()
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), smap)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodGet, si.URL(cmn.NetIntraData)+apc.URLPathObjects.Join(bck.Name, objName), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = bck.AddToQuery(nil).Encode()
+	req.Header.Set(apc.HdrCallerID, t.SID())
+	req.Header.Set(apc.HdrCallerName, t.String())
+	resp, err := t.DataClient().Do(req) //nolint:bodyclose // closed by the caller
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		cos.Close(resp.Body)
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, cmn.NewErrNotFound("%s: manifest %s", t, bck.Cname(objName))
+		}
+		return nil, fmt.Errorf("%s: failed to GET manifest %s: %s", t, bck.Cname(objName), resp.Status)
+	}
+	return resp.Body, nil
+}
+
 // called via ais/prxnotifs generic mechanism
 func AbortReq(jobID string) cmn.HreqArgs {
 	var (
@@ -0,0 +1,393 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/ext/dsort/extract"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// Resumable job (see RequestSpec.Resumable) checkpoints its progress phase by phase
+// into <mountpath>/.ais.dsort/<job ID>/ (single HRW-selected mountpath per target):
+// - meta.json - parsed request spec, participating targets, and the last completed phase;
+// - records   - local records upon extraction (msgpack);
+// - shards    - creation phase metadata received from the final target (msgpack);
+// - created   - names of the shards created so far, one per line.
+//
+// When aborted (for any reason, including target restart) the job retains its
+// checkpoint and extracted contents. Subsequent resume (POST /v1/sort/resume)
+// continues from the last phase completed by all targets. The checkpoint
+// is removed when the job successfully finishes or gets removed.
+
+const (
+	ckptMetaFname    = "meta.json"
+	ckptRecordsFname = "records"
+	ckptShardsFname  = "shards"
+	ckptCreatedFname = "created"
+
+	ckptBufSize = 64 * cos.KiB
+)
+
+type (
+	ckptMeta struct {
+		RS           *ParsedRequestSpec `json:"spec"`
+		Targets      []string           `json:"targets"`
+		Phase        string             `json:"phase"` // last completed phase ("" - none)
+		Compressed   int64              `json:"compressed,string"`
+		Uncompressed int64              `json:"uncompressed,string"`
+	}
+	checkpoint struct {
+		meta    ckptMeta
+		dir     string
+		resume  string // last phase completed by all targets (when resuming)
+		mu      sync.Mutex
+		created map[string]struct{}
+		fh      *os.File // created shards (append-only)
+	}
+
+	// resumeStatus is returned by each target when proxy prepares to resume the job
+	resumeStatus struct {
+		Phase   string   `json:"phase"`
+		Targets []string `json:"targets"`
+	}
+)
+
+func ckptDir(mi *fs.Mountpath, managerUUID string) string {
+	return filepath.Join(mi.Path, fname.DsortDir, managerUUID)
+}
+
+// phaseOrder orders completed phases; creation is never checkpointed as a whole
+func phaseOrder(phase string) int {
+	switch phase {
+	case ExtractionPhase:
+		return 1
+	case SortingPhase:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// minPhase returns the phase that was completed by all targets
+func minPhase(phases ...string) (phase string) {
+	for i, p := range phases {
+		if i == 0 || phaseOrder(p) < phaseOrder(phase) {
+			phase = p
+		}
+	}
+	return
+}
+
+func activeTargets(smap *cluster.Smap) []string {
+	tids := make([]string, 0, len(smap.Tmap))
+	for tid, si := range smap.Tmap {
+		if !smap.InMaintOrDecomm(si) {
+			tids = append(tids, tid)
+		}
+	}
+	sort.Strings(tids)
+	return tids
+}
+
+func newCheckpoint(managerUUID string, rs *ParsedRequestSpec, smap *cluster.Smap) (*checkpoint, error) {
+	mi, _, err := cluster.HrwMpath(managerUUID)
+	if err != nil {
+		return nil, err
+	}
+	ck := &checkpoint{
+		dir:     ckptDir(mi, managerUUID),
+		meta:    ckptMeta{RS: rs, Targets: activeTargets(smap)},
+		created: make(map[string]struct{}),
+	}
+	if err := cos.CreateDir(ck.dir); err != nil {
+		return nil, err
+	}
+	return ck, ck.saveMeta()
+}
+
+// loadCheckpoint looks up the job's checkpoint across available mountpaths
+func loadCheckpoint(managerUUID string) (*checkpoint, error) {
+	avail := fs.GetAvail()
+	for _, mi := range avail {
+		ck := &checkpoint{dir: ckptDir(mi, managerUUID)}
+		if _, err := jsp.Load(filepath.Join(ck.dir, ckptMetaFname), &ck.meta, jsp.Plain()); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		return ck, ck.loadCreated()
+	}
+	return nil, cmn.NewErrNotFound("%s job %q checkpoint", DSortName, managerUUID)
+}
+
+// listCheckpoints returns IDs of all jobs checkpointed on this target
+func listCheckpoints() (uuids []string) {
+	avail := fs.GetAvail()
+	for _, mi := range avail {
+		dirents, err := os.ReadDir(filepath.Join(mi.Path, fname.DsortDir))
+		if err != nil {
+			continue
+		}
+		for _, de := range dirents {
+			if de.IsDir() {
+				uuids = append(uuids, de.Name())
+			}
+		}
+	}
+	return
+}
+
+// removeCheckpoint removes the job's checkpoint (if any) along with the
+// extracted contents retained to resume the job
+func removeCheckpoint(managerUUID string) {
+	ck, err := loadCheckpoint(managerUUID)
+	if err != nil {
+		if !cmn.IsErrNotFound(err) {
+			glog.Error(err)
+		}
+		return
+	}
+	records := extract.NewRecords(1000)
+	if err := ck.loadMsg(ckptRecordsFname, records); err == nil {
+		rs := ck.meta.RS
+		rm := extract.NewRecordManager(ctx.t, rs.Bck, rs.Extension, nil, nil, nil, false)
+		rm.TrackExtractionPaths(records)
+		rm.Cleanup()
+	}
+	ck.destroy()
+}
+
+// resumed returns true if the phase was completed (by all targets) prior to
+// resuming the job; nil checkpoint (non-resumable job) is never resumed
+func (ck *checkpoint) resumed(phase string) bool {
+	return ck != nil && phaseOrder(phase) <= phaseOrder(ck.resume)
+}
+
+func (ck *checkpoint) status() *resumeStatus {
+	return &resumeStatus{Phase: ck.meta.Phase, Targets: ck.meta.Targets}
+}
+
+// checkTargets makes sure that the job is resumed by the same set of targets
+func checkTargets(ckptTargets []string, smap *cluster.Smap) error {
+	tids := activeTargets(smap)
+	if len(tids) == len(ckptTargets) {
+		equal := true
+		for i := range tids {
+			equal = equal && tids[i] == ckptTargets[i]
+		}
+		if equal {
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot resume: targets have changed (checkpointed %v, current %v)", ckptTargets, tids)
+}
+
+func (ck *checkpoint) saveMeta() error {
+	return jsp.Save(filepath.Join(ck.dir, ckptMetaFname), &ck.meta, jsp.Plain(), nil)
+}
+
+func (ck *checkpoint) saveRecords(records *extract.Records, compressed, uncompressed int64) error {
+	if err := ck.saveMsg(ckptRecordsFname, records); err != nil {
+		return err
+	}
+	ck.meta.Phase = ExtractionPhase
+	ck.meta.Compressed, ck.meta.Uncompressed = compressed, uncompressed
+	return ck.saveMeta()
+}
+
+func (ck *checkpoint) saveShards(md *CreationPhaseMetadata) error {
+	if err := ck.saveMsg(ckptShardsFname, md); err != nil {
+		return err
+	}
+	ck.meta.Phase = SortingPhase
+	return ck.saveMeta()
+}
+
+func (ck *checkpoint) saveMsg(name string, v msgp.Encodable) error {
+	return jsp.Save(filepath.Join(ck.dir, name), nil, jsp.Plain(), &msgpWriterTo{v})
+}
+
+func (ck *checkpoint) loadMsg(name string, v msgp.Decodable) error {
+	f, err := os.Open(filepath.Join(ck.dir, name))
+	if err != nil {
+		return err
+	}
+	defer cos.Close(f)
+	return v.DecodeMsg(msgp.NewReaderSize(f, ckptBufSize))
+}
+
+//
+// created shards
+//
+
+// NOTE: a name that is not newline-terminated is the result of an interrupted
+// write and is, therefore, ignored
+func (ck *checkpoint) loadCreated() error {
+	ck.created = make(map[string]struct{})
+	b, err := os.ReadFile(filepath.Join(ck.dir, ckptCreatedFname))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	names := strings.Split(string(b), "\n")
+	for _, name := range names[:len(names)-1] {
+		if name != "" {
+			ck.created[name] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// resetCreated must be called when the creation phase metadata is no longer
+// valid (record distribution is about to be repeated)
+func (ck *checkpoint) resetCreated() error {
+	ck.mu.Lock()
+	defer ck.mu.Unlock()
+	ck.created = make(map[string]struct{})
+	if err := os.Remove(filepath.Join(ck.dir, ckptCreatedFname)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// skipCreated removes already created shards from the creation phase metadata
+func (ck *checkpoint) skipCreated(md *CreationPhaseMetadata) (skipped int) {
+	ck.mu.Lock()
+	shards := md.Shards[:0]
+	for _, s := range md.Shards {
+		if _, ok := ck.created[s.Name]; ok {
+			skipped++
+			continue
+		}
+		shards = append(shards, s)
+	}
+	md.Shards = shards
+	ck.mu.Unlock()
+	return
+}
+
+func (ck *checkpoint) shardCreated(shardName string) (err error) {
+	ck.mu.Lock()
+	defer ck.mu.Unlock()
+	if ck.fh == nil {
+		fpath := filepath.Join(ck.dir, ckptCreatedFname)
+		if ck.fh, err = os.OpenFile(fpath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, cos.PermRWR); err != nil {
+			return
+		}
+	}
+	if _, err = ck.fh.WriteString(shardName + "\n"); err == nil {
+		ck.created[shardName] = struct{}{}
+	}
+	return
+}
+
+func (ck *checkpoint) close() {
+	ck.mu.Lock()
+	if ck.fh != nil {
+		cos.Close(ck.fh)
+		ck.fh = nil
+	}
+	ck.mu.Unlock()
+}
+
+func (ck *checkpoint) destroy() {
+	ck.close()
+	if err := os.RemoveAll(ck.dir); err != nil {
+		glog.Errorf("failed to remove %s checkpoint %q: %v", DSortName, ck.dir, err)
+	}
+}
+
+//
+// Manager
+//
+
+// initCheckpoint creates new checkpoint or, when resuming, validates the one
+// that was loaded (see resumeSortHandler)
+func (m *Manager) initCheckpoint() (err error) {
+	if m.ckpt != nil {
+		return checkTargets(m.ckpt.meta.Targets, m.smap)
+	}
+	m.ckpt, err = newCheckpoint(m.ManagerUUID, m.rs, m.smap)
+	return
+}
+
+// restoreExtraction restores the results of the (completed) extraction phase;
+// local records are needed only to participate in the record distribution
+// but their on-disk contents must be always tracked (for cleanup)
+func (m *Manager) restoreExtraction(distribute bool) error {
+	metrics := m.Metrics.Extraction
+	metrics.begin()
+	defer metrics.finish()
+
+	records := extract.NewRecords(1000)
+	if err := m.ckpt.loadMsg(ckptRecordsFname, records); err != nil {
+		return fmt.Errorf("failed to load %s checkpoint (records): %v", DSortName, err)
+	}
+	m.recManager.TrackExtractionPaths(records)
+	m.dsorter.postExtraction()
+
+	metrics.mu.Lock()
+	metrics.ExtractedRecordCnt = int64(records.Len())
+	metrics.mu.Unlock()
+
+	if !distribute {
+		records.Drain()
+		return nil
+	}
+	m.recManager.Records.Insert(records.All()...)
+	m.compression.compressed.Store(m.ckpt.meta.Compressed)
+	m.compression.uncompressed.Store(m.ckpt.meta.Uncompressed)
+	m.incrementRef(int64(m.recManager.Records.TotalObjectCount()))
+	return nil
+}
+
+// restoreSorting restores creation phase metadata (the results of the completed
+// record distribution) and skips the shards that have already been created
+func (m *Manager) restoreSorting() error {
+	metrics := m.Metrics.Sorting
+	metrics.begin()
+	defer metrics.finish()
+
+	md := &CreationPhaseMetadata{}
+	if err := m.ckpt.loadMsg(ckptShardsFname, md); err != nil {
+		return fmt.Errorf("failed to load %s checkpoint (shards): %v", DSortName, err)
+	}
+	if skipped := m.ckpt.skipCreated(md); skipped > 0 {
+		glog.Infof("[dsort] %s skipping %d already created shard(s)", m.ManagerUUID, skipped)
+	}
+	m.creationPhase.metadata = *md
+	m.dsorter.postRecordDistribution()
+	return nil
+}
+
+type msgpWriterTo struct {
+	v msgp.Encodable
+}
+
+func (w *msgpWriterTo) WriteTo(dst io.Writer) (int64, error) {
+	mw := msgp.NewWriterSize(dst, ckptBufSize)
+	if err := w.v.EncodeMsg(mw); err != nil {
+		return 0, err
+	}
+	return 0, mw.Flush()
+}
@@ -0,0 +1,136 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dsort/extract"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checkpoint", func() {
+	const (
+		mpath = "/tmp/ais_tests_dsort_ckpt"
+		uuid  = PrefixJobID + "ckpt"
+	)
+	var (
+		rs   = &ParsedRequestSpec{Extension: cos.ExtTar, Description: "resumable", Resumable: true, DSorterType: DSorterGeneralType}
+		smap *cluster.Smap
+	)
+
+	BeforeEach(func() {
+		err := cos.CreateDir(mpath)
+		Expect(err).ShouldNot(HaveOccurred())
+		fs.TestNew(nil)
+		_, err = fs.Add(mpath, "daeID")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		smap = &cluster.Smap{Tmap: cluster.NodeMap{"t2": &cluster.Snode{}, "t1": &cluster.Snode{}}}
+	})
+
+	AfterEach(func() {
+		err := os.RemoveAll(mpath)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should save and load phases", func() {
+		ck, err := newCheckpoint(uuid, rs, smap)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(listCheckpoints()).To(Equal([]string{uuid}))
+
+		records := extract.NewRecords(2)
+		records.Insert(
+			&extract.Record{Key: "a", Name: "shard-0|a", DaemonID: "t1", Objects: []*extract.RecordObj{{Extension: ".cls", Size: 10}}},
+			&extract.Record{Key: "b", Name: "shard-0|b", DaemonID: "t1", Objects: []*extract.RecordObj{{Extension: ".jpg", Size: 20}}},
+		)
+		err = ck.saveRecords(records, 100, 200)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		loaded, err := loadCheckpoint(uuid)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(loaded.meta.Phase).To(Equal(ExtractionPhase))
+		Expect(loaded.meta.Targets).To(Equal([]string{"t1", "t2"}))
+		Expect(loaded.meta.RS.Description).To(Equal(rs.Description))
+		Expect(loaded.meta.Compressed).To(Equal(int64(100)))
+		Expect(loaded.meta.Uncompressed).To(Equal(int64(200)))
+
+		restored := extract.NewRecords(2)
+		err = loaded.loadMsg(ckptRecordsFname, restored)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restored.Len()).To(Equal(2))
+		Expect(restored.All()[1].Name).To(Equal("shard-0|b"))
+
+		md := &CreationPhaseMetadata{Shards: []*extract.Shard{{Name: "out-0.tar", Size: 30, Records: records}}}
+		err = loaded.saveShards(md)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		loaded, err = loadCheckpoint(uuid)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(loaded.meta.Phase).To(Equal(SortingPhase))
+		restoredMD := &CreationPhaseMetadata{}
+		err = loaded.loadMsg(ckptShardsFname, restoredMD)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restoredMD.Shards).To(HaveLen(1))
+		Expect(restoredMD.Shards[0].Name).To(Equal("out-0.tar"))
+		Expect(restoredMD.Shards[0].Records.Len()).To(Equal(2))
+
+		loaded.destroy()
+		_, err = loadCheckpoint(uuid)
+		Expect(cmn.IsErrNotFound(err)).To(BeTrue())
+	})
+
+	It("should track created shards and ignore interrupted writes", func() {
+		ck, err := newCheckpoint(uuid, rs, smap)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ck.shardCreated("out-0.tar")).ShouldNot(HaveOccurred())
+		Expect(ck.shardCreated("out-1.tar")).ShouldNot(HaveOccurred())
+		ck.close()
+
+		f, err := os.OpenFile(filepath.Join(ck.dir, ckptCreatedFname), os.O_APPEND|os.O_WRONLY, cos.PermRWR)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = f.WriteString("out-2")
+		Expect(err).ShouldNot(HaveOccurred())
+		f.Close()
+
+		loaded, err := loadCheckpoint(uuid)
+		Expect(err).ShouldNot(HaveOccurred())
+		md := &CreationPhaseMetadata{Shards: []*extract.Shard{{Name: "out-0.tar"}, {Name: "out-1.tar"}, {Name: "out-2"}}}
+		Expect(loaded.skipCreated(md)).To(Equal(2))
+		Expect(md.Shards).To(HaveLen(1))
+		Expect(md.Shards[0].Name).To(Equal("out-2"))
+
+		Expect(loaded.resetCreated()).ShouldNot(HaveOccurred())
+		loaded, err = loadCheckpoint(uuid)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(loaded.created).To(BeEmpty())
+	})
+
+	It("should determine the phase to resume from", func() {
+		Expect(minPhase(SortingPhase, ExtractionPhase, SortingPhase)).To(Equal(ExtractionPhase))
+		Expect(minPhase(SortingPhase, "", ExtractionPhase)).To(Equal(""))
+		Expect(minPhase(SortingPhase, SortingPhase)).To(Equal(SortingPhase))
+
+		var ck *checkpoint
+		Expect(ck.resumed(ExtractionPhase)).To(BeFalse())
+		ck = &checkpoint{resume: ExtractionPhase}
+		Expect(ck.resumed(ExtractionPhase)).To(BeTrue())
+		Expect(ck.resumed(SortingPhase)).To(BeFalse())
+		ck.resume = ""
+		Expect(ck.resumed(ExtractionPhase)).To(BeFalse())
+	})
+
+	It("should require the same set of targets", func() {
+		Expect(checkTargets([]string{"t1", "t2"}, smap)).ShouldNot(HaveOccurred())
+		Expect(checkTargets([]string{"t1"}, smap)).Should(HaveOccurred())
+		Expect(checkTargets([]string{"t1", "t3"}, smap)).Should(HaveOccurred())
+	})
+})
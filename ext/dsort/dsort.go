@@ -6,6 +6,7 @@ package dsort
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/binary"
 	"fmt"
@@ -13,7 +14,6 @@ import (
 	"math"
 	"net/http"
 	"net/url"
-	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -82,11 +82,47 @@ func (m *Manager) start() (err error) {
 	}
 
 	// Phase 1.
-	glog.Infof("[dsort] %s started extraction stage", m.ManagerUUID)
-	if err := m.extractLocalShards(); err != nil {
+	if m.ckpt.resumed(ExtractionPhase) {
+		glog.Infof("[dsort] %s resuming: restoring extraction stage from checkpoint", m.ManagerUUID)
+		if err := m.restoreExtraction(!m.ckpt.resumed(SortingPhase)); err != nil {
+			return err
+		}
+	} else {
+		glog.Infof("[dsort] %s started extraction stage", m.ManagerUUID)
+		if err := m.extractLocalShards(); err != nil {
+			return err
+		}
+		if m.ckpt != nil {
+			err := m.ckpt.saveRecords(m.recManager.Records, m.totalCompressedSize(), m.totalUncompressedSize())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if m.ckpt.resumed(SortingPhase) {
+		glog.Infof("[dsort] %s resuming: restoring sort stage from checkpoint", m.ManagerUUID)
+		if err := m.restoreSorting(); err != nil {
+			return err
+		}
+	} else if err := m.sortAndDistribute(); err != nil {
 		return err
 	}
 
+	// After each target participates in the cluster-wide record distribution,
+	// start listening for the signal to start creating shards locally.
+	glog.Infof("[dsort] %s started creation stage", m.ManagerUUID)
+	if err := m.dsorter.createShardsLocally(); err != nil {
+		return err
+	}
+
+	glog.Infof("[dsort] %s finished successfully", m.ManagerUUID)
+	return nil
+}
+
+// sortAndDistribute runs phases 2 and 3 and waits for the creation phase
+// metadata (the shards to be created locally)
+func (m *Manager) sortAndDistribute() error {
 	s := binary.BigEndian.Uint64(m.rs.TargetOrderSalt)
 	targetOrder := randomTargetOrder(s, m.smap.Tmap)
 	if glog.V(4) {
@@ -104,7 +140,8 @@ func (m *Manager) start() (err error) {
 	// Phase 3. - run only by the final target
 	if curTargetIsFinal {
 		shardSize := m.rs.OutputShardSize
-		if m.extractCreator.UsingCompression() {
+		// (when converting between formats `output_shard_size` applies to uncompressed content)
+		if m.extractCreator.UsingCompression() && m.rs.OutputExtension == m.rs.Extension {
 			// By making the assumption that the input content is reasonably
 			// uniform across all shards, the output shard size required (such
 			// that each gzip compressed output shard will have a size close to
@@ -133,15 +170,9 @@ func (m *Manager) start() (err error) {
 	case <-m.listenAborted():
 		return newDSortAbortedError(m.ManagerUUID)
 	}
-
-	// After each target participates in the cluster-wide record distribution,
-	// start listening for the signal to start creating shards locally.
-	glog.Infof("[dsort] %s started creation stage", m.ManagerUUID)
-	if err := m.dsorter.createShardsLocally(); err != nil {
-		return err
+	if m.ckpt != nil {
+		return m.ckpt.saveShards(&m.creationPhase.metadata)
 	}
-
-	glog.Infof("[dsort] %s finished successfully", m.ManagerUUID)
 	return nil
 }
 
@@ -201,7 +232,7 @@ func (m *Manager) extractShard(name string, metrics *LocalExtraction) func() err
 		}
 
 		lom.Lock(false)
-		f, err := os.Open(lom.FQN)
+		f, err := lom.Open(lom.FQN)
 		if err != nil {
 			phaseInfo.adjuster.releaseSema(lom.Mountpath())
 			lom.Unlock(false)
@@ -214,6 +245,9 @@ func (m *Manager) extractShard(name string, metrics *LocalExtraction) func() err
 
 		expectedUncompressedSize := uint64(float64(lom.SizeBytes()) / m.avgCompressionRatio())
 		toDisk := m.dsorter.preShardExtraction(expectedUncompressedSize)
+		if m.rs.Resumable {
+			toDisk = true // (extracted contents must survive restarts)
+		}
 
 		beforeExtraction := mono.NanoTime()
 
@@ -231,7 +265,7 @@ func (m *Manager) extractShard(name string, metrics *LocalExtraction) func() err
 
 		m.dsorter.postShardExtraction(expectedUncompressedSize) // schedule unreserving reserved memory on next memory update
 		if err != nil {
-			return errors.Errorf("error in ExtractShard, file: %s, err: %v", f.Name(), err)
+			return errors.Errorf("error in ExtractShard, file: %s, err: %v", lom.FQN, err)
 		}
 
 		metrics.mu.Lock()
@@ -383,7 +417,16 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 		wg.Done()
 	}()
 
-	_, err = m.extractCreator.CreateShard(s, w, loadContent)
+	var (
+		idx    *bytes.Buffer
+		idxExt string
+	)
+	if ix, ok := m.extractCreator.(extract.Indexer); ok && ix.IndexExt() != "" {
+		idx, idxExt = &bytes.Buffer{}, ix.IndexExt()
+		_, err = ix.CreateShardWithIndex(s, w, idx, loadContent)
+	} else {
+		_, err = m.extractCreator.CreateShard(s, w, loadContent)
+	}
 	w.CloseWithError(err)
 	if err != nil {
 		r.CloseWithError(err)
@@ -419,49 +462,26 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 	// if we have an extra copy of the object local to this target, we
 	// optimize for performance by not removing the object now.
 	if si.ID() != m.ctx.node.ID() && !m.rs.DryRun {
-		lom.Lock(false)
-		defer lom.Unlock(false)
-
-		// Need to make sure that the object is still there.
-		if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if err := m.sendShard(lom, si); err != nil {
 			return err
 		}
+	} else if !m.rs.DryRun {
+		m.indexShard(lom)
+	}
 
-		if lom.SizeBytes() <= 0 {
-			goto exit
-		}
-
-		file, err := cos.NewFileHandle(lom.FQN)
-		if err != nil {
+	// (companion index, e.g. TFRecord)
+	if idx != nil {
+		if err := m.putShardIndex(shardName+idxExt, idx); err != nil {
 			return err
 		}
+	}
 
-		o := transport.AllocSend()
-		o.Hdr = transport.ObjHdr{
-			ObjName:  shardName,
-			ObjAttrs: cmn.ObjAttrs{Size: lom.SizeBytes(), Cksum: lom.Checksum()},
-		}
-		o.Hdr.Bck.Copy(lom.Bucket())
-
-		// Make send synchronous.
-		streamWg := &sync.WaitGroup{}
-		errCh := make(chan error, 1)
-		o.Callback = func(_ transport.ObjHdr, _ io.ReadCloser, _ any, err error) {
-			errCh <- err
-			streamWg.Done()
-		}
-		streamWg.Add(1)
-		err = m.streams.shards.Send(o, file, si)
-		if err != nil {
-			return err
-		}
-		streamWg.Wait()
-		if err := <-errCh; err != nil {
+	if m.ckpt != nil {
+		if err := m.ckpt.shardCreated(shardName); err != nil {
 			return err
 		}
 	}
 
-exit:
 	metrics.mu.Lock()
 	metrics.CreatedCnt++
 	if si.ID() != m.ctx.node.ID() {
@@ -477,6 +497,91 @@ exit:
 	return nil
 }
 
+// sendShard sends locally created shard (or its index) to the target that owns it (HRW)
+func (m *Manager) sendShard(lom *cluster.LOM, si *cluster.Snode) error {
+	lom.Lock(false)
+	defer lom.Unlock(false)
+
+	// Need to make sure that the object is still there.
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return err
+	}
+
+	if lom.SizeBytes() <= 0 {
+		return nil
+	}
+
+	file, err := lom.Open(lom.FQN)
+	if err != nil {
+		return err
+	}
+
+	o := transport.AllocSend()
+	o.Hdr = transport.ObjHdr{
+		ObjName:  lom.ObjName,
+		ObjAttrs: cmn.ObjAttrs{Size: lom.SizeBytes(), Cksum: lom.Checksum()},
+	}
+	o.Hdr.Bck.Copy(lom.Bucket())
+
+	// Make send synchronous.
+	streamWg := &sync.WaitGroup{}
+	errCh := make(chan error, 1)
+	o.Callback = func(_ transport.ObjHdr, _ io.ReadCloser, _ any, err error) {
+		errCh <- err
+		streamWg.Done()
+	}
+	streamWg.Add(1)
+	err = m.streams.shards.Send(o, file, si)
+	if err != nil {
+		return err
+	}
+	streamWg.Wait()
+	return <-errCh
+}
+
+// indexShard builds archive index of the TAR-formatted shard that belongs to this target
+// (best-effort - see cluster/lom_arch.go)
+func (m *Manager) indexShard(lom *cluster.LOM) {
+	if m.rs.OutputExtension != cos.ExtTar || !strings.HasSuffix(lom.ObjName, cos.ExtTar) {
+		return
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil || lom.IsEncrypted() {
+		return
+	}
+	if lom.ArchIndexFQN() != "" { // (e.g., feat.IndexArchOnPUT)
+		return
+	}
+	if _, err := lom.BuildArchIndex(nil); err != nil {
+		glog.Errorf("[dsort] %s: failed to index shard %s: %v", m.ManagerUUID, lom, err)
+	}
+}
+
+// putShardIndex stores the index generated alongside the shard (see extract.Indexer)
+func (m *Manager) putShardIndex(objName string, idx *bytes.Buffer) error {
+	lom := &cluster.LOM{ObjName: objName}
+	if err := lom.InitBck(&m.rs.OutputBck); err != nil {
+		return err
+	}
+	params := cluster.AllocPutObjParams()
+	{
+		params.WorkTag = "dsort"
+		params.Reader = io.NopCloser(idx)
+		params.Atime = time.Now()
+	}
+	err := m.ctx.t.PutObject(lom, params)
+	cluster.FreePutObjParams(params)
+	if err != nil {
+		return err
+	}
+	si, err := cluster.HrwTarget(lom.Uname(), m.smap)
+	if err != nil || si.ID() == m.ctx.node.ID() {
+		return err
+	}
+	return m.sendShard(lom, si)
+}
+
 // participateInRecordDistribution coordinates the distributed merging and
 // sorting of each target's SortedRecords based on the order defined by
 // targetOrder. It returns a bool, currentTargetIsFinal, which is true iff the
@@ -616,7 +721,12 @@ func (m *Manager) participateInRecordDistribution(targetOrder cluster.Nodes) (cu
 		m.recManager.MergeEnqueuedRecords()
 	}
 
-	err = sortRecords(m.recManager.Records, m.rs.Algorithm)
+	if m.rs.WebDataset.Enabled {
+		err = m.validateSamples()
+	}
+	if err == nil {
+		err = sortRecords(m.recManager.Records, m.rs.Algorithm)
+	}
 	m.dsorter.postRecordDistribution()
 	return true, err
 }
@@ -651,7 +761,7 @@ func (m *Manager) generateShardsWithTemplate(maxSize int64) ([]*extract.Shard, e
 			return nil, errors.Errorf("number of shards to be created exceeds expected number of shards (%d)", shardCount)
 		}
 		shard := &extract.Shard{
-			Name: name + m.rs.Extension,
+			Name: name + m.rs.OutputExtension,
 		}
 
 		shard.Size = curShardSize
@@ -991,3 +1101,17 @@ func randomTargetOrder(salt uint64, tmap cluster.NodeMap) []*cluster.Snode {
 	}
 	return t
 }
+
+// openShardSection opens a section of the (local) input shard - the shard
+// may be encrypted at rest, hence loading it first (see lom.OpenSection)
+func openShardSection(fqn string, offset, size int64) (cos.ReadOpenCloser, error) {
+	lom := cluster.AllocLOM("")
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitFQN(fqn, nil); err != nil {
+		return nil, err
+	}
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		return nil, err
+	}
+	return lom.OpenSection(fqn, offset, size)
+}
@@ -300,16 +300,13 @@ func (ds *dsorterGeneral) loadContent() extract.LoadContentFunc {
 			var n int64
 			switch storeType {
 			case extract.OffsetStoreType:
-				f, err := os.Open(fullContentPath) // TODO: it should be open always
+				// TODO: it should be open always
+				f, err := openShardSection(fullContentPath, obj.Offset-obj.MetadataSize, obj.MetadataSize+obj.Size)
 				if err != nil {
 					return written, errors.WithMessage(err, "(offset) open local content failed")
 				}
 				defer cos.Close(f)
-				_, err = f.Seek(obj.Offset-obj.MetadataSize, io.SeekStart)
-				if err != nil {
-					return written, errors.WithMessage(err, "(offset) seek local content failed")
-				}
-				if n, err = io.CopyBuffer(w, io.LimitReader(f, obj.MetadataSize+obj.Size), buf); err != nil {
+				if n, err = io.CopyBuffer(w, f, buf); err != nil {
 					return written, errors.WithMessage(err, "(offset) copy local content failed")
 				}
 			case extract.SGLStoreType:
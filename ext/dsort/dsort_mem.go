@@ -521,7 +521,7 @@ func (ds *dsorterMem) sendRecordObj(rec *extract.Record, obj *extract.RecordObj,
 	switch obj.StoreType {
 	case extract.OffsetStoreType:
 		hdr.ObjAttrs.Size = obj.MetadataSize + obj.Size
-		r, err := cos.NewFileSectionHandle(fullContentPath, obj.Offset-obj.MetadataSize, hdr.ObjAttrs.Size)
+		r, err := openShardSection(fullContentPath, obj.Offset-obj.MetadataSize, hdr.ObjAttrs.Size)
 		if err != nil {
 			return err
 		}
@@ -121,6 +121,7 @@ func (ec *extractCreatorMock) CreateShard(s *extract.Shard, w io.Writer, loadCon
 	ec.createShard(s, w, loadContent)
 	return 0, nil
 }
+func (*extractCreatorMock) NewMetadata(string) []byte { return nil }
 func (*extractCreatorMock) SupportsOffset() bool      { return true }
 func (ec *extractCreatorMock) UsingCompression() bool { return ec.useCompression }
 func (*extractCreatorMock) MetadataSize() int64       { return 0 }
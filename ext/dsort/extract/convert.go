@@ -0,0 +1,131 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"io"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// interface guard
+var (
+	_ Creator = (*convertCreator)(nil)
+	_ Indexer = (*convertCreator)(nil)
+)
+
+type (
+	// convertCreator extracts shards with `src` and creates them with `dst`.
+	// Record objects are converted on the fly: the original (`src`) metadata
+	// is skipped and replaced with the one generated by `dst`.
+	convertCreator struct {
+		src, dst Creator
+	}
+
+	convertedObj struct {
+		rec  *Record
+		obj  *RecordObj
+		meta []byte
+	}
+
+	// skipWriter discards the first `skip` bytes
+	skipWriter struct {
+		w    io.Writer
+		skip int64
+	}
+)
+
+func ConvertCreator(src, dst Creator) Creator {
+	return &convertCreator{src: src, dst: dst}
+}
+
+func (c *convertCreator) ExtractShard(lom *cluster.LOM, r cos.ReadReaderAt, extractor RecordExtractor, toDisk bool) (int64, int, error) {
+	return c.src.ExtractShard(lom, r, extractor, toDisk)
+}
+
+func (c *convertCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (int64, error) {
+	shard, load := c.convert(s, loadContent)
+	return c.dst.CreateShard(shard, w, load)
+}
+
+func (c *convertCreator) CreateShardWithIndex(s *Shard, w, idx io.Writer, loadContent LoadContentFunc) (int64, error) {
+	ix, ok := c.dst.(Indexer)
+	cos.Assert(ok)
+	shard, load := c.convert(s, loadContent)
+	return ix.CreateShardWithIndex(shard, w, idx, load)
+}
+
+func (c *convertCreator) IndexExt() string {
+	if ix, ok := c.dst.(Indexer); ok {
+		return ix.IndexExt()
+	}
+	return ""
+}
+
+// convert makes a copy of the shard in which all objects carry `dst` metadata
+// (the objects are presented to `dst` as extracted in-memory); the returned
+// function loads the original content while replacing its metadata.
+func (c *convertCreator) convert(s *Shard, loadContent LoadContentFunc) (*Shard, LoadContentFunc) {
+	var (
+		records = s.Records.All()
+		shard   = &Shard{Name: s.Name, Size: s.Size, Records: NewRecords(len(records))}
+		objs    = make(map[*RecordObj]convertedObj, len(records))
+	)
+	for _, rec := range records {
+		nrec := &Record{Key: rec.Key, Name: rec.Name, DaemonID: rec.DaemonID, Objects: make([]*RecordObj, 0, len(rec.Objects))}
+		for _, obj := range rec.Objects {
+			meta := c.dst.NewMetadata(rec.SampleKey() + obj.Extension)
+			nobj := &RecordObj{
+				ContentPath:    obj.ContentPath,
+				ObjectFileType: obj.ObjectFileType,
+				StoreType:      SGLStoreType,
+				MetadataSize:   int64(len(meta)),
+				Size:           obj.Size,
+				Extension:      obj.Extension,
+			}
+			nrec.Objects = append(nrec.Objects, nobj)
+			objs[nobj] = convertedObj{rec: rec, obj: obj, meta: meta}
+		}
+		shard.Records.Insert(nrec)
+	}
+	load := func(w io.Writer, _ *Record, nobj *RecordObj) (int64, error) {
+		co, ok := objs[nobj]
+		cos.Assert(ok)
+		n, err := w.Write(co.meta)
+		if err != nil {
+			return int64(n), err
+		}
+		sw := &skipWriter{w: w, skip: co.obj.MetadataSize}
+		size, err := loadContent(sw, co.rec, co.obj)
+		return int64(n) + size - co.obj.MetadataSize + sw.skip, err
+	}
+	return shard, load
+}
+
+func (c *convertCreator) NewMetadata(name string) []byte { return c.dst.NewMetadata(name) }
+
+// NOTE: extraction-related properties are those of the source format
+func (c *convertCreator) UsingCompression() bool { return c.src.UsingCompression() }
+func (c *convertCreator) SupportsOffset() bool   { return c.src.SupportsOffset() }
+func (c *convertCreator) MetadataSize() int64    { return c.src.MetadataSize() }
+
+////////////////
+// skipWriter //
+////////////////
+
+func (sw *skipWriter) Write(p []byte) (int, error) {
+	l := len(p)
+	if sw.skip > 0 {
+		if int64(l) <= sw.skip {
+			sw.skip -= int64(l)
+			return l, nil
+		}
+		p = p[sw.skip:]
+		sw.skip = 0
+	}
+	n, err := sw.w.Write(p)
+	return l - len(p) + n, err
+}
@@ -0,0 +1,135 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/go-tfdata/tfdata/core"
+	"github.com/klauspost/compress/zstd"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vmihailenco/msgpack"
+)
+
+var _ = Describe("ConvertCreator", func() {
+	var (
+		t       = mock.NewTarget(nil)
+		src     = NewTarExtractCreator(t)
+		files   = map[string]string{"000001.jpg": "jpg-1", "000001.cls": "1", "000002.jpg": "jpg-2", "000002.cls": "2"}
+		content map[*RecordObj][]byte
+		shard   *Shard
+	)
+
+	BeforeEach(func() {
+		content = make(map[*RecordObj][]byte)
+		shard = &Shard{Name: "output", Records: NewRecords(2)}
+		for _, key := range []string{"000001", "000002"} {
+			rec := &Record{Key: key, Name: "input|" + key}
+			for _, ext := range []string{".jpg", ".cls"} {
+				var (
+					data = files[key+ext]
+					meta = src.NewMetadata(key + ext)
+					obj  = &RecordObj{StoreType: SGLStoreType, MetadataSize: int64(len(meta)), Size: int64(len(data)), Extension: ext}
+				)
+				content[obj] = append(meta, data...)
+				rec.Objects = append(rec.Objects, obj)
+			}
+			shard.Records.Insert(rec)
+		}
+	})
+
+	loadContent := func(w io.Writer, _ *Record, obj *RecordObj) (int64, error) {
+		b, ok := content[obj]
+		Expect(ok).To(BeTrue())
+		n, err := w.Write(b)
+		return int64(n), err
+	}
+
+	It("should convert tar to zstd-compressed tar", func() {
+		buf := &bytes.Buffer{}
+		_, err := ConvertCreator(src, NewTarZstdExtractCreator(t)).CreateShard(shard, buf, loadContent)
+		Expect(err).NotTo(HaveOccurred())
+
+		zr, err := zstd.NewReader(buf)
+		Expect(err).NotTo(HaveOccurred())
+		defer zr.Close()
+		tr := tar.NewReader(zr)
+		found := 0
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).NotTo(HaveOccurred())
+			b, err := io.ReadAll(tr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal(files[hdr.Name]))
+			found++
+		}
+		Expect(found).To(Equal(len(files)))
+	})
+
+	It("should convert tar to msgpack", func() {
+		buf := &bytes.Buffer{}
+		_, err := ConvertCreator(src, NewMsgpackExtractCreator(t)).CreateShard(shard, buf, loadContent)
+		Expect(err).NotTo(HaveOccurred())
+
+		var out cmn.GenShard
+		Expect(msgpack.NewDecoder(buf).Decode(&out)).NotTo(HaveOccurred())
+		Expect(out).To(HaveLen(len(files)))
+		for name, data := range files {
+			Expect(string(out[name])).To(Equal(data))
+		}
+	})
+
+	It("should convert tar to TFRecord with index", func() {
+		var (
+			buf, idx = &bytes.Buffer{}, &bytes.Buffer{}
+			c        = ConvertCreator(src, NewTFRecordCreator())
+		)
+		ix, ok := c.(Indexer)
+		Expect(ok).To(BeTrue())
+		Expect(ix.IndexExt()).To(Equal(TFRecordIndexExt))
+		_, err := ix.CreateShardWithIndex(shard, buf, idx, loadContent)
+		Expect(err).NotTo(HaveOccurred())
+
+		size := buf.Len()
+		examples, err := core.NewTFRecordReader(buf).ReadAllExamples()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(examples).To(HaveLen(2))
+		for _, ex := range examples {
+			key := string(ex.GetBytesList("__key__"))
+			Expect(string(ex.GetBytesList("jpg"))).To(Equal(files[key+".jpg"]))
+			Expect(string(ex.GetBytesList("cls"))).To(Equal(files[key+".cls"]))
+		}
+
+		var (
+			lines   []string
+			scanner = bufio.NewScanner(idx)
+		)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		Expect(lines).To(HaveLen(2))
+		Expect(strings.HasPrefix(lines[0], "0 ")).To(BeTrue())
+		var total int
+		for _, line := range lines {
+			var off, sz int
+			_, err := fmt.Sscan(line, &off, &sz)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(off).To(Equal(total))
+			total += sz
+		}
+		Expect(total).To(Equal(size))
+	})
+})
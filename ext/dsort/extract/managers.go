@@ -21,6 +21,13 @@ import (
 	"github.com/pkg/errors"
 )
 
+// shard formats supported by dSort in addition to `cos.ArchExtensions`
+const (
+	ExtTarLz4   = ".tar.lz4"
+	ExtTarZst   = ".tar.zst"
+	ExtTFRecord = ".tfrecord" // output only
+)
+
 const (
 	// Extract methods
 	ExtractToMem cos.Bits = 1 << iota
@@ -53,11 +60,22 @@ type (
 	Creator interface {
 		ExtractShard(lom *cluster.LOM, r cos.ReadReaderAt, extractor RecordExtractor, toDisk bool) (int64, int, error)
 		CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (int64, error)
+		// NewMetadata returns format-specific metadata of a regular file with
+		// the given name; used when creating shards out of records that were
+		// extracted from a different format (see ConvertCreator).
+		NewMetadata(name string) []byte
 		UsingCompression() bool
 		SupportsOffset() bool
 		MetadataSize() int64
 	}
 
+	// Indexer is implemented by creators that, in addition to the shard
+	// itself, generate a companion index (e.g., TFRecord).
+	Indexer interface {
+		CreateShardWithIndex(s *Shard, w, idx io.Writer, loadContent LoadContentFunc) (int64, error)
+		IndexExt() string // "" if the creator does not generate index
+	}
+
 	RecordExtractor interface {
 		ExtractRecordWithBuffer(args extractRecordArgs) (int64, error)
 	}
@@ -69,6 +87,7 @@ type (
 		bck                 cmn.Bck
 		extension           string
 		onDuplicatedRecords func(string) error
+		samples             bool // WebDataset mode: keys are extracted from sample keys (names without extensions)
 
 		extractCreator  Creator
 		keyExtractor    KeyExtractor
@@ -83,7 +102,7 @@ type (
 )
 
 func NewRecordManager(t cluster.Target, bck cmn.Bck, extension string, extractCreator Creator,
-	keyExtractor KeyExtractor, onDuplicatedRecords func(string) error) *RecordManager {
+	keyExtractor KeyExtractor, onDuplicatedRecords func(string) error, samples bool) *RecordManager {
 	return &RecordManager{
 		Records: NewRecords(1000),
 
@@ -91,6 +110,7 @@ func NewRecordManager(t cluster.Target, bck cmn.Bck, extension string, extractCr
 		bck:                 bck,
 		extension:           extension,
 		onDuplicatedRecords: onDuplicatedRecords,
+		samples:             samples,
 
 		extractCreator:  extractCreator,
 		keyExtractor:    keyExtractor,
@@ -127,7 +147,12 @@ func (rm *RecordManager) ExtractRecordWithBuffer(args extractRecordArgs) (size i
 		cos.Assert(args.w != nil)
 	}
 
-	r, ske, needRead := rm.keyExtractor.PrepareExtractor(args.recordName, args.r, ext)
+	keyName := args.recordName
+	if rm.samples {
+		// all members of a sample share the same key
+		keyName = strings.TrimSuffix(args.recordName, ext)
+	}
+	r, ske, needRead := rm.keyExtractor.PrepareExtractor(keyName, args.r, ext)
 	if args.extractMethod.Has(ExtractToMem) {
 		mdSize = int64(len(args.metadata))
 		storeType = SGLStoreType
@@ -360,6 +385,30 @@ func (rm *RecordManager) ExtractionPaths() *sync.Map {
 	return rm.extractionPaths
 }
 
+// TrackExtractionPaths registers on-disk contents of the given (previously
+// extracted, local) records so that they get removed upon Cleanup.
+func (rm *RecordManager) TrackExtractionPaths(records *Records) {
+	for _, r := range records.All() {
+		if r.DaemonID != rm.t.SID() {
+			continue
+		}
+		for _, obj := range r.Objects {
+			if obj.StoreType == DiskStoreType {
+				rm.extractionPaths.Store(rm.FullContentPath(obj), struct{}{})
+			}
+		}
+	}
+}
+
+// KeepExtractionPaths stops tracking on-disk contents so that Cleanup won't
+// remove them (e.g., to resume interrupted job later).
+func (rm *RecordManager) KeepExtractionPaths() {
+	rm.extractionPaths.Range(func(k, _ any) bool {
+		rm.extractionPaths.Delete(k)
+		return true
+	})
+}
+
 func (rm *RecordManager) Cleanup() {
 	rm.Records.Drain()
 	rm.extractionPaths.Range(func(k, v any) bool {
@@ -0,0 +1,139 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bytes"
+	"io"
+	"sort"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/vmihailenco/msgpack"
+)
+
+// interface guard
+var _ Creator = (*msgpackExtractCreator)(nil)
+
+type (
+	// msgpack shard is a single `map[string][]byte` (aka `cmn.GenShard`) - same
+	// as in multi-object archiving (see xact/xs/archive.go). There are no per-file
+	// headers: the metadata of each record object is simply its name.
+	msgpackExtractCreator struct {
+		t cluster.Target
+	}
+
+	// msgpackRecordDataReader collects metadata (name) and data of a single file.
+	msgpackRecordDataReader struct {
+		metadataSize int64
+		written      int64
+		name         bytes.Buffer
+		data         bytes.Buffer
+	}
+)
+
+func NewMsgpackExtractCreator(t cluster.Target) Creator {
+	return &msgpackExtractCreator{t: t}
+}
+
+// ExtractShard decodes the msgpack-formatted shard and extracts its files.
+func (m *msgpackExtractCreator) ExtractShard(lom *cluster.LOM, r cos.ReadReaderAt, extractor RecordExtractor,
+	toDisk bool) (extractedSize int64, extractedCount int, err error) {
+	var (
+		size  int64
+		shard cmn.GenShard
+	)
+	if err = msgpack.NewDecoder(r).Decode(&shard); err != nil {
+		return 0, 0, err
+	}
+
+	names := make([]string, 0, len(shard))
+	for name := range shard {
+		names = append(names, name)
+	}
+	sort.Strings(names) // (deterministic order)
+
+	buf, slab := m.t.PageMM().AllocSize(lom.SizeBytes())
+	defer slab.Free(buf)
+
+	for _, name := range names {
+		extractMethod := ExtractToMem
+		if toDisk {
+			extractMethod = ExtractToDisk
+		}
+		data := shard[name]
+		args := extractRecordArgs{
+			shardName:     lom.ObjName,
+			fileType:      fs.ObjectType,
+			recordName:    name,
+			r:             cos.NewSizedReader(bytes.NewReader(data), int64(len(data))),
+			metadata:      []byte(name),
+			extractMethod: extractMethod,
+			buf:           buf,
+		}
+		if size, err = extractor.ExtractRecordWithBuffer(args); err != nil {
+			return extractedSize, extractedCount, err
+		}
+		delete(shard, name)
+		extractedSize += size
+		extractedCount++
+	}
+	return extractedSize, extractedCount, nil
+}
+
+// CreateShard accumulates all files in memory and then encodes the shard.
+func (*msgpackExtractCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (written int64, err error) {
+	var (
+		n        int64
+		shard    = make(cmn.GenShard, s.Records.Len())
+		rdReader = &msgpackRecordDataReader{}
+	)
+	for _, rec := range s.Records.All() {
+		for _, obj := range rec.Objects {
+			rdReader.reinit(obj.MetadataSize)
+			if n, err = loadContent(rdReader, rec, obj); err != nil {
+				return written + n, err
+			}
+			written += n
+			shard[rdReader.name.String()] = bytes.Clone(rdReader.data.Bytes())
+		}
+	}
+	err = msgpack.NewEncoder(w).Encode(shard)
+	return written, err
+}
+
+func (*msgpackExtractCreator) NewMetadata(name string) []byte { return []byte(name) }
+
+func (*msgpackExtractCreator) UsingCompression() bool { return false }
+func (*msgpackExtractCreator) SupportsOffset() bool   { return false }
+func (*msgpackExtractCreator) MetadataSize() int64    { return 0 } // no headers
+
+/////////////////////////////
+// msgpackRecordDataReader //
+/////////////////////////////
+
+func (rd *msgpackRecordDataReader) reinit(metadataSize int64) {
+	rd.metadataSize = metadataSize
+	rd.written = 0
+	rd.name.Reset()
+	rd.data.Reset()
+}
+
+func (rd *msgpackRecordDataReader) Write(p []byte) (int, error) {
+	l := len(p)
+	if remaining := rd.metadataSize - rd.written; remaining > 0 {
+		if int64(len(p)) < remaining {
+			remaining = int64(len(p))
+		}
+		rd.name.Write(p[:remaining])
+		rd.written += remaining
+		p = p[remaining:]
+	}
+	rd.data.Write(p)
+	rd.written += int64(len(p))
+	return l, nil
+}
@@ -44,6 +44,8 @@ func (*nopExtractCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadCon
 	return written, nil
 }
 
+func (t *nopExtractCreator) NewMetadata(name string) []byte { return t.internal.NewMetadata(name) }
+
 func (*nopExtractCreator) UsingCompression() bool { return false }
 func (*nopExtractCreator) SupportsOffset() bool   { return true }
 func (t *nopExtractCreator) MetadataSize() int64  { return t.internal.MetadataSize() }
@@ -7,6 +7,7 @@ package extract
 import (
 	"encoding/json"
 	"os"
+	"strings"
 	"sync"
 	"unsafe"
 
@@ -127,6 +128,19 @@ func (r *Record) MakeUniqueName(obj *RecordObj) string {
 	return r.Name + obj.Extension
 }
 
+// SampleKey returns the name of the record without the shard prefix - in
+// WebDataset terms, the key shared by all files of a given sample.
+func (r *Record) SampleKey() string {
+	if idx := strings.IndexByte(r.Name, '|'); idx >= 0 {
+		return r.Name[idx+1:]
+	}
+	return r.Name
+}
+
+func (r *Record) HasExt(ext string) bool {
+	return r.exists(ext)
+}
+
 // NewRecords creates new instance of Records struct and allocates n places for
 // the actual Record's
 func NewRecords(n int) *Records {
@@ -193,6 +207,28 @@ func (r *Records) Exists(name, ext string) (exists bool) {
 	return
 }
 
+// Remove removes the records with given names (and all their objects).
+func (r *Records) Remove(names map[string]struct{}) {
+	if len(names) == 0 {
+		return
+	}
+	r.Lock()
+	arr := r.arr[:0]
+	for _, record := range r.arr {
+		if _, ok := names[record.Name]; !ok {
+			arr = append(arr, record)
+			continue
+		}
+		delete(r.m, record.Name)
+		r.totalObjectCount -= len(record.Objects)
+	}
+	for i := len(arr); i < len(r.arr); i++ {
+		r.arr[i] = nil
+	}
+	r.arr = arr
+	r.Unlock()
+}
+
 func (r *Records) merge(records *Records) {
 	r.Insert(records.arr...)
 }
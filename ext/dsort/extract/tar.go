@@ -88,6 +88,12 @@ func (h *tarFileHeader) toTarHeader(size int64) *tar.Header {
 	}
 }
 
+// newTarMetadata returns the metadata of a regular file (used when converting
+// records extracted from a different format)
+func newTarMetadata(name string) []byte {
+	return cos.MustMarshal(tarFileHeader{Name: name, Typeflag: tar.TypeReg, Mode: 0o644})
+}
+
 func newTarRecordDataReader(t cluster.Target) *tarRecordDataReader {
 	rd := &tarRecordDataReader{}
 	rd.metadataBuf, rd.slab = t.ByteMM().Alloc()
@@ -267,6 +273,8 @@ func (t *tarExtractCreator) CreateShard(s *Shard, tarball io.Writer, loadContent
 	return written, nil
 }
 
+func (*tarExtractCreator) NewMetadata(name string) []byte { return newTarMetadata(name) }
+
 func (*tarExtractCreator) UsingCompression() bool { return false }
 func (*tarExtractCreator) SupportsOffset() bool   { return true }
 func (*tarExtractCreator) MetadataSize() int64    { return cos.TarBlockSize } // size of tar header with padding
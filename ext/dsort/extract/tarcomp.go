@@ -15,17 +15,54 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/ext/dsort/filetype"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v3"
 )
 
 // interface guard
-var _ Creator = (*targzExtractCreator)(nil)
+var _ Creator = (*tarCompExtractCreator)(nil)
 
-type targzExtractCreator struct {
-	t cluster.Target
+type (
+	// tarCodec (de)compresses the entire tarball
+	tarCodec interface {
+		newReader(r io.Reader) (io.ReadCloser, error)
+		newWriter(w io.Writer) (io.WriteCloser, error)
+	}
+	gzipCodec struct{}
+	lz4Codec  struct{}
+	zstdCodec struct{}
+
+	// compressed tarball: .tgz, .tar.gz, .tar.lz4, .tar.zst
+	tarCompExtractCreator struct {
+		t     cluster.Target
+		codec tarCodec
+	}
+)
+
+func (gzipCodec) newReader(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) }
+func (gzipCodec) newWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriterLevel(w, gzip.BestSpeed)
+}
+
+func (lz4Codec) newReader(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(lz4.NewReader(r)), nil
+}
+func (lz4Codec) newWriter(w io.Writer) (io.WriteCloser, error) { return lz4.NewWriter(w), nil }
+
+func (zstdCodec) newReader(r io.Reader) (io.ReadCloser, error) {
+	zr, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return zr.IOReadCloser(), nil
 }
 
-// ExtractShard reads the tarball f and extracts its metadata.
-func (t *targzExtractCreator) ExtractShard(lom *cluster.LOM, r cos.ReadReaderAt, extractor RecordExtractor,
+func (zstdCodec) newWriter(w io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedFastest))
+}
+
+// ExtractShard decompresses the tarball f and extracts its metadata.
+func (t *tarCompExtractCreator) ExtractShard(lom *cluster.LOM, r cos.ReadReaderAt, extractor RecordExtractor,
 	toDisk bool) (extractedSize int64, extractedCount int, err error) {
 	var (
 		size    int64
@@ -33,12 +70,12 @@ func (t *targzExtractCreator) ExtractShard(lom *cluster.LOM, r cos.ReadReaderAt,
 		workFQN = fs.CSM.Gen(lom, filetype.DSortFileType, "") // tarFQN
 	)
 
-	gzr, err := gzip.NewReader(r)
+	cr, err := t.codec.newReader(r)
 	if err != nil {
 		return 0, 0, err
 	}
-	defer cos.Close(gzr)
-	tr := tar.NewReader(gzr)
+	defer cos.Close(cr)
+	tr := tar.NewReader(cr)
 
 	// extract to .tar
 	f, err := cos.CreateFile(workFQN)
@@ -114,24 +151,36 @@ func (t *targzExtractCreator) ExtractShard(lom *cluster.LOM, r cos.ReadReaderAt,
 }
 
 func NewTargzExtractCreator(t cluster.Target) Creator {
-	return &targzExtractCreator{t: t}
+	return &tarCompExtractCreator{t: t, codec: gzipCodec{}}
+}
+
+func NewTarLz4ExtractCreator(t cluster.Target) Creator {
+	return &tarCompExtractCreator{t: t, codec: lz4Codec{}}
+}
+
+func NewTarZstdExtractCreator(t cluster.Target) Creator {
+	return &tarCompExtractCreator{t: t, codec: zstdCodec{}}
 }
 
 // CreateShard creates a new shard locally based on the Shard.
-// Note that the order of closing must be trw, gzw, then finally tarball.
-func (t *targzExtractCreator) CreateShard(s *Shard, tarball io.Writer, loadContent LoadContentFunc) (written int64, err error) {
+// Note that the order of closing must be trw, cw, then finally tarball.
+func (t *tarCompExtractCreator) CreateShard(s *Shard, tarball io.Writer, loadContent LoadContentFunc) (written int64, err error) {
 	var (
 		n         int64
 		needFlush bool
-		gzw, _    = gzip.NewWriterLevel(tarball, gzip.BestSpeed)
-		tw        = tar.NewWriter(gzw)
 		rdReader  = newTarRecordDataReader(t.t)
 	)
+	cw, err := t.codec.newWriter(tarball)
+	if err != nil {
+		rdReader.free()
+		return 0, err
+	}
+	tw := tar.NewWriter(cw)
 
 	defer func() {
 		rdReader.free()
 		cos.Close(tw)
-		cos.Close(gzw)
+		cos.Close(cw)
 	}()
 
 	for _, rec := range s.Records.All() {
@@ -147,14 +196,14 @@ func (t *targzExtractCreator) CreateShard(s *Shard, tarball io.Writer, loadConte
 					needFlush = false
 				}
 
-				if n, err = loadContent(gzw, rec, obj); err != nil {
+				if n, err = loadContent(cw, rec, obj); err != nil {
 					return written + n, err
 				}
 
 				// pad to 512 bytes
 				diff := cos.CeilAlignInt64(n, cos.TarBlockSize) - n
 				if diff > 0 {
-					if _, err = gzw.Write(padBuf[:diff]); err != nil {
+					if _, err = cw.Write(padBuf[:diff]); err != nil {
 						return written + n, err
 					}
 					n += diff
@@ -179,6 +228,8 @@ func (t *targzExtractCreator) CreateShard(s *Shard, tarball io.Writer, loadConte
 	return written, nil
 }
 
-func (*targzExtractCreator) UsingCompression() bool { return true }
-func (*targzExtractCreator) SupportsOffset() bool   { return true }
-func (*targzExtractCreator) MetadataSize() int64    { return cos.TarBlockSize } // size of tar header with padding
+func (*tarCompExtractCreator) NewMetadata(name string) []byte { return newTarMetadata(name) }
+
+func (*tarCompExtractCreator) UsingCompression() bool { return true }
+func (*tarCompExtractCreator) SupportsOffset() bool   { return true }
+func (*tarCompExtractCreator) MetadataSize() int64    { return cos.TarBlockSize } // size of tar header with padding
@@ -0,0 +1,89 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/go-tfdata/tfdata/core"
+)
+
+const (
+	TFRecordIndexExt = ".idx"
+	tfKeyFeature     = "__key__"
+)
+
+// interface guard
+var (
+	_ Creator = (*tfrecordCreator)(nil)
+	_ Indexer = (*tfrecordCreator)(nil)
+)
+
+var errTFRecordInput = errors.New("TFRecord is supported only as the output format")
+
+// tfrecordCreator writes each record (sample) as a single tf.Example:
+// `__key__` contains the record's key while each record object becomes a
+// bytes feature named by its extension without the leading dot (e.g.,
+// `000123.jpg` => "jpg"). The (optional) index contains one line per
+// tf.Example: "<offset> <size>" - the format used by the DALI `tfrecord2idx`.
+type tfrecordCreator struct{}
+
+func NewTFRecordCreator() Creator { return &tfrecordCreator{} }
+
+func (*tfrecordCreator) ExtractShard(*cluster.LOM, cos.ReadReaderAt, RecordExtractor, bool) (int64, int, error) {
+	return 0, 0, errTFRecordInput
+}
+
+func (t *tfrecordCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (int64, error) {
+	return t.CreateShardWithIndex(s, w, nil, loadContent)
+}
+
+func (*tfrecordCreator) CreateShardWithIndex(s *Shard, w, idx io.Writer, loadContent LoadContentFunc) (written int64, err error) {
+	var (
+		n      int64
+		offset int64
+		buf    bytes.Buffer
+		tw     = core.NewTFRecordWriter(w)
+	)
+	for _, rec := range s.Records.All() {
+		example := core.NewTFExample()
+		example.AddBytes(tfKeyFeature, []byte(rec.SampleKey()))
+		for _, obj := range rec.Objects {
+			buf.Reset()
+			sw := &skipWriter{w: &buf, skip: obj.MetadataSize}
+			if n, err = loadContent(sw, rec, obj); err != nil {
+				return written + n, err
+			}
+			written += n
+			example.AddBytes(strings.TrimPrefix(obj.Extension, "."), bytes.Clone(buf.Bytes()))
+		}
+		size, err := tw.WriteExample(example)
+		if err != nil {
+			return written, err
+		}
+		if idx != nil {
+			line := strconv.FormatInt(offset, 10) + " " + strconv.Itoa(size) + "\n"
+			if _, err := io.WriteString(idx, line); err != nil {
+				return written, err
+			}
+		}
+		offset += int64(size)
+	}
+	return written, nil
+}
+
+func (*tfrecordCreator) IndexExt() string { return TFRecordIndexExt }
+
+func (*tfrecordCreator) NewMetadata(string) []byte { return nil } // no per-file headers
+
+func (*tfrecordCreator) UsingCompression() bool { return false }
+func (*tfrecordCreator) SupportsOffset() bool   { return false }
+func (*tfrecordCreator) MetadataSize() int64    { return 0 }
@@ -185,6 +185,10 @@ func (z *zipExtractCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadC
 	return written, nil
 }
 
+func (*zipExtractCreator) NewMetadata(name string) []byte {
+	return cos.MustMarshal(zipFileHeader{Name: name})
+}
+
 func (*zipExtractCreator) UsingCompression() bool { return true }
 func (*zipExtractCreator) SupportsOffset() bool   { return false }
 func (*zipExtractCreator) MetadataSize() int64    { return 0 } // zip does not have header size
@@ -5,6 +5,7 @@
 package dsort
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -64,25 +65,6 @@ func ProxyStartSortHandler(w http.ResponseWriter, r *http.Request, parsedRS *Par
 		managerUUID = PrefixJobID + cos.GenUUID() // compare w/ p.httpdlpost
 		smap        = ctx.smapOwner.Get()
 	)
-	checkResponses := func(responses []response) error {
-		for _, resp := range responses {
-			if resp.err == nil {
-				continue
-			}
-			glog.Errorf("[%s] start sort request failed to be broadcast, err: %s",
-				managerUUID, resp.err.Error())
-
-			path := apc.URLPathdSortAbort.Join(managerUUID)
-			broadcastTargets(http.MethodDelete, path, nil, nil, smap)
-
-			s := fmt.Sprintf("failed to execute start sort, err: %s, status: %d",
-				resp.err.Error(), resp.statusCode)
-			cmn.WriteErrMsg(w, r, s, http.StatusInternalServerError)
-			return resp.err
-		}
-
-		return nil
-	}
 
 	// Starting dSort has two phases:
 	// 1. Initialization, ensures that all targets successfully initialized all
@@ -100,7 +82,7 @@ func ProxyStartSortHandler(w http.ResponseWriter, r *http.Request, parsedRS *Par
 	}
 	path := apc.URLPathdSortInit.Join(managerUUID)
 	responses := broadcastTargets(http.MethodPost, path, nil, b, smap)
-	if err := checkResponses(responses); err != nil {
+	if err := checkStartResponses(w, r, managerUUID, smap, responses); err != nil {
 		return
 	}
 
@@ -109,7 +91,92 @@ func ProxyStartSortHandler(w http.ResponseWriter, r *http.Request, parsedRS *Par
 	}
 	path = apc.URLPathdSortStart.Join(managerUUID)
 	responses = broadcastTargets(http.MethodPost, path, nil, nil, smap)
-	if err := checkResponses(responses); err != nil {
+	if err := checkStartResponses(w, r, managerUUID, smap, responses); err != nil {
+		return
+	}
+
+	w.Write([]byte(managerUUID))
+}
+
+// checkStartResponses aborts the job (on all targets) if any of the init/start
+// requests has failed
+func checkStartResponses(w http.ResponseWriter, r *http.Request, managerUUID string, smap *cluster.Smap,
+	responses []response) error {
+	for _, resp := range responses {
+		if resp.err == nil {
+			continue
+		}
+		glog.Errorf("[%s] start sort request failed to be broadcast, err: %s",
+			managerUUID, resp.err.Error())
+
+		path := apc.URLPathdSortAbort.Join(managerUUID)
+		broadcastTargets(http.MethodDelete, path, nil, nil, smap)
+
+		s := fmt.Sprintf("failed to execute start sort, err: %s, status: %d",
+			resp.err.Error(), resp.statusCode)
+		cmn.WriteErrMsg(w, r, s, http.StatusInternalServerError)
+		return resp.err
+	}
+	return nil
+}
+
+// POST /v1/sort/resume?uuid=...
+func ProxyResumeSortHandler(w http.ResponseWriter, r *http.Request) {
+	managerUUID := r.URL.Query().Get(apc.QparamUUID)
+	if managerUUID == "" {
+		cmn.WriteErrMsg(w, r, fmt.Sprintf("invalid request: missing %s job ID", DSortName))
+		return
+	}
+
+	// 1. Collect checkpoint status from all targets to determine the last
+	//    phase completed by all of them.
+	var (
+		smap      = ctx.smapOwner.Get()
+		path      = apc.URLPathdSortResume.Join(managerUUID)
+		responses = broadcastTargets(http.MethodGet, path, nil, nil, smap)
+		phases    = make([]string, 0, len(responses))
+	)
+	for _, resp := range responses {
+		if resp.err == nil && resp.statusCode >= http.StatusBadRequest {
+			resp.err = errors.New(string(resp.res))
+		}
+		if resp.err != nil {
+			s := fmt.Sprintf("%s job %q cannot be resumed: %s returned %v", DSortName, managerUUID, resp.si, resp.err)
+			cmn.WriteErrMsg(w, r, s, resp.statusCode)
+			return
+		}
+		status := &resumeStatus{}
+		if err := js.Unmarshal(resp.res, status); err != nil {
+			cmn.WriteErr(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		if err := checkTargets(status.Targets, smap); err != nil {
+			cmn.WriteErr(w, r, err)
+			return
+		}
+		phases = append(phases, status.Phase)
+	}
+	if len(phases) == 0 {
+		cmn.WriteErr(w, r, cmn.NewErrNoNodes(apc.Target, len(smap.Tmap)))
+		return
+	}
+
+	// 2. Initialize (from checkpoints) and start - same as ProxyStartSortHandler.
+	phase := minPhase(phases...)
+	glog.Infof("[dsort] %s resuming (phase completed by all targets: %q)", managerUUID, phase)
+	query := url.Values{apc.QparamResumePhase: []string{phase}}
+	responses = broadcastTargets(http.MethodPost, path, query, nil, smap)
+	for i := range responses {
+		if responses[i].err == nil && responses[i].statusCode >= http.StatusBadRequest {
+			responses[i].err = errors.New(string(responses[i].res))
+		}
+	}
+	if err := checkStartResponses(w, r, managerUUID, smap, responses); err != nil {
+		return
+	}
+	path = apc.URLPathdSortStart.Join(managerUUID)
+	responses = broadcastTargets(http.MethodPost, path, nil, nil, smap)
+	if err := checkStartResponses(w, r, managerUUID, smap, responses); err != nil {
 		return
 	}
 
@@ -352,6 +419,8 @@ func TargetHandler(w http.ResponseWriter, r *http.Request) {
 		metricsHandler(w, r)
 	case apc.FinishedAck:
 		finishedAckHandler(w, r)
+	case apc.Resume:
+		resumeSortHandler(w, r)
 	default:
 		cmn.WriteErrMsg(w, r, "invalid path")
 	}
@@ -393,6 +462,64 @@ func initSortHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// resumeSortHandler is the handler called for the HTTP endpoint /v1/sort/resume.
+// GET returns the status of the local checkpoint of a resumable job. POST
+// initializes new dSort manager from the checkpoint (compare with initSortHandler)
+// so that the job can continue from the phase given in the query.
+func resumeSortHandler(w http.ResponseWriter, r *http.Request) {
+	apiItems, err := checkRESTItems(w, r, 1, apc.URLPathdSortResume.L)
+	if err != nil {
+		return
+	}
+	managerUUID := apiItems[0]
+	if m, exists := Managers.Get(managerUUID); exists && !m.Metrics.Archived.Load() {
+		cmn.WriteErrMsg(w, r, fmt.Sprintf("%s job %q is still in progress", DSortName, managerUUID))
+		return
+	}
+	ckpt, err := loadCheckpoint(managerUUID)
+	if err != nil {
+		if cmn.IsErrNotFound(err) {
+			cmn.WriteErr(w, r, err, http.StatusNotFound)
+		} else {
+			cmn.WriteErr(w, r, err)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Write(cos.MustMarshal(ckpt.status()))
+	case http.MethodPost:
+		ckpt.resume = r.URL.Query().Get(apc.QparamResumePhase)
+		if phaseOrder(ckpt.resume) > phaseOrder(ckpt.meta.Phase) {
+			err := fmt.Errorf("invalid request: cannot resume %s job %q from phase %q (completed: %q)",
+				DSortName, managerUUID, ckpt.resume, ckpt.meta.Phase)
+			cmn.WriteErr(w, r, err)
+			return
+		}
+		if !ckpt.resumed(SortingPhase) {
+			// records will be distributed anew - the set of shards may change
+			if err := ckpt.resetCreated(); err != nil {
+				cmn.WriteErr(w, r, err)
+				return
+			}
+		}
+		dsortManager, err := Managers.readd(managerUUID)
+		if err != nil {
+			cmn.WriteErr(w, r, err)
+			return
+		}
+		defer dsortManager.unlock()
+		dsortManager.ckpt = ckpt
+		if err = dsortManager.init(ckpt.meta.RS); err != nil {
+			cmn.WriteErr(w, r, err)
+			return
+		}
+	default:
+		cmn.WriteErr405(w, r, http.MethodGet, http.MethodPost)
+	}
+}
+
 // startSortHandler is the handler called for the HTTP endpoint /v1/sort/start.
 // There are three major phases to this function:
 //  1. extractLocalShards
@@ -623,6 +750,7 @@ func removeSortHandler(w http.ResponseWriter, r *http.Request) {
 		cmn.WriteErr(w, r, err)
 		return
 	}
+	removeCheckpoint(managerUUID)
 }
 
 func listSortHandler(w http.ResponseWriter, r *http.Request) {
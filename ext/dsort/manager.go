@@ -106,6 +106,7 @@ type (
 
 		startShardCreation chan struct{}
 		rs                 *ParsedRequestSpec
+		ckpt               *checkpoint // resumable job only
 
 		client        *http.Client // Client for sending records metadata
 		fileExtension string
@@ -178,7 +179,13 @@ func (m *Manager) init(rs *ParsedRequestSpec) error {
 	targetCount := m.smap.CountActiveTs()
 
 	m.rs = rs
+	if rs.OutputExtension == "" {
+		rs.OutputExtension = rs.Extension
+	}
 	m.Metrics = newMetrics(rs.Description, rs.ExtendedMetrics)
+	if rs.WebDataset.Enabled {
+		m.Metrics.Sorting.Samples = newSampleStats()
+	}
 	m.startShardCreation = make(chan struct{}, 1)
 
 	m.ctx.smapOwner.Listeners().Reg(m)
@@ -245,6 +252,10 @@ func (m *Manager) init(rs *ParsedRequestSpec) error {
 	m.state.cleanWait = sync.NewCond(&m.mu)
 
 	m.callTimeout = config.DSort.CallTimeout.D()
+
+	if rs.Resumable {
+		return m.initCheckpoint()
+	}
 	return nil
 }
 
@@ -373,7 +384,20 @@ func (m *Manager) finalCleanup() {
 	// The reason why this is not in regular cleanup is because we are only sure
 	// that this can be freed once we cleanup streams - streams are asynchronous
 	// and we may have race between in-flight request and cleanup.
+	//
+	// Aborted resumable job retains its checkpoint and (if extraction has
+	// completed) extracted contents.
+	keepCkpt := m.ckpt != nil && m.aborted()
+	if keepCkpt && m.ckpt.meta.Phase != "" {
+		m.recManager.KeepExtractionPaths()
+	}
 	m.recManager.Cleanup()
+	if keepCkpt {
+		m.ckpt.close()
+		glog.Infof("[dsort] %s retained checkpoint (completed phase: %q)", m.ManagerUUID, m.ckpt.meta.Phase)
+	} else if m.ckpt != nil {
+		m.ckpt.destroy()
+	}
 
 	m.creationPhase.metadata.SendOrder = nil
 	m.creationPhase.metadata.Shards = nil
@@ -467,16 +491,9 @@ func (m *Manager) setExtractCreator() (err error) {
 		return m.react(m.rs.DuplicatedRecords, msg)
 	}
 
-	var extractCreator extract.Creator
-	switch m.rs.Extension {
-	case cos.ExtTar:
-		extractCreator = extract.NewTarExtractCreator(m.ctx.t)
-	case cos.ExtTarTgz, cos.ExtTgz:
-		extractCreator = extract.NewTargzExtractCreator(m.ctx.t)
-	case cos.ExtZip:
-		extractCreator = extract.NewZipExtractCreator(m.ctx.t)
-	default:
-		cos.Assertf(false, "unknown extension %s", m.rs.Extension)
+	extractCreator := m.newCreator(m.rs.Extension)
+	if m.rs.OutputExtension != m.rs.Extension {
+		extractCreator = extract.ConvertCreator(extractCreator, m.newCreator(m.rs.OutputExtension))
 	}
 
 	if !m.rs.DryRun {
@@ -489,11 +506,34 @@ func (m *Manager) setExtractCreator() (err error) {
 		m.ctx.t, m.rs.Bck,
 		m.rs.Extension, m.extractCreator,
 		keyExtractor, onDuplicatedRecords,
+		m.rs.WebDataset.Enabled,
 	)
 
 	return nil
 }
 
+func (m *Manager) newCreator(ext string) (creator extract.Creator) {
+	switch ext {
+	case cos.ExtTar:
+		creator = extract.NewTarExtractCreator(m.ctx.t)
+	case cos.ExtTarTgz, cos.ExtTgz:
+		creator = extract.NewTargzExtractCreator(m.ctx.t)
+	case extract.ExtTarLz4:
+		creator = extract.NewTarLz4ExtractCreator(m.ctx.t)
+	case extract.ExtTarZst:
+		creator = extract.NewTarZstdExtractCreator(m.ctx.t)
+	case cos.ExtZip:
+		creator = extract.NewZipExtractCreator(m.ctx.t)
+	case cos.ExtMsgpack:
+		creator = extract.NewMsgpackExtractCreator(m.ctx.t)
+	case extract.ExtTFRecord:
+		creator = extract.NewTFRecordCreator()
+	default:
+		cos.Assertf(false, "unknown extension %s", ext)
+	}
+	return
+}
+
 // updateFinishedAck marks daemonID as finished. If all daemons ack then the
 // finalCleanup is dispatched in separate goroutine.
 func (m *Manager) updateFinishedAck(daemonID string) {
@@ -696,6 +736,7 @@ func (m *Manager) makeRecvShardFunc() transport.RecvObj {
 			m.abort(err)
 			return erp
 		}
+		m.indexShard(lom)
 		return nil
 	}
 }
@@ -35,6 +35,7 @@ type ManagerGroup struct {
 
 func InitManagers(db kvdb.Driver) {
 	Managers = NewManagerGroup(db, false)
+	Managers.loadCheckpoints()
 }
 
 // NewManagerGroup returns new, initialized manager group.
@@ -67,6 +68,52 @@ func (mg *ManagerGroup) Add(managerUUID string) (*Manager, error) {
 	return manager, nil
 }
 
+// readd replaces finished (archived) job with new, non-initialized manager
+// to resume the job from its checkpoint. Returned manager is locked.
+func (mg *ManagerGroup) readd(managerUUID string) (*Manager, error) {
+	mg.mtx.Lock()
+	if manager, ok := mg.managers[managerUUID]; ok {
+		if !manager.Metrics.Archived.Load() {
+			mg.mtx.Unlock()
+			return nil, errors.Errorf("%s job %q is still in progress", DSortName, managerUUID)
+		}
+		delete(mg.managers, managerUUID)
+	}
+	key := path.Join(managersKey, managerUUID)
+	_ = mg.db.Delete(dsortCollection, key)
+	mg.mtx.Unlock()
+
+	return mg.Add(managerUUID)
+}
+
+// loadCheckpoints registers resumable jobs interrupted by this target's
+// restart (as aborted, to be subsequently resumed or removed)
+func (mg *ManagerGroup) loadCheckpoints() {
+	for _, managerUUID := range listCheckpoints() {
+		var (
+			m   *Manager
+			key = path.Join(managersKey, managerUUID)
+		)
+		if err := mg.db.Get(dsortCollection, key, &m); err == nil {
+			continue // was aborted prior to restart
+		}
+		ckpt, err := loadCheckpoint(managerUUID)
+		if err != nil {
+			glog.Error(err)
+			continue
+		}
+		m = &Manager{ManagerUUID: managerUUID, Metrics: newMetrics(ckpt.meta.RS.Description, false)}
+		m.Metrics.setAbortedTo(true)
+		m.Metrics.Archived.Store(true)
+		m.Metrics.Errors = append(m.Metrics.Errors, "interrupted by target restart")
+		if err := mg.db.Set(dsortCollection, key, m); err != nil {
+			glog.Error(err)
+			continue
+		}
+		glog.Infof("[dsort] %s interrupted (completed phase: %q), can be resumed", managerUUID, ckpt.meta.Phase)
+	}
+}
+
 func (mg *ManagerGroup) List(descRegex *regexp.Regexp, onlyActive bool) []JobInfo {
 	mg.mtx.Lock()
 	defer mg.mtx.Unlock()
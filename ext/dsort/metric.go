@@ -87,6 +87,24 @@ func newDetailedStats() *DetailedStats {
 	}
 }
 
+func newSampleStats() *SampleStats {
+	return &SampleStats{
+		MinSize:   math.MaxInt64,
+		MinObjCnt: math.MaxInt64,
+	}
+}
+
+func (ss *SampleStats) add(size int64, objCnt int) {
+	ss.Count++
+	ss.ObjectCnt += int64(objCnt)
+	ss.totalSize += size
+	ss.MinSize = cos.MinI64(ss.MinSize, size)
+	ss.MaxSize = cos.MaxI64(ss.MaxSize, size)
+	ss.AvgSize = ss.totalSize / ss.Count
+	ss.MinObjCnt = cos.MinI64(ss.MinObjCnt, int64(objCnt))
+	ss.MaxObjCnt = cos.MaxI64(ss.MaxObjCnt, int64(objCnt))
+}
+
 // PhaseInfo contains general stats and state for given phase. It is base struct
 // which is extended by actual phases structs.
 type PhaseInfo struct {
@@ -154,6 +172,30 @@ type MetaSorting struct {
 	SentStats *TimeStats `json:"sent_stats,omitempty"`
 	// RecvStats describes time statistics about records receiving from another target
 	RecvStats *TimeStats `json:"recv_stats,omitempty"`
+	// Samples describes per-sample statistics (WebDataset mode only). Reported
+	// by the target that performed the final merge of all records.
+	Samples *SampleStats `json:"samples,omitempty"`
+}
+
+// SampleStats contains statistics about WebDataset samples.
+type SampleStats struct {
+	// Count is the number of samples passed on to the creation phase.
+	Count int64 `json:"count,string"`
+	// ObjectCnt is the total number of files in all samples.
+	ObjectCnt int64 `json:"object_count,string"`
+	// IncompleteCnt is the number of samples missing one or more required extensions.
+	IncompleteCnt int64 `json:"incomplete_count,string"`
+	// DuplicatedCnt is the number of samples dropped because the same sample
+	// key has been found in another input shard.
+	DuplicatedCnt int64 `json:"duplicated_count,string"`
+	// Size (in bytes) and the number of files of a single sample.
+	MinSize   int64 `json:"min_size,string"`
+	MaxSize   int64 `json:"max_size,string"`
+	AvgSize   int64 `json:"avg_size,string"`
+	MinObjCnt int64 `json:"min_object_count,string"`
+	MaxObjCnt int64 `json:"max_object_count,string"`
+
+	totalSize int64
 }
 
 // ShardCreation contains metrics for third and last phase of DSort.
@@ -34,7 +34,8 @@ var (
 
 var (
 	errMissingBucket            = errors.New("missing field 'bucket'")
-	errInvalidExtension         = errors.New("extension must be one of '.tar', '.tar.gz', or '.tgz'")
+	errInvalidExtension         = fmt.Errorf("extension must be one of %v", supportedExtensions)
+	errInvalidOutputExtension   = fmt.Errorf("output extension must be one of %v", supportedOutputExtensions)
 	errNegOutputShardSize       = errors.New("output shard size must be >= 0")
 	errEmptyOutputShardSize     = errors.New("output shard size must be set (cannot be 0)")
 	errNegativeConcurrencyLimit = errors.New("concurrency max limit must be 0 (limits will be calculated) or > 0")
@@ -44,10 +45,23 @@ var (
 	errInvalidAlgorithm          = errors.New("invalid algorithm specified")
 	errInvalidSeed               = errors.New("invalid seed provided, should be int")
 	errInvalidAlgorithmExtension = errors.New("invalid extension provided, should be in the format: .ext")
+
+	errInvalidSampleExtension = errors.New("invalid required sample extension, should be in the format: .ext")
+	errSamplesNotEnabled      = errors.New("required sample extensions can only be used with 'webdataset.enabled'")
+
+	errResumableMemDSorter = errors.New("resumable job cannot use memory-based dsorter (" + DSorterMemType + ")")
 )
 
-// supportedExtensions is a list of extensions (archives) supported by dSort
-var supportedExtensions = cos.ArchExtensions
+var (
+	// supportedExtensions is a list of extensions (archives) supported by dSort
+	supportedExtensions = []string{
+		cos.ExtTar, cos.ExtTgz, cos.ExtTarTgz, cos.ExtZip, cos.ExtMsgpack,
+		extract.ExtTarLz4, extract.ExtTarZst,
+	}
+	// supportedOutputExtensions additionally includes output-only formats
+	supportedOutputExtensions = append(supportedExtensions[:len(supportedExtensions):len(supportedExtensions)],
+		extract.ExtTFRecord)
+)
 
 // TODO: maybe this struct should be composed of `type` and `template` where
 // template is interface and each template has it's own struct. Then we could
@@ -83,6 +97,8 @@ type RequestSpec struct {
 	Description string `json:"description" yaml:"description"`
 	// Default: same as `bck` field
 	OutputBck cmn.Bck `json:"output_bck" yaml:"output_bck"`
+	// Default: same as `extension` field
+	OutputExtension string `json:"output_extension" yaml:"output_extension"`
 	// Default: alphanumeric, increasing
 	Algorithm SortAlgorithm `json:"algorithm" yaml:"algorithm"`
 	// Default: ""
@@ -99,6 +115,10 @@ type RequestSpec struct {
 	StreamMultiplier int `json:"stream_multiplier" yaml:"stream_multiplier"`
 	// Default: false
 	ExtendedMetrics bool `json:"extended_metrics" yaml:"extended_metrics"`
+	// Default: disabled
+	WebDataset WebDatasetSpec `json:"webdataset" yaml:"webdataset"`
+	// Default: false (when true, progress is checkpointed and the job can be resumed - see checkpoint.go)
+	Resumable bool `json:"resumable" yaml:"resumable"`
 
 	// debug
 	DSorterType string `json:"dsorter_type"`
@@ -112,6 +132,7 @@ type ParsedRequestSpec struct {
 	Description         string                `json:"description"`
 	OutputBck           cmn.Bck               `json:"output_bck"`
 	Extension           string                `json:"extension"`
+	OutputExtension     string                `json:"output_extension"`
 	OutputShardSize     int64                 `json:"output_shard_size,string"`
 	InputFormat         *parsedInputTemplate  `json:"input_format"`
 	OutputFormat        *parsedOutputTemplate `json:"output_format"`
@@ -124,6 +145,8 @@ type ParsedRequestSpec struct {
 	CreateConcMaxLimit  int                   `json:"create_concurrency_max_limit"`
 	StreamMultiplier    int                   `json:"stream_multiplier"` // TODO: should be removed
 	ExtendedMetrics     bool                  `json:"extended_metrics"`
+	WebDataset          WebDatasetSpec        `json:"webdataset"`
+	Resumable           bool                  `json:"resumable"`
 
 	// debug
 	DSorterType string `json:"dsorter_type"`
@@ -146,6 +169,17 @@ type SortAlgorithm struct {
 	FormatType string `json:"format_type"`
 }
 
+// WebDatasetSpec enables WebDataset-style sample grouping. All archived files
+// that share the same sample key (e.g. `000123.jpg`, `000123.cls`, `000123.json`)
+// form a single sample which is sorted, shuffled, and written as an inseparable
+// unit. Sample keys are expected to be unique across all input shards.
+type WebDatasetSpec struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Extensions each sample must contain, e.g. [".jpg", ".cls"]; incomplete
+	// samples trigger the `ekm_missing_key` reaction.
+	RequiredExts []string `json:"required_extensions,omitempty" yaml:"required_extensions,omitempty"`
+}
+
 // Parse returns a non-nil error if a RequestSpec is invalid. When RequestSpec
 // is valid it parses all the fields, sets the values and returns ParsedRequestSpec.
 func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
@@ -187,6 +221,12 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 		return nil, errInvalidExtension
 	}
 	parsedRS.Extension = rs.Extension
+	parsedRS.OutputExtension = rs.OutputExtension
+	if parsedRS.OutputExtension == "" {
+		parsedRS.OutputExtension = rs.Extension
+	} else if !cos.StringInSlice(parsedRS.OutputExtension, supportedOutputExtensions) {
+		return nil, errInvalidOutputExtension
+	}
 
 	parsedRS.OutputShardSize, err = cos.ParseSize(rs.OutputShardSize, cos.UnitsIEC)
 	if err != nil {
@@ -247,8 +287,19 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 	parsedRS.CreateConcMaxLimit = rs.CreateConcMaxLimit
 	parsedRS.StreamMultiplier = rs.StreamMultiplier
 	parsedRS.ExtendedMetrics = rs.ExtendedMetrics
+	if parsedRS.WebDataset, err = parseWebDataset(rs.WebDataset); err != nil {
+		return nil, err
+	}
 	parsedRS.DSorterType = rs.DSorterType
 	parsedRS.DryRun = rs.DryRun
+	if rs.Resumable {
+		// extracted records must survive restarts and so they always go to disk
+		if rs.DSorterType == DSorterMemType {
+			return nil, errResumableMemDSorter
+		}
+		parsedRS.Resumable = true
+		parsedRS.DSorterType = DSorterGeneralType
+	}
 
 	// Check for values that override the global config.
 	if err := rs.DSortConf.ValidateWithOpts(true); err != nil {
@@ -338,6 +389,27 @@ func parseAlgorithm(algo SortAlgorithm) (parsedAlgo *SortAlgorithm, err error) {
 	return &algo, nil
 }
 
+func parseWebDataset(wds WebDatasetSpec) (WebDatasetSpec, error) {
+	if !wds.Enabled {
+		if len(wds.RequiredExts) > 0 {
+			return wds, errSamplesNotEnabled
+		}
+		return wds, nil
+	}
+	exts := make([]string, 0, len(wds.RequiredExts))
+	for _, ext := range wds.RequiredExts {
+		ext = strings.TrimSpace(ext)
+		if len(ext) < 2 || ext[0] != '.' {
+			return wds, errInvalidSampleExtension
+		}
+		if !cos.StringInSlice(ext, exts) {
+			exts = append(exts, ext)
+		}
+	}
+	wds.RequiredExts = exts
+	return wds, nil
+}
+
 func validateOrderFileURL(orderURL string) (empty, valid bool) {
 	if orderURL == "" {
 		return true, true
@@ -13,6 +13,7 @@ import (
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dsort/extract"
 	"github.com/NVIDIA/aistore/fs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -291,6 +292,42 @@ var _ = Describe("RequestSpec", func() {
 			_, err = rs.Parse()
 			Expect(err).ShouldNot(HaveOccurred())
 		})
+
+		It("should parse spec with output extension", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtZip,
+				OutputExtension: extract.ExtTFRecord,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{10..111}-suffix",
+				OutputShardSize: "10KB",
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.Extension).To(Equal(cos.ExtZip))
+			Expect(parsed.OutputExtension).To(Equal(extract.ExtTFRecord))
+
+			rs.OutputExtension = ""
+			parsed, err = rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.OutputExtension).To(Equal(cos.ExtZip))
+		})
+
+		It("should parse webdataset spec", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{10..111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       SortAlgorithm{Kind: SortKindShuffle},
+				WebDataset:      WebDatasetSpec{Enabled: true, RequiredExts: []string{".jpg", " .cls", ".jpg"}},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.WebDataset.Enabled).To(BeTrue())
+			Expect(parsed.WebDataset.RequiredExts).To(Equal([]string{".jpg", ".cls"}))
+		})
 	})
 
 	Context("request specs which shall NOT pass", func() {
@@ -384,6 +421,22 @@ var _ = Describe("RequestSpec", func() {
 			Expect(err).To(Equal(errInvalidExtension))
 		})
 
+		It("should fail due to input-only output extension", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       extract.ExtTFRecord,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{10..111}-suffix",
+				OutputShardSize: "10KB",
+			}
+			_, err := rs.Parse()
+			Expect(err).To(Equal(errInvalidExtension))
+
+			rs.Extension, rs.OutputExtension = cos.ExtTar, ".tar.bz2"
+			_, err = rs.Parse()
+			Expect(err).To(Equal(errInvalidOutputExtension))
+		})
+
 		It("should fail due to invalid mem usage specification", func() {
 			rs := RequestSpec{
 				Bck:             cmn.Bck{Name: "test"},
@@ -485,5 +538,33 @@ var _ = Describe("RequestSpec", func() {
 			_, err := rs.Parse()
 			Expect(err).Should(HaveOccurred())
 		})
+
+		It("should fail due to invalid required sample extension", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{10..111}-suffix",
+				OutputShardSize: "10KB",
+				WebDataset:      WebDatasetSpec{Enabled: true, RequiredExts: []string{"cls"}},
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(err).To(Equal(errInvalidSampleExtension))
+		})
+
+		It("should fail when required sample extensions are set but webdataset is disabled", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{10..111}-suffix",
+				OutputShardSize: "10KB",
+				WebDataset:      WebDatasetSpec{RequiredExts: []string{".cls"}},
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(err).To(Equal(errSamplesNotEnabled))
+		})
 	})
 })
@@ -0,0 +1,77 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validateSamples is executed by the final target once all records have been
+// merged (WebDataset mode). Each record is a sample: all files with the same
+// sample key that were found in the same input shard. The function:
+//   - drops samples whose key was also found in another input shard (the
+//     lexicographically smallest shard wins) and reacts per `duplicated_records`;
+//   - checks that every sample contains all required extensions and reacts
+//     per `ekm_missing_key` (incomplete samples are retained unless aborted);
+//   - updates per-sample metrics.
+func (m *Manager) validateSamples() error {
+	var (
+		records = m.recManager.Records
+		owners  = make(map[string]string, records.Len()) // sample key => record name (shard|key)
+		dups    = make(map[string]struct{})
+		stats   = newSampleStats()
+	)
+	for _, r := range records.All() {
+		key := r.SampleKey()
+		if owner, ok := owners[key]; !ok || r.Name < owner {
+			owners[key] = r.Name
+		}
+	}
+	for _, r := range records.All() {
+		key := r.SampleKey()
+		if owner := owners[key]; owner != r.Name {
+			dups[r.Name] = struct{}{}
+			stats.DuplicatedCnt++
+			msg := fmt.Sprintf("sample %q has been duplicated (shards: %q, %q)",
+				key, sampleShard(owner), sampleShard(r.Name))
+			if err := m.react(m.rs.DuplicatedRecords, msg); err != nil {
+				return err
+			}
+			continue
+		}
+		var missing []string
+		for _, ext := range m.rs.WebDataset.RequiredExts {
+			if !r.HasExt(ext) {
+				missing = append(missing, ext)
+			}
+		}
+		if len(missing) > 0 {
+			stats.IncompleteCnt++
+			msg := fmt.Sprintf("sample %q (shard %q) is incomplete, missing: %v", key, sampleShard(r.Name), missing)
+			if err := m.react(m.rs.EKMMissingKey, msg); err != nil {
+				return err
+			}
+		}
+		stats.add(r.TotalSize(), len(r.Objects))
+	}
+	records.Remove(dups)
+
+	if stats.Count == 0 {
+		stats.MinSize, stats.MinObjCnt = 0, 0
+	}
+	metrics := m.Metrics.Sorting
+	metrics.mu.Lock()
+	metrics.Samples = stats
+	metrics.mu.Unlock()
+	return nil
+}
+
+func sampleShard(recordName string) string {
+	if idx := strings.IndexByte(recordName, '|'); idx >= 0 {
+		return recordName[:idx]
+	}
+	return ""
+}
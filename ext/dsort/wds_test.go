@@ -0,0 +1,73 @@
+// Package dsort provides APIs for distributed archive file shuffling.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dsort/extract"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebDataset samples", func() {
+	newSample := func(shard, key string, exts ...string) *extract.Record {
+		r := &extract.Record{Key: key, Name: shard + "|" + key}
+		for _, ext := range exts {
+			r.Objects = append(r.Objects, &extract.RecordObj{Size: 10, Extension: ext})
+		}
+		return r
+	}
+	newManager := func(dupReaction, missingReaction string, exts ...string) *Manager {
+		m := &Manager{
+			rs: &ParsedRequestSpec{
+				WebDataset: WebDatasetSpec{Enabled: true, RequiredExts: exts},
+				DSortConf:  cmn.DSortConf{DuplicatedRecords: dupReaction, EKMMissingKey: missingReaction},
+			},
+			recManager: extract.NewRecordManager(nil, cmn.Bck{}, cos.ExtTar, nil, nil, nil, true),
+			Metrics:    newMetrics("", false),
+		}
+		m.recManager.Records.Insert(
+			newSample("shard-0", "000001", ".jpg", ".cls", ".json"),
+			newSample("shard-0", "000002", ".jpg", ".json"),
+			newSample("shard-1", "000003", ".jpg", ".cls"),
+			newSample("shard-2", "000001", ".jpg", ".cls"),
+		)
+		return m
+	}
+
+	It("should drop duplicated samples and report stats", func() {
+		m := newManager(cmn.WarnReaction, cmn.WarnReaction, ".jpg", ".cls")
+		Expect(m.validateSamples()).NotTo(HaveOccurred())
+
+		records := m.recManager.Records
+		Expect(records.Len()).To(Equal(3))
+		for _, r := range records.All() {
+			Expect(r.Name).NotTo(Equal("shard-2|000001"))
+		}
+		Expect(records.TotalObjectCount()).To(Equal(7))
+		Expect(m.Metrics.Warnings).To(HaveLen(2))
+
+		stats := m.Metrics.Sorting.Samples
+		Expect(stats.Count).To(BeEquivalentTo(3))
+		Expect(stats.ObjectCnt).To(BeEquivalentTo(7))
+		Expect(stats.DuplicatedCnt).To(BeEquivalentTo(1))
+		Expect(stats.IncompleteCnt).To(BeEquivalentTo(1))
+		Expect(stats.MinObjCnt).To(BeEquivalentTo(2))
+		Expect(stats.MaxObjCnt).To(BeEquivalentTo(3))
+		Expect(stats.MinSize).To(BeEquivalentTo(20))
+		Expect(stats.MaxSize).To(BeEquivalentTo(30))
+	})
+
+	It("should abort on incomplete sample", func() {
+		m := newManager(cmn.IgnoreReaction, cmn.AbortReaction, ".jpg", ".cls")
+		Expect(m.validateSamples()).To(HaveOccurred())
+	})
+
+	It("should abort on duplicated sample", func() {
+		m := newManager(cmn.AbortReaction, cmn.IgnoreReaction)
+		Expect(m.validateSamples()).To(HaveOccurred())
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -21,8 +22,16 @@ import (
 const PrefixXactID = "etl-"
 
 const (
-	Spec = "spec"
-	Code = "code"
+	Spec     = "spec"
+	Code     = "code"
+	Pipeline = "pipeline"
+)
+
+// limits
+const (
+	MaxPipelineStages = 16
+	MaxArgsSize       = 4 * cos.KiB // transformer arguments: per-request and pipeline stages
+	MaxWasmMemSize    = 4 * cos.GiB // 32-bit WebAssembly address space
 )
 
 const HealthStatusRunning = "Running" // TODO: add the full enum, if exists
@@ -41,6 +50,8 @@ type (
 		IDX       string       `json:"id"`
 		CommTypeX string       `json:"communication"`
 		Timeout   cos.Duration `json:"timeout"`
+		// per-target capacity of the transform cache (see cache.go); zero (default) - no caching
+		CacheSize cos.SizeIEC `json:"cache_size,omitempty"`
 	}
 	InitSpecMsg struct {
 		InitMsgBase
@@ -66,6 +77,28 @@ type (
 		ChunkSize int64 `json:"chunk_size"`
 		// bitwise flags: (streaming | debug | strict | ...)
 		Flags int64 `json:"flags"`
+		// in-process (`runtime.Wasm`) only - see wasm.go
+		Limits WasmLimits `json:"limits"`
+	}
+
+	// resource limits of in-process WebAssembly transformers; zero - system default
+	WasmLimits struct {
+		MemSize      cos.SizeIEC  `json:"mem_size,omitempty"`      // max linear memory per instance
+		ExecTimeout  cos.Duration `json:"exec_timeout,omitempty"`  // max time to transform a single object
+		MaxInstances int          `json:"max_instances,omitempty"` // max concurrently running instances (per target)
+	}
+
+	// ETL pipeline: ordered list of (already initialized) ETLs, with optional
+	// per-stage arguments; objects stream through the stages on the same target
+	// (see pipeline.go)
+	PipelineMsg struct {
+		IDX       string          `json:"id"`
+		Stages    []PipelineStage `json:"stages"`
+		CacheSize cos.SizeIEC     `json:"cache_size,omitempty"` // (see InitMsgBase)
+	}
+	PipelineStage struct {
+		Name string `json:"name"`           // ETL name
+		Args string `json:"args,omitempty"` // opaque (transformer-specific) string or JSON
 	}
 )
 
@@ -77,6 +110,19 @@ type (
 		ObjCount int64  `json:"obj_count"`
 		InBytes  int64  `json:"in_bytes"`
 		OutBytes int64  `json:"out_bytes"`
+		// transform cache, if enabled (see cache.go)
+		CacheHits   int64 `json:"cache_hits,omitempty"`
+		CacheMisses int64 `json:"cache_misses,omitempty"`
+		CacheUsed   int64 `json:"cache_used,omitempty"`
+		// pipelines only
+		Stages []StageInfo `json:"stages,omitempty"`
+	}
+	StageInfo struct {
+		Name     string `json:"name"`
+		ObjCount int64  `json:"obj_count"`
+		InBytes  int64  `json:"in_bytes"`
+		OutBytes int64  `json:"out_bytes"`
+		ErrCount int64  `json:"err_count"`
 	}
 
 	LogsByTarget []Logs
@@ -124,6 +170,7 @@ var commTypes = []string{Hpush, Hpull, Hrev, HpushStdin} // NOTE: must contain a
 var (
 	_ InitMsg = (*InitCodeMsg)(nil)
 	_ InitMsg = (*InitSpecMsg)(nil)
+	_ InitMsg = (*PipelineMsg)(nil)
 )
 
 func (m InitMsgBase) CommType() string { return m.CommTypeX }
@@ -131,6 +178,10 @@ func (m InitMsgBase) Name() string     { return m.IDX }
 
 func (*InitCodeMsg) Type() string { return Code }
 func (*InitSpecMsg) Type() string { return Spec }
+func (*PipelineMsg) Type() string { return Pipeline }
+
+func (m *PipelineMsg) Name() string   { return m.IDX }
+func (*PipelineMsg) CommType() string { return "" }
 
 func (m *InitCodeMsg) String() string {
 	return fmt.Sprintf("init-%s[%s-%s-%s]", Code, m.IDX, m.CommTypeX, m.Runtime)
@@ -140,6 +191,14 @@ func (m *InitSpecMsg) String() string {
 	return fmt.Sprintf("init-%s[%s-%s]", Spec, m.IDX, m.CommTypeX)
 }
 
+func (m *PipelineMsg) String() string {
+	names := make([]string, 0, len(m.Stages))
+	for _, stage := range m.Stages {
+		names = append(names, stage.Name)
+	}
+	return fmt.Sprintf("init-%s[%s: %s]", Pipeline, m.IDX, strings.Join(names, " => "))
+}
+
 // TODO: double-take, unmarshaling-wise. To avoid, include (`Spec`, `Code`) in API calls
 func UnmarshalInitMsg(b []byte) (msg InitMsg, err error) {
 	var msgInf map[string]json.RawMessage
@@ -156,6 +215,11 @@ func UnmarshalInitMsg(b []byte) (msg InitMsg, err error) {
 		err = jsoniter.Unmarshal(b, msg)
 		return
 	}
+	if _, ok := msgInf["stages"]; ok {
+		msg = &PipelineMsg{}
+		err = jsoniter.Unmarshal(b, msg)
+		return
+	}
 	err = fmt.Errorf("invalid etl.InitMsg: %+v", msgInf)
 	return
 }
@@ -173,6 +237,9 @@ func (m *InitCodeMsg) Validate() error {
 	if _, ok := runtime.Get(m.Runtime); !ok {
 		return fmt.Errorf("unsupported runtime %q (supported: %v)", m.Runtime, runtime.GetNames())
 	}
+	if m.Runtime == runtime.Wasm {
+		return m.validateWasm()
+	}
 	if m.CommTypeX == "" {
 		cos.Warningf("empty comm-type, defaulting to %q (%q)", Hpush, m.Runtime)
 		m.CommTypeX = Hpush
@@ -186,7 +253,32 @@ func (m *InitCodeMsg) Validate() error {
 		return fmt.Errorf("chunk-size %d is invalid, expecting 0 <= chunk-size <= MiB (%q, comm-type %q)",
 			m.ChunkSize, m.CommTypeX, m.Runtime)
 	}
-	return nil
+	return validateCacheSize(m.IDX, m.CacheSize)
+}
+
+// WebAssembly module runs in-process, with stdin/stdout communication
+func (m *InitCodeMsg) validateWasm() error {
+	switch m.CommTypeX {
+	case "":
+		m.CommTypeX = HpushStdin
+	case HpushStdin:
+	default:
+		return fmt.Errorf("runtime %q requires comm-type %q (got %q)", m.Runtime, HpushStdin, m.CommTypeX)
+	}
+	if len(m.Deps) > 0 {
+		return fmt.Errorf("runtime %q does not support dependencies (link them into the module)", m.Runtime)
+	}
+	if m.Funcs.Transform == "" {
+		return fmt.Errorf("transform function cannot be empty (runtime %q)", m.Runtime)
+	}
+	if m.Limits.MemSize < 0 || m.Limits.MemSize > MaxWasmMemSize {
+		return fmt.Errorf("invalid memory limit %s, expecting 0 <= limit <= %s (%q)",
+			cos.ToSizeIEC(int64(m.Limits.MemSize), 0), cos.ToSizeIEC(MaxWasmMemSize, 0), m.Runtime)
+	}
+	if m.Limits.ExecTimeout < 0 || m.Limits.MaxInstances < 0 {
+		return fmt.Errorf("invalid negative limits %+v (%q)", m.Limits, m.Runtime)
+	}
+	return validateCacheSize(m.IDX, m.CacheSize)
 }
 
 func ParsePodSpec(errCtx *cmn.ETLErrCtx, spec []byte) (*corev1.Pod, error) {
@@ -251,6 +343,46 @@ func (m *InitSpecMsg) Validate() (err error) {
 	if container.ReadinessProbe.HTTPGet.Port.StrVal != k8s.Default {
 		return cmn.NewErrETL(errCtx, "readinessProbe port must be the %q port", k8s.Default)
 	}
+	return validateCacheSize(m.IDX, m.CacheSize)
+}
+
+// NOTE: the stages must be regular (non-pipeline) ETLs - the proxy checks that they exist
+func (m *PipelineMsg) Validate() error {
+	if err := k8s.ValidateEtlName(m.IDX); err != nil {
+		return err
+	}
+	if len(m.Stages) == 0 {
+		return fmt.Errorf("%s: pipeline has no stages", m)
+	}
+	if len(m.Stages) > MaxPipelineStages {
+		return fmt.Errorf("%s: too many stages (%d, max %d)", m, len(m.Stages), MaxPipelineStages)
+	}
+	for i, stage := range m.Stages {
+		if err := k8s.ValidateEtlName(stage.Name); err != nil {
+			return fmt.Errorf("%s: stage #%d: %v", m, i, err)
+		}
+		if stage.Name == m.IDX {
+			return fmt.Errorf("%s: stage #%d refers to the pipeline itself", m, i)
+		}
+		if err := ValidateArgs(stage.Args); err != nil {
+			return fmt.Errorf("%s: stage #%d (%q): %v", m, i, stage.Name, err)
+		}
+	}
+	return validateCacheSize(m.IDX, m.CacheSize)
+}
+
+// transformer arguments are opaque (string or JSON) - checking size only
+func ValidateArgs(args string) error {
+	if len(args) > MaxArgsSize {
+		return fmt.Errorf("etl args are too long (%d, max %d)", len(args), MaxArgsSize)
+	}
+	return nil
+}
+
+func validateCacheSize(name string, size cos.SizeIEC) error {
+	if size < 0 {
+		return fmt.Errorf("etl[%s]: invalid negative cache size %d", name, size)
+	}
 	return nil
 }
 
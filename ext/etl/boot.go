@@ -7,6 +7,7 @@ package etl
 import (
 	"fmt"
 	"net"
+	"net/http"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -37,6 +38,11 @@ type etlBootstrapper struct {
 	uri             string
 	originalPodName string
 	originalCommand []string
+
+	// local (non-K8s) runtime only
+	client *http.Client
+	proc   *localProc
+	local  bool
 }
 
 func (b *etlBootstrapper) createPodSpec() (err error) {
@@ -0,0 +1,427 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"container/list"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/OneOfOne/xxhash"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Transform cache
+//
+// When enabled (non-zero `cache_size` in the init message), results of inline
+// transformations are stored on the target next to their source objects
+// (`fs.ETLCacheType` content) and served from there upon subsequent GETs.
+//
+// * entries are keyed by the ETL digest (xxhash of its init message; pipelines
+//   also include the digests of their stages), the source object's version
+//   and checksum, and per-request arguments, if any - overwriting the object
+//   invalidates its cached transformation;
+// * (re)initializing or stopping the ETL wipes out all its cached content;
+// * `cache_size` is the per-target capacity enforced via LRU eviction;
+// * deleting or overwriting the object drops its cached transformations (see DropCached);
+// * transformer's response headers (Content-Type, et al.) are cached and served
+//   along with the content;
+// * objects that are not present locally (e.g., not yet cached remote objects),
+//   encrypted objects (the transformed content would be stored in plaintext), and
+//   results larger than the capacity bypass the cache.
+//
+// Note that cache misses are served via `OfflineTransform` - e.g., `hpull://`
+// ETLs do not redirect clients to their transformers, as far as caching is enabled.
+
+type (
+	tcache struct {
+		lru      *list.List               // front: most recently used
+		m        map[string]*list.Element // FQN => *centry
+		mem      *memsys.MMSA
+		name     string
+		digest   string
+		capacity int64
+		size     int64
+		hits     atomic.Int64
+		misses   atomic.Int64
+		mtx      sync.Mutex
+	}
+	centry struct {
+		hdr  http.Header // transformer's response headers (see cacheHdrs)
+		fqn  string
+		key  string
+		size int64
+	}
+
+	// all initialized ETLs by name - including those with caching disabled (for digests)
+	tcaches struct {
+		m   map[string]*tcache
+		mtx sync.RWMutex
+	}
+
+	// write-behind (into cache) that never fails the reader
+	cacheWriter struct {
+		fh     *os.File
+		limit  int64
+		n      int64
+		failed bool
+	}
+)
+
+var caches = &tcaches{m: make(map[string]*tcache, 4)}
+
+// response headers that are not cached (hop-by-hop and those describing the
+// transformer's own response)
+var skipHdrs = cos.NewStrSet(cos.HdrContentLength, "Transfer-Encoding", "Connection", "Keep-Alive", "Date",
+	cos.HdrContentRange, cos.HdrAcceptRanges)
+
+// InitCache (re)initializes transform cache of the (just started) ETL
+func InitCache(t cluster.Target, msg InitMsg) error {
+	b, err := jsoniter.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	tc := &tcache{
+		name:     msg.Name(),
+		digest:   strconv.FormatUint(xxhash.Checksum64S(b, cos.MLCG32), 16),
+		capacity: int64(cacheSize(msg)),
+		mem:      t.PageMM(),
+	}
+	if tc.capacity > 0 {
+		tc.lru = list.New()
+		tc.m = make(map[string]*list.Element, 64)
+	}
+	wipeCache(t, tc.name)
+	caches.mtx.Lock()
+	caches.m[tc.name] = tc
+	caches.mtx.Unlock()
+	return nil
+}
+
+// OnlineTransform serves the transformation from the cache, if enabled and present;
+// otherwise, executes the Communicator's OnlineTransform
+func OnlineTransform(c Communicator, w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName, args string) error {
+	tc := caches.get(c.Name())
+	if tc == nil || tc.capacity == 0 {
+		return c.OnlineTransform(w, r, bck, objName, args)
+	}
+	return tc.transform(c, w, r, bck, objName, args)
+}
+
+// DropCached removes cached transformations of the object that is being deleted
+// or overwritten
+func DropCached(lom *cluster.LOM) {
+	caches.mtx.RLock()
+	for _, tc := range caches.m {
+		if tc.capacity > 0 {
+			tc.drop(fs.CSM.Gen(lom, fs.ETLCacheType, tc.name))
+		}
+	}
+	caches.mtx.RUnlock()
+}
+
+func cacheSize(msg InitMsg) cos.SizeIEC {
+	switch msg := msg.(type) {
+	case *InitSpecMsg:
+		return msg.CacheSize
+	case *InitCodeMsg:
+		return msg.CacheSize
+	case *PipelineMsg:
+		return msg.CacheSize
+	}
+	return 0
+}
+
+func dropCache(t cluster.Target, name string) {
+	caches.mtx.Lock()
+	delete(caches.m, name)
+	caches.mtx.Unlock()
+	wipeCache(t, name)
+}
+
+// remove cached content across all buckets and mountpaths
+func wipeCache(t cluster.Target, name string) {
+	avail, _ := fs.Get()
+	t.Bowner().Get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		for _, mi := range avail {
+			dir := mi.MakePathFQN(bck.Bucket(), fs.ETLCacheType, name)
+			if err := os.RemoveAll(dir); err != nil {
+				glog.Errorf("etl[%s]: failed to remove cached content %q: %v", name, dir, err)
+			}
+		}
+		return false
+	})
+}
+
+/////////////
+// tcaches //
+/////////////
+
+func (cs *tcaches) get(name string) (tc *tcache) {
+	cs.mtx.RLock()
+	tc = cs.m[name]
+	cs.mtx.RUnlock()
+	return
+}
+
+func (cs *tcaches) digest(name string) string {
+	if tc := cs.get(name); tc != nil {
+		return tc.digest
+	}
+	return ""
+}
+
+////////////
+// tcache //
+////////////
+
+func (tc *tcache) transform(c Communicator, w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName, args string) error {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		return err
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			return c.OnlineTransform(w, r, bck, objName, args)
+		}
+		return err
+	}
+	if lom.IsEncrypted() {
+		return c.OnlineTransform(w, r, bck, objName, args)
+	}
+	var (
+		key = tc.key(c, lom, args)
+		fqn = fs.CSM.Gen(lom, fs.ETLCacheType, tc.name)
+	)
+	if hit, err := tc.serve(w, fqn, key); hit {
+		tc.hits.Inc()
+		return err
+	}
+	tc.misses.Inc()
+
+	reader, err := c.OfflineTransform(bck, objName, args, 0 /*timeout*/)
+	if err != nil {
+		return err
+	}
+	return tc.store(w, lom, fqn, key, reader)
+}
+
+// digest(s) | version | checksum [| args]
+func (tc *tcache) key(c Communicator, lom *cluster.LOM, args string) string {
+	var sb strings.Builder
+	sb.WriteString(tc.digest)
+	if pc, ok := c.(*pipelineComm); ok {
+		for i := range pc.stages {
+			sb.WriteByte(',')
+			sb.WriteString(caches.digest(pc.stages[i].Name))
+		}
+	}
+	sb.WriteByte('|')
+	sb.WriteString(lom.Version())
+	if cksum := lom.Checksum(); cksum != nil {
+		sb.WriteByte('|')
+		sb.WriteString(cksum.Value())
+	}
+	if args != "" {
+		sb.WriteByte('|')
+		sb.WriteString(args)
+	}
+	return sb.String()
+}
+
+func (tc *tcache) serve(w http.ResponseWriter, fqn, key string) (hit bool, err error) {
+	tc.mtx.Lock()
+	el, ok := tc.m[fqn]
+	if !ok {
+		tc.mtx.Unlock()
+		return
+	}
+	e := el.Value.(*centry)
+	if e.key != key {
+		tc.remove(el) // stale
+		tc.mtx.Unlock()
+		cos.RemoveFile(fqn)
+		return
+	}
+	tc.lru.MoveToFront(el)
+	size, hdr := e.size, e.hdr
+	tc.mtx.Unlock()
+
+	fh, errO := os.Open(fqn)
+	if errO != nil {
+		// e.g., evicted in the meantime, or mountpath (or bucket) removed
+		tc.mtx.Lock()
+		if el, ok := tc.m[fqn]; ok {
+			tc.remove(el)
+		}
+		tc.mtx.Unlock()
+		return
+	}
+	hit = true
+	for k, v := range hdr {
+		w.Header()[k] = v
+	}
+	w.Header().Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+	buf, slab := tc.mem.AllocSize(size)
+	_, err = io.CopyBuffer(w, fh, buf)
+	slab.Free(buf)
+	cos.Close(fh)
+	return
+}
+
+// send transformed object and, at the same time, write it into the cache
+func (tc *tcache) store(w http.ResponseWriter, lom *cluster.LOM, fqn, key string, reader cos.ReadCloseSizer) error {
+	var (
+		size    = reader.Size()
+		hdr     = cacheHdrs(respHeader(reader))
+		workFQN = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileETLCache)
+		cw      = &cacheWriter{limit: tc.capacity}
+		src     io.Reader
+		err     error
+	)
+	for k, v := range hdr {
+		w.Header()[k] = v
+	}
+	if size >= 0 {
+		w.Header().Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+	}
+	if size > tc.capacity {
+		src = reader // won't fit
+	} else if cw.fh, err = cos.CreateFile(workFQN); err != nil {
+		glog.Errorf("etl[%s]: failed to create cache entry for %s: %v", tc.name, lom, err)
+		src = reader
+	} else {
+		src = io.TeeReader(reader, cw)
+	}
+	if size < 0 {
+		size = memsys.DefaultBufSize
+	}
+	buf, slab := tc.mem.AllocSize(size)
+	_, err = io.CopyBuffer(w, src, buf)
+	slab.Free(buf)
+	cos.Close(reader)
+
+	if cw.fh == nil {
+		return err
+	}
+	errC := cw.fh.Close()
+	if err != nil || errC != nil || cw.failed {
+		cos.RemoveFile(workFQN)
+		return err
+	}
+	if errR := cos.Rename(workFQN, fqn); errR != nil {
+		glog.Errorf("etl[%s]: failed to add cache entry for %s: %v", tc.name, lom, errR)
+		cos.RemoveFile(workFQN)
+		return nil
+	}
+	tc.add(fqn, key, hdr, cw.n)
+	return nil
+}
+
+func cacheHdrs(hdr http.Header) (out http.Header) {
+	for k, v := range hdr {
+		if skipHdrs.Contains(k) {
+			continue
+		}
+		if out == nil {
+			out = make(http.Header, len(hdr))
+		}
+		out[k] = v
+	}
+	return
+}
+
+func (tc *tcache) add(fqn, key string, hdr http.Header, size int64) {
+	var evicted []string
+	tc.mtx.Lock()
+	if el, ok := tc.m[fqn]; ok {
+		e := el.Value.(*centry)
+		tc.size -= e.size
+		e.key, e.hdr, e.size = key, hdr, size
+		tc.lru.MoveToFront(el)
+	} else {
+		tc.m[fqn] = tc.lru.PushFront(&centry{fqn: fqn, key: key, hdr: hdr, size: size})
+	}
+	tc.size += size
+	for tc.size > tc.capacity {
+		el := tc.lru.Back()
+		if el == nil {
+			break
+		}
+		evicted = append(evicted, tc.remove(el))
+	}
+	tc.mtx.Unlock()
+
+	for _, fqn := range evicted {
+		if err := cos.RemoveFile(fqn); err != nil {
+			glog.Errorf("etl[%s]: failed to evict %q: %v", tc.name, fqn, err)
+		}
+	}
+}
+
+func (tc *tcache) drop(fqn string) {
+	tc.mtx.Lock()
+	el, ok := tc.m[fqn]
+	if ok {
+		tc.remove(el)
+	}
+	tc.mtx.Unlock()
+	if ok {
+		if err := cos.RemoveFile(fqn); err != nil {
+			glog.Errorf("etl[%s]: failed to remove %q: %v", tc.name, fqn, err)
+		}
+	}
+}
+
+// (under lock)
+func (tc *tcache) remove(el *list.Element) string {
+	e := tc.lru.Remove(el).(*centry)
+	delete(tc.m, e.fqn)
+	tc.size -= e.size
+	return e.fqn
+}
+
+func (tc *tcache) stats(info *Info) {
+	if tc.capacity == 0 {
+		return
+	}
+	info.CacheHits = tc.hits.Load()
+	info.CacheMisses = tc.misses.Load()
+	tc.mtx.Lock()
+	info.CacheUsed = tc.size
+	tc.mtx.Unlock()
+}
+
+/////////////////
+// cacheWriter //
+/////////////////
+
+func (cw *cacheWriter) Write(b []byte) (int, error) {
+	if cw.failed {
+		return len(b), nil
+	}
+	if cw.n+int64(len(b)) > cw.limit {
+		cw.failed = true // too big
+		return len(b), nil
+	}
+	n, err := cw.fh.Write(b)
+	cw.n += int64(n)
+	if err != nil {
+		cw.failed = true
+	}
+	return len(b), nil
+}
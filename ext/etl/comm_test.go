@@ -5,8 +5,12 @@
 package etl
 
 import (
+	"container/list"
+	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -18,6 +22,7 @@ import (
 	"github.com/NVIDIA/aistore/cluster/mock"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/etl/runtime"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/tools/cryptorand"
 	. "github.com/onsi/ginkgo"
@@ -83,7 +88,7 @@ var _ = Describe("CommunicatorTest", func() {
 			Expect(err).NotTo(HaveOccurred())
 		}))
 		targetServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			err := comm.OnlineTransform(w, r, clusterBck, objName)
+			err := comm.OnlineTransform(w, r, clusterBck, objName, "")
 			Expect(err).NotTo(HaveOccurred())
 		}))
 		proxyServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -133,8 +138,314 @@ var _ = Describe("CommunicatorTest", func() {
 			Expect(b).To(Equal(transformData))
 		})
 	}
+
+	It("should perform transformation "+HpushStdin+" (local process)", func() {
+		xctn := mock.NewXact(apc.ActETLInline)
+		comm = &stdioComm{
+			baseComm: baseComm{t: tMock, xctn: xctn, commType: HpushStdin},
+			argv:     []string{"cat"},
+			env:      os.Environ(),
+		}
+		resp, err := http.Get(proxyServer.URL)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(len(b)).To(Equal(int(dataSize)))
+
+		lom := &cluster.LOM{ObjName: objName}
+		Expect(lom.InitBck(clusterBck.Bucket())).NotTo(HaveOccurred())
+		orig, err := os.ReadFile(lom.FQN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(orig))
+	})
+
+	It("should proxy rather than redirect "+Hpull+" (local process)", func() {
+		pod := &corev1.Pod{}
+		pod.SetName("somename")
+
+		boot := &etlBootstrapper{
+			errCtx: &cmn.ETLErrCtx{},
+			t:      tMock,
+			msg:    InitSpecMsg{InitMsgBase: InitMsgBase{CommTypeX: Hpull}},
+			pod:    pod,
+			xctn:   mock.NewXact(apc.ActETLInline),
+		}
+		spec := localSpec{}
+		Expect(boot.localListen(&spec)).NotTo(HaveOccurred())
+		defer spec.lsock.Close()
+		Expect(boot.uri).To(HavePrefix("http://127.0.0.1:"))
+		Expect(spec.env).To(ContainElement(LocalEnvListenFD + "=3"))
+
+		// play transformer: serve on the inherited socket
+		l, err := net.FileListener(spec.lsock)
+		Expect(err).NotTo(HaveOccurred())
+		defer l.Close()
+		go http.Serve(l, transformerServer.Config.Handler)
+
+		comm = makeCommunicator(commArgs{bootstrapper: boot})
+		Expect(comm).To(BeAssignableToTypeOf(&revProxyComm{}))
+
+		resp, err := http.Get(proxyServer.URL)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(transformData))
+	})
+
+	It("should pass per-request arguments to "+HpushStdin+" (local process)", func() {
+		const args = "--suffix=42"
+		c := &stdioComm{
+			baseComm: baseComm{t: tMock, xctn: mock.NewXact(apc.ActETLInline), commType: HpushStdin},
+			argv:     []string{"sh", "-c", "cat; printf %s \"$" + LocalEnvArgs + "\""},
+			env:      os.Environ(),
+		}
+		w := httptest.NewRecorder()
+		Expect(c.OnlineTransform(w, nil, clusterBck, objName, args)).NotTo(HaveOccurred())
+
+		lom := &cluster.LOM{ObjName: objName}
+		Expect(lom.InitBck(clusterBck.Bucket())).NotTo(HaveOccurred())
+		orig, err := os.ReadFile(lom.FQN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(w.Body.Bytes()).To(Equal(append(orig, args...)))
+
+		r, err := c.OfflineTransform(clusterBck, objName, "", 0)
+		Expect(err).NotTo(HaveOccurred())
+		b, err := io.ReadAll(r)
+		r.Close()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(orig))
+
+		Expect(ValidateArgs(string(make([]byte, MaxArgsSize+1)))).To(HaveOccurred())
+	})
+
+	It("should perform transformation via in-process WebAssembly module", func() {
+		code, err := base64.StdEncoding.DecodeString(wasmUpper)
+		Expect(err).NotTo(HaveOccurred())
+		msg := &InitCodeMsg{Code: code, Runtime: runtime.Wasm}
+		msg.Funcs.Transform = "upper"
+		Expect(msg.Validate()).To(HaveOccurred()) // no name
+
+		msg.IDX = "wasm-upper"
+		Expect(msg.Validate()).NotTo(HaveOccurred())
+		Expect(msg.CommTypeX).To(Equal(HpushStdin))
+
+		wc, err := newWasmComm(msg)
+		Expect(err).NotTo(HaveOccurred())
+		wc.baseComm = baseComm{t: tMock, xctn: mock.NewXact(apc.ActETLInline), name: msg.IDX, commType: HpushStdin}
+		defer wc.rt.Close(context.Background())
+		comm = wc
+
+		lom := &cluster.LOM{ObjName: objName}
+		Expect(lom.InitBck(clusterBck.Bucket())).NotTo(HaveOccurred())
+		orig, err := os.ReadFile(lom.FQN)
+		Expect(err).NotTo(HaveOccurred())
+		expected := make([]byte, len(orig))
+		for i, c := range orig {
+			if c >= 'a' && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			expected[i] = c
+		}
+
+		resp, err := http.Get(proxyServer.URL)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(expected))
+
+		r, err := wc.OfflineTransform(clusterBck, objName, "", 0)
+		Expect(err).NotTo(HaveOccurred())
+		b, err = io.ReadAll(r)
+		r.Close()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(expected))
+	})
+
+	It("should enforce WebAssembly module limits", func() {
+		code, err := base64.StdEncoding.DecodeString(wasmUpper)
+		Expect(err).NotTo(HaveOccurred())
+		msg := &InitCodeMsg{InitMsgBase: InitMsgBase{IDX: "wasm-spin"}, Code: code, Runtime: runtime.Wasm}
+		msg.Funcs.Transform = "missing"
+		_, err = newWasmComm(msg)
+		Expect(err).To(HaveOccurred())
+
+		msg.Funcs.Transform = "spin"
+		msg.Limits.ExecTimeout = cos.Duration(100 * time.Millisecond)
+		wc, err := newWasmComm(msg)
+		Expect(err).NotTo(HaveOccurred())
+		wc.baseComm = baseComm{t: tMock, xctn: mock.NewXact(apc.ActETLInline), name: msg.IDX, commType: HpushStdin}
+		defer wc.rt.Close(context.Background())
+
+		w := httptest.NewRecorder()
+		Expect(wc.OnlineTransform(w, nil, clusterBck, objName, "")).To(HaveOccurred())
+
+		msg.Funcs.Transform = "upper"
+		msg.Limits.MemSize = cos.SizeIEC(cos.GiB * 5)
+		Expect(msg.Validate()).To(HaveOccurred())
+	})
+
+	It("should cache transformations", func() {
+		_ = fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+		_ = fs.CSM.Reg(fs.ETLCacheType, &fs.ETLCacheContentResolver{})
+
+		const name = "cached-etl"
+		c := &stdioComm{
+			baseComm: baseComm{t: tMock, xctn: mock.NewXact(apc.ActETLInline), name: name, commType: HpushStdin},
+			argv:     []string{"cat"},
+			env:      os.Environ(),
+		}
+		msg := &InitSpecMsg{InitMsgBase: InitMsgBase{IDX: name, CommTypeX: HpushStdin, CacheSize: cos.SizeIEC(2 * dataSize)}}
+		Expect(InitCache(tMock, msg)).NotTo(HaveOccurred())
+		defer dropCache(tMock, name)
+
+		lom := &cluster.LOM{ObjName: objName}
+		Expect(lom.InitBck(clusterBck.Bucket())).NotTo(HaveOccurred())
+		get := func() []byte {
+			w := httptest.NewRecorder()
+			Expect(OnlineTransform(c, w, nil, clusterBck, objName, "")).NotTo(HaveOccurred())
+			return w.Body.Bytes()
+		}
+		info := func() (info Info) {
+			caches.get(name).stats(&info)
+			return
+		}
+
+		orig, err := os.ReadFile(lom.FQN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(get()).To(Equal(orig))
+		Expect(info().CacheMisses).To(Equal(int64(1)))
+		Expect(info().CacheUsed).To(Equal(dataSize))
+
+		Expect(get()).To(Equal(orig))
+		Expect(info().CacheHits).To(Equal(int64(1)))
+
+		// overwrite
+		Expect(createRandomFile(lom.FQN, dataSize)).NotTo(HaveOccurred())
+		Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+		lom.SetCksum(cos.NewCksum(cos.ChecksumXXHash, "0123456789abcdef"))
+		Expect(lom.Persist()).NotTo(HaveOccurred())
+		lom.Uncache(true /*delDirty*/)
+
+		updated, err := os.ReadFile(lom.FQN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(get()).To(Equal(updated))
+		Expect(info().CacheMisses).To(Equal(int64(2)))
+		Expect(info().CacheUsed).To(Equal(dataSize))
+
+		// delete (or overwrite via PUT)
+		DropCached(lom)
+		Expect(info().CacheUsed).To(BeZero())
+		_, err = os.Stat(fs.CSM.Gen(lom, fs.ETLCacheType, name))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+		Expect(get()).To(Equal(updated))
+		Expect(info().CacheMisses).To(Equal(int64(3)))
+
+		// re-init
+		Expect(InitCache(tMock, msg)).NotTo(HaveOccurred())
+		Expect(info().CacheUsed).To(BeZero())
+		_, err = os.Stat(fs.CSM.Gen(lom, fs.ETLCacheType, name))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("should serve cached transformer's headers", func() {
+		var (
+			fqn = filepath.Join(tmpDir, "cached")
+			tc  = &tcache{name: "hdr-etl", capacity: 10, lru: list.New(), m: make(map[string]*list.Element), mem: tMock.PageMM()}
+			hdr = cacheHdrs(http.Header{
+				cos.HdrContentType:   []string{"image/jpeg"},
+				cos.HdrContentLength: []string{"100"},
+				"X-Custom":           []string{"abc"},
+			})
+		)
+		Expect(hdr).NotTo(HaveKey(cos.HdrContentLength))
+		Expect(os.WriteFile(fqn, []byte("data"), 0o644)).NotTo(HaveOccurred())
+		tc.add(fqn, "k", hdr, 4)
+
+		w := httptest.NewRecorder()
+		hit, err := tc.serve(w, fqn, "k")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hit).To(BeTrue())
+		Expect(w.Header().Get(cos.HdrContentType)).To(Equal("image/jpeg"))
+		Expect(w.Header().Get("X-Custom")).To(Equal("abc"))
+		Expect(w.Header().Get(cos.HdrContentLength)).To(Equal("4"))
+		Expect(w.Body.String()).To(Equal("data"))
+	})
+
+	It("should evict least recently used transformations", func() {
+		tc := &tcache{name: "lru-etl", capacity: 10, lru: list.New(), m: make(map[string]*list.Element)}
+		tc.add("a", "k", nil, 4)
+		tc.add("b", "k", nil, 4)
+		tc.lru.MoveToFront(tc.m["a"])
+		tc.add("c", "k", nil, 4)
+		Expect(tc.m).To(HaveKey("a"))
+		Expect(tc.m).NotTo(HaveKey("b"))
+		Expect(tc.m).To(HaveKey("c"))
+		Expect(tc.size).To(Equal(int64(8)))
+	})
+
+	It("should perform transformation via "+Pipeline+" of local processes", func() {
+		const suffix = "-pipeline"
+		for _, stage := range []struct {
+			name string
+			argv []string
+		}{
+			{"stage-cat", []string{"cat"}},
+			{"stage-append", []string{"sh", "-c", "cat; printf %s \"$" + LocalEnvArgs + "\""}},
+		} {
+			c := &stdioComm{
+				baseComm: baseComm{t: tMock, xctn: mock.NewXact(apc.ActETLInline), name: stage.name, commType: HpushStdin},
+				argv:     stage.argv,
+				env:      os.Environ(),
+			}
+			Expect(reg.add(stage.name, c)).NotTo(HaveOccurred())
+			defer reg.del(stage.name)
+		}
+		pc := &pipelineComm{
+			baseComm: baseComm{t: tMock, xctn: mock.NewXact(apc.ActETLInline), name: "pipeline", commType: Pipeline},
+			mem:      tMock.PageMM(),
+			stages:   make([]pipelineStage, 2),
+		}
+		pc.stages[0].PipelineStage = PipelineStage{Name: "stage-cat"}
+		pc.stages[1].PipelineStage = PipelineStage{Name: "stage-append", Args: suffix}
+		comm = pc
+
+		resp, err := http.Get(proxyServer.URL)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		lom := &cluster.LOM{ObjName: objName}
+		Expect(lom.InitBck(clusterBck.Bucket())).NotTo(HaveOccurred())
+		orig, err := os.ReadFile(lom.FQN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(append(orig, suffix...)))
+
+		stats := pc.stats()
+		Expect(stats).To(HaveLen(2))
+		for _, stage := range stats {
+			Expect(stage.ObjCount).To(Equal(int64(1)))
+			Expect(stage.ErrCount).To(BeZero())
+		}
+		Expect(stats[0].InBytes).To(Equal(dataSize))
+		Expect(stats[1].OutBytes).To(Equal(dataSize + int64(len(suffix))))
+	})
 })
 
+// WASI module (base64) exporting two functions:
+// - "upper": copy stdin to stdout (in 4KiB chunks) converting ASCII lowercase to uppercase;
+// - "spin": loop forever
+const wasmUpper = "AGFzbQEAAAABDAJgBH9/f38Bf2AAAAJEAhZ3YXNpX3NuYXBzaG90X3ByZXZpZXcxB2ZkX3JlYWQAABZ3YXNpX3NuYXBz" +
+	"aG90X3ByZXZpZXcxCGZkX3dyaXRlAAADAwIBAQUDAQABBxkDBm1lbW9yeQIABXVwcGVyAAIEc3BpbgADCo0BAoIBAQN/QQBBEDYC" +
+	"AAJAA0BBBEGAIDYCAEEIQQA2AgBBAEEAQQFBCBAAGkEIKAIAIQAgAEUNAUEAIQECQANAIAEgAE8NASABLQAQIQIgAkHhAGtBGkkE" +
+	"QCABIAJBIGs6ABALIAFBAWohAQwACwtBBCAANgIAQQFBAEEBQQgQARoMAAsLCwcAA0AMAAsL"
+
 // Creates a file with random content.
 func createRandomFile(fileName string, size int64) error {
 	b := make([]byte, size)
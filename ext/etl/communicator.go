@@ -47,13 +47,20 @@ type (
 		// OnlineTransform uses one of the two ETL container endpoints:
 		//  - Method "PUT", Path "/"
 		//  - Method "GET", Path "/bucket/object"
-		OnlineTransform(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string) error
+		// `args` (optional) are opaque transformer-specific arguments
+		// (see `apc.HdrETLArgs`, `apc.QparamETLArgs`, `LocalEnvArgs`)
+		OnlineTransform(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName, args string) error
 
 		// OfflineTransform interface implementations realize offline ETL.
 		// OfflineTransform is driven by `OfflineDP` - not to confuse
 		// with GET requests from users (such as training models and apps)
 		// to perform on-the-fly transformation.
-		OfflineTransform(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error)
+		OfflineTransform(bck *cluster.Bck, objName, args string, timeout time.Duration) (cos.ReadCloseSizer, error)
+
+		// stream transforms an arbitrary (in-memory) stream - pipeline stage
+		// (see pipeline.go); takes ownership of `src` and closes it in all cases.
+		stream(src cos.ReadCloseSizer, bck *cluster.Bck, objName, args string, timeout time.Duration) (cos.ReadCloseSizer, error)
+
 		Stop()
 
 		CommStats
@@ -68,6 +75,8 @@ type (
 		cluster.Slistener
 		t        cluster.Target
 		xctn     cluster.Xact
+		client   *http.Client
+		proc     *localProc // local (non-K8s) runtime only
 		name     string
 		podName  string
 		commType string
@@ -89,6 +98,12 @@ type (
 		uri string
 	}
 
+	// transformer's response: body and headers (see tcache.store)
+	respReader struct {
+		*cos.ReaderWithArgs
+		hdr http.Header
+	}
+
 	// TODO: Generalize and move to `cos` package
 	cbWriter struct {
 		w       io.Writer
@@ -116,6 +131,11 @@ func makeCommunicator(args commArgs) Communicator {
 		name:      args.bootstrapper.originalPodName,
 		podName:   args.bootstrapper.pod.Name,
 		xctn:      args.bootstrapper.xctn,
+		client:    args.bootstrapper.client,
+		proc:      args.bootstrapper.proc,
+	}
+	if baseComm.client == nil {
+		baseComm.client = args.bootstrapper.t.DataClient()
 	}
 
 	switch args.bootstrapper.msg.CommTypeX {
@@ -128,24 +148,14 @@ func makeCommunicator(args commArgs) Communicator {
 		}
 	case Hpull:
 		baseComm.commType = Hpull
+		if args.bootstrapper.local {
+			// local transformer is not reachable from outside - proxy rather than redirect
+			return newRevProxyComm(baseComm, args.bootstrapper.uri)
+		}
 		return &redirectComm{baseComm: baseComm, uri: args.bootstrapper.uri}
 	case Hrev:
-		transformerURL, err := url.Parse(args.bootstrapper.uri)
-		debug.AssertNoErr(err)
-		rp := &httputil.ReverseProxy{
-			Director: func(req *http.Request) {
-				// Replacing the `req.URL` host with ETL container host
-				req.URL.Scheme = transformerURL.Scheme
-				req.URL.Host = transformerURL.Host
-				req.URL.RawQuery = pruneQuery(req.URL.RawQuery)
-				if _, ok := req.Header["User-Agent"]; !ok {
-					// Explicitly disable `User-Agent` so it's not set to default value.
-					req.Header.Set("User-Agent", "")
-				}
-			},
-		}
 		baseComm.commType = Hrev
-		return &revProxyComm{baseComm: baseComm, rp: rp, uri: args.bootstrapper.uri}
+		return newRevProxyComm(baseComm, args.bootstrapper.uri)
 	case HpushStdin:
 		baseComm.commType = HpushStdin
 		return &pushComm{
@@ -174,7 +184,12 @@ func (c *baseComm) ObjCount() int64    { return c.xctn.Objs() }
 func (c *baseComm) InBytes() int64     { return c.xctn.InBytes() }
 func (c *baseComm) OutBytes() int64    { return c.xctn.OutBytes() }
 
-func (c *baseComm) Stop() { c.xctn.Finish(nil) }
+func (c *baseComm) Stop() {
+	if c.proc != nil {
+		c.proc.stop()
+	}
+	c.xctn.Finish(nil)
+}
 
 func (c *baseComm) getWithTimeout(url string, size int64, timeout time.Duration, tag string) (r cos.ReadCloseSizer, err error) {
 	if err := c.xctn.AbortErr(); err != nil {
@@ -196,7 +211,7 @@ func (c *baseComm) getWithTimeout(url string, size int64, timeout time.Duration,
 	if err != nil {
 		goto finish
 	}
-	resp, err = c.t.DataClient().Do(req) //nolint:bodyclose // Closed by the caller.
+	resp, err = c.client.Do(req) //nolint:bodyclose // Closed by the caller.
 finish:
 	if err != nil {
 		if cancel != nil {
@@ -205,7 +220,7 @@ finish:
 		return nil, err
 	}
 
-	return cos.NewReaderWithArgs(cos.ReaderArgs{
+	return &respReader{cos.NewReaderWithArgs(cos.ReaderArgs{
 		R:      resp.Body,
 		Size:   resp.ContentLength,
 		ReadCb: func(n int, err error) { c.xctn.InObjsAdd(0, int64(n)) },
@@ -216,14 +231,14 @@ finish:
 			c.xctn.InObjsAdd(1, 0)
 			c.xctn.OutObjsAdd(1, size) // see also: `coi.objsAdd`
 		},
-	}), nil
+	}), resp.Header}, nil
 }
 
 //////////////
 // pushComm //
 //////////////
 
-func (pc *pushComm) doRequest(bck *cluster.Bck, objName string, timeout time.Duration) (r cos.ReadCloseSizer, err error) {
+func (pc *pushComm) doRequest(bck *cluster.Bck, objName, args string, timeout time.Duration) (r cos.ReadCloseSizer, err error) {
 	lom := cluster.AllocLOM(objName)
 	defer cluster.FreeLOM(lom)
 
@@ -231,18 +246,18 @@ func (pc *pushComm) doRequest(bck *cluster.Bck, objName string, timeout time.Dur
 		return nil, err
 	}
 
-	r, err = pc.tryDoRequest(lom, timeout)
+	r, err = pc.tryDoRequest(lom, args, timeout)
 	if err != nil && cmn.IsObjNotExist(err) && bck.IsRemote() {
 		_, err = pc.t.GetCold(context.Background(), lom, cmn.OwtGetLock)
 		if err != nil {
 			return nil, err
 		}
-		r, err = pc.tryDoRequest(lom, timeout)
+		r, err = pc.tryDoRequest(lom, args, timeout)
 	}
 	return
 }
 
-func (pc *pushComm) tryDoRequest(lom *cluster.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+func (pc *pushComm) tryDoRequest(lom *cluster.LOM, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
 	if err := pc.xctn.AbortErr(); err != nil {
 		return nil, cmn.NewErrAborted(pc.String(), "do", err)
 	}
@@ -256,26 +271,31 @@ func (pc *pushComm) tryDoRequest(lom *cluster.LOM, timeout time.Duration) (cos.R
 	size := lom.SizeBytes()
 
 	// `fh` is closed by Do(req).
-	fh, err := cos.NewFileHandle(lom.FQN)
+	fh, err := lom.Open(lom.FQN)
 	if err != nil {
 		return nil, err
 	}
+	return pc.put(fh, size, lom.Bck().Name, lom.ObjName, args, timeout)
+}
 
+// PUT `body` to the transformer; `body` is always closed
+func (pc *pushComm) put(body io.ReadCloser, size int64, bckName, objName, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
 	var (
 		req    *http.Request
 		resp   *http.Response
 		cancel func()
-		url    = pc.uri + "/" + lom.Bck().Name + "/" + lom.ObjName
+		err    error
+		url    = pc.uri + "/" + bckName + "/" + objName
 	)
 	if timeout != 0 {
 		var ctx context.Context
 		ctx, cancel = context.WithTimeout(context.Background(), timeout)
-		req, err = http.NewRequestWithContext(ctx, http.MethodPut, url, fh)
+		req, err = http.NewRequestWithContext(ctx, http.MethodPut, url, body)
 	} else {
-		req, err = http.NewRequest(http.MethodPut, url, fh)
+		req, err = http.NewRequest(http.MethodPut, url, body)
 	}
 	if err != nil {
-		cos.Close(fh)
+		cos.Close(body)
 		goto finish
 	}
 	if len(pc.command) != 0 {
@@ -285,7 +305,10 @@ func (pc *pushComm) tryDoRequest(lom *cluster.LOM, timeout time.Duration) (cos.R
 	}
 	req.ContentLength = size
 	req.Header.Set(cos.HdrContentType, cos.ContentBinary)
-	resp, err = pc.t.DataClient().Do(req) //nolint:bodyclose // Closed by the caller.
+	if args != "" {
+		req.Header.Set(apc.HdrETLArgs, args)
+	}
+	resp, err = pc.client.Do(req) //nolint:bodyclose // Closed by the caller.
 finish:
 	if err != nil {
 		if cancel != nil {
@@ -294,7 +317,7 @@ finish:
 		return nil, err
 	}
 
-	return cos.NewReaderWithArgs(cos.ReaderArgs{
+	return &respReader{cos.NewReaderWithArgs(cos.ReaderArgs{
 		R:      resp.Body,
 		Size:   resp.ContentLength,
 		ReadCb: func(n int, err error) { pc.xctn.InObjsAdd(0, int64(n)) },
@@ -303,15 +326,15 @@ finish:
 				cancel()
 			}
 			pc.xctn.InObjsAdd(1, 0)
-			pc.xctn.OutObjsAdd(1, size) // see also: `coi.objsAdd`
+			pc.xctn.OutObjsAdd(1, cos.MaxI64(size, 0)) // see also: `coi.objsAdd`
 		},
-	}), nil
+	}), resp.Header}, nil
 }
 
-func (pc *pushComm) OnlineTransform(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName string) error {
+func (pc *pushComm) OnlineTransform(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName, args string) error {
 	var (
 		size   int64
-		r, err = pc.doRequest(bck, objName, 0 /*timeout*/)
+		r, err = pc.doRequest(bck, objName, args, 0 /*timeout*/)
 	)
 	if err != nil {
 		return err
@@ -326,15 +349,23 @@ func (pc *pushComm) OnlineTransform(w http.ResponseWriter, _ *http.Request, bck
 	return err
 }
 
-func (pc *pushComm) OfflineTransform(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
-	return pc.doRequest(bck, objName, timeout)
+func (pc *pushComm) OfflineTransform(bck *cluster.Bck, objName, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	return pc.doRequest(bck, objName, args, timeout)
+}
+
+func (pc *pushComm) stream(src cos.ReadCloseSizer, bck *cluster.Bck, objName, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := pc.xctn.AbortErr(); err != nil {
+		cos.Close(src)
+		return nil, cmn.NewErrAborted(pc.String(), "stream", err)
+	}
+	return pc.put(src, src.Size(), bck.Name, objName, args, timeout)
 }
 
 //////////////////
 // redirectComm //
 //////////////////
 
-func (rc *redirectComm) OnlineTransform(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string) error {
+func (rc *redirectComm) OnlineTransform(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName, args string) error {
 	if err := rc.xctn.AbortErr(); err != nil {
 		return cmn.NewErrAborted(rc.String(), "online", err)
 	}
@@ -346,26 +377,50 @@ func (rc *redirectComm) OnlineTransform(w http.ResponseWriter, r *http.Request,
 	rc.xctn.OutObjsAdd(1, size)
 
 	// TODO: Is there way to determine `rc.stats.outBytes`?
-	redirectURL := cos.JoinPath(rc.uri, transformerPath(bck, objName))
+	redirectURL := withArgs(cos.JoinPath(rc.uri, transformerPath(bck, objName)), args)
 	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
 	return nil
 }
 
-func (rc *redirectComm) OfflineTransform(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+func (rc *redirectComm) OfflineTransform(bck *cluster.Bck, objName, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
 	size, err := lomLoad(bck, objName)
 	if err != nil {
 		return nil, err
 	}
 
-	etlURL := cos.JoinPath(rc.uri, transformerPath(bck, objName))
+	etlURL := withArgs(cos.JoinPath(rc.uri, transformerPath(bck, objName)), args)
 	return rc.getWithTimeout(etlURL, size, timeout, "offline" /*tag*/)
 }
 
+func (rc *redirectComm) stream(src cos.ReadCloseSizer, _ *cluster.Bck, _, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	return rc.streamStage(rc.uri, src, args, timeout)
+}
+
 //////////////////
 // revProxyComm //
 //////////////////
 
-func (pc *revProxyComm) OnlineTransform(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string) error {
+func newRevProxyComm(baseComm baseComm, uri string) *revProxyComm {
+	transformerURL, err := url.Parse(uri)
+	debug.AssertNoErr(err)
+	rp := &httputil.ReverseProxy{
+		Transport: baseComm.client.Transport,
+		Director: func(req *http.Request) {
+			// Replacing the `req.URL` host with ETL container host
+			req.URL.Scheme = transformerURL.Scheme
+			req.URL.Host = transformerURL.Host
+			req.URL.RawQuery = pruneQuery(req.URL.RawQuery)
+			if _, ok := req.Header["User-Agent"]; !ok {
+				// Explicitly disable `User-Agent` so it's not set to default value.
+				req.Header.Set("User-Agent", "")
+			}
+		},
+	}
+	return &revProxyComm{baseComm: baseComm, rp: rp, uri: uri}
+}
+
+// NOTE: `args` (if any) are forwarded to the transformer as part of the original request's query
+func (pc *revProxyComm) OnlineTransform(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName, _ string) error {
 	size, err := lomLoad(bck, objName)
 	if err != nil {
 		return err
@@ -380,16 +435,34 @@ func (pc *revProxyComm) OnlineTransform(w http.ResponseWriter, r *http.Request,
 	return nil
 }
 
-func (pc *revProxyComm) OfflineTransform(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+func (pc *revProxyComm) OfflineTransform(bck *cluster.Bck, objName, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
 	size, err := lomLoad(bck, objName)
 	if err != nil {
 		return nil, err
 	}
 
-	etlURL := cos.JoinPath(pc.uri, transformerPath(bck, objName))
+	etlURL := withArgs(cos.JoinPath(pc.uri, transformerPath(bck, objName)), args)
 	return pc.getWithTimeout(etlURL, size, timeout, "offline" /*tag*/)
 }
 
+func (pc *revProxyComm) stream(src cos.ReadCloseSizer, _ *cluster.Bck, _, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	return pc.streamStage(pc.uri, src, args, timeout)
+}
+
+////////////////
+// respReader //
+////////////////
+
+func (r *respReader) Header() http.Header { return r.hdr }
+
+// (nil when the transformer does not respond with headers - e.g., stdio and wasm)
+func respHeader(r cos.ReadCloseSizer) http.Header {
+	if rr, ok := r.(*respReader); ok {
+		return rr.hdr
+	}
+	return nil
+}
+
 //////////////
 // cbWriter //
 //////////////
@@ -423,6 +496,14 @@ func transformerPath(bck *cluster.Bck, objName string) string {
 	return "/" + url.PathEscape(bck.MakeUname(objName))
 }
 
+// pass transformer arguments via query (`hpull`, `hrev`)
+func withArgs(etlURL, args string) string {
+	if args == "" {
+		return etlURL
+	}
+	return etlURL + "?" + url.Values{apc.QparamETLArgs: []string{args}}.Encode()
+}
+
 func lomLoad(bck *cluster.Bck, objName string) (int64, error) {
 	lom := cluster.AllocLOM(objName)
 	defer cluster.FreeLOM(lom)
@@ -26,6 +26,9 @@ type OfflineDP struct {
 var _ cluster.DP = (*OfflineDP)(nil)
 
 func NewOfflineDP(msg *apc.TCBMsg, lsnode *cluster.Snode) (*OfflineDP, error) {
+	if err := ValidateArgs(msg.Transform.Args); err != nil {
+		return nil, err
+	}
 	comm, err := GetCommunicator(msg.Transform.Name, lsnode)
 	if err != nil {
 		return nil, err
@@ -43,7 +46,7 @@ func (dp *OfflineDP) Reader(lom *cluster.LOM) (cos.ReadOpenCloser, cmn.ObjAttrsH
 	)
 	debug.Assert(dp.tcbmsg != nil)
 	call := func() (int, error) {
-		r, err = dp.comm.OfflineTransform(lom.Bck(), lom.ObjName, dp.requestTimeout)
+		r, err = dp.comm.OfflineTransform(lom.Bck(), lom.ObjName, dp.tcbmsg.Transform.Args, dp.requestTimeout)
 		return 0, err
 	}
 	// TODO: Check if ETL pod is healthy and wait some more if not (yet).
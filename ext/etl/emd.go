@@ -102,6 +102,8 @@ func (e *MD) UnmarshalJSON(data []byte) (err error) {
 			e.ETLs[k] = &InitCodeMsg{}
 		case Spec:
 			e.ETLs[k] = &InitSpecMsg{}
+		case Pipeline:
+			e.ETLs[k] = &PipelineMsg{}
 		default:
 			err = fmt.Errorf("invalid InitMsg type %q", v.Type)
 			debug.AssertNoErr(err)
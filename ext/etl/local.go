@@ -0,0 +1,630 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/k8s"
+	"github.com/NVIDIA/aistore/sys"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Local (non-Kubernetes) ETL runtime
+//
+// When the target does not run in Kubernetes and the feature flag `feat.LocalETL` is set
+// (disabled by default), each target starts its ETL transformer as a supervised local
+// subprocess (rather than K8s pod + service):
+//
+// * `InitSpecMsg`: the single container's `command` and `args` get executed on the
+//   target's host, with the container's `env` and the `AIS_ETL_*` variables below;
+//   container image is ignored, readiness probe path is used for health checks.
+// * `InitCodeMsg`: the code (and dependencies) are written into a per-ETL working
+//   directory and executed by the local installation of the respective runtime
+//   (see `LocalEnvRuntimeDir`).
+// * Transformer inherits a loopback TCP socket that is already bound and listening
+//   (file descriptor `AIS_ETL_LISTEN_FD`) - the same socket across restarts; or else,
+//   if the pod spec is annotated with `LocalSocketAnnotation`, it listens on the
+//   `AIS_ETL_SOCKET` Unix socket. In the latter case, a spec without `command` refers
+//   to an already running (long-lived) sidecar that the target only attaches to.
+// * Either way, the transformer is reachable only via its target: `hpull://` requests
+//   are reverse-proxied rather than redirected.
+// * `io://` does not require a server: the target executes the command for each
+//   object, with the object on stdin and the transformed result on stdout.
+//
+// Unexpected process exits are restarted (with backoff) up to `localMaxRestarts` times.
+
+const (
+	LocalEnvListenFD   = "AIS_ETL_LISTEN_FD"   // inherited listening socket the local transformer must accept on
+	LocalEnvSocket     = "AIS_ETL_SOCKET"      // Unix socket the local transformer must listen on
+	LocalEnvRuntimeDir = "AIS_ETL_RUNTIME_DIR" // location of the (python) runtime server, e.g. `server.py`
+	LocalEnvArgs       = "AIS_ETL_ARGS"        // `io://` only: transformer-specific (per-request or pipeline stage) arguments
+
+	// pod spec annotation: run (or attach to) transformer over Unix socket
+	LocalSocketAnnotation = "nvidia.com/ais-etl-socket"
+)
+
+const (
+	localDefaultRuntimeDir = "/opt/aistore/etl/runtime"
+	localWorkDir           = "etl"
+	localLogFile           = "transformer.log"
+	localMaxRestarts       = 3
+	localReadyTimeout      = time.Minute
+	localStopTimeout       = 10 * time.Second
+	localLogsMaxSize       = cos.MiB
+	localListenFD          = 3 // (the first and only `exec.Cmd.ExtraFiles`)
+)
+
+type (
+	localSpec struct {
+		argv      []string
+		env       []string
+		dir       string
+		readyPath string
+		socket    string
+		lsock     *os.File // listening socket inherited by the transformer (TCP only)
+	}
+	localProc struct {
+		spec     localSpec
+		errCtx   *cmn.ETLErrCtx
+		cmd      *exec.Cmd
+		done     chan struct{}
+		onFail   func(err error)
+		logPath  string
+		mtx      sync.Mutex
+		restarts int
+		stopped  bool
+	}
+
+	// `io://` over local subprocess: one execution per object
+	stdioComm struct {
+		baseComm
+		argv []string
+		env  []string
+	}
+)
+
+// interface guard
+var _ Communicator = (*stdioComm)(nil)
+
+func isLocal() bool { return k8s.Detect() != nil }
+
+func localProcOf(c Communicator) *localProc {
+	switch c := c.(type) {
+	case *pushComm:
+		return c.proc
+	case *redirectComm:
+		return c.proc
+	case *revProxyComm:
+		return c.proc
+	}
+	return nil
+}
+
+/////////////////////////
+// local bootstrapping //
+/////////////////////////
+
+func localInitSpec(t cluster.Target, msg *InitSpecMsg, xid string, opts StartOpts) error {
+	errCtx := &cmn.ETLErrCtx{TID: t.SID(), ETLName: msg.IDX}
+	pod, err := ParsePodSpec(errCtx, msg.Spec)
+	if err != nil {
+		return err
+	}
+	container := &pod.Spec.Containers[0]
+	spec := localSpec{
+		argv:   append(append([]string{}, container.Command...), container.Args...),
+		socket: pod.Annotations[LocalSocketAnnotation],
+	}
+	if spec.dir, err = localMakeDir(t, msg.IDX); err != nil {
+		return cmn.NewErrETL(errCtx, err.Error())
+	}
+	if probe := container.ReadinessProbe; probe != nil && probe.HTTPGet != nil {
+		spec.readyPath = probe.HTTPGet.Path
+	}
+	for _, env := range container.Env {
+		spec.env = append(spec.env, env.Name+"="+env.Value)
+	}
+	for k, v := range opts.Env {
+		spec.env = append(spec.env, k+"="+v)
+	}
+	return localStart(t, msg, pod, spec, xid)
+}
+
+// Given `InitCodeMsg`, write the code and its dependencies into the working directory
+// and run them using the local runtime installation (compare with etl/runtime/podspec.yaml)
+func localInitCode(t cluster.Target, msg *InitCodeMsg, xid string) error {
+	errCtx := &cmn.ETLErrCtx{TID: t.SID(), ETLName: msg.IDX}
+	dir, err := localMakeDir(t, msg.IDX)
+	if err != nil {
+		return cmn.NewErrETL(errCtx, err.Error())
+	}
+	var (
+		codePath = filepath.Join(dir, "code.py")
+		depsPath = filepath.Join(dir, "requirements.txt")
+		depsDir  = filepath.Join(dir, "runtime")
+		rtDir    = os.Getenv(LocalEnvRuntimeDir)
+	)
+	if rtDir == "" {
+		rtDir = localDefaultRuntimeDir
+	}
+	if err := os.WriteFile(codePath, msg.Code, cos.PermRWR); err != nil {
+		return cmn.NewErrETL(errCtx, err.Error())
+	}
+	if len(msg.Deps) > 0 {
+		if err := os.WriteFile(depsPath, msg.Deps, cos.PermRWR); err != nil {
+			return cmn.NewErrETL(errCtx, err.Error())
+		}
+		out, err := exec.Command("python3", "-m", "pip", "install", "--target="+depsDir, "-r", depsPath).CombinedOutput()
+		if err != nil {
+			return cmn.NewErrETL(errCtx, "failed to install dependencies: %v\n%s", err, out)
+		}
+	}
+	spec := localSpec{
+		dir:       dir,
+		readyPath: "/health",
+		env: []string{
+			"MOD_NAME=code",
+			"FUNC_TRANSFORM=" + msg.Funcs.Transform,
+			"COMM_TYPE=" + msg.CommTypeX,
+			"PYTHONPATH=" + dir + string(os.PathListSeparator) + depsDir,
+		},
+	}
+	if msg.ChunkSize > 0 {
+		spec.env = append(spec.env, "CHUNK_SIZE="+strconv.FormatInt(msg.ChunkSize, 10))
+	}
+	if msg.Flags > 0 {
+		spec.env = append(spec.env, "FLAGS="+strconv.FormatInt(msg.Flags, 10))
+	}
+	if msg.CommTypeX == HpushStdin {
+		spec.argv = []string{"python3", codePath}
+	} else {
+		spec.argv = []string{"python3", filepath.Join(rtDir, "server.py")}
+	}
+	pod := &corev1.Pod{}
+	pod.SetName(msg.IDX)
+	return localStart(t, &InitSpecMsg{InitMsgBase: msg.InitMsgBase}, pod, spec, xid)
+}
+
+// (common for both local `InitCode` and `InitSpec` flows)
+func localStart(t cluster.Target, msg *InitSpecMsg, pod *corev1.Pod, spec localSpec, xid string) (err error) {
+	var (
+		errCtx = &cmn.ETLErrCtx{TID: t.SID(), ETLName: msg.IDX}
+		boot   = &etlBootstrapper{errCtx: errCtx, t: t, msg: *msg, pod: pod}
+	)
+	boot.originalPodName = pod.GetName()
+	pod.SetName(k8s.CleanName(msg.IDX + "-" + t.SID()))
+	errCtx.PodName = pod.GetName()
+	spec.env = append(spec.env, "AIS_TARGET_URL="+t.Snode().URL(cmn.NetPublic)+apc.URLPathETLObject.Join(reqSecret))
+
+	if msg.CommTypeX == HpushStdin {
+		if len(spec.argv) == 0 {
+			return cmn.NewErrETL(errCtx, "%s requires non-empty command", HpushStdin)
+		}
+		boot.setupXaction(xid)
+		c := &stdioComm{
+			baseComm: baseComm{
+				Slistener: newAborter(t, msg.IDX),
+				t:         t,
+				name:      boot.originalPodName,
+				podName:   pod.GetName(),
+				xctn:      boot.xctn,
+				commType:  HpushStdin,
+			},
+			argv: spec.argv,
+			env:  append(os.Environ(), spec.env...),
+		}
+		return localRegister(t, msg.IDX, c)
+	}
+
+	if len(spec.argv) == 0 && spec.socket == "" {
+		return cmn.NewErrETL(errCtx, "empty command requires %q annotation (to attach to a running sidecar)",
+			LocalSocketAnnotation)
+	}
+	if err = boot.localListen(&spec); err != nil {
+		return
+	}
+	if len(spec.argv) > 0 {
+		proc := &localProc{spec: spec, errCtx: errCtx, logPath: filepath.Join(spec.dir, localLogFile)}
+		proc.onFail = func(err error) {
+			if errV := Stop(t, msg.IDX, err); errV != nil {
+				glog.Error(errV)
+			}
+		}
+		if err = proc.start(); err != nil {
+			proc.closeSock()
+			return
+		}
+		boot.proc = proc
+	}
+	if err = boot.localWaitReady(spec.readyPath); err != nil {
+		if boot.proc != nil {
+			boot.proc.stop()
+		}
+		return
+	}
+
+	boot.setupXaction(xid)
+	c := makeCommunicator(commArgs{
+		listener:     newAborter(t, msg.IDX),
+		bootstrapper: boot,
+	})
+	if err = localRegister(t, msg.IDX, c); err != nil {
+		c.Stop()
+	}
+	return
+}
+
+func localRegister(t cluster.Target, name string, c Communicator) error {
+	if err := reg.add(name, c); err != nil {
+		return err
+	}
+	t.Sowner().Listeners().Reg(c)
+	return nil
+}
+
+func localMakeDir(t cluster.Target, name string) (dir string, err error) {
+	dir = filepath.Join(cmn.GCO.Get().ConfigDir, localWorkDir, k8s.CleanName(name+"-"+t.SID()))
+	if err = os.RemoveAll(dir); err != nil {
+		return
+	}
+	err = cos.CreateDir(dir)
+	return
+}
+
+// select transformer's address (TCP or Unix socket) and the corresponding client
+func (b *etlBootstrapper) localListen(spec *localSpec) error {
+	b.local = true
+	if spec.socket != "" {
+		socket := spec.socket
+		b.client = &http.Client{Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		}}
+		b.uri = "http://unix"
+		spec.env = append(spec.env, LocalEnvSocket+"="+socket)
+		return nil
+	}
+	// bind loopback (ephemeral port) and hand over the socket itself - no window
+	// for the port to get taken, and no need for the transformer to report it
+	l, err := net.ListenTCP("tcp", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		return cmn.NewErrETL(b.errCtx, err.Error())
+	}
+	addr := l.Addr().String()
+	spec.lsock, err = l.File()
+	cos.Close(l) // (the duplicate keeps listening)
+	if err != nil {
+		return cmn.NewErrETL(b.errCtx, err.Error())
+	}
+	b.uri = "http://" + addr
+	spec.env = append(spec.env, LocalEnvListenFD+"="+strconv.Itoa(localListenFD))
+	return nil
+}
+
+// poll readiness endpoint until it responds with 200 (compare with `waitPodReady`)
+func (b *etlBootstrapper) localWaitReady(readyPath string) error {
+	var (
+		client   = b.client
+		timeout  = time.Duration(b.msg.Timeout)
+		deadline time.Time
+	)
+	if client == nil {
+		client = b.t.DataClient()
+	}
+	if timeout == 0 {
+		timeout = localReadyTimeout
+	}
+	deadline = time.Now().Add(timeout)
+	for {
+		err := b.localProbe(client, readyPath)
+		if err == nil {
+			return nil
+		}
+		if b.proc != nil && b.proc.exited() {
+			return cmn.NewErrETL(b.errCtx, "transformer exited before becoming ready (see %s)", b.proc.logPath)
+		}
+		if time.Now().After(deadline) {
+			return cmn.NewErrETL(b.errCtx, "transformer not ready after %v: %v", timeout, err)
+		}
+		time.Sleep(time.Second / 2)
+	}
+}
+
+func (b *etlBootstrapper) localProbe(client *http.Client, readyPath string) error {
+	if readyPath == "" {
+		// no readiness endpoint - settle for a successful connect
+		_, err := client.Head(b.uri)
+		return err
+	}
+	resp, err := client.Get(cos.JoinPath(b.uri, readyPath))
+	if err != nil {
+		return err
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("readiness probe %q: status %d", readyPath, resp.StatusCode)
+	}
+	return nil
+}
+
+///////////////
+// localProc //
+///////////////
+
+func (p *localProc) start() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p._start()
+}
+
+func (p *localProc) _start() error {
+	fh, err := os.OpenFile(p.logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, cos.PermRWR)
+	if err != nil {
+		return cmn.NewErrETL(p.errCtx, err.Error())
+	}
+	cmd := exec.Command(p.spec.argv[0], p.spec.argv[1:]...)
+	cmd.Dir = p.spec.dir
+	cmd.Env = append(os.Environ(), p.spec.env...)
+	cmd.Stdout, cmd.Stderr = fh, fh
+	if p.spec.lsock != nil {
+		cmd.ExtraFiles = []*os.File{p.spec.lsock}
+	}
+	if err := cmd.Start(); err != nil {
+		fh.Close()
+		return cmn.NewErrETL(p.errCtx, "failed to start %v: %v", p.spec.argv, err)
+	}
+	p.cmd, p.done = cmd, make(chan struct{})
+	go p.supervise(cmd, fh, p.done)
+	return nil
+}
+
+// wait for the process and restart it (with backoff) upon unexpected exit
+func (p *localProc) supervise(cmd *exec.Cmd, fh *os.File, done chan struct{}) {
+	err := cmd.Wait()
+	fh.Close()
+	close(done)
+
+	p.mtx.Lock()
+	if p.stopped {
+		p.mtx.Unlock()
+		return
+	}
+	if p.restarts >= localMaxRestarts {
+		p.stopped = true
+		p.mtx.Unlock()
+		p.onFail(cmn.NewErrETL(p.errCtx, "transformer exited (%v) and failed to restart %d times",
+			err, localMaxRestarts))
+		return
+	}
+	p.restarts++
+	glog.Warningf("%v: transformer exited (%v), restarting (%d/%d)", p.errCtx, err, p.restarts, localMaxRestarts)
+	p.mtx.Unlock()
+
+	time.Sleep(time.Duration(p.restarts) * time.Second)
+
+	p.mtx.Lock()
+	if !p.stopped {
+		if err := p._start(); err != nil {
+			p.stopped = true
+			p.mtx.Unlock()
+			p.onFail(err)
+			return
+		}
+	}
+	p.mtx.Unlock()
+}
+
+func (p *localProc) exited() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *localProc) stop() {
+	p.mtx.Lock()
+	p.stopped = true
+	cmd, done := p.cmd, p.done
+	p.mtx.Unlock()
+	defer p.closeSock()
+	if cmd == nil || cmd.Process == nil {
+		return
+	}
+	if err := cmd.Process.Signal(os.Interrupt); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		glog.Errorf("%v: %v", p.errCtx, err)
+	}
+	select {
+	case <-done:
+	case <-time.After(localStopTimeout):
+		cmd.Process.Kill()
+		<-done
+	}
+}
+
+// (stopping more than once is fine - so is closing)
+func (p *localProc) closeSock() {
+	if p.spec.lsock != nil {
+		p.spec.lsock.Close()
+	}
+}
+
+func (p *localProc) health() string {
+	if p.exited() {
+		return string(corev1.PodFailed)
+	}
+	return HealthStatusRunning
+}
+
+func (p *localProc) logs() ([]byte, error) {
+	fh, err := os.Open(p.logPath)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	finfo, err := fh.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if off := finfo.Size() - localLogsMaxSize; off > 0 {
+		if _, err := fh.Seek(off, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	return io.ReadAll(fh)
+}
+
+func (p *localProc) metrics() (cpu float64, mem int64, err error) {
+	p.mtx.Lock()
+	cmd := p.cmd
+	p.mtx.Unlock()
+	if cmd == nil || cmd.Process == nil {
+		return 0, 0, fmt.Errorf("%v: transformer is not running", p.errCtx)
+	}
+	stats, err := sys.ProcessStats(cmd.Process.Pid)
+	if err != nil {
+		return 0, 0, err
+	}
+	return stats.CPU.Percent, int64(stats.Mem.Resident), nil
+}
+
+///////////////
+// stdioComm //
+///////////////
+
+func (sc *stdioComm) command(ctx context.Context, lom *cluster.LOM, args string) (cmd *exec.Cmd, size int64, err error) {
+	if err = sc.xctn.AbortErr(); err != nil {
+		return nil, 0, cmn.NewErrAborted(sc.String(), "exec", err)
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err = lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return
+	}
+	fh, err := lom.Open(lom.FQN)
+	if err != nil {
+		return
+	}
+	cmd = sc.newCmd(ctx, args)
+	cmd.Stdin = fh
+	size = lom.SizeBytes()
+	return
+}
+
+// transformer arguments (if any) via `LocalEnvArgs`
+func (sc *stdioComm) newCmd(ctx context.Context, args string) (cmd *exec.Cmd) {
+	cmd = exec.CommandContext(ctx, sc.argv[0], sc.argv[1:]...)
+	cmd.Env = sc.env
+	if args != "" {
+		cmd.Env = append(cmd.Env[:len(cmd.Env):len(cmd.Env)], LocalEnvArgs+"="+args)
+	}
+	return
+}
+
+func (sc *stdioComm) OnlineTransform(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName, args string) error {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		return err
+	}
+	cmd, size, err := sc.command(context.Background(), lom, args)
+	if err != nil {
+		return err
+	}
+	cmd.Stdout = &cbWriter{w: w, writeCb: func(n int) { sc.xctn.InObjsAdd(0, int64(n)) }}
+	err = cmd.Run()
+	cos.Close(cmd.Stdin.(io.Closer))
+	sc.xctn.InObjsAdd(1, 0)
+	sc.xctn.OutObjsAdd(1, size)
+	return err
+}
+
+func (sc *stdioComm) OfflineTransform(bck *cluster.Bck, objName, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	var (
+		ctx    = context.Background()
+		cancel = func() {}
+		lom    = cluster.AllocLOM(objName)
+	)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		return nil, err
+	}
+	if timeout != 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	cmd, size, err := sc.command(ctx, lom, args)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return sc.start(cmd, size, cancel)
+}
+
+func (sc *stdioComm) stream(src cos.ReadCloseSizer, _ *cluster.Bck, _, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := sc.xctn.AbortErr(); err != nil {
+		cos.Close(src)
+		return nil, cmn.NewErrAborted(sc.String(), "stream", err)
+	}
+	var (
+		ctx    = context.Background()
+		cancel = func() {}
+	)
+	if timeout != 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	cmd := sc.newCmd(ctx, args)
+	cmd.Stdin = src
+	return sc.start(cmd, cos.MaxI64(src.Size(), 0), cancel)
+}
+
+// start the command and return its stdout; closes stdin upon completion
+func (sc *stdioComm) start(cmd *exec.Cmd, size int64, cancel func()) (cos.ReadCloseSizer, error) {
+	stdout, err := cmd.StdoutPipe()
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err != nil {
+		cos.Close(cmd.Stdin.(io.Closer))
+		cancel()
+		return nil, err
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:      stdout,
+		Size:   -1,
+		ReadCb: func(n int, err error) { sc.xctn.InObjsAdd(0, int64(n)) },
+		DeferCb: func() {
+			if err := cmd.Wait(); err != nil {
+				glog.Errorf("%s: %v", sc, err)
+			}
+			cos.Close(cmd.Stdin.(io.Closer))
+			cancel()
+			sc.xctn.InObjsAdd(1, 0)
+			sc.xctn.OutObjsAdd(1, size)
+		},
+	}), nil
+}
@@ -0,0 +1,327 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// ETL pipeline
+//
+// A pipeline is an ordered list of already running ETLs (stages). An object
+// streams through the stages on the target that stores it: the output of each
+// stage becomes the input of the next one, without ever touching the disk.
+// Depending on the stage's communication type, the intermediate stream is:
+//
+// * `hpush://`: PUT as request body (stage arguments in `apc.HdrETLArgs` header);
+// * `hpull://`, `hrev://`: registered under a one-time token and fetched by the
+//   transformer from `AIS_TARGET_URL` (stage arguments in `apc.QparamETLArgs` query);
+// * `io://` (local runtime): piped to the command's stdin (stage arguments in
+//   `LocalEnvArgs` environment variable).
+//
+// Stages are resolved by name upon each transformation - stopping a stage ETL
+// makes the pipeline fail until the stage is restarted.
+
+// stage token prefix (see `transformerPath` and `IsStagePath`)
+const stagePrefix = "_stage_"
+
+type (
+	pipelineComm struct {
+		baseComm
+		mem    *memsys.MMSA
+		stages []pipelineStage
+	}
+	pipelineStage struct {
+		PipelineStage
+		objs     atomic.Int64
+		inBytes  atomic.Int64
+		outBytes atomic.Int64
+		errs     atomic.Int64
+	}
+
+	// in-memory intermediate streams awaiting `hpull` and `hrev` transformers
+	stageRegistry struct {
+		m   map[string]*stageEntry
+		mtx sync.Mutex
+	}
+	stageEntry struct {
+		r     cos.ReadCloseSizer
+		taken bool
+	}
+)
+
+// interface guard
+var _ Communicator = (*pipelineComm)(nil)
+
+var stages = &stageRegistry{m: make(map[string]*stageEntry, 4)}
+
+func InitPipeline(t cluster.Target, msg *PipelineMsg, xid string) error {
+	errCtx := &cmn.ETLErrCtx{TID: t.SID(), ETLName: msg.IDX}
+	for _, stage := range msg.Stages {
+		c, exists := reg.get(stage.Name)
+		if !exists {
+			return cmn.NewErrETL(errCtx, "stage %q is not running", stage.Name)
+		}
+		if _, ok := c.(*pipelineComm); ok {
+			return cmn.NewErrETL(errCtx, "stage %q is a pipeline (nested pipelines are not supported)", stage.Name)
+		}
+	}
+	rns := xreg.RenewETL(t, msg, xid)
+	if rns.Err != nil {
+		return cmn.NewErrETL(errCtx, rns.Err.Error())
+	}
+	pc := &pipelineComm{
+		baseComm: baseComm{
+			Slistener: newAborter(t, msg.IDX),
+			t:         t,
+			xctn:      rns.Entry.Get(),
+			name:      msg.IDX,
+			commType:  Pipeline,
+		},
+		mem:    t.PageMM(),
+		stages: make([]pipelineStage, len(msg.Stages)),
+	}
+	for i := range msg.Stages {
+		pc.stages[i].PipelineStage = msg.Stages[i]
+	}
+	if err := reg.add(msg.IDX, pc); err != nil {
+		pc.Stop()
+		return err
+	}
+	t.Sowner().Listeners().Reg(pc)
+	return nil
+}
+
+//////////////////
+// pipelineComm //
+//////////////////
+
+func (pc *pipelineComm) String() string {
+	names := make([]string, 0, len(pc.stages))
+	for i := range pc.stages {
+		names = append(names, pc.stages[i].Name)
+	}
+	return fmt.Sprintf("%s[%s]-%s(%s)", pc.name, pc.xctn.ID(), Pipeline, strings.Join(names, "=>"))
+}
+
+func (pc *pipelineComm) OnlineTransform(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName, args string) error {
+	var (
+		size   int64
+		r, err = pc.transform(bck, objName, args, 0 /*timeout*/)
+	)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if size = r.Size(); size < 0 {
+		size = memsys.DefaultBufSize
+	}
+	buf, slab := pc.mem.AllocSize(size)
+	_, err = io.CopyBuffer(w, r, buf)
+	slab.Free(buf)
+	return err
+}
+
+func (pc *pipelineComm) OfflineTransform(bck *cluster.Bck, objName, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	return pc.transform(bck, objName, args, timeout)
+}
+
+func (pc *pipelineComm) stream(src cos.ReadCloseSizer, _ *cluster.Bck, _, _ string, _ time.Duration) (cos.ReadCloseSizer, error) {
+	cos.Close(src)
+	return nil, fmt.Errorf("%s: nested pipelines are not supported", pc)
+}
+
+// per-request `args` apply to the stages that have no arguments of their own
+func (pc *pipelineComm) transform(bck *cluster.Bck, objName, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := pc.xctn.AbortErr(); err != nil {
+		return nil, cmn.NewErrAborted(pc.String(), "pipeline", err)
+	}
+	src, err := pc.open(bck, objName)
+	if err != nil {
+		return nil, err
+	}
+	size := src.Size()
+
+	var (
+		r   = src
+		hdr http.Header // (the last stage's)
+	)
+	for i := range pc.stages {
+		stage := &pc.stages[i]
+		c, err := GetCommunicator(stage.Name, pc.t.Snode())
+		if err != nil {
+			cos.Close(r)
+			stage.errs.Inc()
+			return nil, err
+		}
+		in := cos.NewReaderWithArgs(cos.ReaderArgs{
+			R:      r,
+			Size:   r.Size(),
+			ReadCb: func(n int, _ error) { stage.inBytes.Add(int64(n)) },
+		})
+		stageArgs := stage.Args
+		if stageArgs == "" {
+			stageArgs = args
+		}
+		out, err := c.stream(in, bck, objName, stageArgs, timeout)
+		if err != nil {
+			stage.errs.Inc()
+			return nil, fmt.Errorf("%s: stage %q: %w", pc, stage.Name, err)
+		}
+		hdr = respHeader(out)
+		r = cos.NewReaderWithArgs(cos.ReaderArgs{
+			R:    out,
+			Size: out.Size(),
+			ReadCb: func(n int, err error) {
+				stage.outBytes.Add(int64(n))
+				if err != nil && !errors.Is(err, io.EOF) {
+					stage.errs.Inc()
+				}
+			},
+			DeferCb: func() { stage.objs.Inc() },
+		})
+	}
+	return &respReader{cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:      r,
+		Size:   r.Size(),
+		ReadCb: func(n int, _ error) { pc.xctn.InObjsAdd(0, int64(n)) },
+		DeferCb: func() {
+			pc.xctn.InObjsAdd(1, 0)
+			pc.xctn.OutObjsAdd(1, size) // see also: `coi.objsAdd`
+		},
+	}), hdr}, nil
+}
+
+// open source object (cold-GET remote object if need be)
+func (pc *pipelineComm) open(bck *cluster.Bck, objName string) (cos.ReadCloseSizer, error) {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		return nil, err
+	}
+	r, err := lomOpen(lom)
+	if err != nil && cmn.IsObjNotExist(err) && bck.IsRemote() {
+		if _, err = pc.t.GetCold(context.Background(), lom, cmn.OwtGetLock); err != nil {
+			return nil, err
+		}
+		r, err = lomOpen(lom)
+	}
+	return r, err
+}
+
+func (pc *pipelineComm) stats() []StageInfo {
+	stats := make([]StageInfo, 0, len(pc.stages))
+	for i := range pc.stages {
+		stage := &pc.stages[i]
+		stats = append(stats, StageInfo{
+			Name:     stage.Name,
+			ObjCount: stage.objs.Load(),
+			InBytes:  stage.inBytes.Load(),
+			OutBytes: stage.outBytes.Load(),
+			ErrCount: stage.errs.Load(),
+		})
+	}
+	return stats
+}
+
+func lomOpen(lom *cluster.LOM) (cos.ReadCloseSizer, error) {
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return nil, err
+	}
+	fh, err := lom.Open(lom.FQN)
+	if err != nil {
+		return nil, err
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{R: fh, Size: lom.SizeBytes()}), nil
+}
+
+///////////////////
+// stageRegistry //
+///////////////////
+
+func (r *stageRegistry) add(src cos.ReadCloseSizer) (token string) {
+	token = stagePrefix + cos.CryptoRandS(16)
+	r.mtx.Lock()
+	r.m[token] = &stageEntry{r: src}
+	r.mtx.Unlock()
+	return
+}
+
+// called by the (pulling) transformer via target's `/v1/etl/_objects/<secret>/<token>`
+func (r *stageRegistry) take(token string) (cos.ReadCloseSizer, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	e, ok := r.m[token]
+	if !ok {
+		return nil, cmn.NewErrNotFound("etl stage %q", token)
+	}
+	if e.taken {
+		return nil, fmt.Errorf("etl stage %q: already read", token)
+	}
+	e.taken = true
+	return e.r, nil
+}
+
+func (r *stageRegistry) size(token string) (int64, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	e, ok := r.m[token]
+	if !ok {
+		return 0, cmn.NewErrNotFound("etl stage %q", token)
+	}
+	return e.r.Size(), nil
+}
+
+// remove upon completion; close the stream if the transformer never asked for it
+func (r *stageRegistry) drop(token string) {
+	r.mtx.Lock()
+	e, ok := r.m[token]
+	delete(r.m, token)
+	r.mtx.Unlock()
+	if ok && !e.taken {
+		cos.Close(e.r)
+	}
+}
+
+// (target) intermediate pipeline streams requested by `hpull` and `hrev` transformers
+func IsStagePath(item string) bool { return strings.HasPrefix(item, stagePrefix) }
+
+func TakeStage(token string) (cos.ReadCloseSizer, error) { return stages.take(token) }
+func StageSize(token string) (int64, error)              { return stages.size(token) }
+
+// (common for `hpull` and `hrev` stages)
+func (c *baseComm) streamStage(uri string, src cos.ReadCloseSizer, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	var (
+		size   = cos.MaxI64(src.Size(), 0)
+		token  = stages.add(src)
+		etlURL = withArgs(cos.JoinPath(uri, "/"+token), args)
+	)
+	r, err := c.getWithTimeout(etlURL, size, timeout, "stage" /*tag*/)
+	if err != nil {
+		stages.drop(token)
+		return nil, err
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:       r,
+		Size:    r.Size(),
+		DeferCb: func() { stages.drop(token) },
+	}), nil
+}
@@ -0,0 +1,57 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PipelineMsg", func() {
+	It("should validate", func() {
+		tests := []struct {
+			msg   PipelineMsg
+			valid bool
+		}{
+			{PipelineMsg{IDX: "my-pipeline", Stages: []PipelineStage{{Name: "decode"}, {Name: "resize", Args: "224x224"}}}, true},
+			{PipelineMsg{IDX: "my-pipeline"}, false},
+			{PipelineMsg{IDX: "My-pipeline", Stages: []PipelineStage{{Name: "decode"}}}, false},
+			{PipelineMsg{IDX: "my-pipeline", Stages: []PipelineStage{{Name: "my-pipeline"}}}, false},
+			{PipelineMsg{IDX: "my-pipeline", Stages: []PipelineStage{{Name: "decode_1"}}}, false},
+			{PipelineMsg{IDX: "my-pipeline", Stages: []PipelineStage{{Name: "decode", Args: strings.Repeat("x", MaxArgsSize+1)}}}, false},
+			{PipelineMsg{IDX: "my-pipeline", Stages: make([]PipelineStage, MaxPipelineStages+1)}, false},
+		}
+		for _, test := range tests {
+			err := test.msg.Validate()
+			if test.valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred(), test.msg.String())
+			}
+		}
+	})
+
+	It("should unmarshal as part of ETL metadata", func() {
+		msg := &PipelineMsg{IDX: "my-pipeline", Stages: []PipelineStage{{Name: "decode"}, {Name: "resize", Args: "224x224"}}}
+		b, err := jsoniter.Marshal(msg)
+		Expect(err).NotTo(HaveOccurred())
+
+		initMsg, err := UnmarshalInitMsg(b)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(initMsg).To(Equal(msg))
+		Expect(initMsg.Type()).To(Equal(Pipeline))
+
+		md := &MD{Version: 3, ETLs: ETLs{"my-pipeline": msg}}
+		b, err = jsoniter.Marshal(md)
+		Expect(err).NotTo(HaveOccurred())
+		clone := &MD{}
+		Expect(jsoniter.Unmarshal(b, clone)).NotTo(HaveOccurred())
+		Expect(clone.ETLs).To(HaveKey("my-pipeline"))
+		Expect(clone.ETLs["my-pipeline"]).To(Equal(msg))
+	})
+})
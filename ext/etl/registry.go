@@ -62,13 +62,20 @@ func (r *registry) list() []Info {
 	r.mtx.RLock()
 	etls := make([]Info, 0, len(r.m))
 	for name, comm := range r.m {
-		etls = append(etls, Info{
+		info := Info{
 			Name:     name,
 			XactID:   comm.Xact().ID(),
 			ObjCount: comm.ObjCount(),
 			InBytes:  comm.InBytes(),
 			OutBytes: comm.OutBytes(),
-		})
+		}
+		if tc := caches.get(name); tc != nil {
+			tc.stats(&info)
+		}
+		if pc, ok := comm.(*pipelineComm); ok {
+			info.Stages = pc.stats()
+		}
+		etls = append(etls, info)
 	}
 	r.mtx.RUnlock()
 	return etls
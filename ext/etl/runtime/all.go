@@ -15,6 +15,8 @@ const (
 	Py38  = "python3.8v2"
 	Py310 = "python3.10v2"
 	Py311 = "python3.11v2"
+
+	Wasm = "wasm" // WebAssembly module executed in-process (no pods)
 )
 
 type (
@@ -28,6 +30,7 @@ type (
 	py38    struct{ runbase }
 	py310   struct{ runbase }
 	py311   struct{ runbase }
+	wasm    struct{ runbase }
 )
 
 var (
@@ -51,8 +54,8 @@ func GetNames() (names []string) {
 }
 
 func init() {
-	all = make(map[string]runtime, 3)
-	for _, r := range []runtime{py38{}, py310{}, py311{}} {
+	all = make(map[string]runtime, 4)
+	for _, r := range []runtime{py38{}, py310{}, py311{}, wasm{}} {
 		if _, ok := all[r.Name()]; ok {
 			debug.Assert(false, "duplicate type "+r.Name())
 		} else {
@@ -73,3 +76,7 @@ func (py310) PodSpec() string { return strings.ReplaceAll(pyPodSpec, "<TAG>", "3
 
 func (py311) Name() string    { return Py311 }
 func (py311) PodSpec() string { return strings.ReplaceAll(pyPodSpec, "<TAG>", "3.11v2") }
+
+// (see etl/wasm.go)
+func (wasm) Name() string    { return Wasm }
+func (wasm) PodSpec() string { return "" }
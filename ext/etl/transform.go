@@ -147,6 +147,9 @@ func (e *Aborter) ListenSmapChanged() {
 
 // (common for both `InitCode` and `InitSpec` flows)
 func InitSpec(t cluster.Target, msg *InitSpecMsg, etlName string, opts StartOpts) error {
+	if isLocal() {
+		return localInitSpec(t, msg, etlName, opts)
+	}
 	errCtx, podName, svcName, err := start(t, msg, etlName, opts)
 	if err != nil {
 		glog.Warning(cmn.NewErrETL(errCtx, "%s: cleanup after unsuccessful Start", t))
@@ -162,6 +165,12 @@ func InitSpec(t cluster.Target, msg *InitSpecMsg, etlName string, opts StartOpts
 // - execute `InitSpec` with the modified podspec
 // See also: etl/runtime/podspec.yaml
 func InitCode(t cluster.Target, msg *InitCodeMsg, xid string) error {
+	if msg.Runtime == runtime.Wasm {
+		return InitWasm(t, msg, xid) // in-process, regardless of deployment
+	}
+	if isLocal() {
+		return localInitCode(t, msg, xid)
+	}
 	var (
 		ftp      = fromToPairs(msg)
 		replacer = strings.NewReplacer(ftp...)
@@ -312,8 +321,10 @@ func Stop(t cluster.Target, id string, errCause error) error {
 	errCtx.PodName = c.PodName()
 	errCtx.SvcName = c.SvcName()
 
-	if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
-		return err
+	if !isLocal() {
+		if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
+			return err
+		}
 	}
 
 	if c := reg.del(id); c != nil {
@@ -321,15 +332,13 @@ func Stop(t cluster.Target, id string, errCause error) error {
 	}
 
 	c.Stop()
+	dropCache(t, id)
 
 	return nil
 }
 
 // StopAll terminates all running ETLs.
 func StopAll(t cluster.Target) {
-	if k8s.Detect() != nil {
-		return
-	}
 	for _, e := range List() {
 		if err := Stop(t, e.Name, nil); err != nil {
 			glog.Error(err)
@@ -352,11 +361,24 @@ func PodLogs(t cluster.Target, transformID string) (logs Logs, err error) {
 	if err != nil {
 		return logs, err
 	}
-	client, err := k8s.GetClient()
-	if err != nil {
-		return logs, err
+	var b []byte
+	switch c := c.(type) {
+	case *pipelineComm:
+		return Logs{TargetID: t.SID()}, nil // no pods of its own (see stages)
+	case *wasmComm:
+		return Logs{TargetID: t.SID(), Logs: c.logs.get()}, nil
+	}
+	if isLocal() {
+		if p := localProcOf(c); p != nil {
+			b, err = p.logs()
+		}
+	} else {
+		var client k8s.Client
+		if client, err = k8s.GetClient(); err != nil {
+			return logs, err
+		}
+		b, err = client.Logs(c.PodName())
 	}
-	b, err := client.Logs(c.PodName())
 	if err != nil {
 		return logs, err
 	}
@@ -371,6 +393,16 @@ func PodHealth(t cluster.Target, etlName string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	switch c.(type) {
+	case *pipelineComm, *wasmComm:
+		return HealthStatusRunning, nil
+	}
+	if isLocal() {
+		if p := localProcOf(c); p != nil {
+			return p.health(), nil
+		}
+		return HealthStatusRunning, nil // sidecar or `io://`
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return "", err
@@ -383,6 +415,23 @@ func PodMetrics(t cluster.Target, etlName string) (*CPUMemUsed, error) {
 	if err != nil {
 		return nil, err
 	}
+	switch c.(type) {
+	case *pipelineComm:
+		return nil, cmn.NewErrNotFound("%s: etl[%s] metrics (pipeline - see its stages)", t, etlName)
+	case *wasmComm:
+		return nil, cmn.NewErrNotFound("%s: etl[%s] metrics (in-process)", t, etlName)
+	}
+	if isLocal() {
+		p := localProcOf(c)
+		if p == nil {
+			return nil, cmn.NewErrNotFound("%s: etl[%s] metrics (not a local process)", t, etlName)
+		}
+		cpuUsed, memUsed, err := p.metrics()
+		if err != nil {
+			return nil, err
+		}
+		return &CPUMemUsed{TargetID: t.SID(), CPU: cpuUsed, Mem: memUsed}, nil
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,266 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/sys"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+// WebAssembly (in-process) ETL runtime
+//
+// `InitCodeMsg` with `runtime.Wasm` carries a WebAssembly module that each target
+// compiles once and then executes inside its own process, using embedded (pure Go)
+// engine - no pods, subprocesses, or sockets:
+//
+// * each transformation runs in a fresh sandboxed instance of the module: object
+//   on stdin, transformed result on stdout (WASI) - same as `io://`; the module
+//   has no access to the filesystem or network;
+// * `funcs.transform` is the exported function to call (e.g., `_start` for WASI
+//   commands); `_initialize`, if exported, runs first;
+// * transformer arguments (per-request or pipeline stage): `LocalEnvArgs` environment variable;
+// * limits (see `WasmLimits`): linear memory per instance, execution time per object,
+//   and the number of concurrently running instances;
+// * stderr is retained (up to `localLogsMaxSize`) and returned as ETL logs.
+
+const (
+	wasmDefaultMemSize     = 256 * cos.MiB
+	wasmDefaultExecTimeout = time.Minute
+	wasmPageSize           = 64 * cos.KiB
+	wasmInitFunc           = "_initialize" // WASI reactor
+)
+
+type (
+	wasmComm struct {
+		baseComm
+		rt      wazero.Runtime
+		mod     wazero.CompiledModule
+		sema    *cos.Semaphore
+		logs    *wasmLogs
+		fn      string
+		timeout time.Duration
+	}
+
+	// bounded (most recent) stderr output of all instances
+	wasmLogs struct {
+		b   []byte
+		mtx sync.Mutex
+	}
+)
+
+// interface guard
+var _ Communicator = (*wasmComm)(nil)
+
+func InitWasm(t cluster.Target, msg *InitCodeMsg, xid string) error {
+	errCtx := &cmn.ETLErrCtx{TID: t.SID(), ETLName: msg.IDX}
+	wc, err := newWasmComm(msg)
+	if err != nil {
+		return cmn.NewErrETL(errCtx, err.Error())
+	}
+	rns := xreg.RenewETL(t, msg, xid)
+	if rns.Err != nil {
+		wc.rt.Close(context.Background())
+		return cmn.NewErrETL(errCtx, rns.Err.Error())
+	}
+	wc.baseComm = baseComm{
+		Slistener: newAborter(t, msg.IDX),
+		t:         t,
+		xctn:      rns.Entry.Get(),
+		name:      msg.IDX,
+		commType:  HpushStdin,
+	}
+	if err := localRegister(t, msg.IDX, wc); err != nil {
+		wc.Stop()
+		return err
+	}
+	return nil
+}
+
+// compile the module (once) and apply the limits
+func newWasmComm(msg *InitCodeMsg) (*wasmComm, error) {
+	var (
+		ctx     = context.Background()
+		limits  = msg.Limits
+		memSize = int64(limits.MemSize)
+	)
+	if memSize == 0 {
+		memSize = wasmDefaultMemSize
+	}
+	cfg := wazero.NewRuntimeConfig().
+		WithCloseOnContextDone(true). // enforce `ExecTimeout` (and abort)
+		WithMemoryLimitPages(uint32(cos.DivCeil(memSize, wasmPageSize)))
+	rt := wazero.NewRuntimeWithConfig(ctx, cfg)
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, rt); err != nil {
+		rt.Close(ctx)
+		return nil, err
+	}
+	mod, err := rt.CompileModule(ctx, msg.Code)
+	if err != nil {
+		rt.Close(ctx)
+		return nil, fmt.Errorf("failed to compile WebAssembly module: %v", err)
+	}
+	if _, ok := mod.ExportedFunctions()[msg.Funcs.Transform]; !ok {
+		rt.Close(ctx)
+		return nil, fmt.Errorf("WebAssembly module does not export transform function %q", msg.Funcs.Transform)
+	}
+	wc := &wasmComm{
+		rt:      rt,
+		mod:     mod,
+		logs:    &wasmLogs{},
+		fn:      msg.Funcs.Transform,
+		timeout: time.Duration(limits.ExecTimeout),
+	}
+	if wc.timeout == 0 {
+		wc.timeout = wasmDefaultExecTimeout
+	}
+	maxInstances := limits.MaxInstances
+	if maxInstances == 0 {
+		maxInstances = sys.NumCPU()
+	}
+	wc.sema = cos.NewSemaphore(maxInstances)
+	return wc, nil
+}
+
+//////////////
+// wasmComm //
+//////////////
+
+func (wc *wasmComm) String() string {
+	return fmt.Sprintf("%s[%s]-%s(wasm)", wc.name, wc.xctn.ID(), wc.commType)
+}
+
+func (wc *wasmComm) Stop() {
+	wc.baseComm.Stop()
+	if err := wc.rt.Close(context.Background()); err != nil {
+		glog.Errorf("%s: %v", wc, err)
+	}
+}
+
+func (wc *wasmComm) OnlineTransform(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName, args string) error {
+	src, err := wc.open(bck, objName)
+	if err != nil {
+		return err
+	}
+	size := src.Size()
+	err = wc.run(context.Background(), src, &cbWriter{w: w, writeCb: func(n int) { wc.xctn.InObjsAdd(0, int64(n)) }}, args)
+	cos.Close(src)
+	wc.xctn.InObjsAdd(1, 0)
+	wc.xctn.OutObjsAdd(1, size)
+	return err
+}
+
+func (wc *wasmComm) OfflineTransform(bck *cluster.Bck, objName, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	src, err := wc.open(bck, objName)
+	if err != nil {
+		return nil, err
+	}
+	return wc.stream(src, bck, objName, args, timeout)
+}
+
+// run the module in the background, with its stdout piped to the returned reader
+func (wc *wasmComm) stream(src cos.ReadCloseSizer, _ *cluster.Bck, _, args string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := wc.xctn.AbortErr(); err != nil {
+		cos.Close(src)
+		return nil, cmn.NewErrAborted(wc.String(), "stream", err)
+	}
+	var (
+		ctx    = context.Background()
+		cancel = func() {}
+		size   = cos.MaxI64(src.Size(), 0)
+		pr, pw = io.Pipe()
+	)
+	if timeout != 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	go func() {
+		err := wc.run(ctx, src, pw, args)
+		cos.Close(src)
+		pw.CloseWithError(err)
+	}()
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:      pr,
+		Size:   -1,
+		ReadCb: func(n int, _ error) { wc.xctn.InObjsAdd(0, int64(n)) },
+		DeferCb: func() {
+			cancel()
+			wc.xctn.InObjsAdd(1, 0)
+			wc.xctn.OutObjsAdd(1, size)
+		},
+	}), nil
+}
+
+func (wc *wasmComm) open(bck *cluster.Bck, objName string) (cos.ReadCloseSizer, error) {
+	if err := wc.xctn.AbortErr(); err != nil {
+		return nil, cmn.NewErrAborted(wc.String(), "open", err)
+	}
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		return nil, err
+	}
+	return lomOpen(lom)
+}
+
+// instantiate the module and call its transform function
+func (wc *wasmComm) run(ctx context.Context, stdin io.Reader, stdout io.Writer, args string) error {
+	wc.sema.Acquire()
+	defer wc.sema.Release()
+
+	ctx, cancel := context.WithTimeout(ctx, wc.timeout)
+	defer cancel()
+	cfg := wazero.NewModuleConfig().
+		WithName(""). // anonymous - allows concurrent instances
+		WithArgs(wc.name).
+		WithStdin(stdin).
+		WithStdout(stdout).
+		WithStderr(wc.logs).
+		WithStartFunctions(wasmInitFunc, wc.fn)
+	if args != "" {
+		cfg = cfg.WithEnv(LocalEnvArgs, args)
+	}
+	mod, err := wc.rt.InstantiateModule(ctx, wc.mod, cfg)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%s: timed out: %v", wc, err)
+		}
+		return fmt.Errorf("%s: %v", wc, err)
+	}
+	return mod.Close(ctx)
+}
+
+//////////////
+// wasmLogs //
+//////////////
+
+func (l *wasmLogs) Write(b []byte) (int, error) {
+	l.mtx.Lock()
+	l.b = append(l.b, b...)
+	if over := len(l.b) - localLogsMaxSize; over > 0 {
+		l.b = append(l.b[:0], l.b[over:]...)
+	}
+	l.mtx.Unlock()
+	return len(b), nil
+}
+
+func (l *wasmLogs) get() []byte {
+	l.mtx.Lock()
+	b := append([]byte(nil), l.b...)
+	l.mtx.Unlock()
+	return b
+}
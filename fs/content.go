@@ -33,12 +33,18 @@ import (
 const (
 	contentTypeLen = 2
 
-	ObjectType   = "ob"
-	WorkfileType = "wk"
-	ECSliceType  = "ec"
-	ECMetaType   = "mt"
+	ObjectType    = "ob"
+	WorkfileType  = "wk"
+	ECSliceType   = "ec"
+	ECMetaType    = "mt"
+	VersionType   = "vr" // non-current object versions (see cmn.VersionConf.Retain)
+	ETLCacheType  = "et" // cached results of inline ETL transformations (see ext/etl/cache.go)
+	ArchIndexType = "ai" // archived files' offsets and sizes (see cluster/lom_arch.go)
 )
 
+// all non-current versions of a given object are stored in a single directory
+const VersionDirSuffix = ".v"
+
 type (
 	ContentResolver interface {
 		// When set to true, services like rebalance have permission to move
@@ -163,10 +169,13 @@ func (f *contentSpecMgr) PermToProcess(fqn string) (ok bool) {
 // FIXME: This should be probably placed somewhere else \/
 
 type (
-	ObjectContentResolver   struct{}
-	WorkfileContentResolver struct{}
-	ECSliceContentResolver  struct{}
-	ECMetaContentResolver   struct{}
+	ObjectContentResolver    struct{}
+	WorkfileContentResolver  struct{}
+	ECSliceContentResolver   struct{}
+	ECMetaContentResolver    struct{}
+	VersionContentResolver   struct{}
+	ETLCacheContentResolver  struct{}
+	ArchIndexContentResolver struct{}
 )
 
 func (*ObjectContentResolver) PermToMove() bool                   { return true }
@@ -235,3 +244,48 @@ func (*ECMetaContentResolver) GenUniqueFQN(base, _ string) string { return base
 func (*ECMetaContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
 	return base, false, true
 }
+
+// non-current versions are moved (e.g., by rebalance) together with their objects
+func (*VersionContentResolver) PermToMove() bool    { return true }
+func (*VersionContentResolver) PermToEvict() bool   { return false }
+func (*VersionContentResolver) PermToProcess() bool { return false }
+
+// <object-name>.v/<version>
+func (*VersionContentResolver) GenUniqueFQN(base, ver string) string {
+	return base + VersionDirSuffix + "/" + ver
+}
+
+// (the version file's base is the version itself)
+func (*VersionContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	if base == "" || strings.Trim(base, "0123456789") != "" {
+		return "", false, false
+	}
+	return base, false, true
+}
+
+// cached transformations are neither moved nor evicted by LRU - the ETL keeps
+// track of (and evicts) them itself and wipes them out upon (re)initialization
+func (*ETLCacheContentResolver) PermToMove() bool    { return false }
+func (*ETLCacheContentResolver) PermToEvict() bool   { return false }
+func (*ETLCacheContentResolver) PermToProcess() bool { return false }
+
+// <etl-name>/<object-name>
+func (*ETLCacheContentResolver) GenUniqueFQN(base, etlName string) string {
+	return etlName + "/" + base
+}
+
+func (*ETLCacheContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
+
+// archive indexes are moved together with their objects (and are removed when
+// the latter get deleted); there's no need to evict them separately
+func (*ArchIndexContentResolver) PermToMove() bool    { return true }
+func (*ArchIndexContentResolver) PermToEvict() bool   { return false }
+func (*ArchIndexContentResolver) PermToProcess() bool { return false }
+
+func (*ArchIndexContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*ArchIndexContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
@@ -29,6 +29,10 @@ const (
 	WorkfileAppend       = "append"         // APPEND to object (as file)
 	WorkfileAppendToArch = "append-to-arch" // APPEND to existing archive
 	WorkfileCreateArch   = "create-arch"    // CREATE multi-object archive
+	WorkfileCompose      = "compose"        // compose (concatenate) objects
+	WorkfileEncrypt      = "encrypt"        // encrypt content at rest (see sse)
+	WorkfileDownload     = "dl"             // resumable download (see ext/dload)
+	WorkfileETLCache     = "etl"            // transform cache entry (see ext/etl)
 )
 
 type ParsedFQN struct {
@@ -13,6 +13,7 @@ require (
 	github.com/golang-jwt/jwt/v4 v4.4.3
 	github.com/json-iterator/go v1.1.12
 	github.com/karrick/godirwalk v1.17.0
+	github.com/klauspost/compress v1.15.13
 	github.com/klauspost/reedsolomon v1.11.3
 	github.com/lufia/iostat v1.2.1
 	github.com/onsi/ginkgo v1.16.5
@@ -22,6 +23,7 @@ require (
 	github.com/prometheus/client_golang v1.14.0
 	github.com/seiflotfy/cuckoofilter v0.0.0-20220411075957-e3b120b3f5fb
 	github.com/teris-io/shortid v0.0.0-20220617161101-71ec9f2aa569
+	github.com/tetratelabs/wazero v1.5.0
 	github.com/tidwall/buntdb v1.2.10
 	github.com/tinylib/msgp v1.1.7
 	github.com/valyala/fasthttp v1.43.0
@@ -74,7 +76,6 @@ require (
 	github.com/jcmturner/rpc/v2 v2.0.3 // indirect
 	github.com/jmespath/go-jmespath v0.4.0 // indirect
 	github.com/josharian/intern v1.0.0 // indirect
-	github.com/klauspost/compress v1.15.13 // indirect
 	github.com/klauspost/cpuid/v2 v2.2.2 // indirect
 	github.com/mailru/easyjson v0.7.7 // indirect
 	github.com/mattn/go-ieproxy v0.0.9 // indirect
@@ -104,7 +104,8 @@ func (r *xactMNC) Run(wg *sync.WaitGroup) {
 
 func (r *xactMNC) visitObj(lom *cluster.LOM, buf []byte) (err error) {
 	var size int64
-	if n := lom.NumCopies(); n == r.copies {
+	// (when retained, non-current versions may still need to be mirrored - see addCopies)
+	if n := lom.NumCopies(); n == r.copies && !lom.Bprops().Versioning.Retains() {
 		return nil
 	} else if n > r.copies {
 		size, err = delCopies(lom, r.copies)
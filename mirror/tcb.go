@@ -47,7 +47,10 @@ type (
 	}
 )
 
-const OpcTxnDone = 27182
+const (
+	OpcTxnDone   = 27182
+	OpcArchIndex = OpcTxnDone + 1 // archive index of the copied object (see cluster/lom_arch.go)
+)
 
 const etlBucketParallelCnt = 2
 
@@ -231,6 +234,10 @@ func (r *XactTCB) recv(hdr transport.ObjHdr, objReader io.Reader, err error) err
 		debug.Assert(refc >= 0)
 		return nil
 	}
+	if hdr.Opcode == OpcArchIndex {
+		r.recvArchIndex(hdr, objReader)
+		return nil
+	}
 	debug.Assert(hdr.Opcode == 0)
 
 	lom := cluster.AllocLOM(hdr.ObjName)
@@ -270,6 +277,19 @@ func (r *XactTCB) recv(hdr transport.ObjHdr, objReader io.Reader, err error) err
 	return erp // NOTE: non-nil signals transport to terminate
 }
 
+// best-effort: the index, if lost, will be rebuilt upon the first read
+func (r *XactTCB) recvArchIndex(hdr transport.ObjHdr, objReader io.Reader) {
+	lom := cluster.AllocLOM(hdr.ObjName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(&hdr.Bck); err != nil {
+		glog.Error(err)
+		return
+	}
+	if err := lom.RecvArchIndex(objReader); err != nil {
+		glog.Errorf("%s: failed to receive %s archive index: %v", r, lom, err)
+	}
+}
+
 func (r *XactTCB) Args() *xreg.TCBArgs { return &r.args }
 
 func (r *XactTCB) String() string {
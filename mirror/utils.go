@@ -24,6 +24,7 @@ func delCopies(lom *cluster.LOM, copies int) (size int64, err error) {
 
 	ndel := lom.NumCopies() - copies
 	if ndel <= 0 {
+		_, err = mirrorVersions(lom, copies, nil)
 		return
 	}
 
@@ -43,7 +44,9 @@ func delCopies(lom *cluster.LOM, copies int) (size int64, err error) {
 	if err = lom.DelCopies(copiesFQN...); err != nil {
 		return
 	}
-	err = lom.Persist()
+	if err = lom.Persist(); err == nil {
+		_, err = mirrorVersions(lom, copies, nil)
+	}
 	return
 }
 
@@ -60,7 +63,7 @@ func addCopies(lom *cluster.LOM, copies int, buf []byte) (size int64, err error)
 
 	// Recheck if we still need to create the copy.
 	if lom.NumCopies() >= copies {
-		return 0, nil
+		return mirrorVersions(lom, copies, buf)
 	}
 
 	//  While copying we may find out that some copies do not exist -
@@ -77,7 +80,16 @@ func addCopies(lom *cluster.LOM, copies int, buf []byte) (size int64, err error)
 		}
 		size += lom.SizeBytes()
 	}
-	return
+	n, err := mirrorVersions(lom, copies, buf)
+	return size + n, err
+}
+
+// non-current versions, if retained, are mirrored along with the object (caller holds wlock)
+func mirrorVersions(lom *cluster.LOM, copies int, buf []byte) (int64, error) {
+	if !lom.Bprops().Versioning.Retains() {
+		return 0, nil
+	}
+	return lom.MirrorVersions(copies, buf)
 }
 
 func drainWorkCh(workCh chan cluster.LIF) (n int) {
@@ -126,7 +126,11 @@ func (reb *Reb) sendFromDisk(ct *cluster.CT, meta *ec.Metadata, target *cluster.
 	// open
 	if lom != nil {
 		defer cluster.FreeLOM(lom)
-		roc, err = lom.NewDeferROC()
+		// non-current versions go first (while still holding rlock)
+		if lom.Bprops().Versioning.Retains() {
+			reb.sendVersions(lom, target)
+		}
+		roc, err = lom.NewDeferRawROC() // encrypted replica remains encrypted (see ec.Metadata.SSEKey)
 	} else {
 		roc, err = cos.NewFileHandle(fqn)
 	}
@@ -137,13 +141,14 @@ func (reb *Reb) sendFromDisk(ct *cluster.CT, meta *ec.Metadata, target *cluster.
 	// transmit
 	ntfn := stageNtfn{daemonID: reb.t.SID(), stage: rebStageTraverse, rebID: reb.rebID.Load(), md: meta, action: action}
 	o := transport.AllocSend()
-	o.Hdr = transport.ObjHdr{ObjName: ct.ObjectName(), ObjAttrs: cmn.ObjAttrs{Size: meta.Size}}
+	o.Hdr = transport.ObjHdr{ObjName: ct.ObjectName(), ObjAttrs: cmn.ObjAttrs{Size: meta.StoredSize()}}
 	o.Hdr.Bck.Copy(ct.Bck().Bucket())
 	if lom != nil {
 		o.Hdr.ObjAttrs.CopyFrom(lom.ObjAttrs())
+		o.Hdr.ObjAttrs.Size = lom.StoredSize()
 	}
 	if meta.SliceID != 0 {
-		o.Hdr.ObjAttrs.Size = ec.SliceSize(meta.Size, meta.Data)
+		o.Hdr.ObjAttrs.Size = ec.SliceSize(meta.StoredSize(), meta.Data)
 	}
 	reb.onAir.Inc()
 	o.Hdr.Opaque = ntfn.NewPack(rebMsgEC)
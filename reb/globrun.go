@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"path/filepath"
 	"runtime"
 	"sync"
@@ -783,6 +784,11 @@ func (rj *rebJogger) _lwalk(lom *cluster.LOM, fqn string) error {
 	if err != nil {
 		return err
 	}
+	// non-current versions and archive index, if any, go first (while still holding rlock)
+	if lom.Bprops().Versioning.Retains() {
+		rj.m.sendVersions(lom, tsi)
+	}
+	rj.sendArchIndex(lom, tsi)
 	// transmit (unlock via transport completion => roc.Close)
 	rj.m.addLomAck(lom)
 	if err := rj.doSend(lom, tsi, roc); err != nil {
@@ -827,3 +833,80 @@ func (rj *rebJogger) doSend(lom *cluster.LOM, tsi *cluster.Snode, roc cos.ReadOp
 	rj.m.inQueue.Inc()
 	return rj.m.dm.Send(o, roc, tsi)
 }
+
+// archive index is sent as is and is not ACK-ed (when lost, it'll be rebuilt upon the first read)
+func (rj *rebJogger) sendArchIndex(lom *cluster.LOM, tsi *cluster.Snode) {
+	o, fh, err := lom.OpenArchIndex(lom.Bucket(), lom.ObjName)
+	if err == nil && o != nil {
+		ihdr := idxHdr{regularAck: regularAck{rebID: rj.m.RebID(), daemonID: rj.m.t.SID()}}
+		o.Hdr.Opaque = ihdr.NewPack()
+		o.Callback = rj.idxSentCallback
+		rj.m.inQueue.Inc()
+		err = rj.m.dm.Send(o, fh, tsi)
+	}
+	if err != nil {
+		glog.Errorf("%s: failed to send %s archive index: %v", rj.m.t, lom, err)
+	}
+}
+
+func (rj *rebJogger) idxSentCallback(hdr transport.ObjHdr, _ io.ReadCloser, _ any, err error) {
+	rj.m.inQueue.Dec()
+	if err != nil && (bool(glog.FastV(4, glog.SmoduleReb)) || !cos.IsRetriableConnErr(err)) {
+		glog.Errorf("%s: failed to send %s archive index: %v", rj.m.t.Snode(), hdr.Cname(), err)
+	}
+}
+
+// Non-current versions are sent as is (in particular, encrypted content remains encrypted)
+// and are not ACK-ed; similar to the objects themselves, the versions are not
+// immediately removed at the source. Used by both regular and EC rebalance (the
+// latter - along with full replicas).
+func (reb *Reb) sendVersions(lom *cluster.LOM, tsi *cluster.Snode) {
+	vers, err := lom.Versions()
+	if err != nil {
+		glog.Errorf("%s: failed to list versions of %s: %v", reb.t, lom, err)
+		return
+	}
+	for _, v := range vers {
+		var (
+			roc   cos.ReadOpenCloser
+			finfo os.FileInfo
+		)
+		if finfo, err = os.Stat(v.FQN); err == nil && finfo.Size() > 0 {
+			roc, err = cos.NewFileHandle(v.FQN)
+		}
+		if err != nil {
+			glog.Errorf("%s: failed to send %s version %q: %v", reb.t, lom, v.Ver, err)
+			continue
+		}
+		var (
+			vhdr = verHdr{
+				regularAck: regularAck{rebID: reb.RebID(), daemonID: reb.t.SID()},
+				noncurrent: v.Noncurrent,
+				size:       v.Size,
+			}
+			o = transport.AllocSend()
+		)
+		o.Hdr.Bck.Copy(lom.Bucket())
+		o.Hdr.ObjName = lom.ObjName
+		o.Hdr.Opaque = vhdr.NewPack()
+		o.Hdr.ObjAttrs.CopyFrom(&v.ObjAttrs)
+		o.Hdr.ObjAttrs.Size = finfo.Size()
+		o.Callback = reb.verSentCallback
+		reb.inQueue.Inc()
+		if err := reb.dm.Send(o, roc, tsi); err != nil {
+			glog.Errorf("%s: failed to send %s version %q: %v", reb.t, lom, v.Ver, err)
+			return
+		}
+	}
+}
+
+func (reb *Reb) verSentCallback(hdr transport.ObjHdr, _ io.ReadCloser, _ any, err error) {
+	reb.inQueue.Dec()
+	if err != nil {
+		if bool(glog.FastV(4, glog.SmoduleReb)) || !cos.IsRetriableConnErr(err) {
+			glog.Errorf("%s: failed to send %s version %q: %v", reb.t.Snode(), hdr.Cname(), hdr.ObjAttrs.Ver, err)
+		}
+		return
+	}
+	reb.xctn().OutObjsAdd(1, hdr.ObjAttrs.Size)
+}
@@ -16,6 +16,8 @@ const (
 	rebMsgRegular   = iota // regular rebalance: acknowledge/Object
 	rebMsgEC               // EC rebalance: acknowledge/CT/Namespace
 	rebMsgStageNtfn        // stage notification (of target transitioning to the next stage)
+	rebMsgVersion          // non-current object version (no ACK)
+	rebMsgArchIndex        // archive index (no ACK)
 )
 const rebMsgKindSize = 1
 const (
@@ -35,6 +37,18 @@ type (
 		sliceID  uint16
 	}
 
+	// non-current object version (see cmn.VersionConf.Retain)
+	verHdr struct {
+		regularAck
+		noncurrent int64 // when the version became non-current
+		size       int64 // (vs on-disk size that may differ - e.g., when encrypted)
+	}
+
+	// archive index (see cluster/lom_arch.go)
+	idxHdr struct {
+		regularAck
+	}
+
 	// stage notification struct - a target sends it when it enters `stage`
 	stageNtfn struct {
 		md       *ec.Metadata
@@ -51,6 +65,8 @@ var (
 	_ cos.Unpacker = (*ecAck)(nil)
 	_ cos.Packer   = (*regularAck)(nil)
 	_ cos.Packer   = (*ecAck)(nil)
+	_ cos.Packer   = (*verHdr)(nil)
+	_ cos.Unpacker = (*verHdr)(nil)
 	_ cos.Packer   = (*stageNtfn)(nil)
 	_ cos.Unpacker = (*stageNtfn)(nil)
 )
@@ -110,6 +126,43 @@ func (eack *ecAck) PackedSize() int {
 	return cos.SizeofI64 + cos.SizeofI16 + cos.PackedStrLen(eack.daemonID)
 }
 
+func (vhdr *verHdr) Unpack(unpacker *cos.ByteUnpack) (err error) {
+	if err = vhdr.regularAck.Unpack(unpacker); err != nil {
+		return
+	}
+	if vhdr.noncurrent, err = unpacker.ReadInt64(); err != nil {
+		return
+	}
+	vhdr.size, err = unpacker.ReadInt64()
+	return
+}
+
+func (vhdr *verHdr) Pack(packer *cos.BytePack) {
+	vhdr.regularAck.Pack(packer)
+	packer.WriteInt64(vhdr.noncurrent)
+	packer.WriteInt64(vhdr.size)
+}
+
+func (vhdr *verHdr) NewPack() []byte {
+	l := rebMsgKindSize + vhdr.PackedSize()
+	packer := cos.NewPacker(nil, l)
+	packer.WriteByte(rebMsgVersion)
+	packer.WriteAny(vhdr)
+	return packer.Bytes()
+}
+
+func (vhdr *verHdr) PackedSize() int {
+	return vhdr.regularAck.PackedSize() + cos.SizeofI64*2
+}
+
+func (ihdr *idxHdr) NewPack() []byte {
+	l := rebMsgKindSize + ihdr.PackedSize()
+	packer := cos.NewPacker(nil, l)
+	packer.WriteByte(rebMsgArchIndex)
+	packer.WriteAny(&ihdr.regularAck)
+	return packer.Bytes()
+}
+
 func (ntfn *stageNtfn) PackedSize() int {
 	total := cos.SizeofI64 + cos.SizeofI32*2 +
 		cos.PackedStrLen(ntfn.daemonID) + 1
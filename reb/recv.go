@@ -50,9 +50,16 @@ func (reb *Reb) recvObj(hdr transport.ObjHdr, objReader io.Reader, err error) er
 		glog.Errorf("Failed to read message type: %v", err)
 		return reb._recvErr(err)
 	}
-	if act == rebMsgRegular {
+	switch act {
+	case rebMsgRegular:
 		err := reb.recvObjRegular(hdr, smap, unpacker, objReader)
 		return reb._recvErr(err)
+	case rebMsgVersion:
+		reb.recvVersion(hdr, unpacker, objReader)
+		return nil
+	case rebMsgArchIndex:
+		reb.recvArchIndex(hdr, unpacker, objReader)
+		return nil
 	}
 	debug.Assertf(act == rebMsgEC, "act=%d", act)
 	err = reb.recvECData(hdr, unpacker, objReader)
@@ -202,6 +209,64 @@ func (reb *Reb) recvObjRegular(hdr transport.ObjHdr, smap *cluster.Smap, unpacke
 	return nil
 }
 
+// non-current versions are best-effort: failure to receive one does not abort rebalance
+func (reb *Reb) recvVersion(hdr transport.ObjHdr, unpacker *cos.ByteUnpack, objReader io.Reader) {
+	vhdr := &verHdr{}
+	if err := unpacker.ReadAny(vhdr); err != nil {
+		glog.Errorf("Failed to parse version header: %v", err)
+		return
+	}
+	if vhdr.rebID != reb.RebID() {
+		glog.Warningf("received %s version %q: %s", hdr.Cname(), hdr.ObjAttrs.Ver, reb.warnID(vhdr.rebID, vhdr.daemonID))
+		return
+	}
+	xreb := reb.xctn()
+	if xreb == nil || xreb.IsAborted() {
+		return
+	}
+	lom := cluster.AllocLOM(hdr.ObjName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(&hdr.Bck); err != nil {
+		glog.Error(err)
+		return
+	}
+	attrs := hdr.ObjAttrs
+	attrs.Size = vhdr.size
+	if objReader == nil {
+		objReader = bytes.NewReader(nil) // (delete marker)
+	}
+	if err := lom.RecvVersion(objReader, &attrs, vhdr.noncurrent); err != nil {
+		glog.Errorf("%s: failed to receive %s version %q: %v", reb.t, lom, attrs.Ver, err)
+		return
+	}
+	xreb.InObjsAdd(1, hdr.ObjAttrs.Size)
+}
+
+// ditto: best-effort
+func (reb *Reb) recvArchIndex(hdr transport.ObjHdr, unpacker *cos.ByteUnpack, objReader io.Reader) {
+	ack := &regularAck{}
+	if err := unpacker.ReadAny(ack); err != nil {
+		glog.Errorf("Failed to parse archive index header: %v", err)
+		return
+	}
+	if ack.rebID != reb.RebID() {
+		glog.Warningf("received %s archive index: %s", hdr.Cname(), reb.warnID(ack.rebID, ack.daemonID))
+		return
+	}
+	if xreb := reb.xctn(); xreb == nil || xreb.IsAborted() {
+		return
+	}
+	lom := cluster.AllocLOM(hdr.ObjName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(&hdr.Bck); err != nil {
+		glog.Error(err)
+		return
+	}
+	if err := lom.RecvArchIndex(objReader); err != nil {
+		glog.Errorf("%s: failed to receive %s archive index: %v", reb.t, lom, err)
+	}
+}
+
 func (reb *Reb) recvRegularAck(hdr transport.ObjHdr, unpacker *cos.ByteUnpack) error {
 	ack := &regularAck{}
 	if err := unpacker.ReadAny(ack); err != nil {
@@ -16,6 +16,7 @@ var verbose bool
 func Xreg() {
 	xreg.RegNonBckXact(&lruFactory{})
 	xreg.RegNonBckXact(&clnFactory{})
+	xreg.RegNonBckXact(&lcyFactory{})
 
 	verbose = bool(glog.FastV(4, glog.SmoduleSpace))
 }
@@ -0,0 +1,378 @@
+// Package space provides storage cleanup and eviction functionality (the latter based on the
+// least recently used cache replacement). It also serves as a built-in garbage-collection
+// mechanism for orphaned workfiles.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package space
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Lifecycle xaction executes per-bucket lifecycle rules (cmn.LifecycleConf):
+// - deletes objects that were last modified more than `expiration_days` ago (ais buckets);
+// - deletes non-current versions that became non-current more than `noncurrent_days` ago
+//   (ais buckets that retain versions - see cmn.VersionConf.Retain);
+// - evicts cached copies of remote objects not accessed for `evict_days`;
+// - aborts multipart uploads with no activity for `abort_mpt_days`.
+// Each target runs it periodically (see ais/tgtspace.go) and only visits the buckets
+// that have (enabled) rules; the same xaction can also be started via `api.StartXaction`.
+
+const day = 24 * time.Hour
+
+type (
+	IniLcy struct {
+		T        cluster.Target
+		Xaction  *XactLcy
+		StatsT   stats.Tracker
+		Buckets  []cmn.Bck // optional list of specific buckets
+		AbortMpt func(bck *cluster.Bck, prefix string, maxAge time.Duration) int
+		Removed  func(lom *cluster.LOM, size int64) // (e.g., bucket quota usage)
+		WG       *sync.WaitGroup
+	}
+	XactLcy struct {
+		xact.Base
+	}
+)
+
+// private
+type (
+	// lcyJ is a single /jogger/ that traverses a given mountpath
+	lcyJ struct {
+		bck   *cluster.Bck
+		rules []*cmn.LifecycleRule // enabled object (expiration, non-current, eviction) rules of the bck
+		smap  *cluster.Smap
+		now   time.Time
+		ini   *IniLcy
+		mi    *fs.Mountpath
+		// stats
+		expired, evicted         int64
+		expiredSize, evictedSize int64
+	}
+	lcyFactory struct {
+		xreg.RenewBase
+		xctn *XactLcy
+	}
+)
+
+// interface guard
+var (
+	_ xreg.Renewable = (*lcyFactory)(nil)
+	_ cluster.Xact   = (*XactLcy)(nil)
+)
+
+////////////////
+// lcyFactory //
+////////////////
+
+func (*lcyFactory) New(args xreg.Args, _ *cluster.Bck) xreg.Renewable {
+	return &lcyFactory{RenewBase: xreg.RenewBase{Args: args}}
+}
+
+func (p *lcyFactory) Start() error {
+	p.xctn = &XactLcy{}
+	p.xctn.InitBase(p.UUID(), apc.ActLifecycle, nil)
+	return nil
+}
+
+func (*lcyFactory) Kind() string        { return apc.ActLifecycle }
+func (p *lcyFactory) Get() cluster.Xact { return p.xctn }
+
+func (*lcyFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (wpr xreg.WPR, err error) {
+	return xreg.WprUse, cmn.NewErrUsePrevXaction(prevEntry.Get().String())
+}
+
+func (*XactLcy) Run(*sync.WaitGroup) { debug.Assert(false) }
+
+func (r *XactLcy) Snap() (snap *cluster.Snap) {
+	snap = &cluster.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
+
+// returns true if there's at least one bucket with enabled lifecycle rules
+func HasLifecycle(bowner cluster.Bowner) (yes bool) {
+	bowner.Get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		yes = bck.Props.Lifecycle.IsSet()
+		return yes
+	})
+	return
+}
+
+func RunLifecycle(ini *IniLcy) {
+	var (
+		xlcy  = ini.Xaction
+		bcks  = _lcyBcks(ini)
+		avail = fs.GetAvail()
+		wg    = &sync.WaitGroup{}
+		now   = time.Now()
+	)
+	defer func() {
+		if ini.WG != nil {
+			ini.WG.Done()
+		}
+	}()
+	if len(avail) == 0 {
+		glog.Warning(cmn.ErrNoMountpaths)
+		xlcy.Finish(cmn.ErrNoMountpaths)
+		return
+	}
+	if ini.WG != nil {
+		ini.WG.Done()
+		ini.WG = nil
+	}
+	if verbose {
+		glog.Infof("%s started, %d bucket%s", xlcy, len(bcks), cos.Plural(len(bcks)))
+	}
+	for _, bck := range bcks {
+		// 1. objects: one jogger per mountpath
+		var rules []*cmn.LifecycleRule
+		for i := range bck.Props.Lifecycle.Rules {
+			rule := &bck.Props.Lifecycle.Rules[i]
+			if !rule.Disabled && (rule.ExpirationDays > 0 || rule.NoncurrentDays > 0 || rule.EvictDays > 0) {
+				rules = append(rules, rule)
+			}
+		}
+		if len(rules) > 0 {
+			joggers := make([]*lcyJ, 0, len(avail))
+			for _, mi := range avail {
+				j := &lcyJ{
+					bck:   bck,
+					rules: rules,
+					smap:  ini.T.Sowner().Get(),
+					now:   now,
+					ini:   ini,
+					mi:    mi,
+				}
+				joggers = append(joggers, j)
+				wg.Add(1)
+				go j.run(wg)
+			}
+			wg.Wait()
+			for _, j := range joggers {
+				xlcy.ObjsAdd(int(j.expired+j.evicted), j.expiredSize+j.evictedSize)
+			}
+		}
+		// 2. multipart uploads
+		for i := range bck.Props.Lifecycle.Rules {
+			rule := &bck.Props.Lifecycle.Rules[i]
+			if rule.Disabled || rule.AbortMptDays == 0 || ini.AbortMpt == nil {
+				continue
+			}
+			if n := ini.AbortMpt(bck, rule.Prefix, time.Duration(rule.AbortMptDays)*day); n > 0 {
+				glog.Infof("%s: %s rule %q: aborted %d multipart upload%s", xlcy, bck, rule.ID, n, cos.Plural(n))
+			}
+		}
+		if err := xlcy.AbortErr(); err != nil {
+			xlcy.Finish(err)
+			return
+		}
+	}
+	xlcy.Finish(nil)
+	if verbose {
+		glog.Infof("%s finished", xlcy)
+	}
+}
+
+// buckets with enabled lifecycle rules (all or selected)
+func _lcyBcks(ini *IniLcy) (bcks []*cluster.Bck) {
+	bmd := ini.T.Bowner().Get()
+	if len(ini.Buckets) > 0 {
+		for i := range ini.Buckets {
+			bck := cluster.CloneBck(&ini.Buckets[i])
+			if err := bck.Init(ini.T.Bowner()); err != nil {
+				glog.Errorf("%s: %v", ini.Xaction, err)
+				continue
+			}
+			if bck.Props.Lifecycle.IsSet() {
+				bcks = append(bcks, bck)
+			}
+		}
+		return
+	}
+	bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+		if bck.Props.Lifecycle.IsSet() {
+			bcks = append(bcks, bck)
+		}
+		return false
+	})
+	return
+}
+
+//////////
+// lcyJ //
+//////////
+
+func (j *lcyJ) String() string { return fmt.Sprintf("%s: jog-%s", j.ini.Xaction, j.mi) }
+
+func (j *lcyJ) run(wg *sync.WaitGroup) {
+	defer wg.Done()
+	opts := &fs.WalkOpts{
+		Mi:       j.mi,
+		Bck:      *j.bck.Bucket(),
+		CTs:      []string{fs.ObjectType},
+		Callback: j.walk,
+		Sorted:   false,
+	}
+	err := fs.Walk(opts)
+	if err != nil && !cmn.IsErrAborted(err) && !cmn.IsErrBucketNought(err) {
+		glog.Errorf("%s: %s: %v", j, j.bck, err)
+	}
+	if j.expired > 0 {
+		j.ini.StatsT.AddMany(
+			cos.NamedVal64{Name: stats.LcyExpireCount, Value: j.expired},
+			cos.NamedVal64{Name: stats.LcyExpireSize, Value: j.expiredSize},
+		)
+	}
+	if j.evicted > 0 {
+		j.ini.StatsT.AddMany(
+			cos.NamedVal64{Name: stats.LruEvictCount, Value: j.evicted},
+			cos.NamedVal64{Name: stats.LruEvictSize, Value: j.evictedSize},
+		)
+	}
+}
+
+func (j *lcyJ) walk(fqn string, de fs.DirEntry) error {
+	if de.IsDir() {
+		return nil
+	}
+	if err := j.ini.Xaction.AbortErr(); err != nil {
+		return cmn.NewErrAborted(j.ini.Xaction.Name(), "", err)
+	}
+	lom := cluster.AllocLOM("")
+	j.visit(lom, fqn)
+	cluster.FreeLOM(lom)
+	return nil
+}
+
+func (j *lcyJ) visit(lom *cluster.LOM, fqn string) {
+	if err := lom.InitFQN(fqn, j.bck.Bucket()); err != nil {
+		return
+	}
+	// fast path: no rule applies to the name
+	var matched bool
+	for _, rule := range j.rules {
+		if cmn.ObjHasPrefix(lom.ObjName, rule.Prefix) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return
+	}
+	if _, local, err := lom.HrwTarget(j.smap); err != nil || !local || !lom.IsHRW() {
+		return // (misplaced: not ours to delete)
+	}
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		return
+	}
+	for _, rule := range j.rules {
+		if !rule.Match(lom.ObjName, lom.ObjAttrs()) {
+			continue
+		}
+		if rule.NoncurrentDays > 0 && lom.Bprops().Versioning.Retains() {
+			j.noncurrent(lom, rule)
+		}
+		if rule.ExpirationDays > 0 && !j.bck.IsRemote() && j.expire(lom, rule) {
+			return
+		}
+		if rule.EvictDays > 0 && j.evict(lom, rule) {
+			return
+		}
+	}
+}
+
+func (j *lcyJ) expire(lom *cluster.LOM, rule *cmn.LifecycleRule) bool {
+	if lom.IsDelMarker() {
+		return false
+	}
+	mtime, ok := lastModified(lom)
+	if !ok || j.now.Sub(mtime) < time.Duration(rule.ExpirationDays)*day {
+		return false
+	}
+	size := lom.SizeBytes()
+	if _, err := j.ini.T.DeleteObject(lom, false /*evict*/); err != nil {
+		if !cmn.IsObjNotExist(err) && !cmn.IsErrObjLocked(err) {
+			glog.Errorf("%s: rule %q: failed to delete %s: %v", j, rule.ID, lom, err)
+		}
+		return false
+	}
+	j.expired++
+	j.expiredSize += size
+	return true
+}
+
+// Object's last-modified time is recorded in its metadata upon PUT and gets carried
+// along when the object migrates (rebalance, mirroring, etc.) - unlike the file's mtime.
+// Objects that were written prior to this metadata fall back to the latter.
+func lastModified(lom *cluster.LOM) (time.Time, bool) {
+	if v, ok := lom.GetCustomKey(cmn.LastModified); ok {
+		mtime, err := time.Parse(time.RFC3339, v)
+		return mtime, err == nil
+	}
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return finfo.ModTime(), true
+}
+
+func (j *lcyJ) noncurrent(lom *cluster.LOM, rule *cmn.LifecycleRule) {
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	n, size, err := lom.DelNoncurrent(j.now.Add(-time.Duration(rule.NoncurrentDays) * day))
+	if err != nil {
+		glog.Errorf("%s: rule %q: failed to delete non-current versions of %s: %v", j, rule.ID, lom, err)
+	}
+	if n == 0 {
+		return
+	}
+	j.expired += int64(n)
+	j.expiredSize += size
+	ec.ECM.SyncVersions(lom) // (no-op unless erasure coded)
+	// delete marker with nothing left to hide
+	if lom.Load(false /*cache it*/, true /*locked*/) != nil || !lom.IsDelMarker() {
+		return
+	}
+	if vers, err := lom.Versions(); err == nil && len(vers) == 0 {
+		if err := lom.Remove(); err != nil {
+			glog.Errorf("%s: failed to remove delete marker %s: %v", j, lom, err)
+		} else if j.ini.Removed != nil {
+			j.ini.Removed(lom, 0)
+		}
+	}
+}
+
+func (j *lcyJ) evict(lom *cluster.LOM, rule *cmn.LifecycleRule) bool {
+	if !lom.Bck().IsRemote() || j.now.Sub(lom.Atime()) < time.Duration(rule.EvictDays)*day {
+		return false
+	}
+	size := lom.SizeBytes()
+	if _, err := j.ini.T.DeleteObject(lom, true /*evict*/); err != nil {
+		if !cmn.IsObjNotExist(err) && !cmn.IsErrObjLocked(err) {
+			glog.Errorf("%s: rule %q: failed to evict %s: %v", j, rule.ID, lom, err)
+		}
+		return false
+	}
+	j.evicted++
+	j.evictedSize += size
+	return true
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/event"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/fs/mpather"
 	"github.com/NVIDIA/aistore/ios"
@@ -56,6 +57,7 @@ type (
 		Buckets             []cmn.Bck // list of buckets to run LRU
 		GetFSUsedPercentage func(path string) (usedPercentage int64, ok bool)
 		GetFSStats          func(path string) (blocks, bavail uint64, bsize int64, err error)
+		Evicted             func(lom *cluster.LOM, size int64) // optional callback (e.g., bucket usage)
 		WG                  *sync.WaitGroup
 		Force               bool // Ignore LRU prop when set to be true.
 	}
@@ -324,6 +326,10 @@ func (j *lruJ) _visit(lom *cluster.LOM) (pushed bool) {
 	if lom.AtimeUnix()+int64(j.config.LRU.DontEvictTime) > j.now {
 		return
 	}
+	// object lock (see ais/tgtlock.go)
+	if lom.Bprops().ObjLock.Enabled && lom.ObjAttrs().CheckLocked(lom.Cname(), time.Unix(0, j.now), false) != nil {
+		return
+	}
 	if lom.HasCopies() && lom.IsCopy() {
 		return
 	}
@@ -374,6 +380,9 @@ func (j *lruJ) evict() (size int64, err error) {
 			continue
 		}
 		objSize := lom.SizeBytes(true /*not loaded*/)
+		if j.ini.Evicted != nil {
+			j.ini.Evicted(lom, objSize)
+		}
 		cluster.FreeLOM(lom)
 		bevicted += objSize
 		size += objSize
@@ -437,6 +446,9 @@ func evictObj(lom *cluster.LOM) (ok bool) {
 		glog.Errorf("%s: failed to remove, err: %v", lom, err)
 	}
 	lom.Unlock(true)
+	if ok {
+		event.Emit(cmn.EventObjEvicted, lom)
+	}
 	return
 }
 
@@ -0,0 +1,169 @@
+// Package sse provides server-side encryption of objects at rest
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn/debug"
+)
+
+// Envelope encryption:
+// - each object (each version thereof) gets its own randomly generated data key (DEK);
+// - object's content is encrypted with the DEK (AES-256-GCM, see stream.go);
+// - DEK is then encrypted ("wrapped") with a key-encryption key (KEK) identified by key ID;
+// - both key ID and wrapped DEK are stored as part of the object's metadata.
+// KEKs never leave the key provider.
+
+const (
+	EnvProvider = "AIS_SSE_PROVIDER" // name of the key provider (default: "file")
+	EnvKeysFile = "AIS_SSE_KEYS"     // file provider: pathname of the keys file
+
+	DfltKeysFname = "sse_keys.json" // file provider: default keys file (in the config directory)
+	DfltKeyID     = "default"
+
+	KeySize = 32 // AES-256
+)
+
+type (
+	// Key provider manages key-encryption keys (KEKs).
+	// The interface is intentionally minimal to support external KMS-s.
+	Provider interface {
+		Name() string
+		// encrypt a (newly generated) data key with the KEK identified by `kid`
+		WrapKey(kid string, dek []byte) (wrapped []byte, err error)
+		// decrypt previously wrapped data key
+		UnwrapKey(kid string, wrapped []byte) (dek []byte, err error)
+	}
+
+	// object's data key: plaintext (never stored) and wrapped
+	DEK struct {
+		KeyID   string
+		Key     []byte
+		Wrapped string // base64
+	}
+)
+
+var (
+	providers = make(map[string]Provider, 2)
+	active    Provider
+	mu        sync.RWMutex
+)
+
+func Reg(p Provider) {
+	mu.Lock()
+	debug.Assert(providers[p.Name()] == nil, p.Name())
+	providers[p.Name()] = p
+	mu.Unlock()
+}
+
+// Init activates the provider named by EnvProvider (default: file provider)
+func Init(confDir string) {
+	Reg(newFileProvider(confDir))
+	name := os.Getenv(EnvProvider)
+	if name == "" {
+		name = fileProviderName
+	}
+	if err := Activate(name); err != nil {
+		glog.Error(err)
+	}
+}
+
+func Activate(name string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	p, ok := providers[name]
+	if !ok {
+		return fmt.Errorf("sse: unknown key provider %q", name)
+	}
+	active = p
+	return nil
+}
+
+func provider() (p Provider, err error) {
+	mu.RLock()
+	p = active
+	mu.RUnlock()
+	if p == nil {
+		err = fmt.Errorf("sse: key provider is not configured (see %s)", EnvProvider)
+	}
+	return
+}
+
+// NewDEK generates a new data key and wraps it with the given KEK
+func NewDEK(kid string) (*DEK, error) {
+	if kid == "" {
+		kid = DfltKeyID
+	}
+	p, err := provider()
+	if err != nil {
+		return nil, err
+	}
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	wrapped, err := p.WrapKey(kid, key)
+	if err != nil {
+		return nil, err
+	}
+	return &DEK{KeyID: kid, Key: key, Wrapped: base64.StdEncoding.EncodeToString(wrapped)}, nil
+}
+
+// UnwrapDEK restores data key from the object's metadata
+func UnwrapDEK(kid, wrapped string) ([]byte, error) {
+	p, err := provider()
+	if err != nil {
+		return nil, err
+	}
+	b, err := base64.StdEncoding.DecodeString(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("sse: invalid wrapped key: %v", err)
+	}
+	return p.UnwrapKey(kid, b)
+}
+
+//
+// AES-GCM key wrapping (can be used by providers that keep KEKs locally)
+//
+
+func Seal(kek, plaintext []byte) ([]byte, error) {
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func Open(kek, sealed []byte) ([]byte, error) {
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	ns := aead.NonceSize()
+	if len(sealed) < ns+aead.Overhead() {
+		return nil, fmt.Errorf("sse: sealed key too short (%d)", len(sealed))
+	}
+	return aead.Open(nil, sealed[:ns], sealed[ns:], nil)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,148 @@
+// Package sse provides server-side encryption of objects at rest
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func writeEnc(t *testing.T, dir string, dek, plain []byte) string {
+	fqn := filepath.Join(dir, fmt.Sprintf("obj-%d", len(plain)))
+	fh, err := os.Create(fqn)
+	tassert.CheckFatal(t, err)
+	w, err := NewWriter(fh, dek)
+	tassert.CheckFatal(t, err)
+	// write in odd-sized pieces to cross chunk boundaries
+	for b := plain; len(b) > 0; {
+		n := 1000 + len(b)%3333
+		if n > len(b) {
+			n = len(b)
+		}
+		_, err := w.Write(b[:n])
+		tassert.CheckFatal(t, err)
+		b = b[n:]
+	}
+	tassert.CheckFatal(t, w.Close())
+	tassert.CheckFatal(t, fh.Close())
+
+	finfo, err := os.Stat(fqn)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, finfo.Size() == CipherSize(int64(len(plain))), "size %d: expected %d, got %d",
+		len(plain), CipherSize(int64(len(plain))), finfo.Size())
+	return fqn
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		dek = make([]byte, KeySize)
+	)
+	_, _ = rand.Read(dek)
+	for _, size := range []int{0, 1, ChunkSize - 1, ChunkSize, ChunkSize + 1, 3*ChunkSize + 17} {
+		plain := make([]byte, size)
+		_, _ = rand.Read(plain)
+		fqn := writeEnc(t, dir, dek, plain)
+
+		r, err := NewReader(fqn, dek, int64(size))
+		tassert.CheckFatal(t, err)
+		got, err := io.ReadAll(r)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, bytes.Equal(got, plain), "size %d: content mismatch", size)
+
+		// random access
+		if size > 2 {
+			off, n := int64(size/3), size/2
+			buf := make([]byte, n)
+			k, err := r.ReadAt(buf, off)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, k == n && bytes.Equal(buf, plain[off:off+int64(n)]), "size %d: range mismatch", size)
+
+			_, err = r.Seek(-1, io.SeekEnd)
+			tassert.CheckFatal(t, err)
+			got, err = io.ReadAll(r)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, bytes.Equal(got, plain[size-1:]), "size %d: seek-end mismatch", size)
+		}
+		tassert.CheckFatal(t, r.Close())
+	}
+}
+
+func TestStreamTamper(t *testing.T) {
+	var (
+		dir   = t.TempDir()
+		dek   = make([]byte, KeySize)
+		size  = 2*ChunkSize + 100
+		plain = make([]byte, size)
+	)
+	_, _ = rand.Read(dek)
+	_, _ = rand.Read(plain)
+
+	// flip a byte
+	fqn := writeEnc(t, dir, dek, plain)
+	b, err := os.ReadFile(fqn)
+	tassert.CheckFatal(t, err)
+	b[ChunkSize+tagSize+5] ^= 0xff
+	tassert.CheckFatal(t, os.WriteFile(fqn, b, 0o644))
+	r, err := NewReader(fqn, dek, int64(size))
+	tassert.CheckFatal(t, err)
+	_, err = io.ReadAll(r)
+	tassert.Fatalf(t, err != nil, "expected error reading tampered content")
+	r.Close()
+
+	// truncate at the chunk boundary (and lie about the size)
+	fqn = writeEnc(t, dir, dek, plain)
+	tassert.CheckFatal(t, os.Truncate(fqn, 2*sealedChunk))
+	r, err = NewReader(fqn, dek, 2*ChunkSize)
+	tassert.CheckFatal(t, err)
+	_, err = io.ReadAll(r)
+	tassert.Fatalf(t, err != nil, "expected error reading truncated content")
+	r.Close()
+
+	// wrong key
+	fqn = writeEnc(t, dir, dek, plain)
+	other := make([]byte, KeySize)
+	_, _ = rand.Read(other)
+	r, err = NewReader(fqn, other, int64(size))
+	tassert.CheckFatal(t, err)
+	_, err = io.ReadAll(r)
+	tassert.Fatalf(t, err != nil, "expected error reading with a wrong key")
+	r.Close()
+}
+
+func TestFileProvider(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		kek = make([]byte, KeySize)
+	)
+	_, _ = rand.Read(kek)
+	keys := fmt.Sprintf("{%q: %q}", DfltKeyID, base64.StdEncoding.EncodeToString(kek))
+	fpath := filepath.Join(dir, "keys.json")
+	tassert.CheckFatal(t, os.WriteFile(fpath, []byte(keys), 0o600))
+	t.Setenv(EnvKeysFile, fpath)
+
+	fp := newFileProvider(dir)
+	Reg(fp)
+	tassert.CheckFatal(t, Activate(fileProviderName))
+
+	dek, err := NewDEK("")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, dek.KeyID == DfltKeyID, "expected default key ID, got %q", dek.KeyID)
+
+	key, err := UnwrapDEK(dek.KeyID, dek.Wrapped)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(key, dek.Key), "unwrapped key mismatch")
+
+	_, err = NewDEK("no-such-key")
+	tassert.Errorf(t, err != nil, "expected error on unknown key ID")
+}
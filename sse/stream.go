@@ -0,0 +1,231 @@
+// Package sse provides server-side encryption of objects at rest
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// On-disk format: plaintext is split into ChunkSize chunks, each sealed with
+// AES-256-GCM using the object's data key and the chunk's index as a nonce
+// (the key is unique per object version - nonces never repeat).
+// The last chunk is additionally authenticated as such, to detect truncation.
+// An empty object is stored as a single sealed empty chunk.
+//
+// Plaintext offsets map to sealed chunks directly, which makes for efficient
+// range reads (see Reader.ReadAt).
+
+const (
+	ChunkSize = 64 * cos.KiB
+
+	tagSize     = 16 // GCM
+	nonceSize   = 12 // ditto
+	sealedChunk = ChunkSize + tagSize
+)
+
+type (
+	Writer struct {
+		w     io.Writer
+		aead  cipher.AEAD
+		buf   []byte // plaintext (up to ChunkSize)
+		out   []byte // sealed
+		idx   uint64
+		nonce [nonceSize]byte
+	}
+	// Reader decrypts object's content; implements cos.LomReader
+	// NOTE: not safe for concurrent use
+	Reader struct {
+		fh      *os.File
+		aead    cipher.AEAD
+		fqn     string
+		dek     []byte
+		plain   []byte // last decrypted chunk
+		sealed  []byte
+		size    int64 // plaintext size
+		nchunks int64
+		cidx    int64 // index of the `plain` chunk
+		off     int64 // Read/Seek offset
+		nonce   [nonceSize]byte
+	}
+)
+
+var errSseTrunc = errors.New("sse: truncated or corrupted content")
+
+// interface guard
+var _ cos.LomReader = (*Reader)(nil)
+
+// CipherSize returns the size of the stored (encrypted) content
+func CipherSize(size int64) int64 {
+	n := (size + ChunkSize - 1) / ChunkSize
+	if n == 0 {
+		n = 1
+	}
+	return size + n*tagSize
+}
+
+func aad(final bool) []byte {
+	if final {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+////////////
+// Writer //
+////////////
+
+// NewWriter returns encrypting writer; the caller must Close it to flush
+// the last chunk (closing does not close the underlying writer)
+func NewWriter(w io.Writer, dek []byte) (*Writer, error) {
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{
+		w:    w,
+		aead: aead,
+		buf:  make([]byte, 0, ChunkSize),
+		out:  make([]byte, 0, sealedChunk),
+	}, nil
+}
+
+func (w *Writer) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		// seal full chunk only when there's more to write (ie., it is not the last one)
+		if len(w.buf) == ChunkSize {
+			if err = w.seal(false); err != nil {
+				return
+			}
+		}
+		l := len(w.buf)
+		k := copy(w.buf[l:ChunkSize], p)
+		w.buf = w.buf[:l+k]
+		n += k
+		p = p[k:]
+	}
+	return
+}
+
+func (w *Writer) Close() error { return w.seal(true) }
+
+func (w *Writer) seal(final bool) error {
+	binary.BigEndian.PutUint64(w.nonce[nonceSize-8:], w.idx)
+	w.out = w.aead.Seal(w.out[:0], w.nonce[:], w.buf, aad(final))
+	if _, err := w.w.Write(w.out); err != nil {
+		return err
+	}
+	w.idx++
+	w.buf = w.buf[:0]
+	return nil
+}
+
+////////////
+// Reader //
+////////////
+
+// NewReader opens encrypted content of a given (plaintext) size
+func NewReader(fqn string, dek []byte, size int64) (*Reader, error) {
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return nil, err
+	}
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	nchunks := (size + ChunkSize - 1) / ChunkSize
+	if nchunks == 0 {
+		nchunks = 1
+	}
+	return &Reader{fh: fh, aead: aead, fqn: fqn, dek: dek, size: size, nchunks: nchunks, cidx: -1}, nil
+}
+
+func (r *Reader) Size() int64 { return r.size }
+
+func (r *Reader) Open() (cos.ReadOpenCloser, error) { return NewReader(r.fqn, r.dek, r.size) }
+
+func (r *Reader) Close() error { return r.fh.Close() }
+
+func (r *Reader) Read(p []byte) (n int, err error) {
+	n, err = r.ReadAt(p, r.off)
+	r.off += int64(n)
+	if n > 0 && err == io.EOF {
+		err = nil
+	}
+	return
+}
+
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.off
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, fmt.Errorf("sse: invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("sse: negative seek offset %d", offset)
+	}
+	r.off = offset
+	return offset, nil
+}
+
+func (r *Reader) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, fmt.Errorf("sse: negative offset %d", off)
+	}
+	for len(p) > 0 && off < r.size {
+		idx := off / ChunkSize
+		if err = r.load(idx); err != nil {
+			return
+		}
+		k := copy(p, r.plain[off-idx*ChunkSize:])
+		n += k
+		off += int64(k)
+		p = p[k:]
+	}
+	if len(p) > 0 {
+		err = io.EOF
+	}
+	return
+}
+
+func (r *Reader) load(idx int64) error {
+	if idx == r.cidx {
+		return nil
+	}
+	plen := r.size - idx*ChunkSize
+	if plen > ChunkSize {
+		plen = ChunkSize
+	}
+	if r.sealed == nil {
+		r.sealed = make([]byte, sealedChunk)
+		r.plain = make([]byte, 0, ChunkSize)
+	}
+	sealed := r.sealed[:plen+tagSize]
+	if _, err := r.fh.ReadAt(sealed, idx*sealedChunk); err != nil {
+		if err == io.EOF {
+			err = errSseTrunc
+		}
+		return err
+	}
+	binary.BigEndian.PutUint64(r.nonce[nonceSize-8:], uint64(idx))
+	plain, err := r.aead.Open(r.plain[:0], r.nonce[:], sealed, aad(idx == r.nchunks-1))
+	if err != nil {
+		r.cidx = -1
+		return fmt.Errorf("%w (%s, chunk %d): %v", errSseTrunc, r.fqn, idx, err)
+	}
+	r.plain, r.cidx = plain, idx
+	return nil
+}
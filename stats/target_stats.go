@@ -42,9 +42,16 @@ const (
 	CleanupStoreCount = "cleanup.store.n"
 	CleanupStoreSize  = "cleanup.store.size"
 
+	LcyExpireCount = "lcy.expire.n"
+	LcyExpireSize  = "lcy.expire.size"
+
 	VerChangeCount = "ver.change.n"
 	VerChangeSize  = "ver.change.size"
 
+	// bucket event notifications (see event package)
+	EventSentCount = "event.sent.n"
+	EventDropCount = "event.drop.n"
+
 	// intra-cluster transmit & receive
 	StreamsOutObjCount = transport.OutObjCount
 	StreamsOutObjSize  = transport.OutObjSize
@@ -56,6 +63,7 @@ const (
 	ErrCksumSize     = "err.cksum.size"
 	ErrMetadataCount = "err.md.n"
 	ErrIOCount       = "err.io.n"
+	ErrEventCount    = "err.event.n" // failed (and retried) event deliveries
 	// special
 	RestartCount = "restart.n"
 
@@ -189,9 +197,15 @@ func (r *Trunner) RegMetrics(node *cluster.Snode) {
 	r.reg(CleanupStoreCount, KindCounter)
 	r.reg(CleanupStoreSize, KindSize)
 
+	r.reg(LcyExpireCount, KindCounter)
+	r.reg(LcyExpireSize, KindSize)
+
 	r.reg(VerChangeCount, KindCounter)
 	r.reg(VerChangeSize, KindSize)
 
+	r.reg(EventSentCount, KindCounter)
+	r.reg(EventDropCount, KindCounter)
+
 	r.reg(PutLatency, KindLatency)
 	r.reg(AppendLatency, KindLatency)
 	r.reg(GetRedirLatency, KindLatency)
@@ -210,6 +224,7 @@ func (r *Trunner) RegMetrics(node *cluster.Snode) {
 
 	r.reg(ErrMetadataCount, KindCounter)
 	r.reg(ErrIOCount, KindCounter)
+	r.reg(ErrEventCount, KindCounter)
 
 	// streams
 	r.reg(StreamsOutObjCount, KindCounter)
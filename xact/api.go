@@ -111,9 +111,12 @@ var Table = map[string]Descriptor{
 	apc.ActRebalance: {Scope: ScopeG, Startable: true, Metasync: true, Owned: false, Mountpath: true, Rebalance: true},
 	apc.ActDownload:  {Scope: ScopeG, Startable: false, Mountpath: true, Idles: true},
 	apc.ActETLInline: {Scope: ScopeG, Startable: false, Mountpath: false},
+	apc.ActGetBatch:  {Scope: ScopeG, Startable: false, Idles: true},
+	apc.ActCompose:   {Scope: ScopeG, Startable: false, Idles: true},
 
 	// (one bucket) | (all buckets)
 	apc.ActLRU:          {DisplayName: "lru-eviction", Scope: ScopeGB, Startable: true, Mountpath: true},
+	apc.ActLifecycle:    {DisplayName: "lifecycle", Scope: ScopeGB, Startable: true, Mountpath: true},
 	apc.ActStoreCleanup: {DisplayName: "cleanup", Scope: ScopeGB, Startable: true, Mountpath: true},
 	apc.ActSummaryBck: {
 		DisplayName: "summary",
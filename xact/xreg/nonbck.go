@@ -40,6 +40,11 @@ func RenewLRU(id string) RenewRes {
 	return dreg.renew(e, nil)
 }
 
+func RenewLifecycle(id string) RenewRes {
+	e := dreg.nonbckXacts[apc.ActLifecycle].New(Args{UUID: id}, nil)
+	return dreg.renew(e, nil)
+}
+
 func RenewStoreCleanup(id string) RenewRes {
 	e := dreg.nonbckXacts[apc.ActStoreCleanup].New(Args{UUID: id}, nil)
 	return dreg.renew(e, nil)
@@ -55,6 +60,16 @@ func RenewETL(t cluster.Target, msg any, xid string) RenewRes {
 	return dreg.renew(e, nil)
 }
 
+func RenewGetBatch(t cluster.Target, xid string) RenewRes {
+	e := dreg.nonbckXacts[apc.ActGetBatch].New(Args{T: t, UUID: xid}, nil)
+	return dreg.renew(e, nil)
+}
+
+func RenewCompose(t cluster.Target, xid string) RenewRes {
+	e := dreg.nonbckXacts[apc.ActCompose].New(Args{T: t, UUID: xid}, nil)
+	return dreg.renew(e, nil)
+}
+
 func RenewBckSummary(t cluster.Target, bck *cluster.Bck, msg *cmn.BsummCtrlMsg) RenewRes {
 	e := dreg.nonbckXacts[apc.ActSummaryBck].New(Args{T: t, UUID: msg.UUID, Custom: msg}, bck)
 	return dreg.renew(e, bck)
@@ -439,7 +439,7 @@ func (wi *archwi) do(lom *cluster.LOM, lrit *lriterator) {
 		}
 	}
 
-	fh, err := cos.NewFileHandle(lom.FQN)
+	fh, err := lom.Open(lom.FQN)
 	debug.AssertNoErr(err)
 	if err != nil {
 		wi.r.raiseErr(err, wi.msg.ContinueOnError)
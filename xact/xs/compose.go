@@ -0,0 +1,253 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/transport"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// compose (server-side concatenation of objects and/or their byte ranges)
+//
+// The proxy redirects `cmn.ComposeMsg` to the target that owns the destination
+// object - the designated target (DT) that writes the destination in the source order:
+// - local sources are read directly;
+// - remote ones are pulled from their respective (HRW) owners, one at a time:
+//   the DT activates the owner (see ais/tgtcompose.go) and, upon receiving, copies
+//   the source (range) directly into the destination (see `recv`) - no buffering.
+// Peer targets, in turn, read the requested source (range) and `Send` it to the DT.
+
+const cmpTrname = "compose" // NOTE: bucket-less, must be identical across all targets
+
+type (
+	// open source object (or its range) for reading - provided by the target
+	ComposeOpen func(src *cmn.ComposeSrc) (cos.ReadCloseSizer, error)
+	// activate the owner of the (remote) source #idx
+	ComposePull func(idx int, src *cmn.ComposeSrc) error
+
+	cmpFactory struct {
+		streamingF
+	}
+	XactCompose struct {
+		dtXact
+	}
+	// designated target's work item
+	ComposeWi struct {
+		r     *XactCompose
+		w     io.Writer
+		uuid  string
+		rx    *cmprx // remote source that is currently being pulled, if any
+		buf   []byte
+		mu    sync.Mutex
+		ended bool
+	}
+	cmprx struct {
+		err  error
+		done chan struct{}
+		idx  int
+		n    int64
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*XactCompose)(nil)
+	_ xreg.Renewable = (*cmpFactory)(nil)
+	_ dtWi           = (*ComposeWi)(nil)
+)
+
+////////////////
+// cmpFactory //
+////////////////
+
+func (*cmpFactory) New(args xreg.Args, _ *cluster.Bck) xreg.Renewable {
+	p := &cmpFactory{streamingF: streamingF{RenewBase: xreg.RenewBase{Args: args}, kind: apc.ActCompose}}
+	return p
+}
+
+func (p *cmpFactory) Start() error {
+	r := &XactCompose{}
+	return r.init(&p.streamingF, r, apc.ActCompose, "compose", cmpTrname, r.recv)
+}
+
+/////////////////
+// XactCompose //
+/////////////////
+
+// (designated target) start a new work item that'll write the sources into `w`
+func (r *XactCompose) Begin(uuid string, w io.Writer) (*ComposeWi, error) {
+	wi := &ComposeWi{r: r, w: w, uuid: uuid}
+	if err := r.begin(uuid, wi); err != nil {
+		return nil, err
+	}
+	return wi, nil
+}
+
+// (designated target) done with the work item, successfully or otherwise
+// NOTE: upon return, the work item no longer writes (and the caller may close its writer)
+func (r *XactCompose) End(wi *ComposeWi) { r.end(wi.uuid, wi) }
+
+// (peer target) read the source #idx and send it over to the designated target `tsi`
+func (r *XactCompose) Send(uuid string, tsi *cluster.Snode, idx int, src *cmn.ComposeSrc, open ComposeOpen) error {
+	return r.goSend(func() {
+		r.sendEntry(uuid, idx, tsi, &src.Bck, src.ObjName, func() (cos.ReadCloseSizer, error) { return open(src) })
+	})
+}
+
+func (r *XactCompose) recv(hdr transport.ObjHdr, objReader io.Reader, err error) error {
+	return r.dtXact.recv(&hdr, objReader, err, cmpParseOpaque, r.deliver)
+}
+
+func (r *XactCompose) deliver(dwi dtWi, idx int, hdr *transport.ObjHdr, objReader io.Reader) error {
+	wi := dwi.(*ComposeWi)
+	uuid := wi.uuid
+
+	// NOTE: writing under lock (see End)
+	wi.mu.Lock()
+	defer wi.mu.Unlock()
+	rx := wi.rx
+	if wi.ended || rx == nil || rx.idx != idx {
+		if verbose {
+			glog.Warningf("%s: compose %q: not expecting source #%d (%s), dropping", r, uuid, idx, hdr.Cname())
+		}
+		return nil
+	}
+	if hdr.Opcode == opcodeMiss {
+		rx.err = errors.New(hdr.ObjName)
+	} else {
+		debug.Assert(hdr.Opcode == 0)
+		rx.n, rx.err = io.CopyBuffer(wi.w, objReader, wi.buffer())
+		if rx.err == nil {
+			if rx.n != hdr.ObjAttrs.Size {
+				rx.err = fmt.Errorf("%s: compose %q: %s short read (%d vs %d)", r, uuid, hdr.Cname(), rx.n, hdr.ObjAttrs.Size)
+			} else {
+				r.InObjsAdd(1, rx.n)
+			}
+		}
+	}
+	wi.rx = nil
+	close(rx.done)
+	return nil
+}
+
+func cmpParseOpaque(opaque []byte) (uuid string, idx int, err error) {
+	return parseOpaque("compose", opaque)
+}
+
+///////////////
+// ComposeWi //
+///////////////
+
+// (designated target) write all sources in the specified order;
+// `remote[i]` is true when the i-th source is stored elsewhere and must be pulled
+func (wi *ComposeWi) Write(msg *cmn.ComposeMsg, remote []bool, open ComposeOpen, pull ComposePull) (size int64, err error) {
+	debug.Assert(len(remote) == len(msg.Sources))
+	for i := range msg.Sources {
+		var (
+			n   int64
+			src = &msg.Sources[i]
+		)
+		if remote[i] {
+			n, err = wi.pull(i, src, pull)
+		} else {
+			n, err = wi.copy(src, open)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("compose: source #%d (%s): %w", i, src.Cname(), err)
+		}
+		wi.r.ObjsAdd(1, n)
+		size += n
+	}
+	return
+}
+
+func (wi *ComposeWi) copy(src *cmn.ComposeSrc, open ComposeOpen) (n int64, err error) {
+	reader, err := open(src)
+	if err != nil {
+		return 0, err
+	}
+	size := reader.Size()
+	n, err = io.CopyBuffer(wi.w, reader, wi.buffer())
+	cos.Close(reader)
+	if err == nil && n != size {
+		err = fmt.Errorf("short read (%d vs %d)", n, size)
+	}
+	return
+}
+
+func (wi *ComposeWi) pull(idx int, src *cmn.ComposeSrc, pull ComposePull) (int64, error) {
+	rx := &cmprx{idx: idx, done: make(chan struct{})}
+	wi.mu.Lock()
+	wi.rx = rx
+	wi.mu.Unlock()
+
+	if err := pull(idx, src); err != nil {
+		wi.unset(rx)
+		return 0, err
+	}
+
+	timer := time.NewTimer(wi.r.config.Timeout.SendFile.D())
+	defer timer.Stop()
+	select {
+	case <-rx.done:
+		return rx.n, rx.err
+	case <-timer.C:
+		if wi.unset(rx) {
+			return rx.n, rx.err // (just made it)
+		}
+		return 0, fmt.Errorf("%s: timed out waiting for %s", wi.r, src.Cname())
+	case <-wi.r.stopCh.Listen():
+		wi.unset(rx)
+		return 0, cmn.NewErrAborted(wi.r.Name(), "compose "+wi.uuid, wi.r.AbortErr())
+	}
+}
+
+// NOTE: local copies and remote receives never overlap (sources are written one at a time)
+func (wi *ComposeWi) buffer() []byte {
+	if wi.buf == nil {
+		wi.buf = make([]byte, memsys.DefaultBufSize)
+	}
+	return wi.buf
+}
+
+// stop expecting `rx`; returns true if it's been received in the meantime
+func (wi *ComposeWi) unset(rx *cmprx) (done bool) {
+	wi.mu.Lock()
+	if wi.rx == rx {
+		wi.rx = nil
+	} else {
+		done = true
+	}
+	wi.mu.Unlock()
+	return
+}
+
+func (wi *ComposeWi) cleanup() {
+	wi.mu.Lock()
+	wi.ended = true
+	wi.rx = nil
+	wi.mu.Unlock()
+}
+
+// tests only
+func TestComposeWrite(w io.Writer, msg *cmn.ComposeMsg, open ComposeOpen) (int64, error) {
+	r := &XactCompose{dtXact{streamingX: streamingX{p: &streamingF{}}}}
+	wi := &ComposeWi{r: r, w: w}
+	return wi.Write(msg, make([]bool, len(msg.Sources)), open, nil)
+}
@@ -0,0 +1,74 @@
+// Package xs_test - compose unit tests.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/xact/xs"
+)
+
+var cmpContent = map[string]string{
+	"ais://abc/a": "0123456789",
+	"gs://xyz/b":  "abcdef",
+}
+
+func cmpOpen(src *cmn.ComposeSrc) (cos.ReadCloseSizer, error) {
+	s, ok := cmpContent[src.Cname()]
+	if !ok {
+		return nil, cmn.NewErrNotFound("%s", src.Cname())
+	}
+	s = s[src.Offset:]
+	if src.Length > 0 {
+		s = s[:src.Length]
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{R: strings.NewReader(s), Size: int64(len(s))}), nil
+}
+
+func cmpMsg() *cmn.ComposeMsg {
+	return &cmn.ComposeMsg{
+		Sources: []cmn.ComposeSrc{
+			{Bck: cmn.Bck{Name: "xyz", Provider: apc.GCP}, ObjName: "b"},
+			{Bck: cmn.Bck{Name: "abc", Provider: apc.AIS}, ObjName: "a", Offset: 2, Length: 3},
+			{Bck: cmn.Bck{Name: "abc", Provider: apc.AIS}, ObjName: "a", Offset: 8},
+		},
+	}
+}
+
+func TestComposeMsgValidate(t *testing.T) {
+	tassert.CheckFatal(t, cmpMsg().Validate())
+	tassert.Errorf(t, (&cmn.ComposeMsg{}).Validate() != nil, "expected error (no sources)")
+
+	msg := cmpMsg()
+	msg.Sources[1].ObjName = ""
+	tassert.Errorf(t, msg.Validate() != nil, "expected error (no object name)")
+	msg = cmpMsg()
+	msg.Sources[2].Length = -1
+	tassert.Errorf(t, msg.Validate() != nil, "expected error (invalid range)")
+	msg = cmpMsg()
+	msg.Sources = make([]cmn.ComposeSrc, cmn.ComposeMaxSources+1)
+	tassert.Errorf(t, msg.Validate() != nil, "expected error (too many sources)")
+}
+
+func TestComposeWrite(t *testing.T) {
+	var buf bytes.Buffer
+	size, err := xs.TestComposeWrite(&buf, cmpMsg(), cmpOpen)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, buf.String() == "abcdef23489", "unexpected result %q", buf.String())
+	tassert.Errorf(t, size == int64(buf.Len()), "size %d vs %d", size, buf.Len())
+
+	// missing source fails the entire compose
+	msg := cmpMsg()
+	msg.Sources[1].ObjName = "missing"
+	buf.Reset()
+	_, err = xs.TestComposeWrite(&buf, msg, cmpOpen)
+	tassert.Errorf(t, err != nil && strings.Contains(err.Error(), "missing"), "expected error (missing source), got %v", err)
+}
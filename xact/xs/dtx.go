@@ -0,0 +1,205 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/transport"
+	"github.com/NVIDIA/aistore/xact"
+)
+
+//
+// designated-target xactions (get-batch, compose) - common logic:
+// - the designated target (DT) begins a work item (identified by its UUID) and
+//   activates the peers that own the remote entries;
+// - each peer reads the requested entries and sends them over to the DT, one
+//   transport object per entry with "<uuid>/<index>" in the header's opaque
+//   (or `opcodeMiss` when the entry cannot be read);
+// - the DT receives and delivers them to the respective work item.
+// Work items are transient (see end) - there's nothing to wait for upon finishing.
+//
+
+type (
+	dtWi interface {
+		cleanup() // stop expecting remote entries and free the resources, if any
+	}
+	// open a given entry for reading
+	dtOpen func() (cos.ReadCloseSizer, error)
+	// deliver the received entry #idx to its work item
+	dtDeliver func(wi dtWi, idx int, hdr *transport.ObjHdr, objReader io.Reader) error
+
+	dtXact struct {
+		streamingX
+		config  *cmn.Config
+		stopCh  *cos.StopCh
+		tag     string // "get-batch", et al. (logs and errors)
+		pending struct {
+			m map[string]dtWi
+			sync.RWMutex
+		}
+	}
+)
+
+func (r *dtXact) init(p *streamingF, xctn cluster.Xact, kind, tag, trname string, recv transport.RecvObj) error {
+	r.streamingX = streamingX{p: p}
+	r.config, r.stopCh, r.tag = cmn.GCO.Get(), cos.NewStopCh(), tag
+	r.pending.m = make(map[string]dtWi, maxNumInParallel)
+	p.xctn = xctn
+	r.DemandBase.Init(p.UUID(), kind, nil /*bck*/, 0 /*use default*/)
+
+	if err := p.newDM(trname, recv, 0 /*pdu*/); err != nil {
+		return err
+	}
+	r.p.dm.SetXact(xctn)
+	r.p.dm.Open()
+
+	xact.GoRunW(xctn)
+	return nil
+}
+
+func (r *dtXact) Run(wg *sync.WaitGroup) {
+	var err error
+	glog.Infoln(r.Name())
+	wg.Done()
+
+	select {
+	case <-r.IdleTimer():
+	case err = <-r.ChanAbort():
+	}
+	r.stopCh.Close() // wake up waiting work items, if any
+
+	r.streamingX.fin(err, false /*unreg Rx*/)
+	r.p.dm.UnregRecv()
+
+	r.pending.Lock()
+	for uuid, wi := range r.pending.m {
+		wi.cleanup()
+		delete(r.pending.m, uuid)
+	}
+	r.pending.Unlock()
+}
+
+func (r *dtXact) Snap() (snap *cluster.Snap) {
+	snap = &cluster.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
+
+// (designated target)
+func (r *dtXact) begin(uuid string, wi dtWi) error {
+	r.IncPending()
+	if err := r.AbortErr(); err != nil {
+		r.DecPending()
+		return cmn.NewErrAborted(r.Name(), r.tag, err)
+	}
+	r.pending.Lock()
+	r.pending.m[uuid] = wi
+	r.wiCnt.Inc()
+	r.pending.Unlock()
+	return nil
+}
+
+// (designated target)
+func (r *dtXact) end(uuid string, wi dtWi) {
+	r.pending.Lock()
+	delete(r.pending.m, uuid)
+	r.wiCnt.Dec()
+	r.pending.Unlock()
+
+	wi.cleanup()
+	r.DecPending()
+}
+
+// (peer target) run `send` asynchronously
+func (r *dtXact) goSend(send func()) error {
+	r.IncPending()
+	if err := r.AbortErr(); err != nil {
+		r.DecPending()
+		return cmn.NewErrAborted(r.Name(), r.tag, err)
+	}
+	go func() {
+		defer r.DecPending()
+		send()
+	}()
+	return nil
+}
+
+// (peer target) read entry #idx and send it to the designated target `tsi`
+func (r *dtXact) sendEntry(uuid string, idx int, tsi *cluster.Snode, bck *cmn.Bck, objName string, open dtOpen) {
+	o := transport.AllocSend()
+	o.Hdr.Bck = *bck
+	o.Hdr.ObjName = objName
+	o.Hdr.Opaque = []byte(uuid + "/" + strconv.Itoa(idx))
+	reader, err := open()
+	if err != nil {
+		o.Hdr.Opcode = opcodeMiss
+		o.Hdr.ObjName = err.Error()
+		err = r.p.dm.Send(o, nil, tsi)
+	} else {
+		size := reader.Size()
+		o.Hdr.ObjAttrs.Size = size
+		if err = r.p.dm.Send(o, cos.NopOpener(reader), tsi); err == nil {
+			r.OutObjsAdd(1, size)
+		}
+	}
+	if err != nil {
+		r.raiseErr(err, true /*contOnErr*/)
+	}
+}
+
+// (designated target) find the work item and deliver the entry
+func (r *dtXact) recv(hdr *transport.ObjHdr, objReader io.Reader, err error,
+	parse func([]byte) (string, int, error), deliver dtDeliver) error {
+	r.IncPending()
+	defer func() {
+		r.DecPending()
+		transport.DrainAndFreeReader(objReader)
+	}()
+	if err != nil && !cos.IsEOF(err) {
+		glog.Error(err)
+		return err
+	}
+
+	uuid, idx, err := parse(hdr.Opaque)
+	if err != nil {
+		return err
+	}
+	r.pending.RLock()
+	wi, ok := r.pending.m[uuid]
+	r.pending.RUnlock()
+	if !ok {
+		if verbose {
+			glog.Warningf("%s: %s %q not found (finished or canceled), dropping %s", r, r.tag, uuid, hdr.Cname())
+		}
+		return nil
+	}
+	return deliver(wi, idx, hdr, objReader)
+}
+
+// "<uuid>/<index>"
+func parseOpaque(tag string, opaque []byte) (uuid string, idx int, err error) {
+	s := string(opaque)
+	i := strings.LastIndexByte(s, '/')
+	if i <= 0 {
+		return "", 0, fmt.Errorf("%s: invalid opaque %q", tag, s)
+	}
+	uuid = s[:i]
+	if idx, err = strconv.Atoi(s[i+1:]); err != nil || idx < 0 {
+		return "", 0, fmt.Errorf("%s: invalid opaque %q", tag, s)
+	}
+	return
+}
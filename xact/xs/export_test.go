@@ -0,0 +1,43 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"regexp"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// LsoWalkArch emulates (sorted) bucket walk over the given objects (name => FQN)
+// and returns the names of the listed entries, including archived files
+func LsoWalkArch(msg *apc.LsoMsg, names []string, fqns map[string]string) (out []string, err error) {
+	r := &LsoXact{}
+	r.walk.pageCh = make(chan *cmn.LsoEntry, 1024)
+	r.walk.stopCh = &cos.StopCh{}
+	r.walk.stopCh.Init()
+	r.walk.wi = &walkInfo{msg: msg}
+	if msg.ArchRegex != "" {
+		r.walk.wi.archRegex = regexp.MustCompile(msg.ArchRegex)
+	}
+	for _, name := range names {
+		if !r.walk.wi.match(name) {
+			continue
+		}
+		if err = r.cbEntry(fqns[name], &cmn.LsoEntry{Name: name}); err != nil {
+			return
+		}
+	}
+	if err = r.flushArch(""); err != nil {
+		return
+	}
+	close(r.walk.pageCh)
+	for e := range r.walk.pageCh {
+		out = append(out, e.Name)
+	}
+	return
+}
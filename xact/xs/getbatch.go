@@ -0,0 +1,413 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/transport"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/vmihailenco/msgpack"
+)
+
+// get-batch (multi-object GET)
+//
+// The proxy redirects `cmn.GetBatchMsg` to a designated target (DT) that:
+// - partitions the entries by their (HRW) owners;
+// - activates the owning peers, each with its own subset (see ais/tgtbatch.go);
+// - writes the resulting archive in the request order: local entries are read
+//   directly, remote ones are received via transport (see `recv`) and, if need be,
+//   awaited;
+// - writes placeholders (see cmn.GetBatchMissingPrefix) in place of the entries
+//   that cannot be read.
+// Peer targets, in turn, read their respective entries and `Send` them to the DT.
+
+const (
+	gbTrname = "getbatch" // NOTE: bucket-less, must be identical across all targets
+
+	opcodeMiss = opcodeAbrt + 1 // peer failed to read (e.g., object does not exist)
+)
+
+const gbErrKey = "AIS.error" // tar PAX record and msgpack key carrying the placeholder's error
+
+type (
+	// open object (or archived file) for reading - provided by the target
+	BatchOpen func(e *cmn.GetBatchEntry) (cos.ReadCloseSizer, error)
+
+	gbFactory struct {
+		streamingF
+	}
+	XactGetBatch struct {
+		dtXact
+	}
+	// designated target's work item
+	GetBatchWi struct {
+		r        *XactGetBatch
+		msg      *cmn.GetBatchMsg
+		uuid     string
+		rx       []*gbrx // by entry index; nil for local entries
+		deadline time.Time
+		mu       sync.Mutex
+		ended    bool
+	}
+	gbrx struct {
+		sgl  *memsys.SGL
+		err  error
+		done chan struct{}
+	}
+
+	// output formats
+	gbWriter interface {
+		write(name string, reader io.Reader, size int64) error
+		missing(name string, err error) error
+		fini() error
+	}
+	gbTarWriter struct {
+		tw    *tar.Writer
+		mtime time.Time
+		buf   []byte
+	}
+	gbMsgpackWriter struct {
+		w   io.Writer
+		enc *msgpack.Encoder
+		buf []byte
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*XactGetBatch)(nil)
+	_ xreg.Renewable = (*gbFactory)(nil)
+	_ dtWi           = (*GetBatchWi)(nil)
+
+	_ gbWriter = (*gbTarWriter)(nil)
+	_ gbWriter = (*gbMsgpackWriter)(nil)
+)
+
+///////////////
+// gbFactory //
+///////////////
+
+func (*gbFactory) New(args xreg.Args, _ *cluster.Bck) xreg.Renewable {
+	p := &gbFactory{streamingF: streamingF{RenewBase: xreg.RenewBase{Args: args}, kind: apc.ActGetBatch}}
+	return p
+}
+
+func (p *gbFactory) Start() error {
+	r := &XactGetBatch{}
+	return r.init(&p.streamingF, r, apc.ActGetBatch, "get-batch", gbTrname, r.recv)
+}
+
+//////////////////
+// XactGetBatch //
+//////////////////
+
+// (designated target) start a new work item; `local[i]` is true when the i-th entry is stored locally
+func (r *XactGetBatch) Begin(uuid string, msg *cmn.GetBatchMsg, local []bool) (*GetBatchWi, error) {
+	debug.Assert(len(local) == len(msg.Entries))
+	wi := &GetBatchWi{
+		r:        r,
+		msg:      msg,
+		uuid:     uuid,
+		rx:       make([]*gbrx, len(msg.Entries)),
+		deadline: time.Now().Add(r.config.Timeout.SendFile.D()),
+	}
+	for i, isLocal := range local {
+		if !isLocal {
+			wi.rx[i] = &gbrx{done: make(chan struct{})}
+		}
+	}
+	if err := r.begin(uuid, wi); err != nil {
+		return nil, err
+	}
+	return wi, nil
+}
+
+// (designated target) done with the work item, successfully or otherwise
+func (r *XactGetBatch) End(wi *GetBatchWi) { r.end(wi.uuid, wi) }
+
+// (peer target) read the entries and send them over to the designated target `tsi`
+func (r *XactGetBatch) Send(uuid string, tsi *cluster.Snode, idx []int, entries []cmn.GetBatchEntry, open BatchOpen) error {
+	debug.Assert(len(idx) == len(entries))
+	return r.goSend(func() {
+		for i := range entries {
+			if r.IsAborted() {
+				return
+			}
+			e := &entries[i]
+			r.sendEntry(uuid, idx[i], tsi, &e.Bck, e.ObjName, func() (cos.ReadCloseSizer, error) { return open(e) })
+		}
+	})
+}
+
+func (r *XactGetBatch) recv(hdr transport.ObjHdr, objReader io.Reader, err error) error {
+	return r.dtXact.recv(&hdr, objReader, err, gbParseOpaque, r.deliver)
+}
+
+func (r *XactGetBatch) deliver(dwi dtWi, idx int, hdr *transport.ObjHdr, objReader io.Reader) (err error) {
+	wi := dwi.(*GetBatchWi)
+	if idx >= len(wi.rx) || wi.rx[idx] == nil {
+		return fmt.Errorf("%s: get-batch %q: unexpected entry #%d (%s)", r, wi.uuid, idx, hdr.Cname())
+	}
+
+	// receive
+	var (
+		sgl      *memsys.SGL
+		errEntry error
+	)
+	if hdr.Opcode == opcodeMiss {
+		errEntry = errors.New(hdr.ObjName)
+	} else {
+		debug.Assert(hdr.Opcode == 0)
+		sgl = r.p.T.PageMM().NewSGL(hdr.ObjAttrs.Size)
+		if _, err = io.Copy(sgl, objReader); err != nil {
+			sgl.Free()
+			sgl, errEntry = nil, err
+		} else {
+			r.InObjsAdd(1, hdr.ObjAttrs.Size)
+		}
+	}
+
+	// deliver
+	wi.mu.Lock()
+	if wi.ended {
+		if sgl != nil {
+			sgl.Free()
+		}
+	} else {
+		rx := wi.rx[idx]
+		rx.sgl, rx.err = sgl, errEntry
+		close(rx.done)
+	}
+	wi.mu.Unlock()
+	return nil
+}
+
+////////////////
+// GetBatchWi //
+////////////////
+
+// (designated target) write resulting archive in the request order
+func (wi *GetBatchWi) Write(w io.Writer, open BatchOpen) (err error) {
+	var (
+		aw gbWriter
+		r  = wi.r
+	)
+	switch wi.msg.Mime {
+	case cos.ExtMsgpack:
+		aw, err = newMsgpackWriter(w, len(wi.msg.Entries))
+		if err != nil {
+			return
+		}
+	default:
+		debug.Assert(wi.msg.Mime == cos.ExtTar, wi.msg.Mime)
+		aw = newTarWriter(w)
+	}
+	for i := range wi.msg.Entries {
+		var (
+			e        = &wi.msg.Entries[i]
+			name     = e.NameInArch()
+			reader   cos.ReadCloseSizer
+			errEntry error
+		)
+		if rx := wi.rx[i]; rx == nil {
+			reader, errEntry = open(e)
+		} else {
+			reader, errEntry, err = wi.wait(rx, e)
+			if err != nil {
+				break
+			}
+		}
+		if errEntry != nil {
+			if verbose {
+				glog.Infof("%s: get-batch %q: %s placeholder: %v", r, wi.uuid, e.Cname(), errEntry)
+			}
+			if err = aw.missing(name, errEntry); err != nil {
+				break
+			}
+			continue
+		}
+		size := reader.Size()
+		err = aw.write(name, reader, size)
+		cos.Close(reader)
+		if err != nil {
+			break
+		}
+		r.ObjsAdd(1, size)
+	}
+	if err == nil {
+		err = aw.fini()
+	}
+	return
+}
+
+func (wi *GetBatchWi) wait(rx *gbrx, e *cmn.GetBatchEntry) (reader cos.ReadCloseSizer, errEntry, err error) {
+	timer := time.NewTimer(time.Until(wi.deadline))
+	defer timer.Stop()
+	select {
+	case <-rx.done:
+		if rx.err != nil {
+			return nil, rx.err, nil
+		}
+		// NOTE: handing over the SGL - to be freed upon reader's Close()
+		wi.mu.Lock()
+		sgl := rx.sgl
+		rx.sgl = nil
+		wi.mu.Unlock()
+		return cos.NewReaderWithArgs(cos.ReaderArgs{R: sgl, Size: sgl.Size(), DeferCb: sgl.Free}), nil, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("%s: timed out waiting for %s", wi.r, e.Cname()), nil
+	case <-wi.r.stopCh.Listen():
+		return nil, nil, cmn.NewErrAborted(wi.r.Name(), "get-batch "+wi.uuid, wi.r.AbortErr())
+	}
+}
+
+// mark the (remote) entry as failed - e.g., when its owner cannot be reached
+func (wi *GetBatchWi) Fail(idx int, err error) {
+	wi.mu.Lock()
+	rx := wi.rx[idx]
+	select {
+	case <-rx.done:
+	default:
+		rx.err = err
+		close(rx.done)
+	}
+	wi.mu.Unlock()
+}
+
+func (wi *GetBatchWi) cleanup() {
+	wi.mu.Lock()
+	if !wi.ended {
+		wi.ended = true
+		for _, rx := range wi.rx {
+			if rx != nil && rx.sgl != nil {
+				rx.sgl.Free()
+				rx.sgl = nil
+			}
+		}
+	}
+	wi.mu.Unlock()
+}
+
+func gbParseOpaque(opaque []byte) (uuid string, idx int, err error) {
+	return parseOpaque("get-batch", opaque)
+}
+
+/////////////////
+// gbTarWriter //
+/////////////////
+
+func newTarWriter(w io.Writer) *gbTarWriter {
+	return &gbTarWriter{tw: tar.NewWriter(w), mtime: time.Now()}
+}
+
+func (aw *gbTarWriter) write(name string, reader io.Reader, size int64) (err error) {
+	tarhdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     int64(cos.PermRWR),
+		ModTime:  aw.mtime,
+	}
+	if err = aw.tw.WriteHeader(tarhdr); err != nil {
+		return
+	}
+	if aw.buf == nil {
+		aw.buf = make([]byte, memsys.DefaultBufSize)
+	}
+	_, err = io.CopyBuffer(aw.tw, io.LimitReader(reader, size), aw.buf)
+	return
+}
+
+func (aw *gbTarWriter) missing(name string, errEntry error) error {
+	tarhdr := &tar.Header{
+		Typeflag:   tar.TypeReg,
+		Name:       cmn.GetBatchMissingPrefix + name,
+		Mode:       int64(cos.PermRWR),
+		ModTime:    aw.mtime,
+		PAXRecords: map[string]string{gbErrKey: errEntry.Error()},
+		Format:     tar.FormatPAX,
+	}
+	return aw.tw.WriteHeader(tarhdr)
+}
+
+func (aw *gbTarWriter) fini() error { return aw.tw.Close() }
+
+/////////////////////
+// gbMsgpackWriter //
+/////////////////////
+
+// array of maps (one per entry, in the request order):
+// {"name": string, "data": bin} or, for placeholders, {"name": string, "data": <empty>, gbErrKey: string}
+func newMsgpackWriter(w io.Writer, num int) (*gbMsgpackWriter, error) {
+	aw := &gbMsgpackWriter{w: w, enc: msgpack.NewEncoder(w)}
+	return aw, aw.enc.EncodeArrayLen(num)
+}
+
+func (aw *gbMsgpackWriter) write(name string, reader io.Reader, size int64) (err error) {
+	if err = aw.enc.EncodeMapLen(2); err != nil {
+		return
+	}
+	if err = aw.hdr(name, size); err != nil {
+		return
+	}
+	if aw.buf == nil {
+		aw.buf = make([]byte, memsys.DefaultBufSize)
+	}
+	var n int64
+	n, err = io.CopyBuffer(aw.w, io.LimitReader(reader, size), aw.buf)
+	if err == nil && n != size {
+		err = fmt.Errorf("get-batch: %s: short read (%d vs %d)", name, n, size)
+	}
+	return
+}
+
+func (aw *gbMsgpackWriter) missing(name string, errEntry error) (err error) {
+	if err = aw.enc.EncodeMapLen(3); err != nil {
+		return
+	}
+	if err = aw.hdr(cmn.GetBatchMissingPrefix+name, 0); err != nil {
+		return
+	}
+	if err = aw.enc.EncodeString(gbErrKey); err != nil {
+		return
+	}
+	return aw.enc.EncodeString(errEntry.Error())
+}
+
+func (aw *gbMsgpackWriter) hdr(name string, size int64) (err error) {
+	if err = aw.enc.EncodeString("name"); err != nil {
+		return
+	}
+	if err = aw.enc.EncodeString(name); err != nil {
+		return
+	}
+	if err = aw.enc.EncodeString("data"); err != nil {
+		return
+	}
+	return aw.enc.EncodeBytesLen(int(size))
+}
+
+func (*gbMsgpackWriter) fini() error { return nil }
+
+// tests only
+func TestGetBatchWrite(w io.Writer, msg *cmn.GetBatchMsg, open BatchOpen) error {
+	r := &XactGetBatch{dtXact{streamingX: streamingX{p: &streamingF{}}}}
+	wi := &GetBatchWi{r: r, msg: msg, rx: make([]*gbrx, len(msg.Entries))}
+	return wi.Write(w, open)
+}
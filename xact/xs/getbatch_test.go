@@ -0,0 +1,112 @@
+// Package xs_test - get-batch unit tests.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/xact/xs"
+	"github.com/vmihailenco/msgpack"
+)
+
+var gbContent = map[string]string{
+	"ais://abc/1.jpg":           "one",
+	"gcp://xyz/2.jpg":           "two-two",
+	"ais://abc/shard.tar/3.jpg": "three-three-three",
+}
+
+func gbOpen(e *cmn.GetBatchEntry) (cos.ReadCloseSizer, error) {
+	s, ok := gbContent[e.Cname()]
+	if !ok {
+		return nil, cmn.NewErrNotFound("%s", e.Cname())
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{R: strings.NewReader(s), Size: int64(len(s))}), nil
+}
+
+func gbMsg(mime string) *cmn.GetBatchMsg {
+	msg := &cmn.GetBatchMsg{
+		Mime: mime,
+		Entries: []cmn.GetBatchEntry{
+			{Bck: cmn.Bck{Name: "xyz", Provider: apc.GCP}, ObjName: "2.jpg"},
+			{Bck: cmn.Bck{Name: "abc", Provider: apc.AIS}, ObjName: "missing"},
+			{Bck: cmn.Bck{Name: "abc", Provider: apc.AIS}, ObjName: "shard.tar", ArchPath: "3.jpg"},
+			{Bck: cmn.Bck{Name: "abc", Provider: apc.AIS}, ObjName: "1.jpg"},
+		},
+	}
+	return msg
+}
+
+func TestGetBatchMsgValidate(t *testing.T) {
+	msg := gbMsg("")
+	tassert.CheckFatal(t, msg.Validate())
+	tassert.Errorf(t, msg.Mime == cos.ExtTar, "expected default %q, got %q", cos.ExtTar, msg.Mime)
+
+	tassert.Errorf(t, (&cmn.GetBatchMsg{}).Validate() != nil, "expected error (no entries)")
+	tassert.Errorf(t, gbMsg(cos.ExtZip).Validate() != nil, "expected error (unsupported %q)", cos.ExtZip)
+	msg = gbMsg(cos.ExtMsgpack)
+	msg.Entries[1].ObjName = ""
+	tassert.Errorf(t, msg.Validate() != nil, "expected error (no object name)")
+}
+
+func TestGetBatchTar(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		msg = gbMsg(cos.ExtTar)
+	)
+	tassert.CheckFatal(t, msg.Validate())
+	tassert.CheckFatal(t, xs.TestGetBatchWrite(&buf, msg, gbOpen))
+
+	tr := tar.NewReader(&buf)
+	for i := range msg.Entries {
+		e := &msg.Entries[i]
+		hdr, err := tr.Next()
+		tassert.CheckFatal(t, err)
+		data, err := io.ReadAll(tr)
+		tassert.CheckFatal(t, err)
+		expected, ok := gbContent[e.Cname()]
+		if !ok {
+			tassert.Errorf(t, hdr.Name == cmn.GetBatchMissingPrefix+e.NameInArch(), "entry #%d: unexpected name %q", i, hdr.Name)
+			tassert.Errorf(t, hdr.Size == 0 && hdr.PAXRecords["AIS.error"] != "", "entry #%d: expected placeholder", i)
+			continue
+		}
+		tassert.Errorf(t, hdr.Name == e.NameInArch(), "entry #%d: expected %q, got %q", i, e.NameInArch(), hdr.Name)
+		tassert.Errorf(t, string(data) == expected, "entry #%d: expected %q, got %q", i, expected, data)
+	}
+	_, err := tr.Next()
+	tassert.Errorf(t, err == io.EOF, "expected EOF, got %v", err)
+}
+
+func TestGetBatchMsgpack(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		out []map[string]any
+		msg = gbMsg(cos.ExtMsgpack)
+	)
+	tassert.CheckFatal(t, msg.Validate())
+	tassert.CheckFatal(t, xs.TestGetBatchWrite(&buf, msg, gbOpen))
+	tassert.CheckFatal(t, msgpack.NewDecoder(&buf).Decode(&out))
+	tassert.Fatalf(t, len(out) == len(msg.Entries), "expected %d entries, got %d", len(msg.Entries), len(out))
+
+	for i := range msg.Entries {
+		e := &msg.Entries[i]
+		name, data := out[i]["name"].(string), out[i]["data"].([]byte)
+		expected, ok := gbContent[e.Cname()]
+		if !ok {
+			tassert.Errorf(t, name == cmn.GetBatchMissingPrefix+e.NameInArch(), "entry #%d: unexpected name %q", i, name)
+			tassert.Errorf(t, len(data) == 0 && out[i]["AIS.error"] != nil, "entry #%d: expected placeholder", i)
+			continue
+		}
+		tassert.Errorf(t, name == e.NameInArch(), "entry #%d: expected %q, got %q", i, e.NameInArch(), name)
+		tassert.Errorf(t, string(data) == expected, "entry #%d: expected %q, got %q", i, expected, data)
+	}
+}
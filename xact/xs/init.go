@@ -21,6 +21,8 @@ func Xreg() {
 	xreg.RegNonBckXact(&resFactory{})
 	xreg.RegNonBckXact(&rebFactory{})
 	xreg.RegNonBckXact(&etlFactory{})
+	xreg.RegNonBckXact(&gbFactory{})
+	xreg.RegNonBckXact(&cmpFactory{})
 
 	xreg.RegBckXact(&bmvFactory{})
 	xreg.RegBckXact(&evdFactory{kind: apc.ActEvictObjects})
@@ -57,6 +57,7 @@ type (
 			stopCh *cos.StopCh        // to abort bucket walk
 			wi     *walkInfo          // walking context and state
 			wg     sync.WaitGroup     // wait until this walk finishes
+			arch   cmn.LsoEntries     // archived files pending emission (sorted) - see emit()
 			done   bool               // done walking (indication)
 		}
 		streamingX
@@ -69,8 +70,9 @@ type (
 	}
 	// archived file
 	archEntry struct {
-		name string
-		size uint64 // uncompressed size
+		name   string
+		size   uint64 // uncompressed size
+		offset int64  // data offset in the archive (tar and zip only)
 	}
 )
 
@@ -216,6 +218,7 @@ func (r *LsoXact) listRemote() bool { return r.p.Bck.IsRemote() && !r.msg.IsFlag
 func (r *LsoXact) initWalk() {
 	r.walk.pageCh = make(chan *cmn.LsoEntry, pageChSize)
 	r.walk.done = false
+	r.walk.arch = nil
 	r.walk.stopCh = cos.NewStopCh()
 	r.walk.wg.Add(1)
 
@@ -261,10 +264,11 @@ func (r *LsoXact) doPage() *LsoRsp {
 		lst  = r.lastPage[idx:]
 		page *cmn.LsoResult
 	)
-	debug.Assert(uint(len(lst)) >= cnt || r.walk.done)
-	if uint(len(lst)) >= cnt {
-		entries := lst[:cnt]
-		page = &cmn.LsoResult{UUID: r.msg.UUID, Entries: entries, ContinuationToken: entries[cnt-1].Name}
+	end, full := pageEnd(lst, cnt)
+	debug.Assert(full || r.walk.done)
+	if full {
+		entries := lst[:end]
+		page = &cmn.LsoResult{UUID: r.msg.UUID, Entries: entries, ContinuationToken: entries[end-1].Name}
 	} else {
 		page = &cmn.LsoResult{UUID: r.msg.UUID, Entries: lst}
 	}
@@ -288,7 +292,23 @@ func (r *LsoXact) havePage(token string, cnt uint) bool {
 		return true
 	}
 	idx := r.findToken(token)
-	return idx+cnt < uint(len(r.lastPage))
+	end, full := pageEnd(r.lastPage[idx:], cnt)
+	return full && int(idx)+end < len(r.lastPage)
+}
+
+// returns the number of entries that make up a page of `cnt` (current) objects;
+// non-current versions (apc.LsVersions) are not counted
+func pageEnd(lst []*cmn.LsoEntry, cnt uint) (int, bool) {
+	var n uint
+	for i, e := range lst {
+		if e.IsNoncurrent() {
+			continue
+		}
+		if n++; n == cnt {
+			return i + 1, true
+		}
+	}
+	return len(lst), false
 }
 
 func (r *LsoXact) nextPageR() error {
@@ -427,7 +447,9 @@ func (r *LsoXact) nextPageA() {
 		if cmn.TokenGreaterEQ(r.token, obj.Name) {
 			continue
 		}
-		cnt++
+		if !obj.IsNoncurrent() {
+			cnt++
+		}
 		r.lastPage = append(r.lastPage, obj)
 	}
 }
@@ -483,20 +505,17 @@ func (r *LsoXact) doWalk(msg *apc.LsoMsg) {
 					return filepath.SkipDir
 				}
 				entry := &cmn.LsoEntry{Name: relPath, Flags: apc.EntryIsDir}
-				select {
-				case r.walk.pageCh <- entry:
-					/* do nothing */
-				case <-r.walk.stopCh.Listen():
-					return errStopped
-				}
+				return r.emit(entry)
 			}
 		}
 		return nil
 	}
-	if err := fs.WalkBck(opts); err != nil {
-		if err != filepath.SkipDir && err != errStopped {
-			glog.Errorf("%s walk failed, err %v", r, err)
-		}
+	err := fs.WalkBck(opts)
+	if err == nil || err == filepath.SkipDir {
+		err = r.flushArch("")
+	}
+	if err != nil && err != filepath.SkipDir && err != errStopped {
+		glog.Errorf("%s walk failed, err %v", r, err)
 	}
 	close(r.walk.pageCh)
 	r.walk.wg.Done()
@@ -507,52 +526,117 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 	if err != nil || entry == nil {
 		return err
 	}
+	return r.cbEntry(fqn, entry)
+}
+
+func (r *LsoXact) cbEntry(fqn string, entry *cmn.LsoEntry) error {
 	msg := r.walk.wi.lsmsg()
-	if entry.Name <= msg.StartAfter {
+
+	// (the object itself may be selected only because the prefix, start-after, or
+	// continuation token point inside it - see walkInfo.inArch)
+	if r.walk.wi.wantObj(entry.Name) && entry.Name > msg.StartAfter {
+		if err := r.cbObj(entry, msg); err != nil {
+			return err
+		}
+	}
+	if !msg.IsFlagSet(apc.LsArchDir) || !r.walk.wi.inArch(entry.Name, msg.Prefix, msg.StartAfter) {
 		return nil
 	}
-	if r.walk.wi.msg.IsFlagSet(apc.LsNoRecursion) {
+	return r.cbArch(fqn, entry, msg)
+}
+
+func (r *LsoXact) cbObj(entry *cmn.LsoEntry, msg *apc.LsoMsg) error {
+	if msg.IsFlagSet(apc.LsNoRecursion) {
 		// Check if the object is nested deeper than it is allowed.
 		// Do not return SkipDir in this case as it may result in missing
 		// directories and objects in the response.
-		relName := strings.TrimPrefix(entry.Name, r.walk.wi.msg.Prefix)
+		relName := strings.TrimPrefix(entry.Name, msg.Prefix)
 		if strings.Contains(relName, "/") {
 			return nil
 		}
 	}
 
-	select {
-	case r.walk.pageCh <- entry:
-		/* do nothing */
-	case <-r.walk.stopCh.Listen():
-		return errStopped
-	}
-
-	if !msg.IsFlagSet(apc.LsArchDir) {
-		return nil
+	// non-current versions go first
+	if msg.IsFlagSet(apc.LsVersions) && entry.IsStatusOK() {
+		vers, err := r.walk.wi.versions(r.Bck().Bucket(), entry.Name)
+		if err != nil {
+			return err
+		}
+		for _, e := range vers {
+			if err := r.emit(e); err != nil {
+				return err
+			}
+		}
 	}
+	return r.emit(entry)
+}
 
-	// arch
+// expand archive: archived files are filtered by prefix, start-after, continuation token,
+// and `msg.ArchRegex`, and then queued to be emitted in the sorted order
+func (r *LsoXact) cbArch(fqn string, entry *cmn.LsoEntry, msg *apc.LsoMsg) error {
 	archList, err := listArchive(fqn)
 	if archList == nil || err != nil {
 		return err
 	}
+	wi := r.walk.wi
 	for _, archEntry := range archList {
+		name := path.Join(entry.Name, archEntry.name)
+		if !wi.wantObj(name) || name <= msg.StartAfter {
+			continue
+		}
+		if wi.archRegex != nil && !wi.archRegex.MatchString(name) {
+			continue
+		}
 		e := &cmn.LsoEntry{
-			Name:  path.Join(entry.Name, archEntry.name),
-			Flags: entry.Flags | apc.EntryInArch,
-			Size:  int64(archEntry.size),
+			Name:   name,
+			Flags:  entry.Flags | apc.EntryInArch,
+			Size:   int64(archEntry.size),
+			Offset: archEntry.offset,
 		}
-		select {
-		case r.walk.pageCh <- e:
-			/* do nothing */
-		case <-r.walk.stopCh.Listen():
-			return errStopped
+		r.walk.arch = append(r.walk.arch, e)
+	}
+	// (archived files of the neighboring objects may interleave, e.g. "a.tar/x" > "a.tar.gz/y")
+	sort.Slice(r.walk.arch, func(i, j int) bool { return r.walk.arch[i].Name < r.walk.arch[j].Name })
+	return nil
+}
+
+// emit sends the next listed entry, preceded by the pending archived files that sort before it
+// (name-wise: object "a.tar-1", if exists, goes after "a.tar" but before "a.tar/x")
+func (r *LsoXact) emit(entry *cmn.LsoEntry) error {
+	if err := r.flushArch(entry.Name); err != nil {
+		return err
+	}
+	return r.send(entry)
+}
+
+// flush pending archived files (all of them when `upto` is empty)
+func (r *LsoXact) flushArch(upto string) error {
+	var i int
+	for ; i < len(r.walk.arch); i++ {
+		e := r.walk.arch[i]
+		if upto != "" && e.Name >= upto {
+			break
+		}
+		if err := r.send(e); err != nil {
+			return err
 		}
+		r.walk.arch[i] = nil
+	}
+	if i > 0 {
+		r.walk.arch = r.walk.arch[:copy(r.walk.arch, r.walk.arch[i:])]
 	}
 	return nil
 }
 
+func (r *LsoXact) send(entry *cmn.LsoEntry) error {
+	select {
+	case r.walk.pageCh <- entry:
+		return nil
+	case <-r.walk.stopCh.Listen():
+		return errStopped
+	}
+}
+
 func (r *LsoXact) Snap() (snap *cluster.Snap) {
 	snap = &cluster.Snap{}
 	r.ToSnap(snap)
@@ -609,9 +693,13 @@ func listArchive(fqn string) ([]*archEntry, error) {
 }
 
 // list: tar, tgz, zip, msgpack
+// (when the reader is seekable, tar reader seeks over archived content - see also cluster.NewArchIndex)
 func listTar(reader io.Reader) ([]*archEntry, error) {
-	fileList := make([]*archEntry, 0, 8)
-	tr := tar.NewReader(reader)
+	var (
+		fileList  = make([]*archEntry, 0, 8)
+		tr        = tar.NewReader(reader)
+		seeker, _ = reader.(io.Seeker)
+	)
 	for {
 		hdr, err := tr.Next()
 		if err != nil {
@@ -624,6 +712,11 @@ func listTar(reader io.Reader) ([]*archEntry, error) {
 			continue
 		}
 		e := &archEntry{name: hdr.Name, size: uint64(hdr.Size)}
+		if seeker != nil {
+			if e.offset, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+				return nil, err
+			}
+		}
 		fileList = append(fileList, e)
 	}
 }
@@ -651,6 +744,9 @@ func listZip(readerAt cos.ReadReaderAt, size int64) ([]*archEntry, error) {
 			name: f.FileHeader.Name,
 			size: f.FileHeader.UncompressedSize64,
 		}
+		if e.offset, err = f.DataOffset(); err != nil {
+			return nil, err
+		}
 		fileList = append(fileList, e)
 	}
 	return fileList, nil
@@ -0,0 +1,149 @@
+// Package xs_test - listing archived content: sorting, prefix, start-after, paging, and arch_regex.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/xact/xs"
+)
+
+// objects (sorted, as walked) and their archived files, if any;
+// note that "a.tar/..." sorts after both "a.tar-1" and "a.tar.gz/..."
+var (
+	lsoObjs = []string{"a.tar", "a.tar-1", "a.tar.gz", "b"}
+	lsoArch = map[string][]string{
+		"a.tar":    {"y/z", "x", "y/w"},
+		"a.tar.gz": {"x", "m"},
+	}
+)
+
+func lsoCreate(t *testing.T) (fqns map[string]string, all []string) {
+	dir := t.TempDir()
+	fqns = make(map[string]string, len(lsoObjs))
+	for _, name := range lsoObjs {
+		fqn := filepath.Join(dir, name)
+		fh, err := os.Create(fqn)
+		tassert.CheckFatal(t, err)
+		if files, ok := lsoArch[name]; ok {
+			var (
+				w   io.Writer = fh
+				gzw *gzip.Writer
+			)
+			if strings.HasSuffix(name, ".gz") {
+				gzw = gzip.NewWriter(fh)
+				w = gzw
+			}
+			lsoWriteTar(t, w, files)
+			if gzw != nil {
+				tassert.CheckFatal(t, gzw.Close())
+			}
+			for _, f := range files {
+				all = append(all, name+"/"+f)
+			}
+		}
+		tassert.CheckFatal(t, fh.Close())
+		fqns[name] = fqn
+		all = append(all, name)
+	}
+	sort.Strings(all)
+	return
+}
+
+func lsoWriteTar(t *testing.T, w io.Writer, files []string) {
+	tw := tar.NewWriter(w)
+	for _, f := range files {
+		content := "content of " + f
+		tassert.CheckFatal(t, tw.WriteHeader(&tar.Header{Name: f, Size: int64(len(content)), Mode: 0o644, Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		tassert.CheckFatal(t, err)
+	}
+	tassert.CheckFatal(t, tw.Close())
+}
+
+func lsoCheck(t *testing.T, tag string, msg *apc.LsoMsg, fqns map[string]string, expected []string) {
+	t.Helper()
+	out, err := xs.LsoWalkArch(msg, lsoObjs, fqns)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, strings.Join(out, " ") == strings.Join(expected, " "), "%s: expected %v, got %v", tag, expected, out)
+}
+
+func lsoFilter(names []string, prefix string) (out []string) {
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			out = append(out, name)
+		}
+	}
+	return
+}
+
+func TestLsoArchSorted(t *testing.T) {
+	fqns, all := lsoCreate(t)
+	msg := &apc.LsoMsg{Flags: apc.LsArchDir}
+	lsoCheck(t, "all", msg, fqns, all)
+
+	// without LsArchDir, archives are listed as plain objects
+	lsoCheck(t, "objects", &apc.LsoMsg{}, fqns, lsoObjs)
+}
+
+func TestLsoArchPrefix(t *testing.T) {
+	fqns, all := lsoCreate(t)
+	for _, prefix := range []string{"a", "a.tar", "a.tar/", "a.tar/y", "a.tar/y/w", "a.tar.", "a.tar.gz/", "a.tar-", "c"} {
+		msg := &apc.LsoMsg{Flags: apc.LsArchDir, Prefix: prefix}
+		lsoCheck(t, "prefix "+prefix, msg, fqns, lsoFilter(all, prefix))
+	}
+}
+
+// resuming the listing after any given entry - an object or an archived file - must yield
+// the remaining entries in order, and so must paging at any boundary
+func TestLsoArchPaging(t *testing.T) {
+	fqns, all := lsoCreate(t)
+	for _, prefix := range []string{"", "a.tar", "a.tar/"} {
+		expected := lsoFilter(all, prefix)
+		for i := 1; i < len(expected); i++ {
+			marker := expected[i-1]
+			msg := &apc.LsoMsg{Flags: apc.LsArchDir, Prefix: prefix, StartAfter: marker}
+			lsoCheck(t, "start-after "+marker, msg, fqns, expected[i:])
+
+			msg = &apc.LsoMsg{Flags: apc.LsArchDir, Prefix: prefix, ContinuationToken: marker}
+			lsoCheck(t, "token "+marker, msg, fqns, expected[i:])
+		}
+	}
+}
+
+func TestLsoArchRegex(t *testing.T) {
+	fqns, _ := lsoCreate(t)
+	tests := []struct {
+		regex    string
+		archived []string // selected archived files (objects are always listed)
+	}{
+		{`/y/`, []string{"a.tar/y/w", "a.tar/y/z"}},
+		{`\.gz/`, []string{"a.tar.gz/m", "a.tar.gz/x"}},
+		{`/x$`, []string{"a.tar.gz/x", "a.tar/x"}},
+		{`^nothing`, nil},
+	}
+	for _, test := range tests {
+		expected := append([]string{}, lsoObjs...)
+		expected = append(expected, test.archived...)
+		sort.Strings(expected)
+		msg := &apc.LsoMsg{Flags: apc.LsArchDir, ArchRegex: test.regex}
+		lsoCheck(t, "regex "+test.regex, msg, fqns, expected)
+
+		// in combination with paging
+		marker := "a.tar.gz"
+		msg = &apc.LsoMsg{Flags: apc.LsArchDir, ArchRegex: test.regex, ContinuationToken: marker}
+		i := sort.SearchStrings(expected, marker) + 1
+		lsoCheck(t, "regex "+test.regex+", token "+marker, msg, fqns, expected[i:])
+	}
+}
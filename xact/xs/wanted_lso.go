@@ -63,7 +63,7 @@ func setWanted(e *cmn.LsoEntry, lom *cluster.LOM, tmformat string, wanted cos.Bi
 		case apc.GetPropsEC:
 			// TODO?: risk of significant slow-down loading EC metafiles
 		case apc.GetPropsCustom:
-			if md := lom.GetCustomMD(); len(md) > 0 {
+			if md := cmn.PublicCustomMD(lom.GetCustomMD()); len(md) > 0 {
 				e.Custom = fmt.Sprintf("%+v", md)
 			}
 		default:
@@ -71,3 +71,30 @@ func setWanted(e *cmn.LsoEntry, lom *cluster.LOM, tmformat string, wanted cos.Bi
 		}
 	}
 }
+
+// same as above for a non-current version of the (current) `lom`
+func setWantedVer(e *cmn.LsoEntry, v *cluster.LomVer, lom *cluster.LOM, tmformat string, wanted cos.BitFlags) {
+	for name, fl := range allmap {
+		if !wanted.IsSet(fl) {
+			continue
+		}
+		switch name {
+		case apc.GetPropsSize:
+			e.Size = v.Size
+		case apc.GetPropsVersion:
+			e.Version = v.Ver
+		case apc.GetPropsChecksum:
+			e.Checksum = v.Cksum.Value()
+		case apc.GetPropsAtime:
+			e.Atime = cos.FormatNanoTime(v.Atime, tmformat)
+		case apc.GetPropsLocation:
+			e.Location = lom.Location()
+		case apc.GetPropsCopies:
+			e.Copies = int16(v.Copies)
+		case apc.GetPropsCustom:
+			if md := cmn.PublicCustomMD(v.GetCustomMD()); len(md) > 0 {
+				e.Custom = fmt.Sprintf("%+v", md)
+			}
+		}
+	}
+}
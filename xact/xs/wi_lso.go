@@ -7,12 +7,14 @@ package xs
 
 import (
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/fs"
 )
 
@@ -28,6 +30,8 @@ type (
 		lomVisitedCb lomVisitedCb
 		msg          *apc.LsoMsg
 		markerDir    string
+		filter       cmn.CustomFilter
+		archRegex    *regexp.Regexp // apc.LsArchDir only
 		wanted       cos.BitFlags
 	}
 )
@@ -45,6 +49,19 @@ func newWalkInfo(t cluster.Target, msg *apc.LsoMsg, lomVisitedCb lomVisitedCb) (
 		msg:          msg,
 		wanted:       wanted(msg),
 	}
+	if msg.IsFlagSet(apc.LsVersions) {
+		wi.wanted = wi.wanted.Set(allmap[apc.GetPropsVersion])
+	}
+	if msg.CustomFilter != "" {
+		var err error
+		wi.filter, err = cmn.ParseCustomFilter(msg.CustomFilter)
+		debug.AssertNoErr(err) // validated by proxy
+	}
+	if msg.ArchRegex != "" && msg.IsFlagSet(apc.LsArchDir) {
+		var err error
+		wi.archRegex, err = regexp.Compile(msg.ArchRegex)
+		debug.AssertNoErr(err) // ditto
+	}
 	if msg.ContinuationToken != "" { // marker is always a filename
 		wi.markerDir = filepath.Dir(msg.ContinuationToken)
 		if wi.markerDir == "." {
@@ -81,20 +98,42 @@ func (wi *walkInfo) processDir(fqn string) error {
 	return nil
 }
 
-// Returns true if LOM is to be included in the result set.
-func (wi *walkInfo) match(lom *cluster.LOM) bool {
-	if !cmn.ObjHasPrefix(lom.ObjName, wi.msg.Prefix) {
+// Returns true if LOM is to be included in the result set
+// or, when listing archived content, if it may contain archived files that are.
+func (wi *walkInfo) match(objName string) bool {
+	if wi.wantObj(objName) {
+		return true
+	}
+	if !wi.msg.IsFlagSet(apc.LsArchDir) {
 		return false
 	}
-	if wi.msg.ContinuationToken != "" && cmn.TokenGreaterEQ(wi.msg.ContinuationToken, lom.ObjName) {
+	return wi.inArch(objName, wi.msg.Prefix, wi.msg.ContinuationToken)
+}
+
+// Returns true if a given name (object or archived file) passes prefix and continuation token.
+func (wi *walkInfo) wantObj(name string) bool {
+	if !cmn.ObjHasPrefix(name, wi.msg.Prefix) {
 		return false
 	}
-	return true
+	return wi.msg.ContinuationToken == "" || !cmn.TokenGreaterEQ(wi.msg.ContinuationToken, name)
+}
+
+// Returns true if archived files named "<objName>/..." can possibly match the prefix
+// and sort after the marker (continuation token or start-after).
+func (*walkInfo) inArch(objName, prefix, marker string) bool {
+	dir := objName + "/"
+	if !cmn.DirHasOrIsPrefix(dir, prefix) {
+		return false
+	}
+	return marker == "" || marker < dir || strings.HasPrefix(marker, dir)
 }
 
 // new entry to be added to the listed page
 func (wi *walkInfo) ls(lom *cluster.LOM, status uint16) (e *cmn.LsoEntry) {
 	e = &cmn.LsoEntry{Name: lom.ObjName, Flags: status | apc.EntryIsCached}
+	if wi.msg.IsFlagSet(apc.LsVersions) && lom.IsDelMarker() {
+		e.Flags |= apc.EntryIsDelMarker
+	}
 	if wi.msg.IsFlagSet(apc.LsNameOnly) {
 		return
 	}
@@ -120,7 +159,7 @@ func (wi *walkInfo) cb(lom *cluster.LOM, fqn string) (*cmn.LsoEntry, error) {
 		return nil, err
 	}
 
-	if !wi.match(lom) {
+	if !wi.match(lom.ObjName) {
 		return nil, nil
 	}
 
@@ -136,7 +175,8 @@ func (wi *walkInfo) cb(lom *cluster.LOM, fqn string) (*cmn.LsoEntry, error) {
 	}
 
 	// shortcut #1: name-only optimizes-out loading md (NOTE: won't show misplaced and copies)
-	if wi.msg.IsFlagSet(apc.LsNameOnly) {
+	// (not when there may be delete markers to skip)
+	if wi.msg.IsFlagSet(apc.LsNameOnly) && wi.filter == nil && !lom.Bprops().Versioning.Retains() {
 		if !isOK(status) {
 			return nil, nil
 		}
@@ -149,6 +189,12 @@ func (wi *walkInfo) cb(lom *cluster.LOM, fqn string) (*cmn.LsoEntry, error) {
 		}
 		return nil, err
 	}
+	if wi.filter != nil && !wi.filter.Match(lom.GetCustomMD()) {
+		return nil, nil
+	}
+	if lom.IsDelMarker() && (!wi.msg.IsFlagSet(apc.LsVersions) || !isOK(status)) {
+		return nil, nil
+	}
 	if local && lom.IsCopy() {
 		// still may change below
 		status = apc.LocIsCopy
@@ -175,3 +221,30 @@ func (wi *walkInfo) cb(lom *cluster.LOM, fqn string) (*cmn.LsoEntry, error) {
 	}
 	return wi.ls(lom, status), nil
 }
+
+// non-current versions of a given object (see apc.LsVersions), oldest first
+func (wi *walkInfo) versions(bck *cmn.Bck, objName string) (entries []*cmn.LsoEntry, err error) {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err = lom.InitBck(bck); err != nil {
+		return
+	}
+	vers, err := lom.Versions()
+	if err != nil || len(vers) == 0 {
+		return
+	}
+	entries = make([]*cmn.LsoEntry, 0, len(vers))
+	for _, v := range vers {
+		e := &cmn.LsoEntry{Name: objName, Flags: apc.LocOK | apc.EntryIsCached | apc.EntryIsNoncurrent}
+		if v.IsDelMarker() {
+			e.Flags |= apc.EntryIsDelMarker
+		}
+		if !wi.msg.IsFlagSet(apc.LsNameOnly) {
+			setWantedVer(e, v, lom, wi.msg.TimeFormat, wi.wanted)
+		} else {
+			e.Version = v.Ver
+		}
+		entries = append(entries, e)
+	}
+	return
+}